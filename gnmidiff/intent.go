@@ -49,7 +49,25 @@ func (intent *setRequestIntent) writeUpdate(pathToLeaf string, val interface{},
 	return nil
 }
 
-// populateUpdate populates all leaf updates at the given path into the intent.
+// populateIntentUpdate populates the updates at the path of the intent key,
+// which is within origin, into the intent as per populateUpdate using the
+// schema of origin, or, where origin has no schema, populates the value of
+// the update as a single opaque value.
+func (intent *setRequestIntent) populateIntentUpdate(origin, key string, tv *gpb.TypedValue, schema *ytypes.Schema, origins *ytypes.Origins) error {
+	s, opaque := originSchema(origin, schema, origins)
+	if !opaque {
+		return intent.populateUpdate(origin, strings.TrimPrefix(key, originPathStr(origin, "")), tv, s, true)
+	}
+	val, err := opaqueValue(tv)
+	if err != nil {
+		return err
+	}
+	return intent.writeUpdate(key, val, true)
+}
+
+// populateUpdate populates all leaf updates at the given path within origin,
+// which is empty for the OpenConfig origin, into the intent. The paths of the
+// intent are prefixed by the origin as per originPathStr.
 //
 // For any leaf updates, the corresponding path in the intent's delete is
 // removed (if exists).
@@ -65,14 +83,14 @@ func (intent *setRequestIntent) writeUpdate(pathToLeaf string, val interface{},
 // Note: The input path must NOT end with "/".
 //
 // e.g. /a for b/c="foo" would introduce an update of /a/b/c="foo" into the intent.
-func (intent *setRequestIntent) populateUpdate(path string, tv *gpb.TypedValue, schema *ytypes.Schema, errorOnOverwrite bool) error {
+func (intent *setRequestIntent) populateUpdate(origin, path string, tv *gpb.TypedValue, schema *ytypes.Schema, errorOnOverwrite bool) error {
 	if len(path) > 0 && path[len(path)-1] == '/' {
 		return fmt.Errorf("gnmidiff: invalid input path %q, must not end with \"/\"", path)
 	}
 
 	// Populate updates when the schema is unknown.
 	if schema == nil {
-		return populateUpdateNoSchema(intent, path, tv, errorOnOverwrite)
+		return populateUpdateNoSchema(intent, origin, path, tv, errorOnOverwrite)
 	}
 
 	gpath, err := ygot.StringToStructuredPath(path)
@@ -119,7 +137,7 @@ func (intent *setRequestIntent) populateUpdate(path string, tv *gpb.TypedValue,
 	if targetSchema.IsLeaf() || targetSchema.IsLeafList() {
 		// leaf replace is the same as a leaf update, so remove
 		// the deletion action to keep the intent minimal.
-		delete(intent.Deletes, path)
+		delete(intent.Deletes, originPathStr(origin, path))
 	} else {
 		// For a non-leaf update, we want to call SetNode on
 		// the non-leaf node itself to avoid creating key
@@ -165,20 +183,22 @@ func (intent *setRequestIntent) populateUpdate(path string, tv *gpb.TypedValue,
 			// This was a list key that was created by SetNode, so drop it.
 			continue
 		}
-		if err := intent.writeUpdate(pathToLeaf, val, errorOnOverwrite); err != nil {
+		if err := intent.writeUpdate(originPathStr(origin, pathToLeaf), val, errorOnOverwrite); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// populateUpdateNoSchema populates all leaf updates at the given path into the intent.
+// populateUpdateNoSchema populates all leaf updates at the given path within
+// origin into the intent.
 //
 // e.g. /a for b/c="foo" would introduce an update of /a/b/c="foo" into the intent.
 //
 // - errorOnOverwrite indicates that if the update overwrote any current values
 // in the intent, then error out.
-func populateUpdateNoSchema(intent *setRequestIntent, path string, tv *gpb.TypedValue, errorOnOverwrite bool) error {
+func populateUpdateNoSchema(intent *setRequestIntent, origin, path string, tv *gpb.TypedValue, errorOnOverwrite bool) error {
+	path = originPathStr(origin, path)
 	var leafVal interface{}
 	isLeaf := true
 	switch tv.GetValue().(type) {
@@ -216,6 +236,24 @@ func populateUpdateNoSchema(intent *setRequestIntent, path string, tv *gpb.Typed
 	return nil
 }
 
+// opaqueValue returns the value of the TypedValue of a path whose origin is
+// not interpreted, such that values that are encoded as text, such as CLI
+// configuration, are compared as text.
+func opaqueValue(tv *gpb.TypedValue) (interface{}, error) {
+	switch tv.GetValue().(type) {
+	case *gpb.TypedValue_AsciiVal:
+		return tv.GetAsciiVal(), nil
+	case *gpb.TypedValue_JsonVal:
+		return string(tv.GetJsonVal()), nil
+	case *gpb.TypedValue_JsonIetfVal:
+		return string(tv.GetJsonIetfVal()), nil
+	case *gpb.TypedValue_ProtoBytes:
+		return binaryBase64(tv.GetProtoBytes()), nil
+	default:
+		return protoLeafToJSON(tv)
+	}
+}
+
 // binaryBase64 takes an input byte slice and returns it as a base64
 // encoded string.
 func binaryBase64(i []byte) string {
@@ -33,7 +33,7 @@ func (diff SetToNotifsDiff) Format(f Format) string {
 // * https://github.com/openconfig/oc-pyang
 // * https://github.com/openconfig/public/blob/master/doc/openconfig_style_guide.md
 func DiffSetRequestToNotifications(setreq *gpb.SetRequest, notifs []*gpb.Notification, schema *ytypes.Schema) (SetToNotifsDiff, error) {
-	setIntent, err := minimalSetRequestIntent(setreq, schema)
+	setIntent, err := minimalSetRequestIntent(setreq, schema, nil)
 	if err != nil {
 		return SetToNotifsDiff{}, fmt.Errorf("DiffSetRequestToNotifications while calculating setIntent: %v", err)
 	}
@@ -62,7 +62,7 @@ func DiffSetRequestToNotifications(setreq *gpb.SetRequest, notifs []*gpb.Notific
 			if err != nil {
 				return SetToNotifsDiff{}, err
 			}
-			if err := updateIntent.populateUpdate(path, upd.Val, schema, false); err != nil {
+			if err := updateIntent.populateUpdate("", path, upd.Val, schema, false); err != nil {
 				return SetToNotifsDiff{}, err
 			}
 		}
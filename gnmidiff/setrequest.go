@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/derekparker/trie"
+	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
 	"github.com/openconfig/ygot/ytypes"
 
@@ -52,15 +53,28 @@ func (diff SetRequestIntentDiff) Format(f Format) string {
 // * https://github.com/openconfig/oc-pyang
 // * https://github.com/openconfig/public/blob/master/doc/openconfig_style_guide.md
 //
+// The paths whose origin is not the OpenConfig origin are diffed as opaque
+// values, unless a *ytypes.Origins option is supplied whose Schemas contain a
+// schema for their origin, in which case their values are decoded against
+// that schema. The Opaque field of the option is not used, and any other
+// options are ignored.
+//
 // Currently, support is only for SetRequests whose delete, replace and updates
 // that don't have conflicts. If a conflict exists, then an error will be
 // returned.
-func DiffSetRequest(a *gpb.SetRequest, b *gpb.SetRequest, schema *ytypes.Schema) (SetRequestIntentDiff, error) {
-	intentA, err := minimalSetRequestIntent(a, schema)
+func DiffSetRequest(a *gpb.SetRequest, b *gpb.SetRequest, schema *ytypes.Schema, opts ...ytypes.UnmarshalOpt) (SetRequestIntentDiff, error) {
+	var origins *ytypes.Origins
+	for _, o := range opts {
+		if v, ok := o.(*ytypes.Origins); ok {
+			origins = v
+			break
+		}
+	}
+	intentA, err := minimalSetRequestIntent(a, schema, origins)
 	if err != nil {
 		return SetRequestIntentDiff{}, fmt.Errorf("DiffSetRequest on a: %v", err)
 	}
-	intentB, err := minimalSetRequestIntent(b, schema)
+	intentB, err := minimalSetRequestIntent(b, schema, origins)
 	if err != nil {
 		return SetRequestIntentDiff{}, fmt.Errorf("DiffSetRequest on b: %v", err)
 	}
@@ -106,10 +120,19 @@ func DiffSetRequest(a *gpb.SetRequest, b *gpb.SetRequest, schema *ytypes.Schema)
 
 // minimalSetRequestIntent returns a unique and minimal intent for a SetRequest.
 //
+// The union_replace operations are treated as replaces, where the values of
+// those at the same path, or at paths within one another, are merged. The
+// paths whose origin is not the OpenConfig origin are identified within the
+// intent by their origin, e.g. "cli:/interfaces". The values of such an origin
+// are decoded against its schema within origins, or, where it has none, such
+// as the "cli" origin, are kept as opaque values that are not interpreted.
+// Where an opaque path is replaced, the intent contains both its deletion and
+// its value.
+//
 // TODO: Currently, support is only for SetRequests whose delete, replace and updates
 // that don't have conflicts. If a conflict exists, then an error will be
 // returned.
-func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema) (setRequestIntent, error) {
+func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema, origins *ytypes.Origins) (setRequestIntent, error) {
 	if req == nil {
 		req = &gpb.SetRequest{}
 	}
@@ -123,9 +146,10 @@ func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema) (setReq
 		Updates: map[string]interface{}{},
 	}
 	// NOTE: This simple trie will not work if we intend to check conflicts with wildcard deletion paths.
+	// The metadata of each path within the trie is its *gpb.Path.
 	t := trie.New()
 	for _, gPath := range req.Delete {
-		path, err := fullPathStr(prefix, gPath)
+		path, _, err := intentPathStr(req.Prefix, prefix, gPath)
 		if err != nil {
 			return setRequestIntent{}, err
 		}
@@ -133,10 +157,10 @@ func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema) (setReq
 			return setRequestIntent{}, fmt.Errorf("gnmidiff: conflicting replaces in SetRequest: %v", path)
 		}
 		intent.Deletes[path] = struct{}{}
-		t.Add(path, nil)
+		t.Add(path, intentPath(req.Prefix, gPath))
 	}
 	for _, upd := range req.Replace {
-		path, err := fullPathStr(prefix, upd.Path)
+		path, origin, err := intentPathStr(req.Prefix, prefix, upd.Path)
 		if err != nil {
 			return setRequestIntent{}, err
 		}
@@ -144,27 +168,64 @@ func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema) (setReq
 			return setRequestIntent{}, fmt.Errorf("gnmidiff: conflicting replaces in SetRequest: %v", path)
 		}
 		intent.Deletes[path] = struct{}{}
-		t.Add(path, nil)
+		t.Add(path, intentPath(req.Prefix, upd.Path))
 
-		if err := intent.populateUpdate(path, upd.GetVal(), schema, true); err != nil {
+		if err := intent.populateIntentUpdate(origin, path, upd.GetVal(), schema, origins); err != nil {
+			return setRequestIntent{}, err
+		}
+	}
+
+	// The paths of union_replaces are only deleted where they are not
+	// within the path of another union_replace, since their values are
+	// merged.
+	unionPaths := map[string]*gpb.Path{}
+	for _, upd := range req.UnionReplace {
+		path, origin, err := intentPathStr(req.Prefix, prefix, upd.Path)
+		if err != nil {
+			return setRequestIntent{}, err
+		}
+		// A union_replace conflicts with a delete or replace at the
+		// same path, which are the only paths within Deletes so far.
+		if _, ok := intent.Deletes[path]; ok {
+			return setRequestIntent{}, fmt.Errorf("gnmidiff: conflicting replaces in SetRequest: %v", path)
+		}
+		// The values of an opaque origin cannot be merged, hence it
+		// cannot be union_replaced more than once at the same path.
+		if _, opaque := originSchema(origin, schema, origins); opaque && unionPaths[path] != nil {
+			return setRequestIntent{}, fmt.Errorf("gnmidiff: conflicting replaces in SetRequest: %v", path)
+		}
+		unionPaths[path] = intentPath(req.Prefix, upd.Path)
+	}
+	for path, p := range unionPaths {
+		if !isWithinPaths(p, unionPaths) {
+			intent.Deletes[path] = struct{}{}
+			t.Add(path, p)
+		}
+	}
+	for _, upd := range req.UnionReplace {
+		path, origin, err := intentPathStr(req.Prefix, prefix, upd.Path)
+		if err != nil {
+			return setRequestIntent{}, err
+		}
+		if err := intent.populateIntentUpdate(origin, path, upd.GetVal(), schema, origins); err != nil {
 			return setRequestIntent{}, err
 		}
 	}
 
 	// Do prefix match to check for conflicting replace paths.
 	for _, path := range t.Keys() {
-		if matches := t.PrefixSearch(path + "/"); len(matches) >= 1 {
+		if matches := pathsWithin(t, path); len(matches) >= 1 {
 			return setRequestIntent{}, fmt.Errorf("gnmidiff: conflicting replaces in SetRequest: %v, %v", path, matches)
 		}
 	}
 
 	for _, upd := range req.Update {
-		path, err := fullPathStr(prefix, upd.Path)
+		path, origin, err := intentPathStr(req.Prefix, prefix, upd.Path)
 		if err != nil {
 			return setRequestIntent{}, err
 		}
 
-		if err := intent.populateUpdate(path, upd.GetVal(), schema, true); err != nil {
+		if err := intent.populateIntentUpdate(origin, path, upd.GetVal(), schema, origins); err != nil {
 			return setRequestIntent{}, err
 		}
 	}
@@ -184,6 +245,98 @@ func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema) (setReq
 	return intent, nil
 }
 
+// isWithinPaths reports whether path is strictly within any of paths.
+func isWithinPaths(path *gpb.Path, paths map[string]*gpb.Path) bool {
+	for _, p := range paths {
+		if isWithin(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWithin reports whether the path p is strictly within the path q. The
+// paths are compared element by element, rather than by their string
+// representations, since key values may contain "/".
+func isWithin(p, q *gpb.Path) bool {
+	return len(p.GetElem()) > len(q.GetElem()) && util.PathMatchesPathElemPrefix(p, q)
+}
+
+// pathsWithin returns the paths of the trie t that are strictly within path,
+// where the metadata of each path of t is its *gpb.Path. The paths found by
+// prefix search are candidates, which are then compared element by element.
+func pathsWithin(t *trie.Trie, path string) []string {
+	n, ok := t.Find(path)
+	if !ok {
+		return nil
+	}
+	var within []string
+	for _, m := range t.PrefixSearch(path + "/") {
+		if mn, ok := t.Find(m); ok && isWithin(mn.Meta().(*gpb.Path), n.Meta().(*gpb.Path)) {
+			within = append(within, m)
+		}
+	}
+	return within
+}
+
+// intentPath returns the path p of a SetRequest whose prefix is pfx, joined
+// with the prefix. Its origin is empty where it is the OpenConfig origin.
+func intentPath(pfx, p *gpb.Path) *gpb.Path {
+	origin := pfx.GetOrigin()
+	if origin == "" {
+		origin = p.GetOrigin()
+	}
+	if origin == "openconfig" {
+		origin = ""
+	}
+	return &gpb.Path{
+		Origin: origin,
+		Elem:   append(append([]*gpb.PathElem{}, pfx.GetElem()...), p.GetElem()...),
+	}
+}
+
+// intentPathStr returns the path of the intent for the path p of a SetRequest
+// whose prefix is pfx, and whose string representation is prefix, along with
+// its origin, which is empty for the OpenConfig origin. The paths of other
+// origins are prefixed by the origin followed by a colon.
+func intentPathStr(pfx *gpb.Path, prefix string, p *gpb.Path) (string, string, error) {
+	path, err := fullPathStr(prefix, p)
+	if err != nil {
+		return "", "", err
+	}
+	origin := pfx.GetOrigin()
+	if origin == "" {
+		origin = p.GetOrigin()
+	}
+	if origin == "openconfig" {
+		origin = ""
+	}
+	return originPathStr(origin, path), origin, nil
+}
+
+// originPathStr returns the path of the intent for path within origin, which
+// is empty for the OpenConfig origin.
+func originPathStr(origin, path string) string {
+	if origin == "" {
+		return path
+	}
+	return origin + ":" + path
+}
+
+// originSchema returns the schema against which the values of origin, which
+// is empty for the OpenConfig origin, are decoded, where schema is that of
+// the OpenConfig origin and origins supplies those of other origins. It also
+// reports whether the values of origin are opaque since it has no schema.
+func originSchema(origin string, schema *ytypes.Schema, origins *ytypes.Origins) (*ytypes.Schema, bool) {
+	if origin == "" {
+		return schema, false
+	}
+	if origins != nil && origins.Schemas[origin] != nil {
+		return origins.Schemas[origin], false
+	}
+	return nil, true
+}
+
 // prefixStr returns the path version of a prefix path, handling corner cases.
 func prefixStr(prefix *gpb.Path) (string, error) {
 	if prefix == nil {
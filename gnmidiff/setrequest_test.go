@@ -1191,7 +1191,7 @@ func TestMinimalSetRequestIntent(t *testing.T) {
 					}
 				}
 				t.Run(fmt.Sprintf("withSchema-%v", withSchema), func(t *testing.T) {
-					got, err := minimalSetRequestIntent(tt.inSetRequest, inSchema)
+					got, err := minimalSetRequestIntent(tt.inSetRequest, inSchema, nil)
 					if (err != nil) != tt.wantErr {
 						t.Fatalf("got error: %v, want error: %v", err, tt.wantErr)
 					}
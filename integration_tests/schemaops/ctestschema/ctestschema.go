@@ -1428,126 +1428,136 @@ var (
 	// contents of a goyang yang.Entry struct, which defines the schema for the
 	// fields within the struct.
 	ySchema = []byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5d, 0x4d, 0x6f, 0xdb, 0x46,
-		0x13, 0xbe, 0xfb, 0x57, 0x10, 0x3c, 0x47, 0xb1, 0xa5, 0xd7, 0x89, 0xf3, 0xfa, 0x96, 0xc6, 0x0d,
-		0x0a, 0xa4, 0x6e, 0x83, 0xa4, 0xe9, 0xa5, 0x30, 0x0a, 0x46, 0x5a, 0x29, 0x84, 0x25, 0xd2, 0x20,
-		0x97, 0xae, 0x85, 0x42, 0xff, 0xbd, 0xa0, 0x28, 0x31, 0x22, 0xc5, 0x8f, 0x99, 0xd9, 0xa5, 0x4c,
-		0x45, 0x4f, 0xd1, 0x43, 0x2c, 0xed, 0x6a, 0xb9, 0xf3, 0xb5, 0x33, 0xcf, 0xec, 0x0c, 0xff, 0x3d,
-		0x73, 0x1c, 0xc7, 0x71, 0x7f, 0xf3, 0x16, 0xca, 0xbd, 0x76, 0xdc, 0x89, 0x7a, 0xf4, 0xc7, 0xca,
-		0x7d, 0x91, 0x7d, 0xfa, 0xc1, 0x0f, 0x26, 0xee, 0xb5, 0x33, 0xdc, 0xfc, 0xf9, 0x2e, 0x0c, 0xa6,
-		0xfe, 0xcc, 0xbd, 0x76, 0x2e, 0x36, 0x1f, 0xdc, 0xf8, 0x91, 0x7b, 0xed, 0x64, 0x3f, 0xb1, 0xfe,
-		0x20, 0x8c, 0x26, 0x2a, 0x52, 0x93, 0xc1, 0xdc, 0x8f, 0x75, 0x5c, 0xf8, 0xaa, 0xb0, 0x4a, 0x71,
-		0xd8, 0x8b, 0xe2, 0xa0, 0xe2, 0xa2, 0xf9, 0xc7, 0xe5, 0xc5, 0xf3, 0x2f, 0x3e, 0x46, 0x6a, 0xea,
-		0x3f, 0xed, 0x2d, 0x56, 0x58, 0x70, 0xbc, 0xb7, 0xcc, 0xfa, 0xeb, 0xcf, 0x61, 0x12, 0x8d, 0x55,
-		0xe5, 0xd4, 0xec, 0x51, 0xd4, 0xf2, 0x9f, 0x30, 0x4a, 0x9f, 0xc6, 0x7d, 0xc8, 0x56, 0x79, 0x51,
-		0x3d, 0xf0, 0x17, 0x2f, 0x7e, 0x1b, 0xcd, 0x92, 0x85, 0x0a, 0xb4, 0x7b, 0xed, 0xe8, 0x28, 0x51,
-		0x35, 0x03, 0x77, 0x46, 0xad, 0x1f, 0x6a, 0x6f, 0xd4, 0xaa, 0xf0, 0xc9, 0xaa, 0xb4, 0xd7, 0x32,
-		0xc1, 0x2b, 0x09, 0x5f, 0xbf, 0x9f, 0x2a, 0xfa, 0xd7, 0x6d, 0xa9, 0x9a, 0x0d, 0xad, 0xec, 0xa0,
-		0xb0, 0x85, 0xc8, 0x1e, 0x2a, 0x9b, 0xd8, 0xec, 0x62, 0xb3, 0x8d, 0xce, 0xbe, 0x6a, 0x36, 0xd6,
-		0xb0, 0xb3, 0x95, 0xad, 0xf9, 0x80, 0xf1, 0x96, 0xda, 0x2d, 0x14, 0xc8, 0x09, 0x9a, 0x8d, 0x6f,
-		0xd9, 0x4d, 0x33, 0x8b, 0xc9, 0xac, 0xe6, 0xb0, 0x9c, 0xc9, 0x7a, 0xae, 0x08, 0x88, 0x45, 0x41,
-		0x2c, 0x12, 0x7c, 0xd1, 0x68, 0x16, 0x91, 0x16, 0x51, 0x21, 0x8b, 0x4c, 0x3e, 0xf0, 0x5e, 0x2d,
-		0xe9, 0x64, 0xdb, 0x72, 0x25, 0x9d, 0x44, 0xdc, 0xf7, 0x46, 0x88, 0x2e, 0x88, 0xc3, 0xa9, 0xc2,
-		0x24, 0x11, 0x2a, 0xa1, 0x70, 0x49, 0x85, 0xcc, 0x58, 0xd8, 0x8c, 0x85, 0x4e, 0x2e, 0x7c, 0x34,
-		0x21, 0x24, 0x0a, 0x63, 0xfe, 0x18, 0x7f, 0x2c, 0x1f, 0x94, 0x8c, 0x53, 0xb1, 0x8e, 0xfc, 0x60,
-		0xc6, 0x61, 0xd6, 0xd6, 0x78, 0xbd, 0xb1, 0xba, 0x83, 0xb7, 0x41, 0x10, 0x6a, 0x4f, 0xfb, 0x61,
-		0xc0, 0xdb, 0xc7, 0x72, 0x16, 0xea, 0x41, 0x38, 0x1e, 0x8c, 0xc3, 0xc5, 0x43, 0xa4, 0xe2, 0x38,
-		0x3d, 0x5c, 0x95, 0x37, 0x4d, 0x7f, 0x84, 0x48, 0xe2, 0x33, 0x0b, 0x5b, 0x70, 0x1f, 0xbd, 0x79,
-		0xa2, 0xf8, 0xea, 0x9e, 0x4d, 0x23, 0x92, 0xe8, 0x46, 0x4d, 0xbd, 0x64, 0x9e, 0x8a, 0xda, 0x5f,
-		0x74, 0xfa, 0x4c, 0xb2, 0x49, 0x83, 0x6c, 0x25, 0xd2, 0xbc, 0x3b, 0x18, 0x20, 0x18, 0x20, 0x18,
-		0xa0, 0x1e, 0x19, 0x20, 0x23, 0x87, 0x85, 0xb9, 0x31, 0x37, 0x1e, 0x7f, 0x53, 0x0b, 0xef, 0xc1,
-		0xd3, 0xdf, 0x52, 0xf6, 0x9c, 0x8f, 0xb5, 0x8a, 0x75, 0xf6, 0xd9, 0x79, 0x21, 0x7c, 0x2c, 0xfc,
-		0x75, 0xbe, 0x71, 0x7c, 0xcf, 0x64, 0xfb, 0x68, 0xd8, 0x03, 0xc9, 0x8f, 0x62, 0xf8, 0x4f, 0x44,
-		0xb3, 0x05, 0xe7, 0xbb, 0x8f, 0xce, 0x37, 0xd9, 0xcc, 0xe4, 0x94, 0x4e, 0x75, 0x31, 0x52, 0x53,
-		0x0a, 0xb5, 0xb7, 0x76, 0xe5, 0x8a, 0x30, 0xf6, 0xe3, 0x46, 0x3d, 0x5e, 0xbe, 0xdc, 0x48, 0xfe,
-		0x79, 0x2a, 0x79, 0x1d, 0x48, 0x7f, 0x33, 0xb0, 0x43, 0x02, 0x1a, 0x62, 0x84, 0xa3, 0x08, 0x47,
-		0x69, 0x40, 0x95, 0x09, 0x70, 0x25, 0x14, 0x2b, 0xf8, 0x87, 0xa7, 0xe0, 0x1f, 0x52, 0xc5, 0x94,
-		0x0b, 0xbc, 0x99, 0x01, 0x71, 0x86, 0x22, 0x2b, 0x16, 0x5d, 0x13, 0x11, 0x36, 0x14, 0x65, 0x53,
-		0x91, 0xb6, 0x26, 0xda, 0xd6, 0x44, 0xdc, 0x5c, 0xd4, 0x79, 0x22, 0xcf, 0x14, 0x7d, 0xb1, 0x0a,
-		0x88, 0x80, 0x44, 0x0b, 0xc0, 0xa2, 0x61, 0x9c, 0x6f, 0x4d, 0x39, 0x6c, 0x28, 0x89, 0x25, 0x65,
-		0xb1, 0xa5, 0x34, 0xd6, 0x95, 0xc7, 0xba, 0x12, 0xd9, 0x53, 0x26, 0x99, 0x52, 0x09, 0x95, 0x4b,
-		0x8e, 0x43, 0xd8, 0xc3, 0x25, 0x0c, 0x71, 0x0a, 0x5b, 0x14, 0x10, 0xe2, 0x18, 0xf6, 0x71, 0x0d,
-		0x73, 0x41, 0x10, 0x90, 0x80, 0x09, 0xc4, 0x5a, 0x01, 0x66, 0xad, 0x00, 0xb5, 0x36, 0x80, 0x5b,
-		0x21, 0x90, 0x0b, 0x83, 0x0f, 0x83, 0x0f, 0x83, 0x0f, 0x83, 0xdf, 0x03, 0x83, 0xdf, 0xa9, 0x03,
-		0x6e, 0x48, 0x28, 0x21, 0x30, 0x6e, 0x88, 0x99, 0xcb, 0x49, 0xc4, 0x20, 0x8f, 0x28, 0xc4, 0x30,
-		0x08, 0x2d, 0x84, 0x27, 0x0c, 0xe2, 0x6c, 0xc4, 0xd9, 0x1d, 0x9e, 0x08, 0x82, 0x9c, 0x41, 0xed,
-		0x11, 0x70, 0x25, 0x98, 0x2b, 0xc8, 0x29, 0x1c, 0xc6, 0x3a, 0xc4, 0xda, 0xd3, 0x4a, 0x6e, 0x1f,
-		0xb2, 0xe9, 0x07, 0x46, 0xe2, 0x46, 0xb0, 0x10, 0xb0, 0x10, 0x40, 0xe2, 0x10, 0x98, 0x21, 0x30,
-		0x43, 0x60, 0x66, 0x18, 0x98, 0x1d, 0x04, 0xb8, 0x7a, 0xf0, 0x22, 0x15, 0xe8, 0x81, 0x15, 0x13,
-		0xb3, 0xf3, 0x5b, 0xb0, 0x34, 0xb0, 0x34, 0xb0, 0x34, 0x9d, 0x3b, 0xfe, 0x36, 0x02, 0x80, 0xaa,
-		0x40, 0x20, 0xff, 0x5f, 0x1a, 0x11, 0x1c, 0xd6, 0x88, 0x01, 0x7d, 0xff, 0xfe, 0x1f, 0xd0, 0x77,
-		0x98, 0x5e, 0x98, 0xde, 0x1f, 0xd8, 0xc9, 0x3b, 0x2d, 0xb0, 0x3a, 0x43, 0x71, 0xba, 0x42, 0xa3,
-		0xac, 0x5e, 0x36, 0xfb, 0xb0, 0xf6, 0xa1, 0x39, 0xb5, 0x74, 0xbf, 0xfa, 0xb1, 0x7e, 0xab, 0x35,
-		0xf3, 0x8e, 0xda, 0xad, 0x1f, 0xfc, 0x3c, 0x57, 0xa9, 0xda, 0xc6, 0x3c, 0x7b, 0xeb, 0xde, 0x7a,
-		0x4f, 0x3b, 0x33, 0x87, 0x6f, 0x2e, 0x2f, 0x5f, 0x5f, 0x5d, 0x5e, 0x5e, 0x5c, 0xfd, 0xef, 0xea,
-		0xe2, 0xff, 0xaf, 0x5e, 0x0d, 0x5f, 0x0f, 0x5f, 0x31, 0x7e, 0xec, 0xf7, 0x8c, 0x4d, 0x3f, 0x19,
-		0x40, 0xf8, 0x49, 0xac, 0x22, 0x2e, 0x42, 0x67, 0x60, 0x9c, 0x77, 0x0d, 0xf2, 0x56, 0xc8, 0xbe,
-		0x4a, 0x62, 0x15, 0x2b, 0x86, 0xb8, 0x60, 0x7c, 0xd7, 0x94, 0xe8, 0x01, 0xe6, 0x9a, 0x33, 0xf5,
-		0x4b, 0xfa, 0x40, 0xd9, 0xd6, 0xac, 0x2a, 0x89, 0x7a, 0xd2, 0x91, 0x37, 0x48, 0x82, 0x58, 0x7b,
-		0x5f, 0xe7, 0xcc, 0xda, 0x9d, 0x1d, 0x9e, 0x71, 0xbd, 0x2c, 0x03, 0x78, 0x55, 0x20, 0xa4, 0xb6,
-		0xf1, 0x55, 0x23, 0x61, 0xed, 0x0e, 0x63, 0xe5, 0x0b, 0xad, 0xe0, 0x00, 0x23, 0x8f, 0xbe, 0xeb,
-		0x45, 0x5d, 0x96, 0xed, 0x03, 0xd0, 0x65, 0x25, 0x4c, 0xa2, 0x64, 0xac, 0x83, 0x8d, 0xdc, 0x6e,
-		0x54, 0x39, 0x3d, 0x63, 0xfe, 0xde, 0xf9, 0xb7, 0x7b, 0x42, 0xd5, 0x61, 0xc5, 0x72, 0x94, 0x0e,
-		0xca, 0x64, 0x68, 0x29, 0x2a, 0x56, 0x4a, 0x8a, 0x5d, 0x16, 0x33, 0x42, 0x59, 0x8c, 0xc5, 0x00,
-		0x07, 0x5d, 0x1a, 0xba, 0x8a, 0xd6, 0x51, 0x04, 0x83, 0x22, 0x69, 0xfe, 0x29, 0x43, 0xb0, 0x42,
-		0x82, 0x14, 0x86, 0x3c, 0x65, 0x01, 0xcd, 0x82, 0x66, 0x59, 0xd0, 0x2c, 0x3e, 0xe4, 0x2f, 0x81,
-		0xf8, 0xf7, 0x21, 0xfd, 0x82, 0x7b, 0x44, 0x46, 0xf5, 0xed, 0xe8, 0x69, 0x14, 0x0e, 0x84, 0xfd,
-		0x4a, 0xf2, 0x99, 0xd0, 0x51, 0xe8, 0x28, 0x4e, 0xbf, 0xc2, 0x9a, 0x68, 0x01, 0x04, 0xfd, 0x86,
-		0x7e, 0x1f, 0xa9, 0x7e, 0x1f, 0x01, 0x86, 0x42, 0x48, 0xc0, 0xac, 0x2c, 0xb5, 0xc5, 0x6c, 0x4d,
-		0xa0, 0xd0, 0x12, 0x26, 0xf4, 0x04, 0x49, 0x29, 0x21, 0xd2, 0x90, 0xfd, 0x60, 0x64, 0x3b, 0x38,
-		0xc0, 0x31, 0x47, 0xfd, 0xc5, 0x80, 0xb0, 0x48, 0xd5, 0x99, 0x40, 0xaf, 0x0c, 0x3e, 0x23, 0x67,
-		0x1b, 0xea, 0x24, 0x86, 0x91, 0x4d, 0xe0, 0x64, 0x0f, 0x18, 0x48, 0x19, 0x31, 0x3b, 0x60, 0x02,
-		0x95, 0xb1, 0xd1, 0x7f, 0x3b, 0x70, 0x19, 0x0d, 0xdd, 0x5f, 0x09, 0xc5, 0xe2, 0x8e, 0xc5, 0x67,
-		0xa2, 0xa9, 0x93, 0x99, 0x38, 0xb7, 0x11, 0xe0, 0xad, 0x81, 0xd4, 0xab, 0x29, 0xb3, 0x62, 0xf6,
-		0x77, 0x6e, 0xd9, 0x17, 0x79, 0x3f, 0xc5, 0xa7, 0xf9, 0xbe, 0xe6, 0xce, 0x7a, 0xb9, 0x18, 0x2d,
-		0x92, 0xb9, 0xf6, 0xef, 0xd5, 0x92, 0xde, 0xb6, 0x7b, 0x6f, 0xc6, 0x61, 0x3a, 0x78, 0x47, 0xbd,
-		0x6c, 0xe1, 0x1d, 0x59, 0xef, 0xe1, 0x5d, 0x22, 0x2f, 0xbd, 0x9d, 0x77, 0x79, 0x22, 0x3a, 0x7b,
-		0xa3, 0xb3, 0xb7, 0x95, 0x40, 0x08, 0x39, 0xa3, 0x9e, 0xe6, 0x8c, 0x86, 0xa2, 0xa4, 0xd1, 0x10,
-		0xb8, 0x19, 0xe2, 0xea, 0x1f, 0x28, 0xae, 0x3e, 0x8d, 0xde, 0xde, 0xf7, 0x6a, 0x39, 0x12, 0xa9,
-		0xfb, 0x08, 0xea, 0x0e, 0x75, 0x3f, 0x9c, 0xba, 0x27, 0x7e, 0xa0, 0x5f, 0x5f, 0x0a, 0xd4, 0xfd,
-		0x0d, 0x63, 0xca, 0x27, 0x2f, 0x98, 0xa9, 0x43, 0xdc, 0xfa, 0xbb, 0xf5, 0xe5, 0xfd, 0x4c, 0xdc,
-		0x3f, 0x37, 0x58, 0xbc, 0xb4, 0x06, 0xe2, 0x7d, 0xe4, 0x8d, 0x53, 0x7b, 0x75, 0xe3, 0xcf, 0x7c,
-		0xee, 0xe5, 0xde, 0x22, 0x6f, 0xd4, 0xcc, 0xd3, 0xfe, 0x63, 0xfa, 0x2c, 0x53, 0x6f, 0x1e, 0xab,
-		0x83, 0x54, 0xe1, 0xdc, 0x7a, 0x4f, 0xe6, 0xa4, 0x33, 0xbb, 0x94, 0xdc, 0x57, 0x6a, 0xfe, 0xd0,
-		0x57, 0x1e, 0xf1, 0x2e, 0x8c, 0xe6, 0x49, 0xdb, 0x44, 0xd8, 0x4e, 0xb8, 0x8e, 0x9c, 0x18, 0x0e,
-		0x73, 0xf8, 0xee, 0x47, 0x6a, 0x8b, 0x7a, 0x92, 0xb4, 0x1b, 0x44, 0x61, 0xa8, 0x17, 0xe1, 0xe4,
-		0xbc, 0x0e, 0xa6, 0xad, 0xfb, 0xa2, 0xdb, 0x97, 0x66, 0x0c, 0x59, 0x6f, 0xcd, 0x18, 0xe2, 0xb5,
-		0x19, 0x78, 0x6d, 0xc6, 0xf3, 0xbc, 0x36, 0x63, 0xd8, 0x91, 0x02, 0x8c, 0x58, 0x0a, 0x30, 0x82,
-		0x02, 0x40, 0x01, 0x9e, 0x47, 0x01, 0x46, 0xa8, 0x88, 0x81, 0x0a, 0x20, 0xbb, 0x81, 0x08, 0x09,
-		0x11, 0x52, 0x9f, 0x22, 0x24, 0x24, 0x0f, 0xa0, 0x4d, 0x27, 0xa3, 0x4d, 0x48, 0x1e, 0xec, 0x21,
-		0xe0, 0x48, 0x1e, 0x88, 0x49, 0x87, 0xe4, 0x81, 0xfd, 0xe4, 0x01, 0x4a, 0xbf, 0x70, 0x2c, 0xc1,
-		0xc9, 0xeb, 0xa5, 0x93, 0x87, 0x8c, 0x17, 0x54, 0x1d, 0xaa, 0xee, 0xa0, 0x0a, 0xec, 0xb9, 0x13,
-		0x4a, 0xcf, 0x52, 0x23, 0x36, 0x74, 0x1a, 0xc2, 0xd5, 0x8e, 0x2b, 0xc5, 0x78, 0x8e, 0x26, 0x8a,
-		0xc7, 0x50, 0x3c, 0x26, 0x65, 0xb2, 0x83, 0xe2, 0x31, 0x6a, 0xe8, 0xd2, 0x5d, 0xf1, 0x98, 0xd8,
-		0x32, 0xb2, 0x4b, 0xcb, 0x6e, 0xf3, 0xf9, 0x16, 0x8b, 0xcc, 0x92, 0x59, 0xca, 0x16, 0x35, 0xa9,
-		0x14, 0xdd, 0x96, 0x5a, 0xa3, 0xf3, 0x68, 0x71, 0x4d, 0xac, 0x03, 0xdb, 0x3b, 0x20, 0x51, 0x70,
-		0x24, 0x71, 0x94, 0x2c, 0x17, 0x1c, 0x71, 0xab, 0xcc, 0xf6, 0x28, 0xcc, 0x17, 0x6d, 0x07, 0x25,
-		0x49, 0x1d, 0x9a, 0xd6, 0x03, 0x24, 0xed, 0x88, 0x4e, 0x16, 0xcf, 0xd9, 0xe2, 0x3b, 0x5d, 0x56,
-		0x9c, 0x2f, 0x81, 0x13, 0x26, 0x3d, 0xa7, 0xa5, 0xa1, 0x9c, 0x71, 0xab, 0x57, 0xa3, 0x30, 0x4e,
-		0xd8, 0xd2, 0xd5, 0x0e, 0x84, 0xc1, 0xee, 0x37, 0xdc, 0x26, 0xba, 0x82, 0xfe, 0xc2, 0x92, 0xbe,
-		0xc2, 0x92, 0x54, 0x07, 0xaf, 0x8f, 0xb0, 0x0d, 0x4c, 0x40, 0xdc, 0x37, 0xd8, 0x2e, 0x2e, 0xc0,
-		0xeb, 0x13, 0x6c, 0xeb, 0x9e, 0xe6, 0x9d, 0xdd, 0x78, 0xb4, 0xc5, 0xe9, 0xba, 0xb3, 0x56, 0xd9,
-		0xdf, 0xea, 0x6c, 0x92, 0x8a, 0xfd, 0xf5, 0x37, 0x15, 0x0d, 0x26, 0x9e, 0xf6, 0x1a, 0xca, 0xfb,
-		0xbf, 0x8f, 0x39, 0x4c, 0x41, 0x7f, 0x2f, 0xeb, 0xf9, 0xad, 0x95, 0xf3, 0xb7, 0x54, 0x76, 0xd3,
-		0x2a, 0xba, 0xe1, 0x3b, 0xf7, 0xc9, 0x77, 0x5e, 0x84, 0x7a, 0x42, 0x77, 0x94, 0xd7, 0xa3, 0x71,
-		0x9b, 0x13, 0xb7, 0x39, 0xf9, 0xd8, 0x32, 0x11, 0x53, 0xb6, 0x8d, 0x05, 0x8b, 0xab, 0x25, 0x6c,
-		0xa1, 0xb9, 0x36, 0xfa, 0xfa, 0xe4, 0xa7, 0x58, 0x63, 0x5d, 0x43, 0xc5, 0xf9, 0x5d, 0x71, 0x1a,
-		0x35, 0xdf, 0x5e, 0x25, 0xdd, 0x5a, 0x25, 0x5b, 0xf0, 0x11, 0x2c, 0x38, 0x2c, 0x38, 0x2c, 0xf8,
-		0x89, 0x59, 0xf0, 0x5e, 0x1a, 0xce, 0x86, 0xfc, 0xdd, 0x21, 0x3b, 0x9a, 0xd5, 0xc5, 0x23, 0x55,
-		0x70, 0x79, 0x3a, 0xf6, 0x26, 0x1d, 0x4a, 0x08, 0x86, 0x92, 0xa0, 0xd8, 0x2c, 0xad, 0x36, 0x22,
-		0x2a, 0x0f, 0x44, 0x58, 0x64, 0x1e, 0x16, 0x15, 0x69, 0xda, 0x7e, 0xb6, 0x96, 0xc6, 0x23, 0x4c,
-		0x42, 0x4f, 0x33, 0x1c, 0xb4, 0xc7, 0x76, 0xd0, 0xe2, 0x3d, 0x38, 0xd6, 0x84, 0xcb, 0x26, 0x1e,
-		0x8c, 0x3b, 0x62, 0xe8, 0x8a, 0x80, 0x0e, 0x2d, 0xfb, 0x93, 0xf0, 0xaa, 0x02, 0x18, 0x20, 0x18,
-		0xa0, 0x23, 0x37, 0x40, 0x7d, 0x79, 0x97, 0x42, 0x29, 0x88, 0x2c, 0xfd, 0xdd, 0x6d, 0xf3, 0x15,
-		0x56, 0xeb, 0x09, 0x20, 0x5d, 0x40, 0xba, 0x9e, 0xa7, 0xf3, 0x04, 0x1a, 0x4f, 0x40, 0x03, 0x10,
-		0x82, 0xc2, 0x03, 0x84, 0x07, 0xd8, 0x1f, 0x0f, 0x10, 0x11, 0x1e, 0x22, 0x3c, 0xe8, 0xf7, 0x29,
-		0xeb, 0xf7, 0x91, 0x04, 0x50, 0x27, 0xf4, 0x42, 0x3a, 0x83, 0x32, 0xc3, 0x20, 0x99, 0xcf, 0x19,
-		0xc5, 0x73, 0xf5, 0xad, 0x29, 0x3a, 0xcc, 0x40, 0x37, 0x33, 0x9a, 0x5e, 0x3b, 0xf5, 0x65, 0x3b,
-		0xaf, 0x17, 0x2f, 0xe6, 0x2a, 0x27, 0x91, 0xeb, 0x12, 0xd4, 0x67, 0x3b, 0xeb, 0xd6, 0xad, 0xe7,
-		0xfa, 0xf1, 0xbb, 0x1c, 0x32, 0xf9, 0xbc, 0xfe, 0xfd, 0x3d, 0xfb, 0xe8, 0xfa, 0xf1, 0x7b, 0xef,
-		0x5e, 0x7d, 0x0a, 0xc3, 0x7d, 0xdb, 0x59, 0x7e, 0x4e, 0x77, 0xf7, 0xab, 0x02, 0x0d, 0x6f, 0xd4,
-		0xa3, 0x3f, 0xde, 0xa8, 0xd5, 0xea, 0x6c, 0xf5, 0x1f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x01, 0x00,
-		0x00, 0xff, 0xff, 0x4b, 0xb8, 0x23, 0xfa, 0x27, 0xc8, 0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5d, 0xdf, 0x6f, 0xdb, 0xc6,
+		0x0f, 0x7f, 0xf7, 0x5f, 0x41, 0xdc, 0x73, 0xdc, 0xc4, 0xfe, 0xa6, 0x4d, 0xbf, 0x7e, 0xeb, 0x9a,
+		0x15, 0x03, 0xba, 0x6c, 0x45, 0xbb, 0xee, 0x65, 0x08, 0x06, 0xd5, 0xbe, 0xb8, 0x42, 0x6c, 0x29,
+		0x90, 0x4e, 0x59, 0x8c, 0x21, 0xff, 0xfb, 0xa0, 0xc8, 0x76, 0x25, 0x59, 0x3f, 0x48, 0xde, 0x49,
+		0x51, 0x12, 0x0a, 0x7d, 0x68, 0xec, 0x93, 0xa5, 0x3b, 0x7e, 0xc8, 0x23, 0x3f, 0x14, 0xa9, 0x7f,
+		0x47, 0x00, 0x00, 0xea, 0x37, 0x6f, 0xad, 0xd5, 0x0c, 0xd4, 0x42, 0xdf, 0xfa, 0x73, 0xad, 0x8e,
+		0xb2, 0x4f, 0x3f, 0xfa, 0xc1, 0x42, 0xcd, 0x60, 0xb2, 0xfd, 0xf3, 0x7d, 0x18, 0x5c, 0xf9, 0x4b,
+		0x35, 0x83, 0x93, 0xed, 0x07, 0xe7, 0x7e, 0xa4, 0x66, 0x90, 0xfd, 0x04, 0x00, 0x80, 0x0a, 0xa3,
+		0x85, 0x8e, 0xf4, 0x62, 0xbc, 0xf2, 0x63, 0x13, 0x17, 0xbe, 0x2a, 0x5c, 0xa5, 0x38, 0xec, 0xa8,
+		0x38, 0xa8, 0x78, 0xd1, 0xfd, 0xc7, 0xe5, 0x8b, 0xef, 0xbf, 0xf8, 0x14, 0xe9, 0x2b, 0xff, 0xee,
+		0xe0, 0x62, 0x85, 0x0b, 0xce, 0x0f, 0x2e, 0x03, 0x00, 0xa0, 0xbe, 0x84, 0x49, 0x34, 0xd7, 0x95,
+		0xa7, 0x66, 0xb7, 0xa2, 0x37, 0xff, 0x84, 0x51, 0x7a, 0x37, 0xea, 0x26, 0xbb, 0xca, 0x51, 0xf5,
+		0xc0, 0x5f, 0xbc, 0xf8, 0x5d, 0xb4, 0x4c, 0xd6, 0x3a, 0x30, 0x6a, 0x06, 0x26, 0x4a, 0x74, 0xcd,
+		0xc0, 0xdc, 0xa8, 0x87, 0x9b, 0x3a, 0x18, 0x75, 0x5f, 0xf8, 0xe4, 0xbe, 0x34, 0xd7, 0xf2, 0x82,
+		0x57, 0x2e, 0x7c, 0xfd, 0x7c, 0xaa, 0xd6, 0xbf, 0x6e, 0x4a, 0xd5, 0x62, 0x68, 0x15, 0x07, 0x46,
+		0x2c, 0x48, 0xf1, 0x60, 0xc5, 0x44, 0x16, 0x17, 0x59, 0x6c, 0x78, 0xf1, 0x55, 0x8b, 0xb1, 0x46,
+		0x9c, 0xad, 0x62, 0xdd, 0x1d, 0x6a, 0xbe, 0x5b, 0xed, 0x96, 0x15, 0xd8, 0x2f, 0x68, 0x36, 0xbe,
+		0x65, 0x36, 0xcd, 0x22, 0x46, 0x8b, 0x9a, 0x22, 0x72, 0xa2, 0xe8, 0xa9, 0x10, 0x60, 0x43, 0x81,
+		0x0d, 0x09, 0x3a, 0x34, 0x9a, 0x21, 0xd2, 0x02, 0x15, 0x34, 0x64, 0x76, 0x87, 0xba, 0xd6, 0x1b,
+		0xfc, 0xb2, 0xed, 0xa4, 0x92, 0x9e, 0x84, 0x9c, 0xf7, 0x16, 0x44, 0x27, 0xc8, 0xe1, 0x58, 0x30,
+		0x71, 0x40, 0xc5, 0x04, 0x17, 0x17, 0x64, 0xd6, 0x60, 0xb3, 0x06, 0x1d, 0x1f, 0x7c, 0x38, 0x10,
+		0x22, 0xc1, 0xb8, 0x3b, 0xd4, 0x1f, 0x9b, 0x1b, 0xcd, 0x93, 0x54, 0x6c, 0x22, 0x3f, 0x58, 0x52,
+		0x84, 0xb5, 0x33, 0x5e, 0x6f, 0x9d, 0xce, 0xe0, 0x5d, 0x10, 0x84, 0xc6, 0x33, 0x7e, 0x18, 0xd0,
+		0xe6, 0xb1, 0x59, 0x86, 0x66, 0x1c, 0xce, 0xc7, 0xf3, 0x70, 0x7d, 0x13, 0xe9, 0x38, 0x4e, 0x37,
+		0x57, 0xed, 0x5d, 0xa5, 0x3f, 0x82, 0x5c, 0xe2, 0x91, 0x83, 0x29, 0xa8, 0x5b, 0x6f, 0x95, 0x68,
+		0xba, 0xba, 0x67, 0xa7, 0x21, 0x97, 0xe8, 0x5c, 0x5f, 0x79, 0xc9, 0xca, 0xa8, 0x19, 0xfc, 0x85,
+		0x5f, 0x9f, 0x45, 0x76, 0xd2, 0x38, 0xbb, 0x12, 0xea, 0xbc, 0x4b, 0x31, 0x40, 0x62, 0x80, 0xc4,
+		0x00, 0x0d, 0xc8, 0x00, 0x59, 0x39, 0x2c, 0xc4, 0x89, 0xa9, 0x78, 0xfe, 0x5d, 0xaf, 0xbd, 0x1b,
+		0xcf, 0x7c, 0x57, 0x33, 0x50, 0xc7, 0x73, 0xa3, 0x63, 0x93, 0x7d, 0x76, 0x5c, 0x08, 0x1f, 0x0b,
+		0x7f, 0x1d, 0x6f, 0x1d, 0xdf, 0x11, 0x6f, 0x1e, 0x0d, 0x73, 0x40, 0xf9, 0x51, 0x04, 0xff, 0x09,
+		0x69, 0xb6, 0xc4, 0xf9, 0xee, 0xc6, 0xfc, 0xd8, 0x61, 0x19, 0x6d, 0x66, 0xf6, 0x2b, 0x9d, 0xea,
+		0x62, 0xa4, 0xaf, 0x30, 0xab, 0xbd, 0xb3, 0x2b, 0x67, 0x88, 0xb1, 0x9f, 0xb6, 0xea, 0xf1, 0xea,
+		0xd5, 0x16, 0xf9, 0xc7, 0x29, 0xf2, 0x3a, 0x40, 0x7f, 0x33, 0xb1, 0x53, 0x3b, 0xed, 0x26, 0xa2,
+		0xa7, 0x76, 0xe2, 0xa2, 0x11, 0xcf, 0x38, 0x1c, 0x45, 0x11, 0x55, 0x28, 0x3c, 0x11, 0x03, 0xd4,
+		0x89, 0xf8, 0x87, 0xe2, 0x1f, 0x62, 0x61, 0xba, 0x3b, 0xb0, 0xc4, 0x5b, 0xbd, 0x80, 0x31, 0x44,
+		0x9c, 0x25, 0x64, 0xd9, 0xd0, 0xb5, 0x81, 0xb0, 0x25, 0x94, 0x6d, 0x21, 0xed, 0x0c, 0xda, 0xce,
+		0x20, 0x6e, 0x0f, 0x75, 0x1a, 0xe4, 0x89, 0xd0, 0x67, 0xab, 0xc0, 0xee, 0x20, 0x11, 0x89, 0xb5,
+		0x28, 0xc1, 0x13, 0x8b, 0x75, 0x4a, 0x71, 0xc2, 0x3c, 0x9d, 0xab, 0x1c, 0x2e, 0x94, 0xc4, 0x91,
+		0xb2, 0xb8, 0x52, 0x1a, 0xe7, 0xca, 0xe3, 0x5c, 0x89, 0xdc, 0x29, 0x13, 0x4f, 0xa9, 0x98, 0xca,
+		0x45, 0x0f, 0x10, 0xdc, 0xf3, 0x12, 0x96, 0x3c, 0x85, 0xab, 0x15, 0x60, 0xf2, 0x18, 0xee, 0x79,
+		0x0d, 0x7b, 0x20, 0x30, 0x96, 0x80, 0x48, 0xc4, 0xd6, 0x4a, 0x9f, 0x42, 0xcc, 0x3a, 0x21, 0x6a,
+		0x5d, 0x10, 0xb7, 0x4c, 0x22, 0xb7, 0x0e, 0xb8, 0x62, 0xf0, 0xc5, 0xe0, 0xf7, 0xa2, 0xe7, 0x20,
+		0x06, 0x5f, 0x0c, 0x3e, 0x74, 0xee, 0x80, 0x5b, 0x2e, 0x14, 0x93, 0x18, 0xb7, 0xe4, 0xcc, 0xf9,
+		0x4b, 0x44, 0x58, 0x1e, 0x56, 0x88, 0x61, 0x11, 0x5a, 0x30, 0x77, 0x18, 0x89, 0xb3, 0x25, 0xce,
+		0xee, 0x70, 0x47, 0x60, 0xe4, 0x0c, 0x6c, 0x72, 0x08, 0x87, 0x20, 0xa5, 0xe7, 0x14, 0xfa, 0xb1,
+		0x0e, 0xb1, 0xf1, 0x8c, 0xe6, 0xdb, 0x87, 0xec, 0xf4, 0x9e, 0x99, 0xb8, 0xa9, 0x58, 0x08, 0xb1,
+		0x10, 0x00, 0xc2, 0xc4, 0x55, 0x29, 0x87, 0x04, 0x66, 0xce, 0x95, 0xc7, 0xb9, 0x12, 0xb9, 0x53,
+		0x26, 0x9e, 0x52, 0x31, 0x95, 0xcb, 0x7e, 0x1b, 0x1e, 0x5e, 0x60, 0xd6, 0x0b, 0x71, 0x75, 0xe3,
+		0x45, 0x3a, 0x30, 0x63, 0x27, 0x26, 0x26, 0xf7, 0x5b, 0x62, 0x69, 0xc4, 0xd2, 0x88, 0xa5, 0xe9,
+		0xdc, 0xf1, 0x77, 0x11, 0x00, 0x54, 0x05, 0x02, 0xfb, 0x7f, 0xdc, 0x88, 0xa0, 0x5f, 0x23, 0x26,
+		0xec, 0x3b, 0x80, 0xb0, 0xef, 0x62, 0x7a, 0x01, 0xc4, 0xf4, 0x3e, 0x7b, 0x27, 0xef, 0x65, 0x91,
+		0xd5, 0x19, 0x8b, 0xd3, 0x15, 0x1b, 0xe5, 0xf4, 0x61, 0xb3, 0x8f, 0x7a, 0x43, 0x0b, 0xb4, 0xd5,
+		0xaf, 0x7e, 0x6c, 0xde, 0x19, 0x43, 0x7c, 0x46, 0xed, 0xc2, 0x0f, 0x7e, 0x5e, 0xe9, 0x54, 0x6d,
+		0x63, 0x9a, 0xbd, 0x55, 0x17, 0xde, 0x5d, 0xee, 0xcc, 0xc9, 0xdb, 0xd3, 0xd3, 0x37, 0x67, 0xa7,
+		0xa7, 0x27, 0x67, 0xff, 0x3b, 0x3b, 0xf9, 0xff, 0xeb, 0xd7, 0x93, 0x37, 0x93, 0xd7, 0x84, 0x1f,
+		0xfb, 0x3d, 0x13, 0xd3, 0x4f, 0x16, 0x14, 0x7e, 0x12, 0xeb, 0x88, 0xca, 0xd0, 0x59, 0x18, 0xe7,
+		0xbc, 0x41, 0xde, 0x81, 0xec, 0x1b, 0x27, 0x56, 0x71, 0x62, 0x88, 0x0b, 0xc6, 0xf7, 0x61, 0x25,
+		0x06, 0xc0, 0xb9, 0xee, 0x85, 0xfa, 0x35, 0xbd, 0xa1, 0x6c, 0x6a, 0x4e, 0x95, 0x44, 0xdf, 0x99,
+		0xc8, 0x1b, 0x27, 0x41, 0x6c, 0xbc, 0x6f, 0x2b, 0x9a, 0x18, 0xf3, 0x32, 0xa3, 0x7a, 0x59, 0x16,
+		0xf4, 0x2a, 0x03, 0xa4, 0xe0, 0x98, 0x5f, 0xb5, 0x02, 0x2b, 0x74, 0xc6, 0xb1, 0xd2, 0x41, 0x0b,
+		0xf4, 0x0d, 0x0c, 0x3d, 0xfa, 0x72, 0x10, 0x75, 0x59, 0xae, 0x37, 0x40, 0x45, 0x4a, 0x98, 0x44,
+		0xc9, 0xdc, 0x04, 0x5b, 0xdc, 0x6e, 0x55, 0x39, 0xdd, 0x63, 0xfe, 0xce, 0xfd, 0x5f, 0xbd, 0xa0,
+		0xea, 0xb0, 0xc2, 0x57, 0x5d, 0x94, 0xc9, 0xe0, 0x52, 0x54, 0xa4, 0x94, 0x14, 0xb9, 0x2c, 0x66,
+		0x2a, 0x65, 0x31, 0x38, 0x73, 0x25, 0x5d, 0x1a, 0xda, 0xc1, 0x24, 0x45, 0x30, 0xf6, 0xa0, 0xe3,
+		0x83, 0x0f, 0x6f, 0x7d, 0xe1, 0x79, 0x14, 0x49, 0x3b, 0x69, 0x82, 0xc0, 0x48, 0x61, 0xf0, 0x53,
+		0x16, 0xa2, 0x59, 0xa2, 0x59, 0x0e, 0x34, 0x8b, 0x4e, 0xf9, 0x73, 0x28, 0xfe, 0x43, 0x4a, 0xbf,
+		0xe0, 0x1e, 0xa1, 0x59, 0x7d, 0x37, 0x7a, 0x1a, 0x85, 0x63, 0x66, 0xbf, 0x92, 0xfd, 0x99, 0xa2,
+		0xa3, 0xa2, 0xa3, 0x00, 0xb2, 0xfb, 0xfd, 0x38, 0xa4, 0x05, 0x90, 0xe8, 0xb7, 0xe8, 0xf7, 0x53,
+		0xd5, 0xef, 0x27, 0xc0, 0xa1, 0x20, 0x12, 0x30, 0xf7, 0x8e, 0xda, 0x62, 0xb6, 0x26, 0x50, 0x70,
+		0x09, 0x13, 0x7c, 0x82, 0xa4, 0x94, 0x10, 0x69, 0xc8, 0x7e, 0x10, 0xb2, 0x1d, 0x14, 0xe2, 0x98,
+		0xa2, 0xfe, 0x6c, 0x42, 0x98, 0xa5, 0xea, 0x44, 0xa2, 0x97, 0x47, 0x9f, 0xa1, 0xb3, 0x0d, 0x75,
+		0x88, 0x21, 0x64, 0x13, 0x28, 0xd9, 0x03, 0x02, 0x53, 0x86, 0x10, 0x32, 0x58, 0x52, 0x65, 0x24,
+		0x61, 0x83, 0x33, 0xba, 0xac, 0x5d, 0xe8, 0x08, 0x13, 0x36, 0xa2, 0xb1, 0xf5, 0x75, 0x72, 0x46,
+		0x9a, 0x3a, 0x9e, 0x89, 0x53, 0x47, 0x23, 0x3a, 0xa5, 0xae, 0x46, 0xb8, 0xf9, 0x36, 0xf7, 0x77,
+		0x6e, 0x99, 0x17, 0x7a, 0x3e, 0x6a, 0x54, 0x7d, 0xcd, 0xdc, 0xf5, 0xf6, 0x30, 0x5a, 0x27, 0x2b,
+		0xe3, 0x5f, 0xeb, 0x0d, 0xbe, 0x6d, 0xf7, 0xc1, 0x19, 0xfd, 0x74, 0xf0, 0x8e, 0x06, 0xd9, 0xc2,
+		0x3b, 0x72, 0xde, 0xc3, 0xbb, 0xb4, 0xbc, 0xf8, 0x76, 0xde, 0xe5, 0x13, 0xa5, 0xb3, 0xb7, 0x74,
+		0xf6, 0x6e, 0x1c, 0x26, 0xad, 0xd4, 0xa8, 0x9b, 0xe0, 0xc0, 0x72, 0x46, 0x13, 0x56, 0xd2, 0x68,
+		0x22, 0xbc, 0x19, 0xf6, 0x90, 0xb8, 0x1a, 0x00, 0x40, 0x5a, 0xeb, 0x0e, 0xa0, 0xb7, 0xf7, 0xb5,
+		0xde, 0x4c, 0x59, 0xea, 0x3e, 0x15, 0x75, 0x17, 0x75, 0xef, 0x4f, 0xdd, 0x13, 0x3f, 0x30, 0x6f,
+		0x4e, 0x19, 0xea, 0xfe, 0x96, 0x70, 0xca, 0x67, 0x2f, 0x58, 0xea, 0x3e, 0x9e, 0xfa, 0xbb, 0xf0,
+		0xf9, 0xfd, 0x4c, 0xd4, 0x9f, 0x5b, 0x2e, 0x9e, 0x5b, 0x03, 0xf1, 0x21, 0xf2, 0xe6, 0xa9, 0xbd,
+		0x3a, 0xf7, 0x97, 0x3e, 0xf5, 0xe1, 0xde, 0xa2, 0x6c, 0xf4, 0xd2, 0x33, 0xfe, 0x6d, 0x7a, 0x2f,
+		0x57, 0xde, 0x2a, 0xd6, 0xbd, 0x54, 0xe1, 0x5c, 0x78, 0x77, 0xf6, 0x4b, 0x67, 0xf7, 0x50, 0xf2,
+		0x50, 0x57, 0xf3, 0x59, 0x3f, 0xf2, 0x28, 0xef, 0xc2, 0xc0, 0x25, 0xc2, 0x72, 0xe1, 0xba, 0xe4,
+		0xc4, 0x00, 0x64, 0x33, 0x27, 0xeb, 0xbc, 0xf8, 0xee, 0x43, 0xb0, 0x45, 0x03, 0x49, 0xda, 0x8d,
+		0xa3, 0x30, 0x34, 0xeb, 0x70, 0x71, 0x5c, 0x47, 0xd3, 0xd6, 0x7d, 0xd1, 0xed, 0x4b, 0x33, 0x26,
+		0xa4, 0xb7, 0x66, 0x4c, 0xe4, 0xb5, 0x19, 0xf2, 0xda, 0x8c, 0xc7, 0x79, 0x6d, 0xc6, 0xa4, 0x23,
+		0x05, 0x98, 0x92, 0x14, 0x60, 0x2a, 0x0a, 0x20, 0x0a, 0xf0, 0x38, 0x0a, 0x30, 0x95, 0x8a, 0x18,
+		0x51, 0x01, 0xc9, 0x6e, 0xd8, 0x5a, 0x54, 0x0e, 0xac, 0x98, 0xf0, 0xe2, 0xc2, 0xcc, 0x1a, 0x6e,
+		0xd6, 0xb0, 0xe3, 0xc3, 0x0f, 0x07, 0x43, 0x24, 0x1c, 0xe9, 0x96, 0xb9, 0xff, 0x08, 0x49, 0x92,
+		0x07, 0xa2, 0x4d, 0x00, 0x92, 0x3c, 0x00, 0x90, 0xe4, 0xc1, 0x13, 0xa5, 0xbb, 0x25, 0x79, 0xe0,
+		0x72, 0x35, 0x1f, 0x37, 0x79, 0x20, 0xa5, 0x5f, 0x00, 0xb2, 0x2d, 0x89, 0x93, 0x37, 0x40, 0x27,
+		0x4f, 0x32, 0x5e, 0xa2, 0xea, 0xa2, 0xea, 0x20, 0x55, 0x60, 0x8f, 0x9d, 0x50, 0x7a, 0x94, 0x1a,
+		0xb1, 0x09, 0x34, 0x84, 0xab, 0x1d, 0x57, 0x8a, 0xd1, 0x1c, 0x4d, 0x29, 0x1e, 0x93, 0xe2, 0x31,
+		0xae, 0x90, 0x41, 0x8a, 0xc7, 0xb0, 0xa1, 0x4b, 0x77, 0xc5, 0x63, 0x6c, 0xcb, 0x48, 0x2e, 0x2d,
+		0xbb, 0xd8, 0x9f, 0xef, 0xb0, 0xc8, 0x2c, 0x59, 0xa6, 0x62, 0xd1, 0x8b, 0x4a, 0xe8, 0xb6, 0xd4,
+		0x1a, 0x1d, 0x47, 0xeb, 0x19, 0xb2, 0x0e, 0xec, 0x60, 0x83, 0x94, 0x82, 0xa3, 0x1a, 0xed, 0xe8,
+		0xb3, 0xe0, 0x88, 0x5a, 0x65, 0x76, 0xb0, 0xc2, 0x74, 0x68, 0x83, 0x94, 0x24, 0x75, 0x68, 0x5a,
+		0x7b, 0x48, 0xda, 0x21, 0x9d, 0x2c, 0x9a, 0xb3, 0x45, 0x77, 0xba, 0x9c, 0x38, 0x5f, 0xbb, 0x83,
+		0xd1, 0xaf, 0x98, 0xba, 0x4f, 0x03, 0x33, 0x94, 0xb3, 0xda, 0xaf, 0xc1, 0x36, 0x8c, 0x63, 0xec,
+		0xdb, 0xe0, 0x8c, 0xc2, 0x20, 0xf7, 0x1b, 0x6e, 0x83, 0x2e, 0xa3, 0xbf, 0x30, 0xa7, 0xaf, 0x30,
+		0x27, 0xd5, 0x81, 0x07, 0x11, 0x38, 0xe2, 0x04, 0x58, 0x60, 0x02, 0xe7, 0xbc, 0x00, 0x1e, 0x54,
+		0x80, 0x27, 0x06, 0x5a, 0x47, 0x5d, 0xba, 0x8d, 0x47, 0x5b, 0x9c, 0xae, 0x4b, 0x67, 0x95, 0xfd,
+		0xad, 0xce, 0x26, 0xaa, 0xd8, 0xdf, 0x7c, 0xd7, 0xd1, 0x78, 0xe1, 0x19, 0xaf, 0xa1, 0xbc, 0xff,
+		0xc7, 0x98, 0x7e, 0x0a, 0xfa, 0x07, 0x59, 0xcf, 0xef, 0xac, 0x9c, 0xbf, 0xa5, 0xb2, 0x1b, 0x57,
+		0xd1, 0x2d, 0xbe, 0x33, 0x4b, 0x64, 0x2d, 0xba, 0xcb, 0xf5, 0x9d, 0xd7, 0xa1, 0x59, 0xe0, 0x1d,
+		0xe5, 0x87, 0xd1, 0xf2, 0x34, 0xa7, 0x3c, 0xcd, 0x49, 0xe7, 0x96, 0x91, 0x9c, 0xb2, 0x6b, 0x2e,
+		0x98, 0x5d, 0x2d, 0xe1, 0x8a, 0xcd, 0x75, 0xd1, 0xd7, 0x67, 0xbf, 0x8b, 0x35, 0xd6, 0x35, 0xdc,
+		0x8f, 0x10, 0xf7, 0xd4, 0xf2, 0xf4, 0x2a, 0xea, 0xa9, 0x55, 0xb4, 0x05, 0x9f, 0x8a, 0x05, 0x17,
+		0x0b, 0x2e, 0x16, 0xfc, 0x85, 0x59, 0xf0, 0x41, 0x1a, 0xce, 0x86, 0xfc, 0x5d, 0x9f, 0x1d, 0xcd,
+		0xea, 0xe2, 0x11, 0xa8, 0xa0, 0xcb, 0xd3, 0xb1, 0xe7, 0xe9, 0x50, 0x44, 0x30, 0x94, 0x04, 0xc5,
+		0x66, 0x69, 0xb5, 0x11, 0x51, 0x79, 0xa0, 0x84, 0x45, 0x68, 0xb9, 0xd7, 0x86, 0x45, 0xc5, 0x35,
+		0x6d, 0xdf, 0x5b, 0x4b, 0xe3, 0x25, 0x4c, 0x92, 0x9e, 0x66, 0xb2, 0xd1, 0x3e, 0xb5, 0x8d, 0x56,
+		0xde, 0x83, 0xe3, 0x0c, 0x5c, 0x5c, 0x90, 0x59, 0x83, 0xcd, 0x1a, 0x74, 0x7c, 0xf0, 0xe1, 0x40,
+		0x88, 0x04, 0x23, 0xdd, 0xfb, 0xe3, 0x7b, 0x81, 0x44, 0x6f, 0x90, 0x3a, 0x03, 0xe9, 0xd0, 0xd2,
+		0xed, 0xf3, 0xaa, 0xf2, 0x90, 0xaa, 0x18, 0x20, 0x31, 0x40, 0x4f, 0xd4, 0x00, 0x0d, 0xe5, 0x5d,
+		0x0a, 0xa5, 0x20, 0xb2, 0xf4, 0x77, 0xb7, 0xcd, 0x57, 0x48, 0xad, 0x27, 0x84, 0xe9, 0x12, 0xa6,
+		0xeb, 0x71, 0x3a, 0x4f, 0x48, 0xe3, 0x09, 0xd1, 0x00, 0x09, 0x41, 0xed, 0xcc, 0x29, 0x07, 0x54,
+		0x4c, 0x70, 0x71, 0x41, 0x66, 0x0d, 0x36, 0x6b, 0xd0, 0xf1, 0xc1, 0x87, 0x03, 0x21, 0x12, 0x8c,
+		0x74, 0xb3, 0xdc, 0xbf, 0x07, 0x28, 0x11, 0x1e, 0x80, 0x44, 0x78, 0xa2, 0xdf, 0x2f, 0x57, 0xbf,
+		0x9f, 0x48, 0x00, 0xf5, 0x82, 0x5e, 0x48, 0x67, 0x51, 0x66, 0x18, 0x24, 0xab, 0x15, 0xa1, 0x78,
+		0xae, 0xbe, 0x35, 0x45, 0x87, 0x19, 0xe8, 0x66, 0x41, 0xe3, 0x6b, 0xa7, 0xbe, 0xee, 0xce, 0x1b,
+		0xc4, 0x8b, 0xb9, 0x4a, 0xb3, 0xaa, 0x4d, 0x50, 0x8f, 0x72, 0xd7, 0xad, 0xbb, 0x9e, 0xf2, 0xe3,
+		0xf7, 0x7b, 0xca, 0xe4, 0xcb, 0xc3, 0xef, 0x1f, 0xd8, 0x47, 0xe5, 0xc7, 0x1f, 0xbc, 0x6b, 0xfd,
+		0x39, 0x0c, 0x0f, 0x6d, 0xa7, 0x5a, 0x87, 0x8b, 0x64, 0xa5, 0x53, 0x23, 0x13, 0xdf, 0x78, 0x73,
+		0x5d, 0x91, 0xfb, 0xce, 0xdd, 0xfa, 0x43, 0xfa, 0x35, 0x0a, 0x66, 0x87, 0xbb, 0x86, 0xaa, 0x78,
+		0x3e, 0x39, 0x37, 0x3a, 0xf7, 0x74, 0x77, 0x6e, 0x21, 0xcb, 0x8b, 0xa4, 0x8e, 0x46, 0x35, 0x02,
+		0x3c, 0xd7, 0xb7, 0xfe, 0x7c, 0xab, 0xd3, 0xf7, 0xa3, 0xfb, 0xff, 0x00, 0x00, 0x00, 0xff, 0xff,
+		0x03, 0x00, 0xf3, 0xba, 0xe4, 0x80, 0xa4, 0xc8, 0x00, 0x00,
 	}
 )
 
@@ -0,0 +1,357 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaops_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/gnmidiff"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/integration_tests/schemaops/utestschema"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestDiffSetRequestUnionReplace(t *testing.T) {
+	// entryPath returns the path of the entry of unordered-list with the
+	// supplied key.
+	entryPath := func(key string) *gpb.Path {
+		return &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "unordered-lists"},
+			{Name: "unordered-list", Key: map[string]string{"key": key}},
+		}}
+	}
+	valuePath := func(key string) *gpb.Path {
+		p := entryPath(key)
+		p.Elem = append(p.Elem, &gpb.PathElem{Name: "config"}, &gpb.PathElem{Name: "value"})
+		return p
+	}
+	entry := func(key string, value *string) *gpb.TypedValue {
+		v, err := ygot.EncodeTypedValue(&ctestschema.UnorderedList{Key: ygot.String(key), Value: value}, gpb.Encoding_JSON_IETF)
+		if err != nil {
+			t.Fatalf("cannot encode list entry: %v", err)
+		}
+		return v
+	}
+	str := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+	}
+	str7951 := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`"` + s + `"`)}}
+	}
+	ascii := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: s}}
+	}
+	withOrigin := func(origin string, p *gpb.Path) *gpb.Path {
+		p.Origin = origin
+		return p
+	}
+	pathStr := func(p *gpb.Path) string {
+		s, err := ygot.PathToString(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	keys := func(paths ...string) map[string]struct{} {
+		m := map[string]struct{}{}
+		for _, p := range paths {
+			m[p] = struct{}{}
+		}
+		return m
+	}
+
+	tests := []struct {
+		desc            string
+		inA             *gpb.SetRequest
+		inB             *gpb.SetRequest
+		wantCommonDels  map[string]struct{}
+		wantMissingDels map[string]struct{}
+		wantExtraDels   map[string]struct{}
+		// wantCommonUpdates, if non-nil, are the updates that are
+		// common to both SetRequests.
+		wantCommonUpdates map[string]interface{}
+		wantErr           bool
+	}{{
+		desc: "union_replaces at the same path are merged",
+		inA: &gpb.SetRequest{
+			UnionReplace: []*gpb.Update{
+				{Path: entryPath("eth0"), Val: entry("eth0", nil)},
+				{Path: entryPath("eth0"), Val: entry("eth0", ygot.String("foo"))},
+			},
+		},
+		inB: &gpb.SetRequest{
+			Replace: []*gpb.Update{
+				{Path: entryPath("eth0"), Val: entry("eth0", ygot.String("foo"))},
+			},
+		},
+		wantCommonDels: keys(pathStr(entryPath("eth0"))),
+	}, {
+		desc: "union_replace within another union_replace with a slash in its key",
+		inA: &gpb.SetRequest{
+			UnionReplace: []*gpb.Update{
+				{Path: valuePath("eth0/1"), Val: str7951("bar")},
+				{Path: entryPath("eth0/1"), Val: entry("eth0/1", nil)},
+			},
+		},
+		inB: &gpb.SetRequest{
+			Replace: []*gpb.Update{
+				{Path: entryPath("eth0/1"), Val: entry("eth0/1", ygot.String("bar"))},
+			},
+		},
+		wantCommonDels: keys(pathStr(entryPath("eth0/1"))),
+	}, {
+		desc: "union_replaces of entries whose key extends that of another",
+		inA: &gpb.SetRequest{
+			UnionReplace: []*gpb.Update{
+				{Path: entryPath("eth0"), Val: entry("eth0", ygot.String("foo"))},
+				{Path: entryPath("eth0/1"), Val: entry("eth0/1", ygot.String("bar"))},
+			},
+		},
+		inB: &gpb.SetRequest{
+			Replace: []*gpb.Update{
+				{Path: entryPath("eth0"), Val: entry("eth0", ygot.String("foo"))},
+			},
+			Update: []*gpb.Update{
+				{Path: entryPath("eth0/1"), Val: entry("eth0/1", ygot.String("bar"))},
+			},
+		},
+		wantCommonDels:  keys(pathStr(entryPath("eth0"))),
+		wantMissingDels: keys(pathStr(entryPath("eth0/1"))),
+	}, {
+		desc: "conflicting replaces",
+		inA: &gpb.SetRequest{
+			Replace: []*gpb.Update{
+				{Path: entryPath("eth0/1"), Val: entry("eth0/1", ygot.String("foo"))},
+				{Path: valuePath("eth0/1"), Val: str("bar")},
+			},
+		},
+		inB:     &gpb.SetRequest{},
+		wantErr: true,
+	}, {
+		desc: "conflicting union_replace and replace",
+		inA: &gpb.SetRequest{
+			Replace: []*gpb.Update{
+				{Path: entryPath("eth0"), Val: entry("eth0", nil)},
+			},
+			UnionReplace: []*gpb.Update{
+				{Path: entryPath("eth0"), Val: entry("eth0", ygot.String("foo"))},
+			},
+		},
+		inB:     &gpb.SetRequest{},
+		wantErr: true,
+	}, {
+		desc: "union_replaces of openconfig and cli origins",
+		inA: &gpb.SetRequest{
+			UnionReplace: []*gpb.Update{
+				{Path: withOrigin("openconfig", valuePath("eth0")), Val: str7951("foo")},
+				{Path: &gpb.Path{Origin: "cli"}, Val: ascii("hostname foo")},
+			},
+		},
+		inB: &gpb.SetRequest{
+			Replace: []*gpb.Update{
+				{Path: &gpb.Path{Origin: "cli"}, Val: ascii("hostname foo")},
+				{Path: valuePath("eth0"), Val: str("foo")},
+			},
+		},
+		wantCommonDels: keys("cli:"),
+		wantCommonUpdates: map[string]interface{}{
+			pathStr(valuePath("eth0")): "foo",
+			"cli:":                     "hostname foo",
+		},
+	}, {
+		desc: "cli origin in prefix",
+		inA: &gpb.SetRequest{
+			Prefix: &gpb.Path{Origin: "cli"},
+			Delete: []*gpb.Path{ygot.MustStringToPath("/system")},
+			Update: []*gpb.Update{
+				{Path: ygot.MustStringToPath("/interfaces"), Val: ascii("mtu 9000")},
+			},
+		},
+		inB: &gpb.SetRequest{
+			Delete: []*gpb.Path{withOrigin("cli", ygot.MustStringToPath("/system"))},
+			Update: []*gpb.Update{
+				{Path: withOrigin("cli", ygot.MustStringToPath("/interfaces")), Val: ascii("mtu 9000")},
+			},
+		},
+		wantCommonDels: keys("cli:/system"),
+		wantCommonUpdates: map[string]interface{}{
+			"cli:/interfaces": "mtu 9000",
+		},
+	}, {
+		desc: "conflicting opaque union_replaces",
+		inA: &gpb.SetRequest{
+			UnionReplace: []*gpb.Update{
+				{Path: &gpb.Path{Origin: "cli"}, Val: ascii("hostname foo")},
+				{Path: &gpb.Path{Origin: "cli"}, Val: ascii("hostname bar")},
+			},
+		},
+		inB:     &gpb.SetRequest{},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema, err := ctestschema.Schema()
+			if err != nil {
+				t.Fatalf("cannot get schema: %v", err)
+			}
+			got, err := gnmidiff.DiffSetRequest(tt.inA, tt.inB, schema)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DiffSetRequest: got error %v, want error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			empty := map[string]struct{}{}
+			for _, d := range []struct {
+				name      string
+				got, want map[string]struct{}
+			}{
+				{"common deletes", got.CommonDeletes, tt.wantCommonDels},
+				{"missing deletes", got.MissingDeletes, tt.wantMissingDels},
+				{"extra deletes", got.ExtraDeletes, tt.wantExtraDels},
+			} {
+				want := d.want
+				if want == nil {
+					want = empty
+				}
+				if diff := cmp.Diff(want, d.got); diff != "" {
+					t.Errorf("DiffSetRequest: %s (-want, +got):\n%s", d.name, diff)
+				}
+			}
+			if tt.wantCommonUpdates != nil {
+				if diff := cmp.Diff(tt.wantCommonUpdates, got.CommonUpdates); diff != "" {
+					t.Errorf("DiffSetRequest: common updates (-want, +got):\n%s", diff)
+				}
+			}
+			if len(got.MissingUpdates) != 0 || len(got.ExtraUpdates) != 0 || len(got.MismatchedUpdates) != 0 {
+				t.Errorf("DiffSetRequest: got update differences:\n%s", got.Format(gnmidiff.Format{}))
+			}
+		})
+	}
+}
+
+func TestDiffSetRequestOrigins(t *testing.T) {
+	cschema, err := ctestschema.Schema()
+	if err != nil {
+		t.Fatalf("cannot get schema: %v", err)
+	}
+	uschema, err := utestschema.Schema()
+	if err != nil {
+		t.Fatalf("cannot get schema: %v", err)
+	}
+	origins := &ytypes.Origins{Schemas: map[string]*ytypes.Schema{
+		"compressed":   cschema,
+		"uncompressed": uschema,
+	}}
+
+	// entryPath returns the path of the entry of unordered-list with the
+	// supplied key within origin.
+	entryPath := func(origin, key string) *gpb.Path {
+		return &gpb.Path{Origin: origin, Elem: []*gpb.PathElem{
+			{Name: "unordered-lists"},
+			{Name: "unordered-list", Key: map[string]string{"key": key}},
+		}}
+	}
+	valuePath := func(origin, key string) *gpb.Path {
+		p := entryPath(origin, key)
+		p.Elem = append(p.Elem, &gpb.PathElem{Name: "config"}, &gpb.PathElem{Name: "value"})
+		return p
+	}
+	entry := func(key, value string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"key": "` + key + `", "config": {"key": "` + key + `", "value": "` + value + `"}}`)}}
+	}
+	keyOnly := func(key string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"key": "` + key + `", "config": {"key": "` + key + `"}}`)}}
+	}
+	str := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+	}
+
+	a := &gpb.SetRequest{
+		Replace: []*gpb.Update{
+			{Path: entryPath("compressed", "eth0"), Val: entry("eth0", "foo")},
+			{Path: entryPath("uncompressed", "eth0"), Val: entry("eth0", "bar")},
+		},
+	}
+	b := &gpb.SetRequest{
+		Replace: []*gpb.Update{
+			{Path: entryPath("compressed", "eth0"), Val: keyOnly("eth0")},
+			{Path: entryPath("uncompressed", "eth0"), Val: keyOnly("eth0")},
+		},
+		Update: []*gpb.Update{
+			{Path: valuePath("compressed", "eth0"), Val: str("foo")},
+			{Path: valuePath("uncompressed", "eth0"), Val: str("baz")},
+		},
+	}
+
+	tests := []struct {
+		desc    string
+		inOpts  []ytypes.UnmarshalOpt
+		want    gnmidiff.SetRequestIntentDiff
+		wantErr bool
+	}{{
+		desc:   "origins decoded against their schemas",
+		inOpts: []ytypes.UnmarshalOpt{origins},
+		want: gnmidiff.SetRequestIntentDiff{
+			DeleteDiff: gnmidiff.DeleteDiff{
+				MissingDeletes: map[string]struct{}{},
+				ExtraDeletes:   map[string]struct{}{},
+				CommonDeletes: map[string]struct{}{
+					"compressed:/unordered-lists/unordered-list[key=eth0]":   {},
+					"uncompressed:/unordered-lists/unordered-list[key=eth0]": {},
+				},
+			},
+			UpdateDiff: gnmidiff.UpdateDiff{
+				MissingUpdates: map[string]interface{}{},
+				ExtraUpdates:   map[string]interface{}{},
+				CommonUpdates: map[string]interface{}{
+					"compressed:/unordered-lists/unordered-list[key=eth0]/config/key":   "eth0",
+					"compressed:/unordered-lists/unordered-list[key=eth0]/config/value": "foo",
+					"compressed:/unordered-lists/unordered-list[key=eth0]/key":          "eth0",
+					"uncompressed:/unordered-lists/unordered-list[key=eth0]/config/key": "eth0",
+					"uncompressed:/unordered-lists/unordered-list[key=eth0]/key":        "eth0",
+				},
+				MismatchedUpdates: map[string]gnmidiff.MismatchedUpdate{
+					"uncompressed:/unordered-lists/unordered-list[key=eth0]/config/value": {A: "bar", B: "baz"},
+				},
+			},
+		},
+	}, {
+		// The value of the replaced entry is opaque, hence an update
+		// within it conflicts with it.
+		desc:    "origins without schemas are opaque",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := gnmidiff.DiffSetRequest(a, b, cschema, tt.inOpts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DiffSetRequest: got error %v, want error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DiffSetRequest (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
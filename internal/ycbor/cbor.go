@@ -0,0 +1,446 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ycbor implements the subset of CBOR (RFC 8949) that is required to
+// encode and decode YANG data trees as per RFC 9254, mapping CBOR data items
+// to and from generic Go values.
+package ycbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CBOR tags used by the YANG-CBOR encoding, as defined in RFC 9254.
+const (
+	// TagDecimalFraction is the tag of a decimal64 value, which is encoded
+	// as a decimal fraction, i.e., an array of [exponent, mantissa].
+	TagDecimalFraction uint64 = 4
+	// TagBits is the tag of a bits value within a union.
+	TagBits uint64 = 43
+	// TagEnumeration is the tag of an enumeration value within a union.
+	TagEnumeration uint64 = 44
+	// TagIdentityref is the tag of an identityref value that is encoded as
+	// a SID within a union.
+	TagIdentityref uint64 = 45
+	// TagInstanceIdentifier is the tag of an instance-identifier value that
+	// is encoded as a SID within a union.
+	TagInstanceIdentifier uint64 = 46
+	// TagAbsoluteSID is the tag of a key that is an absolute SID rather
+	// than a delta from the SID of its parent.
+	TagAbsoluteSID uint64 = 47
+)
+
+// Major types of CBOR data items.
+const (
+	majorUint byte = iota
+	majorNint
+	majorBytes
+	majorText
+	majorArray
+	majorMap
+	majorTag
+	majorSimple
+)
+
+// Tag is a tagged CBOR data item.
+type Tag struct {
+	// Number is the tag number.
+	Number uint64
+	// Content is the data item that is tagged.
+	Content any
+}
+
+// Null is the CBOR null value. It can be used where a nil value would
+// otherwise be interpreted as the absence of a value. Null values are
+// decoded as nil.
+type Null struct{}
+
+// Marshal returns the CBOR encoding of v, which must be composed of nil,
+// Null, bool, string, []byte, signed and unsigned integers, float32, float64,
+// Tag, []any, map[string]any and map[int64]any values. The entries of maps
+// are output in the deterministic order of RFC 8949 section 4.2.1.
+func Marshal(v any) ([]byte, error) {
+	var b bytes.Buffer
+	if err := encode(&b, v); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// writeHead writes the initial byte of a data item of major type m, along
+// with its argument n, to b using the shortest possible encoding.
+func writeHead(b *bytes.Buffer, m byte, n uint64) {
+	m <<= 5
+	switch {
+	case n < 24:
+		b.WriteByte(m | byte(n))
+	case n <= math.MaxUint8:
+		b.Write([]byte{m | 24, byte(n)})
+	case n <= math.MaxUint16:
+		b.WriteByte(m | 25)
+		b.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	case n <= math.MaxUint32:
+		b.WriteByte(m | 26)
+		b.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	default:
+		b.WriteByte(m | 27)
+		b.Write(binary.BigEndian.AppendUint64(nil, n))
+	}
+}
+
+// writeInt writes the signed integer i to b.
+func writeInt(b *bytes.Buffer, i int64) {
+	if i < 0 {
+		writeHead(b, majorNint, uint64(-(i + 1)))
+		return
+	}
+	writeHead(b, majorUint, uint64(i))
+}
+
+// encode writes the CBOR encoding of v to b.
+func encode(b *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case nil, Null:
+		b.WriteByte(majorSimple<<5 | 22)
+	case bool:
+		if v {
+			b.WriteByte(majorSimple<<5 | 21)
+		} else {
+			b.WriteByte(majorSimple<<5 | 20)
+		}
+	case string:
+		writeHead(b, majorText, uint64(len(v)))
+		b.WriteString(v)
+	case []byte:
+		writeHead(b, majorBytes, uint64(len(v)))
+		b.Write(v)
+	case int:
+		writeInt(b, int64(v))
+	case int8:
+		writeInt(b, int64(v))
+	case int16:
+		writeInt(b, int64(v))
+	case int32:
+		writeInt(b, int64(v))
+	case int64:
+		writeInt(b, v)
+	case uint:
+		writeHead(b, majorUint, uint64(v))
+	case uint8:
+		writeHead(b, majorUint, uint64(v))
+	case uint16:
+		writeHead(b, majorUint, uint64(v))
+	case uint32:
+		writeHead(b, majorUint, uint64(v))
+	case uint64:
+		writeHead(b, majorUint, v)
+	case float32:
+		b.WriteByte(majorSimple<<5 | 26)
+		b.Write(binary.BigEndian.AppendUint32(nil, math.Float32bits(v)))
+	case float64:
+		b.WriteByte(majorSimple<<5 | 27)
+		b.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(v)))
+	case Tag:
+		writeHead(b, majorTag, v.Number)
+		return encode(b, v.Content)
+	case []any:
+		writeHead(b, majorArray, uint64(len(v)))
+		for _, e := range v {
+			if err := encode(b, e); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		keys := make([]any, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		return encodeMap(b, keys, func(k any) any { return v[k.(string)] })
+	case map[int64]any:
+		keys := make([]any, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		return encodeMap(b, keys, func(k any) any { return v[k.(int64)] })
+	default:
+		return fmt.Errorf("cannot encode value %v of type %T as CBOR", v, v)
+	}
+	return nil
+}
+
+// encodeMap writes a map with the given keys to b, where the value of each
+// key is returned by val. The entries are sorted by the bytewise
+// lexicographic order of the encoded keys.
+func encodeMap(b *bytes.Buffer, keys []any, val func(any) any) error {
+	type entry struct {
+		k []byte
+		v any
+	}
+	entries := make([]entry, 0, len(keys))
+	for _, k := range keys {
+		ek, err := Marshal(k)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{k: ek, v: val(k)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].k, entries[j].k) < 0 })
+
+	writeHead(b, majorMap, uint64(len(entries)))
+	for _, e := range entries {
+		b.Write(e.k)
+		if err := encode(b, e.v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unmarshal decodes the single CBOR data item in data into a generic Go
+// value. Unsigned and negative integers are decoded as int64 where they are
+// within its range, and unsigned integers larger than this as uint64. Text
+// strings are decoded as string, byte strings as []byte, floating-point
+// values as float64, null and undefined as nil, arrays as []any, maps as
+// map[any]any and tagged data items as Tag. Data whose arrays, maps and tags
+// are nested more than 1000 deep is rejected.
+func Unmarshal(data []byte) (any, error) {
+	d := &decoder{data: data}
+	v, err := d.value()
+	if err != nil {
+		return nil, err
+	}
+	if d.off != len(d.data) {
+		return nil, fmt.Errorf("invalid CBOR: %d bytes of trailing data", len(d.data)-d.off)
+	}
+	return v, nil
+}
+
+// errBreak is returned by decoder.value when a break stop code is read.
+var errBreak = errors.New("unexpected CBOR break")
+
+// indefinite is the additional information of an indefinite-length item.
+const indefinite = 31
+
+// maxDepth is the maximum depth to which arrays, maps and tags may be nested
+// within the decoded data, such that untrusted input cannot exhaust the stack
+// of the decoding goroutine.
+const maxDepth = 1000
+
+// decoder decodes CBOR data items from data, starting at offset off.
+type decoder struct {
+	data []byte
+	off  int
+	// depth is the number of arrays, maps and tags that enclose the data
+	// item being decoded.
+	depth int
+}
+
+// read returns the next n bytes of the input.
+func (d *decoder) read(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.off) {
+		return nil, errors.New("invalid CBOR: unexpected end of data")
+	}
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+// head reads the initial byte of a data item, returning its major type and
+// additional information, along with its argument. The argument is not
+// read for indefinite-length items.
+func (d *decoder) head() (byte, byte, uint64, error) {
+	ib, err := d.read(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	m, ai := ib[0]>>5, ib[0]&0x1f
+	var n uint64
+	switch {
+	case ai < 24:
+		n = uint64(ai)
+	case ai <= 27:
+		b, err := d.read(1 << (ai - 24))
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		for _, c := range b {
+			n = n<<8 | uint64(c)
+		}
+	case ai == indefinite && (m == majorBytes || m == majorText || m == majorArray || m == majorMap || m == majorSimple):
+	default:
+		return 0, 0, 0, fmt.Errorf("invalid CBOR: additional information %d for major type %d", ai, m)
+	}
+	return m, ai, n, nil
+}
+
+// value decodes the next data item.
+func (d *decoder) value() (any, error) {
+	m, ai, n, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	if m == majorArray || m == majorMap || m == majorTag {
+		if d.depth == maxDepth {
+			return nil, fmt.Errorf("invalid CBOR: data items are nested more than %d deep", maxDepth)
+		}
+		d.depth++
+		defer func() { d.depth-- }()
+	}
+
+	switch m {
+	case majorUint:
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case majorNint:
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("negative integer -1-%d overflows int64", n)
+		}
+		return -1 - int64(n), nil
+	case majorBytes, majorText:
+		var s []byte
+		if ai == indefinite {
+			for {
+				cm, cai, cn, err := d.head()
+				if err != nil {
+					return nil, err
+				}
+				if cm == majorSimple && cai == indefinite {
+					break
+				}
+				if cm != m || cai == indefinite {
+					return nil, errors.New("invalid CBOR: invalid chunk in indefinite-length string")
+				}
+				c, err := d.read(cn)
+				if err != nil {
+					return nil, err
+				}
+				s = append(s, c...)
+			}
+		} else {
+			c, err := d.read(n)
+			if err != nil {
+				return nil, err
+			}
+			s = append([]byte{}, c...)
+		}
+		if m == majorText {
+			return string(s), nil
+		}
+		return s, nil
+	case majorArray:
+		a := []any{}
+		for i := uint64(0); ai == indefinite || i < n; i++ {
+			v, err := d.value()
+			if err == errBreak && ai == indefinite {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		return a, nil
+	case majorMap:
+		mv := map[any]any{}
+		for i := uint64(0); ai == indefinite || i < n; i++ {
+			k, err := d.value()
+			if err == errBreak && ai == indefinite {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !isValidKey(k) {
+				return nil, fmt.Errorf("unsupported CBOR map key %v of type %T", k, k)
+			}
+			v, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := mv[k]; ok {
+				return nil, fmt.Errorf("duplicate CBOR map key %v", k)
+			}
+			mv[k] = v
+		}
+		return mv, nil
+	case majorTag:
+		v, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		return Tag{Number: n, Content: v}, nil
+	}
+
+	// Major type 7, simple values and floating-point numbers.
+	switch {
+	case ai == 20:
+		return false, nil
+	case ai == 21:
+		return true, nil
+	case ai == 22, ai == 23:
+		return nil, nil
+	case ai == 25:
+		return halfToFloat64(uint16(n)), nil
+	case ai == 26:
+		return float64(math.Float32frombits(uint32(n))), nil
+	case ai == 27:
+		return math.Float64frombits(n), nil
+	case ai == indefinite:
+		return nil, errBreak
+	}
+	return nil, fmt.Errorf("unsupported CBOR simple value %d", n)
+}
+
+// isValidKey returns true if k is a supported map key, i.e., an integer, a
+// text string, or a tagged integer.
+func isValidKey(k any) bool {
+	switch k := k.(type) {
+	case int64, uint64, string:
+		return true
+	case Tag:
+		switch k.Content.(type) {
+		case int64, uint64:
+			return true
+		}
+	}
+	return false
+}
+
+// halfToFloat64 returns the value of the IEEE 754 half-precision number h.
+func halfToFloat64(h uint16) float64 {
+	exp, mant := int(h>>10)&0x1f, float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		f = math.Inf(1)
+		if mant != 0 {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
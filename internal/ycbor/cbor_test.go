@@ -0,0 +1,254 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ycbor
+
+import (
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	// Test vectors are from RFC 8949 Appendix A, where applicable.
+	tests := []struct {
+		desc string
+		in   any
+		// want is the hex encoding of the expected output.
+		want string
+		// wantDecoded is the value expected to be decoded from want, where it
+		// differs from in.
+		wantDecoded any
+	}{{
+		desc: "small unsigned integer",
+		in:   int64(10),
+		want: "0a",
+	}, {
+		desc: "one byte unsigned integer",
+		in:   int64(100),
+		want: "1864",
+	}, {
+		desc: "eight byte unsigned integer",
+		in:   uint64(18446744073709551615),
+		want: "1bffffffffffffffff",
+	}, {
+		desc:        "unsigned integer of narrow type",
+		in:          uint16(1000),
+		want:        "1903e8",
+		wantDecoded: int64(1000),
+	}, {
+		desc: "negative integer",
+		in:   int64(-1000),
+		want: "3903e7",
+	}, {
+		desc: "float",
+		in:   1.1,
+		want: "fb3ff199999999999a",
+	}, {
+		desc: "booleans and null",
+		in:   []any{false, true, nil},
+		want: "83f4f5f6",
+	}, {
+		desc:        "explicit null",
+		in:          Null{},
+		want:        "f6",
+		wantDecoded: nil,
+	}, {
+		desc: "text string",
+		in:   "IETF",
+		want: "6449455446",
+	}, {
+		desc: "byte string",
+		in:   []byte{1, 2, 3, 4},
+		want: "4401020304",
+	}, {
+		desc: "decimal fraction",
+		in:   Tag{Number: TagDecimalFraction, Content: []any{int64(-2), int64(27315)}},
+		want: "c48221196ab3",
+	}, {
+		desc: "map with text keys in deterministic order",
+		in:   map[string]any{"b": int64(2), "a": int64(1), "aa": int64(3)},
+		want: "a3616101616202626161" + "03",
+		wantDecoded: map[any]any{
+			"a": int64(1), "b": int64(2), "aa": int64(3),
+		},
+	}, {
+		desc: "map with integer keys in deterministic order",
+		in:   map[int64]any{-1: "x", 10: "y", 1: "z"},
+		want: "a3" + "01617a" + "0a6179" + "206178",
+		wantDecoded: map[any]any{
+			int64(-1): "x", int64(10): "y", int64(1): "z",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("Marshal(%v): got unexpected error: %v", tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, hex.EncodeToString(got)); diff != "" {
+				t.Errorf("Marshal(%v): did not get expected output, diff(-want,+got):\n%s", tt.in, diff)
+			}
+
+			want := tt.in
+			if tt.wantDecoded != nil || tt.in == (Null{}) {
+				want = tt.wantDecoded
+			}
+			decoded, err := Unmarshal(got)
+			if err != nil {
+				t.Fatalf("Unmarshal(%x): got unexpected error: %v", got, err)
+			}
+			if diff := cmp.Diff(want, decoded); diff != "" {
+				t.Errorf("Unmarshal(%x): did not get expected output, diff(-want,+got):\n%s", got, diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               string
+		want             any
+		wantErrSubstring string
+	}{{
+		desc: "half-precision float",
+		in:   "f93c00",
+		want: 1.0,
+	}, {
+		desc: "negative half-precision float",
+		in:   "f9c400",
+		want: -4.0,
+	}, {
+		desc: "half-precision infinity",
+		in:   "f97c00",
+		want: math.Inf(1),
+	}, {
+		desc: "single-precision float",
+		in:   "fa47c35000",
+		want: 100000.0,
+	}, {
+		desc: "undefined",
+		in:   "f7",
+		want: nil,
+	}, {
+		desc: "indefinite-length text string",
+		in:   "7f657374726561646d696e67ff",
+		want: "streaming",
+	}, {
+		desc: "indefinite-length array and map",
+		in:   "bf61619f0102ffff",
+		want: map[any]any{"a": []any{int64(1), int64(2)}},
+	}, {
+		desc: "tagged key",
+		in:   "a1d82f1903e8f6",
+		want: map[any]any{Tag{Number: TagAbsoluteSID, Content: int64(1000)}: nil},
+	}, {
+		desc:             "truncated input",
+		in:               "1903",
+		wantErrSubstring: "unexpected end of data",
+	}, {
+		desc:             "trailing data",
+		in:               "0101",
+		wantErrSubstring: "1 bytes of trailing data",
+	}, {
+		desc:             "unexpected break",
+		in:               "ff",
+		wantErrSubstring: "unexpected CBOR break",
+	}, {
+		desc:             "duplicate map key",
+		in:               "a2616101616102",
+		wantErrSubstring: "duplicate CBOR map key a",
+	}, {
+		desc:             "unsupported map key",
+		in:               "a18001",
+		wantErrSubstring: "unsupported CBOR map key",
+	}, {
+		desc:             "overflowing negative integer",
+		in:               "3bffffffffffffffff",
+		wantErrSubstring: "overflows int64",
+	}, {
+		desc:             "arrays nested too deeply",
+		in:               strings.Repeat("81", maxDepth+1) + "00",
+		wantErrSubstring: "nested more than 1000 deep",
+	}, {
+		desc:             "indefinite-length maps nested too deeply",
+		in:               strings.Repeat("bf6161", maxDepth+1) + "00",
+		wantErrSubstring: "nested more than 1000 deep",
+	}, {
+		desc:             "tags nested too deeply",
+		in:               strings.Repeat("c1", maxDepth+1) + "00",
+		wantErrSubstring: "nested more than 1000 deep",
+	}, {
+		desc:             "megabytes of nested arrays",
+		in:               strings.Repeat("81", 4<<20),
+		wantErrSubstring: "nested more than 1000 deep",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in, err := hex.DecodeString(tt.in)
+			if err != nil {
+				t.Fatalf("invalid test input %s: %v", tt.in, err)
+			}
+			got, err := Unmarshal(in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Unmarshal(%s): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Unmarshal(%s): did not get expected output, diff(-want,+got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalMaxDepth(t *testing.T) {
+	in, err := hex.DecodeString(strings.Repeat("81", maxDepth) + "00")
+	if err != nil {
+		t.Fatalf("invalid test input: %v", err)
+	}
+	got, err := Unmarshal(in)
+	if err != nil {
+		t.Fatalf("Unmarshal(): got unexpected error: %v", err)
+	}
+	depth := 0
+	for {
+		a, ok := got.([]any)
+		if !ok {
+			break
+		}
+		if len(a) != 1 {
+			t.Fatalf("Unmarshal(): got array %v at depth %d, want array of one item", a, depth)
+		}
+		got = a[0]
+		depth++
+	}
+	if depth != maxDepth || got != int64(0) {
+		t.Errorf("Unmarshal(): got %v nested in %d arrays, want 0 nested in %d arrays", got, depth, maxDepth)
+	}
+}
+
+func TestMarshalError(t *testing.T) {
+	if _, err := Marshal(struct{}{}); err == nil {
+		t.Errorf("Marshal(struct{}{}): did not get expected error")
+	}
+}
@@ -165,3 +165,22 @@ func GetOrderedMapElement(om goOrderedMap, k reflect.Value) (reflect.Value, bool
 
 	return v, !v.IsZero(), nil
 }
+
+// DeleteFromOrderedMap calls the given ordered map's Delete function given the
+// key value, and returns whether an element was deleted.
+func DeleteFromOrderedMap(om goOrderedMap, k reflect.Value) (bool, error) {
+	deleteMethod, err := MethodByName(reflect.ValueOf(om), "Delete")
+	if err != nil {
+		return false, err
+	}
+
+	ret := deleteMethod.Call([]reflect.Value{k})
+	if got, wantReturnN := len(ret), 1; got != wantReturnN {
+		return false, fmt.Errorf("method Delete() doesn't have expected number of return values, got %v, want %v", got, wantReturnN)
+	}
+	if gotKind := ret[0].Kind(); gotKind != reflect.Bool {
+		return false, fmt.Errorf("method Delete() did not return a bool value, got %v", gotKind)
+	}
+
+	return ret[0].Bool(), nil
+}
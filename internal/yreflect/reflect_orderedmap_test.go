@@ -121,3 +121,48 @@ func TestGetOrderedMapElement(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteFromOrderedMap(t *testing.T) {
+	tests := []struct {
+		desc          string
+		inMap         ygot.GoOrderedMap
+		inKey         any
+		wantDeleted   bool
+		wantKeys      []string
+		wantErrSubstr string
+	}{{
+		desc:        "has",
+		inMap:       ctestschema.GetOrderedMap(t),
+		inKey:       "foo",
+		wantDeleted: true,
+		wantKeys:    []string{"bar"},
+	}, {
+		desc:     "has-not",
+		inMap:    ctestschema.GetOrderedMap(t),
+		inKey:    "fooo",
+		wantKeys: []string{"foo", "bar"},
+	}, {
+		desc:          "invalid",
+		inMap:         &invalidOrderedMapType{},
+		wantErrSubstr: "did not find Delete() method on type",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := yreflect.DeleteFromOrderedMap(tt.inMap, reflect.ValueOf(tt.inKey))
+			if diff := errdiff.Substring(err, tt.wantErrSubstr); diff != "" {
+				t.Fatalf("DeleteFromOrderedMap: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if got != tt.wantDeleted {
+				t.Errorf("got deleted: %v, want: %v", got, tt.wantDeleted)
+			}
+			if diff := cmp.Diff(tt.wantKeys, tt.inMap.(*ctestschema.OrderedList_OrderedMap).Keys()); diff != "" {
+				t.Errorf("keys (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
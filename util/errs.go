@@ -31,6 +31,12 @@ func (e Errors) String() string {
 	return e.Error()
 }
 
+// Unwrap returns the errors within the slice, such that errors.Is and
+// errors.As consider each of them.
+func (e Errors) Unwrap() []error {
+	return []error(e)
+}
+
 // NewErrs returns a slice of error with a single element err.
 // If err is nil, returns nil.
 func NewErrs(err error) Errors {
@@ -94,15 +100,17 @@ func PrefixErrors(errs Errors, pfx string) Errors {
 }
 
 // UniqueErrors returns the unique errors from the supplied Errors slice. Errors
-// are considered equal if they have equal stringified values.
+// are considered equal if they have equal stringified values. The first of
+// each set of equal errors is returned, in the order of the supplied slice.
 func UniqueErrors(errs Errors) Errors {
-	u := map[string]error{}
-	for _, err := range errs {
-		u[fmt.Sprintf("%v", err)] = err
-	}
-
+	seen := map[string]bool{}
 	var ne Errors
-	for _, err := range u {
+	for _, err := range errs {
+		s := fmt.Sprintf("%v", err)
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
 		ne = append(ne, err)
 	}
 	return ne
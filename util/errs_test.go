@@ -44,6 +44,14 @@ func TestToString(t *testing.T) {
 	}
 }
 
+func TestUnwrap(t *testing.T) {
+	target := errors.New("err3")
+	var err error = Errors{fmt.Errorf("err1"), fmt.Errorf("wrapped: %w", target)}
+	if !errors.Is(err, target) {
+		t.Errorf("errors.Is(%v, %v): got false, want true", err, target)
+	}
+}
+
 func TestNewErrs(t *testing.T) {
 	var errs Errors
 	errs = NewErrs(nil)
@@ -201,3 +209,11 @@ func TestUniqueErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestUniqueErrorsOrder(t *testing.T) {
+	in := Errors{errors.New("two"), errors.New("one"), errors.New("two")}
+	want := Errors{errors.New("two"), errors.New("one")}
+	if got := UniqueErrors(in); len(got) != len(want) || !errsEqual(got, want) {
+		t.Errorf("UniqueErrors(%v): did not get expected result, got: %v, want: %v", in, got, want)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/openconfig/goyang/pkg/yang"
@@ -598,6 +599,30 @@ type PathQueryResult struct {
 type PathQueryNodeMemo struct {
 	Parent *PathQueryNodeMemo
 	Memo   PathQueryMemo
+
+	// mu guards Memo when it is accessed using Get and Set.
+	mu sync.RWMutex
+}
+
+// Get returns the result of the previous query for the path query from the
+// node, and whether such a query was made. It is safe for concurrent use with
+// Set.
+func (node *PathQueryNodeMemo) Get(query string) (PathQueryResult, bool) {
+	node.mu.RLock()
+	defer node.mu.RUnlock()
+	r, ok := node.Memo[query]
+	return r, ok
+}
+
+// Set stores the result of the query for the path query from the node. It is
+// safe for concurrent use with Get.
+func (node *PathQueryNodeMemo) Set(query string, r PathQueryResult) {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	if node.Memo == nil {
+		node.Memo = PathQueryMemo{}
+	}
+	node.Memo[query] = r
 }
 
 // GetRoot returns the PathQueryNodeMemo of the current node's tree's root.
@@ -17,6 +17,7 @@ package util
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -30,6 +31,36 @@ import (
 // SchemaTree.
 const CompressedSchemaAnnotation string = "isCompressedSchema"
 
+// WhenXPathAnnotation stores the name of the annotation recording the XPath
+// of the when statement that is specified on a schema entry itself, which is
+// empty if there is none. It distinguishes the entry's own when statement
+// from those it inherits from augment and uses statements, all of which are
+// stored within the entry's Extra field, and is added by ygen to each entry
+// that has a when statement.
+const WhenXPathAnnotation string = "whenXPath"
+
+// RestrictionErrorsAnnotation stores the name of the annotation recording the
+// error-message and error-app-tag substatements of the range, length and
+// pattern restrictions of the type of a leaf or leaf-list, as returned by
+// EntryRestrictionErrors. It is added by ygen to each entry whose type has
+// such restrictions, since the statements are otherwise only available from
+// the entry's Node.
+const RestrictionErrorsAnnotation string = "restrictionErrors"
+
+// ModuleNamespacesAnnotation stores the name of the annotation recording the
+// XML namespace of each YANG module from which a schema tree was built, keyed
+// by the name of the module, as returned by ModuleNamespaces. It is added by
+// ygen to the root entry of the schema tree, since the namespaces are
+// otherwise only available from the entries' Nodes.
+const ModuleNamespacesAnnotation string = "moduleNamespaces"
+
+// MetadataAnnotationsAnnotation stores the name of the annotation recording
+// the RFC 7952 metadata annotations defined by the YANG modules from which a
+// schema tree was built, as returned by MetadataAnnotations. It is added by
+// ygen to the root entry of the schema tree, since the md:annotation
+// statements are otherwise only available from the entries' Nodes.
+const MetadataAnnotationsAnnotation string = "metadataAnnotations"
+
 // Children returns all child elements of a directory element e that are not
 // RPC entries.
 func Children(e *yang.Entry) []*yang.Entry {
@@ -595,3 +626,319 @@ func ListKeyFieldsMap(e *yang.Entry) map[string]bool {
 	}
 	return r
 }
+
+// RestrictionError records the error-message and error-app-tag substatements
+// of a range, length or pattern restriction of a YANG type.
+type RestrictionError struct {
+	// Statement is the keyword of the restriction, i.e., "range",
+	// "length" or "pattern".
+	Statement string `json:"statement"`
+	// Argument is the argument of the restriction statement.
+	Argument string `json:"argument"`
+	// ErrorMessage and ErrorAppTag are the arguments of the error-message
+	// and error-app-tag substatements of the restriction.
+	ErrorMessage string `json:"error-message,omitempty"`
+	ErrorAppTag  string `json:"error-app-tag,omitempty"`
+}
+
+// TypeRestrictionErrors returns the RestrictionErrors of the restrictions
+// that apply to values of the type specified by the type statement t. These
+// are the patterns specified by t and by each of the typedefs that it is
+// derived from, along with the most derived range and length statements, since
+// these replace those of the typedefs. For a union, the restrictions of each
+// of its member types are included. Restrictions that have neither an
+// error-message nor an error-app-tag are omitted.
+func TypeRestrictionErrors(t *yang.Type) []*RestrictionError {
+	var out []*RestrictionError
+	appendRes := func(stmt, arg string, msg, tag *yang.Value) {
+		if msg == nil && tag == nil {
+			return
+		}
+		re := &RestrictionError{Statement: stmt, Argument: arg}
+		if msg != nil {
+			re.ErrorMessage = msg.Name
+		}
+		if tag != nil {
+			re.ErrorAppTag = tag.Name
+		}
+		out = append(out, re)
+	}
+
+	seen := map[*yang.Type]bool{}
+	var add func(t *yang.Type, hasRange, hasLength bool)
+	add = func(t *yang.Type, hasRange, hasLength bool) {
+		if t == nil || seen[t] {
+			return
+		}
+		seen[t] = true
+		if r := t.Range; r != nil && !hasRange {
+			appendRes("range", r.Name, r.ErrorMessage, r.ErrorAppTag)
+			hasRange = true
+		}
+		if l := t.Length; l != nil && !hasLength {
+			appendRes("length", l.Name, l.ErrorMessage, l.ErrorAppTag)
+			hasLength = true
+		}
+		for _, p := range t.Pattern {
+			appendRes("pattern", p.Name, p.ErrorMessage, p.ErrorAppTag)
+		}
+		if t.YangType != nil {
+			add(t.YangType.Base, hasRange, hasLength)
+		}
+		for _, ut := range t.Type {
+			add(ut, false, false)
+		}
+	}
+	add(t, false, false)
+	return out
+}
+
+// YangTypeRestrictionErrors returns the RestrictionErrors of the restrictions
+// that apply to values of the resolved type t, as per TypeRestrictionErrors.
+// Since a YangType does not refer to the type statement that it was resolved
+// from, only the restrictions specified by the typedefs that it is derived
+// from are known; the range and length statements of these are omitted where
+// the type statement replaces them. EntryRestrictionErrors should be used
+// where the schema entry is available.
+func YangTypeRestrictionErrors(t *yang.YangType) []*RestrictionError {
+	if t == nil || t.Base == nil {
+		return nil
+	}
+	res := TypeRestrictionErrors(t.Base)
+	base := t.Base.YangType
+	if base == nil {
+		return res
+	}
+	var out []*RestrictionError
+	for _, r := range res {
+		switch {
+		case r.Statement == "range" && !t.Range.Equal(base.Range):
+		case r.Statement == "length" && !t.Length.Equal(base.Length):
+		default:
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// EntryRestrictionErrors returns the RestrictionErrors of the type of the leaf
+// or leaf-list e, as per TypeRestrictionErrors. They are determined from the
+// entry's Node where it is available, or otherwise from the annotation added by
+// ygen, such that they are available for the schemas stored within generated
+// code.
+func EntryRestrictionErrors(e *yang.Entry) []*RestrictionError {
+	if e == nil {
+		return nil
+	}
+	switch n := e.Node.(type) {
+	case *yang.Leaf:
+		return TypeRestrictionErrors(n.Type)
+	case *yang.LeafList:
+		return TypeRestrictionErrors(n.Type)
+	}
+
+	switch a := e.Annotation[RestrictionErrorsAnnotation].(type) {
+	case []*RestrictionError:
+		return a
+	case []interface{}:
+		// The annotation has been unmarshalled from JSON.
+		var out []*RestrictionError
+		for _, v := range a {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			str := func(k string) string {
+				s, _ := m[k].(string)
+				return s
+			}
+			out = append(out, &RestrictionError{
+				Statement:    str("statement"),
+				Argument:     str("argument"),
+				ErrorMessage: str("error-message"),
+				ErrorAppTag:  str("error-app-tag"),
+			})
+		}
+		return out
+	}
+	return nil
+}
+
+// ModuleNamespaces returns the XML namespace of each of the YANG modules from
+// which the schema tree containing e was built, keyed by the name of the
+// module. The namespaces are taken from the ModuleNamespacesAnnotation of the
+// root entry of the tree where it is present, and otherwise from the module
+// that is the Node of the root entry. It returns nil if neither is available.
+func ModuleNamespaces(e *yang.Entry) map[string]string {
+	if e == nil {
+		return nil
+	}
+	for e.Parent != nil {
+		e = e.Parent
+	}
+
+	switch a := e.Annotation[ModuleNamespacesAnnotation].(type) {
+	case map[string]string:
+		return a
+	case map[string]interface{}:
+		// The annotation has been unmarshalled from the JSON
+		// serialisation of the schema.
+		ns := map[string]string{}
+		for k, v := range a {
+			if s, ok := v.(string); ok {
+				ns[k] = s
+			}
+		}
+		return ns
+	}
+
+	m, ok := e.Node.(*yang.Module)
+	if !ok || m.Modules == nil {
+		return nil
+	}
+	ns := map[string]string{}
+	for _, mod := range m.Modules.Modules {
+		if mod.Namespace != nil {
+			ns[mod.Name] = mod.Namespace.Name
+		}
+	}
+	return ns
+}
+
+// MetadataAnnotations returns the types of the RFC 7952 metadata annotations
+// defined by the YANG modules from which the schema tree containing e was
+// built, keyed by their names as used in RFC 7951 JSON, as per
+// ModuleMetadataAnnotations. The annotations are taken from the
+// MetadataAnnotationsAnnotation of the root entry of the tree where it is
+// present, and otherwise from the modules of the Node of the root entry. It
+// returns nil if neither is available.
+func MetadataAnnotations(e *yang.Entry) (map[string]*yang.YangType, error) {
+	if e == nil {
+		return nil, nil
+	}
+	for e.Parent != nil {
+		e = e.Parent
+	}
+
+	switch a := e.Annotation[MetadataAnnotationsAnnotation].(type) {
+	case map[string]*yang.YangType:
+		return a, nil
+	case map[string]interface{}:
+		// The annotation has been unmarshalled from the JSON
+		// serialisation of the schema, hence the types are decoded from
+		// their serialisation.
+		j, err := json.Marshal(a)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %v", MetadataAnnotationsAnnotation, err)
+		}
+		md := map[string]*yang.YangType{}
+		if err := json.Unmarshal(j, &md); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %v", MetadataAnnotationsAnnotation, err)
+		}
+		return md, nil
+	}
+
+	m, ok := e.Node.(*yang.Module)
+	if !ok || m.Modules == nil {
+		return nil, nil
+	}
+	var md map[string]*yang.YangType
+	for _, mod := range m.Modules.Modules {
+		mmd, err := ModuleMetadataAnnotations(mod)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range mmd {
+			if md == nil {
+				md = map[string]*yang.YangType{}
+			}
+			md[k] = v
+		}
+	}
+	return md, nil
+}
+
+// ModuleMetadataAnnotations returns the resolved types of the RFC 7952
+// metadata annotations defined by the md:annotation statements of the module
+// m, keyed by the name of the annotation prefixed with the name of m, e.g.,
+// ietf-origin:origin. It returns an error if the type of an annotation
+// cannot be resolved.
+func ModuleMetadataAnnotations(m *yang.Module) (map[string]*yang.YangType, error) {
+	if m == nil || m.Kind() != "module" {
+		return nil, nil
+	}
+	exts, err := yang.MatchingExtensions(m, "ietf-yang-metadata", "annotation")
+	if err != nil {
+		return nil, err
+	}
+
+	var md map[string]*yang.YangType
+	for _, ext := range exts {
+		var ts *yang.Statement
+		for _, s := range ext.SubStatements() {
+			if s.Keyword == "type" {
+				ts = s
+			}
+		}
+		if ts == nil {
+			return nil, fmt.Errorf("%s: no type specified for annotation %s", ext.Location(), ext.Argument)
+		}
+		// The type is resolved as that of a leaf of the module, since
+		// the substatements of extensions are not otherwise processed.
+		l := &yang.Leaf{Name: ext.Argument, Source: ext, Parent: m}
+		l.Type = typeFromStatement(ts, l)
+		le := yang.ToEntry(l)
+		if len(le.Errors) != 0 {
+			return nil, fmt.Errorf("cannot resolve type of annotation %s: %v", ext.Argument, le.Errors)
+		}
+		if md == nil {
+			md = map[string]*yang.YangType{}
+		}
+		md[fmt.Sprintf("%s:%s", m.Name, ext.Argument)] = le.Type
+	}
+	return md, nil
+}
+
+// typeFromStatement returns the Type that is specified by the type statement
+// s, whose parent is the Node parent.
+func typeFromStatement(s *yang.Statement, parent yang.Node) *yang.Type {
+	t := &yang.Type{Name: s.Argument, Source: s, Parent: parent}
+	value := func(s *yang.Statement) *yang.Value {
+		return &yang.Value{Name: s.Argument, Source: s, Parent: t}
+	}
+	// subValue returns the value of the substatement of s with the given
+	// keyword, or nil if there is no such substatement.
+	subValue := func(s *yang.Statement, keyword string) *yang.Value {
+		for _, ss := range s.SubStatements() {
+			if ss.Keyword == keyword {
+				return value(ss)
+			}
+		}
+		return nil
+	}
+	for _, ss := range s.SubStatements() {
+		switch ss.Keyword {
+		case "base":
+			t.IdentityBase = value(ss)
+		case "bit":
+			t.Bit = append(t.Bit, &yang.Bit{Name: ss.Argument, Source: ss, Parent: t, Position: subValue(ss, "position")})
+		case "enum":
+			t.Enum = append(t.Enum, &yang.Enum{Name: ss.Argument, Source: ss, Parent: t, Value: subValue(ss, "value")})
+		case "fraction-digits":
+			t.FractionDigits = value(ss)
+		case "length":
+			t.Length = &yang.Length{Name: ss.Argument, Source: ss, Parent: t}
+		case "path":
+			t.Path = value(ss)
+		case "pattern":
+			t.Pattern = append(t.Pattern, &yang.Pattern{Name: ss.Argument, Source: ss, Parent: t})
+		case "range":
+			t.Range = &yang.Range{Name: ss.Argument, Source: ss, Parent: t}
+		case "require-instance":
+			t.RequireInstance = value(ss)
+		case "type":
+			t.Type = append(t.Type, typeFromStatement(ss, t))
+		}
+	}
+	return t
+}
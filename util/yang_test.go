@@ -15,7 +15,9 @@
 package util
 
 import (
+	"encoding/json"
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -2058,3 +2060,241 @@ func TestListKeyFieldsMap(t *testing.T) {
 		})
 	}
 }
+
+func TestEntryRestrictionErrors(t *testing.T) {
+	ms := yang.NewModules()
+	if err := ms.Parse(`
+		module test {
+			prefix "t";
+			namespace "urn:t";
+
+			typedef small {
+				type uint8 {
+					range "0..10" {
+						error-message "too large";
+					}
+				}
+			}
+
+			leaf narrowed {
+				type small {
+					range "0..5";
+				}
+			}
+			leaf u {
+				type union {
+					type small;
+					type string {
+						pattern "a.*" {
+							error-app-tag "no-a";
+						}
+					}
+				}
+			}
+		}`, "test.yang"); err != nil {
+		t.Fatalf("cannot parse module: %v", err)
+	}
+	if errs := ms.Process(); errs != nil {
+		t.Fatalf("cannot process module: %v", errs)
+	}
+	m := yang.ToEntry(ms.Modules["test"])
+
+	tests := []struct {
+		desc string
+		in   *yang.Entry
+		want []*RestrictionError
+	}{{
+		desc: "range of typedef replaced by leaf",
+		in:   m.Dir["narrowed"],
+	}, {
+		desc: "union members",
+		in:   m.Dir["u"],
+		want: []*RestrictionError{
+			{Statement: "range", Argument: "0..10", ErrorMessage: "too large"},
+			{Statement: "pattern", Argument: "a.*", ErrorAppTag: "no-a"},
+		},
+	}, {
+		desc: "annotation unmarshalled from JSON",
+		in: &yang.Entry{
+			Annotation: map[string]interface{}{
+				RestrictionErrorsAnnotation: []interface{}{
+					map[string]interface{}{"statement": "length", "argument": "1..4", "error-message": "bad length"},
+				},
+			},
+		},
+		want: []*RestrictionError{
+			{Statement: "length", Argument: "1..4", ErrorMessage: "bad length"},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, EntryRestrictionErrors(tt.in)); diff != "" {
+				t.Errorf("EntryRestrictionErrors(): did not get expected result, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	// The range of the typedef is replaced by that of the leaf, hence its
+	// error statements do not apply.
+	if got := YangTypeRestrictionErrors(m.Dir["narrowed"].Type); got != nil {
+		t.Errorf("YangTypeRestrictionErrors(): got %v, want nil", got)
+	}
+}
+
+func TestModuleNamespaces(t *testing.T) {
+	ms := yang.NewModules()
+	if err := ms.Parse(`
+		module test {
+			prefix "t";
+			namespace "urn:t";
+			container c { leaf l { type string; } }
+		}`, "test.yang"); err != nil {
+		t.Fatalf("cannot parse module: %v", err)
+	}
+	if err := ms.Parse(`
+		module other {
+			prefix "o";
+			namespace "urn:o";
+		}`, "other.yang"); err != nil {
+		t.Fatalf("cannot parse module: %v", err)
+	}
+	if errs := ms.Process(); errs != nil {
+		t.Fatalf("cannot process module: %v", errs)
+	}
+	m := yang.ToEntry(ms.Modules["test"])
+
+	tests := []struct {
+		desc string
+		in   *yang.Entry
+		want map[string]string
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "modules of root entry",
+		in:   m.Dir["c"].Dir["l"],
+		want: map[string]string{"test": "urn:t", "other": "urn:o"},
+	}, {
+		desc: "annotation",
+		in: &yang.Entry{
+			Annotation: map[string]interface{}{
+				ModuleNamespacesAnnotation: map[string]string{"test": "urn:t"},
+			},
+		},
+		want: map[string]string{"test": "urn:t"},
+	}, {
+		desc: "annotation unmarshalled from JSON",
+		in: &yang.Entry{
+			Parent: &yang.Entry{
+				Annotation: map[string]interface{}{
+					ModuleNamespacesAnnotation: map[string]interface{}{"test": "urn:t"},
+				},
+			},
+		},
+		want: map[string]string{"test": "urn:t"},
+	}, {
+		desc: "no namespaces",
+		in:   &yang.Entry{Name: "e"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, ModuleNamespaces(tt.in)); diff != "" {
+				t.Errorf("ModuleNamespaces (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetadataAnnotations(t *testing.T) {
+	ms := yang.NewModules()
+	for _, m := range []string{`
+		module ietf-yang-metadata {
+			prefix "md";
+			namespace "urn:ietf:params:xml:ns:yang:ietf-yang-metadata";
+			extension annotation { argument name; }
+		}`, `
+		module test {
+			prefix "t";
+			namespace "urn:t";
+			import ietf-yang-metadata { prefix md; }
+			typedef counter { type uint32 { range "0..10"; } }
+			identity base-id;
+			identity derived { base base-id; }
+			md:annotation origin { type identityref { base base-id; } }
+			md:annotation count { type t:counter; }
+			md:annotation mode { type enumeration { enum a; enum b { value 10; } } }
+			container c { leaf l { type string; } }
+		}`} {
+		if err := ms.Parse(m, ""); err != nil {
+			t.Fatalf("cannot parse module: %v", err)
+		}
+	}
+	if errs := ms.Process(); errs != nil {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	m := yang.ToEntry(ms.Modules["test"])
+
+	got, err := MetadataAnnotations(m.Dir["c"].Dir["l"])
+	if err != nil {
+		t.Fatalf("MetadataAnnotations: %v", err)
+	}
+	var names []string
+	for k := range got {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	if diff := cmp.Diff([]string{"test:count", "test:mode", "test:origin"}, names); diff != "" {
+		t.Errorf("MetadataAnnotations names (-want, +got):\n%s", diff)
+	}
+	if got, want := got["test:count"].Kind, yang.Yuint32; got != want {
+		t.Errorf("test:count: got kind %v, want %v", got, want)
+	}
+	if got, want := got["test:count"].Range.String(), "0..10"; got != want {
+		t.Errorf("test:count: got range %s, want %s", got, want)
+	}
+	if got, want := got["test:mode"].Enum.ValueMap(), map[int64]string{0: "a", 10: "b"}; !cmp.Equal(got, want) {
+		t.Errorf("test:mode: got enum %v, want %v", got, want)
+	}
+	if got, want := got["test:origin"].IdentityBase.Name, "base-id"; got != want {
+		t.Errorf("test:origin: got base %s, want %s", got, want)
+	}
+
+	// The annotations of the root entry are used where they are present,
+	// including where they are unmarshalled from JSON.
+	j, err := json.Marshal(map[string]*yang.YangType{"test:count": got["test:count"]})
+	if err != nil {
+		t.Fatalf("cannot marshal types: %v", err)
+	}
+	var a map[string]interface{}
+	if err := json.Unmarshal(j, &a); err != nil {
+		t.Fatalf("cannot unmarshal types: %v", err)
+	}
+	fromJSON, err := MetadataAnnotations(&yang.Entry{
+		Parent: &yang.Entry{Annotation: map[string]interface{}{MetadataAnnotationsAnnotation: a}},
+	})
+	if err != nil {
+		t.Fatalf("MetadataAnnotations: %v", err)
+	}
+	if got, want := fromJSON["test:count"].Range.String(), "0..10"; got != want {
+		t.Errorf("test:count from JSON: got range %s, want %s", got, want)
+	}
+
+	if got, err := MetadataAnnotations(&yang.Entry{Name: "e"}); err != nil || got != nil {
+		t.Errorf("MetadataAnnotations of entry without modules: got %v, %v, want nil, nil", got, err)
+	}
+
+	if err := ms.Parse(`
+		module bad {
+			prefix "b";
+			namespace "urn:b";
+			import ietf-yang-metadata { prefix md; }
+			md:annotation a { type unknown-type; }
+		}`, ""); err != nil {
+		t.Fatalf("cannot parse module: %v", err)
+	}
+	_, err = ModuleMetadataAnnotations(ms.Modules["bad"])
+	if diff := errdiff.Substring(err, "cannot resolve type of annotation a"); diff != "" {
+		t.Errorf("ModuleMetadataAnnotations with unknown type: %s", diff)
+	}
+}
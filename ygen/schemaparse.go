@@ -34,7 +34,9 @@ import (
 // root-level enties (and their subtrees) within the input module set. All
 // YANG directories are annotated in the output JSON with the name of the type
 // they correspond to in the generated code, and the absolute schema path that
-// the entry corresponds to. In the case that the fake root struct that is provided
+// the entry corresponds to. The root entry is annotated with the XML namespace
+// of each module, and the RFC 7952 metadata annotations that they define. In
+// the case that the fake root struct that is provided
 // is nil, a synthetic root entry is used to store the schema tree.
 func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry, compressed bool, inclDescriptions bool) ([]byte, error) {
 	rootEntry := &yang.Entry{
@@ -66,6 +68,36 @@ func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry,
 		rootEntry.Annotation[util.CompressedSchemaAnnotation] = compressed
 	}
 
+	// Annotate the root with the namespaces of the modules, such that they
+	// are available to the XML encoding once the Nodes of the entries are
+	// not.
+	ns := map[string]string{}
+	for _, m := range ms {
+		for k, v := range util.ModuleNamespaces(m) {
+			ns[k] = v
+		}
+	}
+	if len(ns) != 0 {
+		rootEntry.Annotation[util.ModuleNamespacesAnnotation] = ns
+	}
+
+	// Annotate the root with the RFC 7952 metadata annotations defined by
+	// the modules, such that their values can be validated once the
+	// md:annotation statements are not available.
+	md := map[string]*yang.YangType{}
+	for _, m := range ms {
+		mmd, err := util.MetadataAnnotations(m)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range mmd {
+			md[k] = v
+		}
+	}
+	if len(md) != 0 {
+		rootEntry.Annotation[util.MetadataAnnotationsAnnotation] = md
+	}
+
 	j, err := json.MarshalIndent(rootEntry, "", strings.Repeat(" ", 4))
 	if err != nil {
 		return nil, fmt.Errorf("JSON marshalling error: %v", err)
@@ -99,6 +131,16 @@ func annotateChildren(e *yang.Entry, dn map[string]string, inclDescriptions bool
 //     in the supplied dn map to the annotations.
 //   - add the YANG schema path to the annotations, where e
 //     corresponds to a YANG directory.
+//   - add the XPath of the entry's own when statement to the
+//     annotations, where e has when statements, such that it
+//     can be distinguished from those inherited from augment and
+//     uses statements once the Node of the entry is no longer
+//     available.
+//   - add the error-message and error-app-tag statements of the
+//     restrictions of the type of a leaf or leaf-list to the
+//     annotations, where they are specified, such that they can be
+//     returned by validation once the Node of the entry is no longer
+//     available.
 func annotateEntry(e *yang.Entry, dn map[string]string, inclDescriptions bool) {
 	if !inclDescriptions {
 		e.Description = ""
@@ -112,6 +154,15 @@ func annotateEntry(e *yang.Entry, dn map[string]string, inclDescriptions bool) {
 	if e.IsDir() {
 		e.Annotation["schemapath"] = e.Path()
 	}
+	if len(e.Extra["when"]) > 0 && e.Node != nil {
+		w, _ := e.GetWhenXPath()
+		e.Annotation[util.WhenXPathAnnotation] = w
+	}
+	if (e.IsLeaf() || e.IsLeafList()) && e.Node != nil {
+		if res := util.EntryRestrictionErrors(e); len(res) > 0 {
+			e.Annotation[util.RestrictionErrorsAnnotation] = res
+		}
+	}
 }
 
 // WriteGzippedByteSlice takes an input slice of bytes, gzips it
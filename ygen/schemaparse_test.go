@@ -23,6 +23,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
 )
 
@@ -513,3 +514,157 @@ func TestSchemaRoundtrip(t *testing.T) {
 		}
 	}
 }
+
+func TestAnnotateEntryWhen(t *testing.T) {
+	ms := compileModules(t, map[string]string{
+		"module": `
+			module module {
+				prefix "m";
+				namespace "urn:m";
+
+				container foo {
+					leaf type { type string; }
+					leaf own {
+						when "../type = 'a'";
+						type string;
+					}
+				}
+
+				augment "/foo" {
+					when "type = 'b'";
+					leaf augmented {
+						type string;
+					}
+					leaf both {
+						when "../type != 'c'";
+						type string;
+					}
+				}
+			}
+		`,
+	})
+
+	tests := []struct {
+		desc         string
+		inPath       string
+		wantWhen     string
+		wantAnnotate bool
+	}{{
+		desc:         "own when",
+		inPath:       "foo/own",
+		wantWhen:     "../type = 'a'",
+		wantAnnotate: true,
+	}, {
+		desc:         "inherited when",
+		inPath:       "foo/augmented",
+		wantWhen:     "",
+		wantAnnotate: true,
+	}, {
+		desc:         "own and inherited when",
+		inPath:       "foo/both",
+		wantWhen:     "../type != 'c'",
+		wantAnnotate: true,
+	}, {
+		desc:   "no when",
+		inPath: "foo/type",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			e := findEntry(t, ms, "module", tt.inPath)
+			annotateEntry(e, map[string]string{}, false)
+			got, ok := e.Annotation[util.WhenXPathAnnotation]
+			if ok != tt.wantAnnotate {
+				t.Fatalf("annotateEntry(%s): got annotation present %v, want %v", tt.inPath, ok, tt.wantAnnotate)
+			}
+			if ok && got != tt.wantWhen {
+				t.Errorf("annotateEntry(%s): got when %q, want %q", tt.inPath, got, tt.wantWhen)
+			}
+		})
+	}
+}
+
+func TestAnnotateEntryRestrictionErrors(t *testing.T) {
+	ms := compileModules(t, map[string]string{
+		"module": `
+			module module {
+				prefix "m";
+				namespace "urn:m";
+
+				typedef name {
+					type string {
+						pattern "[a-z]+" {
+							error-message "name must be lower case";
+							error-app-tag "bad-name";
+						}
+					}
+				}
+
+				container foo {
+					leaf mtu {
+						type uint16 {
+							range "68..9000" {
+								error-message "mtu out of range";
+							}
+						}
+					}
+					leaf-list names {
+						type name {
+							length "1..8" {
+								error-app-tag "name-too-long";
+							}
+						}
+					}
+					leaf plain { type string; }
+				}
+			}
+		`,
+	})
+
+	tests := []struct {
+		desc   string
+		inPath string
+		want   []*util.RestrictionError
+	}{{
+		desc:   "leaf with range",
+		inPath: "foo/mtu",
+		want: []*util.RestrictionError{{
+			Statement:    "range",
+			Argument:     "68..9000",
+			ErrorMessage: "mtu out of range",
+		}},
+	}, {
+		desc:   "leaf-list with length and typedef pattern",
+		inPath: "foo/names",
+		want: []*util.RestrictionError{{
+			Statement:   "length",
+			Argument:    "1..8",
+			ErrorAppTag: "name-too-long",
+		}, {
+			Statement:    "pattern",
+			Argument:     "[a-z]+",
+			ErrorMessage: "name must be lower case",
+			ErrorAppTag:  "bad-name",
+		}},
+	}, {
+		desc:   "no restrictions",
+		inPath: "foo/plain",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			e := findEntry(t, ms, "module", tt.inPath)
+			annotateEntry(e, map[string]string{}, false)
+			got, ok := e.Annotation[util.RestrictionErrorsAnnotation]
+			if ok != (tt.want != nil) {
+				t.Fatalf("annotateEntry(%s): got annotation present %v, want %v", tt.inPath, ok, tt.want != nil)
+			}
+			if !ok {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("annotateEntry(%s): did not get expected annotation, (-want, +got):\n%s", tt.inPath, diff)
+			}
+		})
+	}
+}
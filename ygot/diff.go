@@ -546,7 +546,10 @@ func FormatDiff(n *gnmipb.Notification) string {
 //
 // NOTE: Currently only YANG `ordered-by user lists` are supported as atomic
 // nodes. Further, they're always treated as so since there is no way of
-// representing order using TypedValue scalar types.
+// representing order using TypedValue scalar types, unless the
+// OrderedMapEdits DiffOpt is specified, in which case the changes to ordered
+// maps that are present in both original and modified are described by
+// non-atomic updates and deletes of their entries.
 //
 // The original struct is considered as the "from" data, with the
 // modified struct the "to" such that:
@@ -675,6 +678,16 @@ func diff(original, modified GoStruct, withAtomic bool, opts ...DiffOpt) ([]*gnm
 		return nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
 	}
 
+	if mode := hasWithDefaults(opts); mode != WithDefaultsExplicit {
+		var err error
+		if original, err = withDefaults(original, mode); err != nil {
+			return nil, fmt.Errorf("could not apply with-defaults mode to original struct: %v", err)
+		}
+		if modified, err = withDefaults(modified, mode); err != nil {
+			return nil, fmt.Errorf("could not apply with-defaults mode to modified struct: %v", err)
+		}
+	}
+
 	origLeaves, err := findSetLeaves(original, withAtomic, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("could not extract set leaves from original struct: %v", err)
@@ -698,7 +711,13 @@ func diff(original, modified GoStruct, withAtomic bool, opts ...DiffOpt) ([]*gnm
 	n := &gnmipb.Notification{}
 	processUpdate := func(path string, modVal *pathInfo, origVal *pathInfo) error {
 		diffopts := hasDiffPathOpt(opts)
-		if orderedMap, isOrderedMap := modVal.val.(GoOrderedMap); isOrderedMap {
+		if orderedMap, isOrderedMap := modVal.val.(GoOrderedMap); isOrderedMap && origVal != nil && hasOrderedMapEdits(opts) != nil {
+			notifs, err := orderedMapEditNotifs(n, origVal.val.(GoOrderedMap), orderedMap, modVal.path, opts)
+			if err != nil {
+				return err
+			}
+			atomicNotifs = append(atomicNotifs, notifs...)
+		} else if isOrderedMap {
 			preferShadowPath := diffopts != nil && diffopts.PreferShadowPath
 			notif, err := orderedMapNotif(orderedMap, newPathElemGNMIPath(modVal.path.GetElem()), 0, preferShadowPath)
 			if err != nil {
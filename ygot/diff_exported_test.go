@@ -708,6 +708,219 @@ func TestDiffOrderedMap(t *testing.T) {
 				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "baz-val"}},
 			}},
 		}},
+	}, {
+		name: "edits-reorder",
+		inOrig: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMapLonger(t),
+		},
+		inMod: &ctestschema.Device{
+			OrderedList: func() *ctestschema.OrderedList_OrderedMap {
+				om := ctestschema.GetOrderedMapLonger(t)
+				bar := om.Get("bar")
+				om.Delete("bar")
+				if err := om.Append(bar); err != nil {
+					t.Fatal(err)
+				}
+				return om
+			}(),
+		},
+		inOpts: []ygot.DiffOpt{&ygot.OrderedMapEdits{}},
+		want:   &gnmipb.Notification{},
+		wantNonAtomic: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=bar]`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"@":{"yang:insert":"after","yang:key":"[key='baz']"}}`)}},
+			}},
+		},
+	}, {
+		name: "edits-delete-and-modify",
+		inOrig: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap(t),
+		},
+		inMod: &ctestschema.Device{
+			OrderedList: func() *ctestschema.OrderedList_OrderedMap {
+				om := ctestschema.GetOrderedMap(t)
+				om.Delete("foo")
+				om.Get("bar").Value = ygot.String("new")
+				return om
+			}(),
+		},
+		inOpts: []ygot.DiffOpt{&ygot.OrderedMapEdits{}},
+		want: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(`/ordered-lists/ordered-list[key=foo]/config/key`), mustPath(`/ordered-lists/ordered-list[key=foo]/key`), mustPath(`/ordered-lists/ordered-list[key=foo]/config/value`)},
+			Update: []*gnmipb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=bar]/config/value`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "new"}},
+			}},
+		},
+		wantNonAtomic: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(`/ordered-lists/ordered-list[key=foo]`)},
+			Update: []*gnmipb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=bar]/config/value`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "new"}},
+			}},
+		},
+	}, {
+		name: "edits-insert",
+		inOrig: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap(t),
+		},
+		inMod: &ctestschema.Device{
+			OrderedList: func() *ctestschema.OrderedList_OrderedMap {
+				om := ctestschema.GetOrderedMapLonger(t)
+				bar := om.Get("bar")
+				om.Delete("bar")
+				if err := om.Append(bar); err != nil {
+					t.Fatal(err)
+				}
+				return om
+			}(),
+		},
+		inOpts: []ygot.DiffOpt{&ygot.OrderedMapEdits{}},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=baz]/config/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "baz"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=baz]/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "baz"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=baz]/config/value`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "baz-val"}},
+			}},
+		},
+		wantNonAtomic: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=baz]`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"@":{"yang:insert":"after","yang:key":"[key='foo']"},"ctestschema:config":{"key":"baz","value":"baz-val"},"ctestschema:key":"baz"}`)}},
+			}},
+		},
+	}, {
+		name: "edits-move-last-to-front",
+		inOrig: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMapLonger(t),
+		},
+		inMod: &ctestschema.Device{
+			OrderedList: func() *ctestschema.OrderedList_OrderedMap {
+				om := ctestschema.GetOrderedMapLonger(t)
+				for _, k := range []string{"foo", "bar"} {
+					v := om.Get(k)
+					om.Delete(k)
+					if err := om.Append(v); err != nil {
+						t.Fatal(err)
+					}
+				}
+				return om
+			}(),
+		},
+		inOpts: []ygot.DiffOpt{&ygot.OrderedMapEdits{}},
+		want:   &gnmipb.Notification{},
+		wantNonAtomic: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=baz]`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"@":{"yang:insert":"first"}}`)}},
+			}},
+		},
+	}, {
+		name: "edits-delete-insert-move-and-modify",
+		inOrig: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMapLonger(t),
+		},
+		inMod: &ctestschema.Device{
+			OrderedList: func() *ctestschema.OrderedList_OrderedMap {
+				om := &ctestschema.OrderedList_OrderedMap{}
+				for _, k := range []string{"baz", "qux", "foo"} {
+					v, err := om.AppendNew(k)
+					if err != nil {
+						t.Fatal(err)
+					}
+					v.Value = ygot.String(k + "-val")
+				}
+				om.Get("foo").Value = ygot.String("new")
+				return om
+			}(),
+		},
+		inOpts: []ygot.DiffOpt{&ygot.OrderedMapEdits{}},
+		want: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(`/ordered-lists/ordered-list[key=bar]/config/key`), mustPath(`/ordered-lists/ordered-list[key=bar]/key`), mustPath(`/ordered-lists/ordered-list[key=bar]/config/value`)},
+			Update: []*gnmipb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=foo]/config/value`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "new"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=qux]/config/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "qux"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=qux]/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "qux"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=qux]/config/value`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "qux-val"}},
+			}},
+		},
+		wantNonAtomic: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(`/ordered-lists/ordered-list[key=bar]`)},
+			Update: []*gnmipb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=foo]/config/value`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "new"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=qux]`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"@":{"yang:insert":"after","yang:key":"[key='baz']"},"ctestschema:config":{"key":"qux","value":"qux-val"},"ctestschema:key":"qux"}`)}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=foo]`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"@":{"yang:insert":"after","yang:key":"[key='qux']"}}`)}},
+			}},
+		},
+	}, {
+		name:   "edits-from-empty",
+		inOrig: &ctestschema.Device{},
+		inMod: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap(t),
+		},
+		inOpts: []ygot.DiffOpt{&ygot.OrderedMapEdits{}},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=foo]/config/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=foo]/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=foo]/config/value`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo-val"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=bar]/config/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=bar]/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar"}},
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=bar]/config/value`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar-val"}},
+			}},
+		},
+		wantAtomic: []*gnmipb.Notification{{
+			Prefix: mustPath("/ordered-lists"),
+			Atomic: true,
+			Update: []*gnmipb.Update{{
+				Path: mustPath(`ordered-list[key=foo]/config/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+			}, {
+				Path: mustPath(`ordered-list[key=foo]/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+			}, {
+				Path: mustPath(`ordered-list[key=foo]/config/value`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo-val"}},
+			}, {
+				Path: mustPath(`ordered-list[key=bar]/config/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar"}},
+			}, {
+				Path: mustPath(`ordered-list[key=bar]/key`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar"}},
+			}, {
+				Path: mustPath(`ordered-list[key=bar]/config/value`),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar-val"}},
+			}},
+		}},
 	}}
 
 	for _, tt := range tests {
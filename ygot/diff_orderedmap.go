@@ -0,0 +1,540 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// This file implements the diffing of the entries of `ordered-by user` lists,
+// which are represented by ordered maps in the ygot-generated code, as edit
+// scripts that contain the fewest insertions and moves of their entries.
+
+// OrderedMapEditOp is the operation of an edit of the entries of an ordered
+// map.
+type OrderedMapEditOp int
+
+const (
+	// OrderedMapDelete deletes an entry.
+	OrderedMapDelete OrderedMapEditOp = iota
+	// OrderedMapInsert inserts an entry that is not present in the ordered
+	// map at the position specified by the edit.
+	OrderedMapInsert
+	// OrderedMapMove moves an entry that is present in the ordered map to
+	// the position specified by the edit.
+	OrderedMapMove
+)
+
+// String returns the name of the operation.
+func (o OrderedMapEditOp) String() string {
+	switch o {
+	case OrderedMapDelete:
+		return "delete"
+	case OrderedMapInsert:
+		return "insert"
+	case OrderedMapMove:
+		return "move"
+	}
+	return fmt.Sprintf("OrderedMapEditOp(%d)", int(o))
+}
+
+// OrderedMapEdit is an edit of the entries of an ordered map.
+type OrderedMapEdit struct {
+	// Op is the operation of the edit.
+	Op OrderedMapEditOp
+	// Key is the key of the entry, which is of the key type of the
+	// ordered map.
+	Key any
+	// Where is the position at which an inserted or moved entry is
+	// placed, which is YANGPatchFirst or YANGPatchAfter.
+	Where YANGPatchWhere
+	// Point is the key of the entry after which an inserted or moved
+	// entry is placed, where Where is YANGPatchAfter.
+	Point any
+	// Value is the entry that is inserted.
+	Value GoStruct
+}
+
+// OrderedMapEditScript is a sequence of edits that transforms the entries of
+// an ordered map.
+type OrderedMapEditScript struct {
+	// Path is the path of the list that is represented by the ordered map.
+	Path *gnmipb.Path
+	// Edits are the edits, which are applied in order. The deleted entries
+	// come first, in their original order, followed by the inserted and
+	// moved entries in their modified order.
+	Edits []*OrderedMapEdit
+}
+
+// DiffOrderedMaps takes an original and modified GoStruct, which must be of the
+// same type, and returns the edit scripts that transform the entries of the
+// ordered maps within original into those within modified, sorted by the
+// path of the ordered map. Each script contains the fewest edits that
+// reproduce the modified order: the entries that are present in both and are
+// not within the longest sequence of them that is in the same order in both
+// are moved, and the entries that are only present in modified are inserted.
+//
+// Ordered maps within entries that are present in both GoStructs are
+// diffed, while those within inserted entries are part of the inserted
+// values. The changes to the leaves of the entries are not included, and are
+// returned by Diff.
+//
+// The DiffPathOpt and WithDefaults DiffOpts are supported.
+func DiffOrderedMaps(original, modified GoStruct, opts ...DiffOpt) ([]*OrderedMapEditScript, error) {
+	original, modified, err := checkDiffInputs(original, modified, opts)
+	if err != nil {
+		return nil, err
+	}
+	return orderedMapScripts(original, modified, &gnmipb.Path{}, opts)
+}
+
+// orderedMapFields returns the ordered maps within s keyed by the string form
+// of their path.
+func orderedMapFields(s GoStruct, opts []DiffOpt) (map[string]*pathInfo, error) {
+	leaves, err := findSetLeaves(s, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := toStringPathMap(leaves)
+	if err != nil {
+		return nil, err
+	}
+	for p, v := range paths {
+		if _, ok := v.val.(GoOrderedMap); !ok {
+			delete(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// orderedMapScripts returns the edit scripts that transform the ordered maps
+// within the GoStruct o into those within m, where parent is their path.
+func orderedMapScripts(o, m GoStruct, parent *gnmipb.Path, opts []DiffOpt) ([]*OrderedMapEditScript, error) {
+	of, err := orderedMapFields(o, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract ordered maps from original struct: %v", err)
+	}
+	mf, err := orderedMapFields(m, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract ordered maps from modified struct: %v", err)
+	}
+	var paths []string
+	for p := range of {
+		paths = append(paths, p)
+	}
+	for p := range mf {
+		if _, ok := of[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var scripts []*OrderedMapEditScript
+	for _, p := range paths {
+		var om, mm GoOrderedMap
+		var path *gnmipb.Path
+		if v, ok := of[p]; ok {
+			om, path = v.val.(GoOrderedMap), v.path
+		}
+		if v, ok := mf[p]; ok {
+			mm, path = v.val.(GoOrderedMap), v.path
+		}
+		path = joingNMIPaths(parent, path)
+
+		okeys, oe, err := orderedMapKeys(om)
+		if err != nil {
+			return nil, err
+		}
+		mkeys, me, err := orderedMapKeys(mm)
+		if err != nil {
+			return nil, err
+		}
+		script := &OrderedMapEditScript{Path: path}
+		for _, e := range orderEdits(okeys, mkeys) {
+			edit := &OrderedMapEdit{Op: e.op, Key: e.id, Where: e.where, Point: e.point}
+			if e.op == OrderedMapInsert {
+				edit.Value = me[e.id]
+			}
+			script.Edits = append(script.Edits, edit)
+		}
+		if len(script.Edits) != 0 {
+			scripts = append(scripts, script)
+		}
+
+		for _, k := range mkeys {
+			if _, ok := oe[k]; !ok {
+				continue
+			}
+			ep, err := orderedMapEntryPath(k, me[k], path)
+			if err != nil {
+				return nil, err
+			}
+			nested, err := orderedMapScripts(oe[k], me[k], ep, opts)
+			if err != nil {
+				return nil, err
+			}
+			scripts = append(scripts, nested...)
+		}
+	}
+	return scripts, nil
+}
+
+// orderedMapKeys returns the keys of the ordered map om, which may be nil, in
+// order, along with its entries keyed by key.
+func orderedMapKeys(om GoOrderedMap) ([]any, map[any]GoStruct, error) {
+	keys, entries, err := orderedMapEntries(reflect.ValueOf(om))
+	if err != nil {
+		return nil, nil, err
+	}
+	var ks []any
+	structs := map[any]GoStruct{}
+	for _, k := range keys {
+		s, ok := entries[k.Interface()].Interface().(GoStruct)
+		if !ok {
+			return nil, nil, fmt.Errorf("%T: entry %v was not a valid GoStruct", om, k.Interface())
+		}
+		ks = append(ks, k.Interface())
+		structs[k.Interface()] = s
+	}
+	return ks, structs, nil
+}
+
+// orderedMapEntryPath returns the path of the entry v with key k of the
+// ordered map whose path is parent.
+func orderedMapEntryPath(k any, v GoStruct, parent *gnmipb.Path) (*gnmipb.Path, error) {
+	p, err := mapValuePath(reflect.ValueOf(k), reflect.ValueOf(v), newPathElemGNMIPath(parent.GetElem()))
+	if err != nil {
+		return nil, err
+	}
+	return p.ToProto()
+}
+
+// orderEdit is an edit of a sequence of identifiers.
+type orderEdit[T comparable] struct {
+	op    OrderedMapEditOp
+	id    T
+	where YANGPatchWhere
+	point T
+}
+
+// orderEdits returns the fewest edits that transform the sequence of distinct
+// identifiers orig into mod. The identifiers that are only present in orig
+// are deleted first. The identifiers of mod are then processed in order,
+// where those that are not present in orig are inserted, and those that are
+// not within the longest common subsequence of orig and mod are moved. Each
+// is placed after its predecessor within mod, or first.
+func orderEdits[T comparable](orig, mod []T) []*orderEdit[T] {
+	inOrig, inMod := map[T]bool{}, map[T]bool{}
+	for _, id := range orig {
+		inOrig[id] = true
+	}
+	for _, id := range mod {
+		inMod[id] = true
+	}
+
+	var edits []*orderEdit[T]
+	var oc, mc []T
+	for _, id := range orig {
+		if !inMod[id] {
+			edits = append(edits, &orderEdit[T]{op: OrderedMapDelete, id: id})
+			continue
+		}
+		oc = append(oc, id)
+	}
+	for _, id := range mod {
+		if inOrig[id] {
+			mc = append(mc, id)
+		}
+	}
+
+	inOrder := map[T]bool{}
+	for _, id := range distinctCommonSubsequence(oc, mc) {
+		inOrder[id] = true
+	}
+	for i, id := range mod {
+		e := &orderEdit[T]{id: id, where: YANGPatchFirst}
+		if i > 0 {
+			e.where, e.point = YANGPatchAfter, mod[i-1]
+		}
+		switch {
+		case !inOrig[id]:
+			e.op = OrderedMapInsert
+		case !inOrder[id]:
+			e.op = OrderedMapMove
+		default:
+			continue
+		}
+		edits = append(edits, e)
+	}
+	return edits
+}
+
+// distinctCommonSubsequence returns the longest sequence of the elements of
+// a that are also in the same order within b, where the elements of each of a
+// and b are distinct. It is the longest increasing subsequence of the indices
+// within a of the elements of b, which is found in O(n log n) time.
+func distinctCommonSubsequence[T comparable](a, b []T) []T {
+	idx := make(map[T]int, len(a))
+	for i, v := range a {
+		idx[v] = i
+	}
+	var vs []T
+	var is []int
+	for _, v := range b {
+		if i, ok := idx[v]; ok {
+			vs = append(vs, v)
+			is = append(is, i)
+		}
+	}
+
+	// The subsequence is found from the end of b, such that, of the
+	// subsequences of the same length, that of the earliest elements of b
+	// is returned. heads[l] is the index within is of the first element
+	// of the increasing subsequence of length l+1 whose first element is
+	// greatest, and next[j] is the index of the element that follows is[j]
+	// within the subsequence that it starts.
+	var heads []int
+	next := make([]int, len(is))
+	for j := len(is) - 1; j >= 0; j-- {
+		l := sort.Search(len(heads), func(t int) bool { return is[heads[t]] <= is[j] })
+		next[j] = -1
+		if l > 0 {
+			next[j] = heads[l-1]
+		}
+		if l == len(heads) {
+			heads = append(heads, j)
+		} else {
+			heads[l] = j
+		}
+	}
+	if len(heads) == 0 {
+		return nil
+	}
+	out := make([]T, 0, len(heads))
+	for j := heads[len(heads)-1]; j >= 0; j = next[j] {
+		out = append(out, vs[j])
+	}
+	return out
+}
+
+// YANGInsertAnnotation and YANGKeyAnnotation are the names of the RFC 7951
+// metadata annotations of the JSON value of an entry of an `ordered-by user`
+// list that specify its position, which are the insert and key attributes of
+// RFC 7950 section 7.8.6. The value of YANGInsertAnnotation is a
+// YANGPatchWhere, and that of YANGKeyAnnotation is the key predicates of the
+// entry before or after which the entry is placed, such as "[name='a']".
+const (
+	YANGInsertAnnotation = "yang:insert"
+	YANGKeyAnnotation    = "yang:key"
+)
+
+// OrderedMapEdits is a DiffOpt that specifies that DiffWithAtomic describes
+// the changes to an ordered map that is present in both the original and
+// modified GoStructs by non-atomic updates and deletes, rather than by an
+// atomic Notification that replaces the ordered map.
+//
+// The entries that are only present in the original are deleted, and the
+// changes to the leaves of the entries that are present in both are
+// described as by Diff. The edits of the entries that are returned by
+// DiffOrderedMaps are then described by updates, in order, of the paths of
+// the inserted and moved entries. Their JSON_IETF values are the RFC 7951
+// JSON of the inserted entries, or an empty object for the moved entries,
+// whose position is specified by the YANGInsertAnnotation and
+// YANGKeyAnnotation metadata annotations, which ytypes applies when
+// unmarshalling them.
+type OrderedMapEdits struct{}
+
+// IsDiffOpt marks OrderedMapEdits as a diff option.
+func (*OrderedMapEdits) IsDiffOpt() {}
+
+// hasOrderedMapEdits returns the first OrderedMapEdits from an opts slice, or
+// nil if there isn't one.
+func hasOrderedMapEdits(opts []DiffOpt) *OrderedMapEdits {
+	for _, o := range opts {
+		if v, ok := o.(*OrderedMapEdits); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// orderedMapEditNotifs appends to n the deletes and updates that transform the
+// ordered map orig, whose path is path, into mod, as per OrderedMapEdits, and
+// returns the atomic Notifications of the ordered maps within its entries.
+func orderedMapEditNotifs(n *gnmipb.Notification, orig, mod GoOrderedMap, path *gnmipb.Path, opts []DiffOpt) ([]*gnmipb.Notification, error) {
+	okeys, oe, err := orderedMapKeys(orig)
+	if err != nil {
+		return nil, err
+	}
+	mkeys, me, err := orderedMapKeys(mod)
+	if err != nil {
+		return nil, err
+	}
+	edits := orderEdits(okeys, mkeys)
+
+	for _, e := range edits {
+		if e.op != OrderedMapDelete {
+			continue
+		}
+		ep, err := orderedMapEntryPath(e.id, oe[e.id], path)
+		if err != nil {
+			return nil, err
+		}
+		n.Delete = append(n.Delete, ep)
+	}
+
+	var atomicNotifs []*gnmipb.Notification
+	for _, k := range mkeys {
+		if _, ok := oe[k]; !ok {
+			continue
+		}
+		ep, err := orderedMapEntryPath(k, me[k], path)
+		if err != nil {
+			return nil, err
+		}
+		notifs, err := diff(oe[k], me[k], true, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, no := range notifs {
+			if no.Atomic {
+				no.Prefix = joingNMIPaths(ep, no.Prefix)
+				atomicNotifs = append(atomicNotifs, no)
+				continue
+			}
+			for _, d := range no.Delete {
+				n.Delete = append(n.Delete, joingNMIPaths(ep, d))
+			}
+			for _, u := range no.Update {
+				u.Path = joingNMIPaths(ep, u.Path)
+				n.Update = append(n.Update, u)
+			}
+		}
+	}
+
+	for _, e := range edits {
+		if e.op == OrderedMapDelete {
+			continue
+		}
+		u, err := orderedMapEditUpdate(e, me, path)
+		if err != nil {
+			return nil, err
+		}
+		n.Update = append(n.Update, u)
+	}
+	return atomicNotifs, nil
+}
+
+// orderedMapEditUpdate returns the update that applies the insert or move e
+// of an entry of the ordered map whose path is path, as per OrderedMapEdits,
+// where entries are the entries of the modified ordered map keyed by key.
+func orderedMapEditUpdate(e *orderEdit[any], entries map[any]GoStruct, path *gnmipb.Path) (*gnmipb.Update, error) {
+	ep, err := orderedMapEntryPath(e.id, entries[e.id], path)
+	if err != nil {
+		return nil, err
+	}
+	j := map[string]any{}
+	if e.op == OrderedMapInsert {
+		if j, err = ConstructIETFJSON(entries[e.id], &RFC7951JSONConfig{AppendModuleName: true}); err != nil {
+			return nil, fmt.Errorf("%v: %v", ep, err)
+		}
+	}
+	md, ok := j["@"].(map[string]any)
+	if !ok {
+		md = map[string]any{}
+		j["@"] = md
+	}
+	md[YANGInsertAnnotation] = string(e.where)
+	if e.where == YANGPatchAfter {
+		pp, err := orderedMapEntryPath(e.point, entries[e.point], path)
+		if err != nil {
+			return nil, err
+		}
+		md[YANGKeyAnnotation] = keyPredicates(pp.GetElem()[len(pp.GetElem())-1].GetKey())
+	}
+	js, err := json.Marshal(j)
+	if err != nil {
+		return nil, fmt.Errorf("%v: cannot encode JSON, %v", ep, err)
+	}
+	return &gnmipb.Update{
+		Path: ep,
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: js}},
+	}, nil
+}
+
+// keyPredicates returns the RFC 7950 key predicates of the keys of a list
+// entry, such as "[name='a']", sorted by the name of the key leaf. Each value
+// is quoted with single quotes, or double quotes where it contains a single
+// quote.
+func keyPredicates(keys map[string]string) string {
+	var names []string
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, k := range names {
+		q := "'"
+		if strings.Contains(keys[k], q) {
+			q = `"`
+		}
+		fmt.Fprintf(&b, "[%s=%s%s%s]", k, q, keys[k], q)
+	}
+	return b.String()
+}
+
+// parseKeyPredicates returns the keys of a list entry that are specified by
+// the RFC 7950 key predicates s, such as "[name='a']", as returned by
+// keyPredicates.
+func parseKeyPredicates(s string) (map[string]string, error) {
+	invalid := fmt.Errorf("invalid key predicates: %q", s)
+	keys := map[string]string{}
+	for p := strings.TrimSpace(s); p != ""; p = strings.TrimSpace(p) {
+		if p[0] != '[' {
+			return nil, invalid
+		}
+		eq := strings.IndexByte(p, '=')
+		if eq < 0 {
+			return nil, invalid
+		}
+		name := util.StripModulePrefix(strings.TrimSpace(p[1:eq]))
+		p = strings.TrimSpace(p[eq+1:])
+		if name == "" || p == "" || p[0] != '\'' && p[0] != '"' {
+			return nil, invalid
+		}
+		end := strings.IndexByte(p[1:], p[0])
+		if end < 0 {
+			return nil, invalid
+		}
+		keys[name] = p[1 : end+1]
+		p = strings.TrimSpace(p[end+2:])
+		if p == "" || p[0] != ']' {
+			return nil, invalid
+		}
+		p = p[1:]
+	}
+	if len(keys) == 0 {
+		return nil, invalid
+	}
+	return keys, nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestDiffOrderedMaps(t *testing.T) {
+	orderedMap := func(keys ...string) *ctestschema.OrderedList_OrderedMap {
+		om := &ctestschema.OrderedList_OrderedMap{}
+		for _, k := range keys {
+			v, err := om.AppendNew(k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.Value = ygot.String(k + "-val")
+		}
+		return om
+	}
+
+	nestedOrig := &ctestschema.Device{OrderedList: ctestschema.GetNestedOrderedMap(t)}
+	nestedMod := &ctestschema.Device{OrderedList: ctestschema.GetNestedOrderedMap(t)}
+	nestedMod.OrderedList.Get("foo").OrderedList.Delete("foo")
+	if _, err := nestedMod.OrderedList.Get("foo").OrderedList.AppendNew("foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	newEntry := orderedMap("baz")
+
+	tests := []struct {
+		desc       string
+		inOrig     ygot.GoStruct
+		inMod      ygot.GoStruct
+		want       []*ygot.OrderedMapEditScript
+		wantErrSub string
+	}{{
+		desc:   "no changes",
+		inOrig: &ctestschema.Device{OrderedList: orderedMap("a", "b", "c")},
+		inMod:  &ctestschema.Device{OrderedList: orderedMap("a", "b", "c")},
+	}, {
+		desc:   "changes to entries only",
+		inOrig: &ctestschema.Device{OrderedList: orderedMap("a", "b")},
+		inMod: func() *ctestschema.Device {
+			d := &ctestschema.Device{OrderedList: orderedMap("a", "b")}
+			d.OrderedList.Get("a").Value = ygot.String("new")
+			return d
+		}(),
+	}, {
+		desc:   "move one entry of many",
+		inOrig: &ctestschema.Device{OrderedList: orderedMap("a", "b", "c", "d", "e", "f")},
+		inMod:  &ctestschema.Device{OrderedList: orderedMap("a", "b", "e", "c", "d", "f")},
+		want: []*ygot.OrderedMapEditScript{{
+			Path: mustPath("/ordered-lists/ordered-list"),
+			Edits: []*ygot.OrderedMapEdit{{
+				Op:    ygot.OrderedMapMove,
+				Key:   "e",
+				Where: ygot.YANGPatchAfter,
+				Point: "b",
+			}},
+		}},
+	}, {
+		desc:   "delete, insert and move",
+		inOrig: &ctestschema.Device{OrderedList: orderedMap("foo", "bar", "qux")},
+		inMod: &ctestschema.Device{OrderedList: func() *ctestschema.OrderedList_OrderedMap {
+			om := orderedMap("bar", "foo")
+			if err := om.Append(newEntry.Get("baz")); err != nil {
+				t.Fatal(err)
+			}
+			return om
+		}()},
+		want: []*ygot.OrderedMapEditScript{{
+			Path: mustPath("/ordered-lists/ordered-list"),
+			Edits: []*ygot.OrderedMapEdit{{
+				Op:  ygot.OrderedMapDelete,
+				Key: "qux",
+			}, {
+				Op:    ygot.OrderedMapMove,
+				Key:   "foo",
+				Where: ygot.YANGPatchAfter,
+				Point: "bar",
+			}, {
+				Op:    ygot.OrderedMapInsert,
+				Key:   "baz",
+				Where: ygot.YANGPatchAfter,
+				Point: "foo",
+				Value: newEntry.Get("baz"),
+			}},
+		}},
+	}, {
+		desc:   "ordered map deleted",
+		inOrig: &ctestschema.Device{OrderedList: orderedMap("a")},
+		inMod:  &ctestschema.Device{},
+		want: []*ygot.OrderedMapEditScript{{
+			Path: mustPath("/ordered-lists/ordered-list"),
+			Edits: []*ygot.OrderedMapEdit{{
+				Op:  ygot.OrderedMapDelete,
+				Key: "a",
+			}},
+		}},
+	}, {
+		desc:   "nested ordered map",
+		inOrig: nestedOrig,
+		inMod:  nestedMod,
+		want: []*ygot.OrderedMapEditScript{{
+			Path: mustPath("/ordered-lists/ordered-list[key=foo]/ordered-lists/ordered-list"),
+			Edits: []*ygot.OrderedMapEdit{{
+				Op:    ygot.OrderedMapMove,
+				Key:   "foo",
+				Where: ygot.YANGPatchAfter,
+				Point: "bar",
+			}},
+		}},
+	}, {
+		desc: "multi-keyed ordered map",
+		inOrig: &ctestschema.Device{
+			OrderedMultikeyedList: ctestschema.GetOrderedMapMultikeyed(t),
+		},
+		inMod: func() *ctestschema.Device {
+			d := &ctestschema.Device{OrderedMultikeyedList: ctestschema.GetOrderedMapMultikeyed(t)}
+			d.OrderedMultikeyedList.Delete(ctestschema.OrderedMultikeyedList_Key{Key1: "foo", Key2: 42})
+			return d
+		}(),
+		want: []*ygot.OrderedMapEditScript{{
+			Path: mustPath("/ordered-multikeyed-lists/ordered-multikeyed-list"),
+			Edits: []*ygot.OrderedMapEdit{{
+				Op:  ygot.OrderedMapDelete,
+				Key: ctestschema.OrderedMultikeyedList_Key{Key1: "foo", Key2: 42},
+			}},
+		}},
+	}, {
+		desc:       "different types",
+		inOrig:     &ctestschema.Device{},
+		inMod:      &ctestschema.OtherData{},
+		wantErrSub: "cannot diff structs of different types",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.DiffOrderedMaps(tt.inOrig, tt.inMod)
+			if diff := errdiff.Substring(err, tt.wantErrSub); diff != "" {
+				t.Fatalf("DiffOrderedMaps: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("DiffOrderedMaps (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
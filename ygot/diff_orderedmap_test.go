@@ -0,0 +1,220 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// applyOrderEdits applies edits to the sequence s, and returns the result.
+func applyOrderEdits(s []string, edits []*orderEdit[string]) ([]string, error) {
+	s = slices.Clone(s)
+	for _, e := range edits {
+		i := slices.Index(s, e.id)
+		switch e.op {
+		case OrderedMapDelete, OrderedMapMove:
+			if i < 0 {
+				return nil, fmt.Errorf("%v of %q, which is not present", e.op, e.id)
+			}
+			s = slices.Delete(s, i, i+1)
+		case OrderedMapInsert:
+			if i >= 0 {
+				return nil, fmt.Errorf("insert of %q, which is present", e.id)
+			}
+		}
+		if e.op == OrderedMapDelete {
+			continue
+		}
+		pos := 0
+		if e.where == YANGPatchAfter {
+			if pos = slices.Index(s, e.point) + 1; pos == 0 {
+				return nil, fmt.Errorf("%v of %q after %q, which is not present", e.op, e.id, e.point)
+			}
+		}
+		s = slices.Insert(s, pos, e.id)
+	}
+	return s, nil
+}
+
+// sequence returns the identifiers of n entries in order.
+func sequence(n int) []string {
+	s := make([]string, n)
+	for i := range s {
+		s[i] = fmt.Sprint(i)
+	}
+	return s
+}
+
+func TestOrderEdits(t *testing.T) {
+	many := sequence(5000)
+	tests := []struct {
+		desc      string
+		inOrig    []string
+		inMod     []string
+		wantEdits []*orderEdit[string]
+	}{{
+		desc:   "identical",
+		inOrig: []string{"a", "b", "c"},
+		inMod:  []string{"a", "b", "c"},
+	}, {
+		desc:   "move one entry",
+		inOrig: []string{"a", "b", "c", "d", "e"},
+		inMod:  []string{"a", "d", "b", "c", "e"},
+		wantEdits: []*orderEdit[string]{
+			{op: OrderedMapMove, id: "d", where: YANGPatchAfter, point: "a"},
+		},
+	}, {
+		desc:   "move to front",
+		inOrig: []string{"a", "b", "c"},
+		inMod:  []string{"c", "a", "b"},
+		wantEdits: []*orderEdit[string]{
+			{op: OrderedMapMove, id: "c", where: YANGPatchFirst},
+		},
+	}, {
+		desc:   "move last of many to front",
+		inOrig: many,
+		inMod:  append([]string{"4999"}, many[:4999]...),
+		wantEdits: []*orderEdit[string]{
+			{op: OrderedMapMove, id: "4999", where: YANGPatchFirst},
+		},
+	}, {
+		desc:   "move first of many to back",
+		inOrig: many,
+		inMod:  append(slices.Clone(many[1:]), "0"),
+		wantEdits: []*orderEdit[string]{
+			{op: OrderedMapMove, id: "0", where: YANGPatchAfter, point: "4999"},
+		},
+	}, {
+		desc:   "delete, insert and move",
+		inOrig: []string{"a", "b", "c", "d"},
+		inMod:  []string{"x", "d", "a", "c"},
+		wantEdits: []*orderEdit[string]{
+			{op: OrderedMapDelete, id: "b"},
+			{op: OrderedMapInsert, id: "x", where: YANGPatchFirst},
+			{op: OrderedMapMove, id: "d", where: YANGPatchAfter, point: "x"},
+		},
+	}, {
+		desc:  "from empty",
+		inMod: []string{"a", "b"},
+		wantEdits: []*orderEdit[string]{
+			{op: OrderedMapInsert, id: "a", where: YANGPatchFirst},
+			{op: OrderedMapInsert, id: "b", where: YANGPatchAfter, point: "a"},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := orderEdits(tt.inOrig, tt.inMod)
+			if diff := cmp.Diff(tt.wantEdits, got, cmp.AllowUnexported(orderEdit[string]{})); diff != "" {
+				t.Errorf("orderEdits (-want, +got):\n%s", diff)
+			}
+			applied, err := applyOrderEdits(tt.inOrig, got)
+			if err != nil {
+				t.Fatalf("cannot apply edits: %v", err)
+			}
+			if diff := cmp.Diff(tt.inMod, applied); diff != "" {
+				t.Errorf("applying edits did not result in modified sequence (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestOrderEditsRandom tests that the edits returned by orderEdits for random
+// sequences reproduce the modified sequence, with a move for each common
+// element that is not within their longest common subsequence.
+func TestOrderEditsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	sequence := func() []string {
+		var s []string
+		for _, i := range r.Perm(12)[:r.Intn(12)] {
+			s = append(s, fmt.Sprint(i))
+		}
+		return s
+	}
+	for i := 0; i < 500; i++ {
+		orig, mod := sequence(), sequence()
+		edits := orderEdits(orig, mod)
+		got, err := applyOrderEdits(orig, edits)
+		if err != nil {
+			t.Fatalf("orderEdits(%v, %v): cannot apply edits: %v", orig, mod, err)
+		}
+		if !slices.Equal(got, mod) {
+			t.Fatalf("orderEdits(%v, %v): applying edits got %v, want %v", orig, mod, got, mod)
+		}
+
+		var oc, mc []string
+		for _, s := range orig {
+			if slices.Contains(mod, s) {
+				oc = append(oc, s)
+			}
+		}
+		for _, s := range mod {
+			if slices.Contains(orig, s) {
+				mc = append(mc, s)
+			}
+		}
+		moves := 0
+		for _, e := range edits {
+			if e.op == OrderedMapMove {
+				moves++
+			}
+		}
+		inOrder, _ := commonSubsequence(oc, mc)
+		want := len(mc)
+		for _, in := range inOrder {
+			if in {
+				want--
+			}
+		}
+		if moves != want {
+			t.Fatalf("orderEdits(%v, %v): got %d moves, want %d", orig, mod, moves, want)
+		}
+	}
+}
+
+func BenchmarkOrderEdits(b *testing.B) {
+	orig := sequence(5000)
+	shuffled := slices.Clone(orig)
+	rand.New(rand.NewSource(1)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	reversed := slices.Clone(orig)
+	slices.Reverse(reversed)
+
+	for _, bb := range []struct {
+		desc string
+		mod  []string
+	}{{
+		desc: "move last to front",
+		mod:  append([]string{orig[len(orig)-1]}, orig[:len(orig)-1]...),
+	}, {
+		desc: "reverse",
+		mod:  reversed,
+	}, {
+		desc: "shuffle",
+		mod:  shuffled,
+	}} {
+		b.Run(bb.desc, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				orderEdits(orig, bb.mod)
+			}
+		})
+	}
+}
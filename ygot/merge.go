@@ -0,0 +1,693 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// MergeChoice specifies which of the values of a conflicting node is used
+// by MergeThreeWay.
+type MergeChoice int
+
+const (
+	// MergeChooseOurs specifies that the value of the node in ours is
+	// used. It is the choice where no MergeConflictResolver is supplied.
+	MergeChooseOurs MergeChoice = iota
+	// MergeChooseTheirs specifies that the value of the node in theirs is
+	// used.
+	MergeChooseTheirs
+	// MergeChooseBase specifies that the value of the node in base is
+	// used.
+	MergeChooseBase
+)
+
+// String returns the name of the MergeChoice.
+func (c MergeChoice) String() string {
+	switch c {
+	case MergeChooseOurs:
+		return "ours"
+	case MergeChooseTheirs:
+		return "theirs"
+	case MergeChooseBase:
+		return "base"
+	}
+	return fmt.Sprintf("MergeChoice(%d)", int(c))
+}
+
+// MergeConflict is a node that is modified differently in ours and theirs
+// relative to base by MergeThreeWay.
+type MergeConflict struct {
+	// Path is the path of the node.
+	Path *gnmipb.Path
+	// Base, Ours and Theirs are the values of the node in each of the
+	// GoStructs, which are nil where it is not populated. The values of
+	// leaves are their Go values, e.g., a string rather than a *string,
+	// and the values of containers and list entries are GoStructs. Where
+	// the order of the entries of an `ordered-by user` list conflicts,
+	// they are the keys of the list in order, as a []any.
+	Base, Ours, Theirs any
+	// Resolution is the value of the node that is used.
+	Resolution MergeChoice
+}
+
+// MergeConflictResolver is a MergeOpt that specifies how MergeThreeWay
+// resolves conflicts.
+type MergeConflictResolver struct {
+	// Resolve is called with each conflict, and returns the choice of
+	// value that is used.
+	Resolve func(*MergeConflict) MergeChoice
+}
+
+// IsMergeOpt marks MergeConflictResolver as a MergeOpt.
+func (*MergeConflictResolver) IsMergeOpt() {}
+
+// hasMergeConflictResolver returns the first MergeConflictResolver from an
+// opts slice, or nil if there isn't one.
+func hasMergeConflictResolver(opts []MergeOpt) *MergeConflictResolver {
+	for _, o := range opts {
+		if r, ok := o.(*MergeConflictResolver); ok {
+			return r
+		}
+	}
+	return nil
+}
+
+// MergeThreeWay performs a three-way merge of the changes made to base by
+// ours and by theirs, which must all be GoStructs of the same type, and
+// returns a new GoStruct containing the result, along with the conflicts
+// encountered, sorted by path. base may be nil, in which case it is treated
+// as being empty.
+//
+// Each leaf that is changed in only one of ours and theirs, or is changed to
+// the same value in both, takes the changed value. Where a leaf is changed to
+// different values, which includes being deleted in one and not the other,
+// the conflict is resolved by the MergeConflictResolver, or in favour of ours
+// if none is supplied. Where a container or list entry is deleted in one of
+// ours and theirs, and modified in the other, the conflict is of the node as
+// a whole. Keyed lists are merged by key.
+//
+// `ordered-by user` lists are merged by key, and their order is that of
+// whichever of ours and theirs has changed the relative order of the entries
+// that are present in all three, where the entries that are only present in
+// the other are inserted after the entry that precedes them. Where ours and
+// theirs both insert entries at the same position, those of ours precede
+// those of theirs. Where both change the relative order differently, the
+// order conflicts.
+//
+// Annotations are merged as per leaves, where conflicts are resolved in
+// favour of ours and are not reported.
+func MergeThreeWay(base, ours, theirs GoStruct, opts ...MergeOpt) (GoStruct, []*MergeConflict, error) {
+	t := reflect.TypeOf(ours)
+	if reflect.TypeOf(theirs) != t || base != nil && reflect.TypeOf(base) != t {
+		return nil, nil, fmt.Errorf("cannot merge structs that are not of matching types, base: %T, ours: %T, theirs: %T", base, ours, theirs)
+	}
+	if util.IsNilOrInvalidValue(reflect.ValueOf(ours)) || util.IsNilOrInvalidValue(reflect.ValueOf(theirs)) {
+		return nil, nil, fmt.Errorf("cannot merge nil structs, ours: %v, theirs: %v", ours, theirs)
+	}
+	if !util.IsTypeStructPtr(t) {
+		return nil, nil, fmt.Errorf("cannot merge non-struct pointer type %T", ours)
+	}
+
+	m := &threeWayMerger{}
+	if r := hasMergeConflictResolver(opts); r != nil {
+		m.resolve = r.Resolve
+	}
+	bv := reflect.New(t.Elem()).Elem()
+	if !util.IsNilOrInvalidValue(reflect.ValueOf(base)) {
+		bv = reflect.ValueOf(base).Elem()
+	}
+	out, err := m.mergeStruct(bv, reflect.ValueOf(ours).Elem(), reflect.ValueOf(theirs).Elem(), newPathElemGNMIPath(nil))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paths := map[*MergeConflict]string{}
+	for _, c := range m.conflicts {
+		ps, err := PathToString(c.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot convert path of conflict to string: %v", err)
+		}
+		paths[c] = ps
+	}
+	sort.SliceStable(m.conflicts, func(i, j int) bool {
+		return paths[m.conflicts[i]] < paths[m.conflicts[j]]
+	})
+	return out.Interface().(GoStruct), m.conflicts, nil
+}
+
+// threeWayMerger holds the state of a MergeThreeWay call.
+type threeWayMerger struct {
+	// resolve is the resolver of conflicts, if any.
+	resolve func(*MergeConflict) MergeChoice
+	// conflicts are the conflicts encountered.
+	conflicts []*MergeConflict
+}
+
+// conflict records the conflict of the node at path p, whose values are
+// supplied, and returns its resolution.
+func (m *threeWayMerger) conflict(p *gnmiPath, base, ours, theirs any) (MergeChoice, error) {
+	pp, err := p.ToProto()
+	if err != nil {
+		return MergeChooseOurs, err
+	}
+	c := &MergeConflict{Path: pp, Base: base, Ours: ours, Theirs: theirs}
+	if m.resolve != nil {
+		c.Resolution = m.resolve(c)
+	}
+	m.conflicts = append(m.conflicts, c)
+	return c.Resolution, nil
+}
+
+// mergeStruct merges the struct values b, o and t, which are rooted at p, and
+// returns a pointer to a new struct containing the result.
+func (m *threeWayMerger) mergeStruct(b, o, t reflect.Value, p *gnmiPath) (reflect.Value, error) {
+	stype := o.Type()
+	out := reflect.New(stype)
+
+	var errs errlist.List
+	for i := 0; i < stype.NumField(); i++ {
+		ft := stype.Field(i)
+		bf, of, tf := b.Field(i), o.Field(i), t.Field(i)
+
+		if util.IsYgotAnnotation(ft) {
+			v, ok := pickLeaf(bf, of, tf)
+			if !ok {
+				v = of
+			}
+			c, err := cloneLeaf(v)
+			if err != nil {
+				errs.Add(fmt.Errorf("%v->%s: %v", p, ft.Name, err))
+				continue
+			}
+			out.Elem().Field(i).Set(c)
+			continue
+		}
+
+		fps, err := structTagToLibPaths(ft, p, false)
+		if err != nil {
+			errs.Add(fmt.Errorf("%v->%s: %v", p, ft.Name, err))
+			continue
+		}
+		fp := fps[0]
+
+		var v reflect.Value
+		_, isOrderedMap := of.Interface().(GoOrderedMap)
+		switch {
+		case isOrderedMap:
+			v, err = m.mergeOrderedMap(bf, of, tf, fp)
+		case util.IsTypeStructPtr(ft.Type):
+			v, err = m.mergeNode(bf, of, tf, ft.Type, fp)
+		case ft.Type.Kind() == reflect.Map:
+			v, err = m.mergeMap(bf, of, tf, fp)
+		default:
+			v, err = m.mergeLeaf(bf, of, tf, fp)
+		}
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		if v.IsValid() {
+			out.Elem().Field(i).Set(v)
+		}
+	}
+	return out, errs.Err()
+}
+
+// mergeLeaf merges the values b, o and t of the leaf at path p, and returns
+// a copy of the resulting value.
+func (m *threeWayMerger) mergeLeaf(b, o, t reflect.Value, p *gnmiPath) (reflect.Value, error) {
+	if v, ok := pickLeaf(b, o, t); ok {
+		return cloneLeaf(v)
+	}
+	c, err := m.conflict(p, leafConflictValue(b), leafConflictValue(o), leafConflictValue(t))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return cloneLeaf(choose(c, b, o, t))
+}
+
+// pickLeaf returns the result of merging the values b, o and t of a leaf,
+// and whether they can be merged without conflict.
+func pickLeaf(b, o, t reflect.Value) (reflect.Value, bool) {
+	switch {
+	case leafEqual(o, t), leafEqual(t, b):
+		return o, true
+	case leafEqual(o, b):
+		return t, true
+	}
+	return reflect.Value{}, false
+}
+
+// leafEqual reports whether the values a and b of a leaf are equal, where
+// unset values are equal.
+func leafEqual(a, b reflect.Value) bool {
+	if leafUnset(a) || leafUnset(b) {
+		return leafUnset(a) && leafUnset(b)
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// leafUnset reports whether the value v of a leaf is not set.
+func leafUnset(v reflect.Value) bool {
+	return !v.IsValid() || v.IsZero() || v.Kind() == reflect.Slice && v.Len() == 0
+}
+
+// leafConflictValue returns the value of the leaf v that is reported in a
+// MergeConflict.
+func leafConflictValue(v reflect.Value) any {
+	switch {
+	case leafUnset(v):
+		return nil
+	case v.Kind() == reflect.Ptr:
+		return v.Elem().Interface()
+	}
+	return v.Interface()
+}
+
+// cloneLeaf returns a copy of the value v of a leaf or unkeyed list. The
+// entries of unkeyed lists are deep copied.
+func cloneLeaf(v reflect.Value) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v, nil
+		}
+		n := reflect.New(v.Type().Elem())
+		n.Elem().Set(v.Elem())
+		return n, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return v, nil
+		}
+		n := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if !util.IsTypeStructPtr(v.Type().Elem()) {
+			reflect.Copy(n, v)
+			return n, nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			e, err := cloneNode(v.Index(i), v.Type().Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			n.Index(i).Set(e)
+		}
+		return n, nil
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, nil
+		}
+		c, err := cloneLeaf(v.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		n := reflect.New(v.Type()).Elem()
+		n.Set(c)
+		return n, nil
+	}
+	return v, nil
+}
+
+// choose returns whichever of b, o and t is specified by c.
+func choose(c MergeChoice, b, o, t reflect.Value) reflect.Value {
+	switch c {
+	case MergeChooseTheirs:
+		return t
+	case MergeChooseBase:
+		return b
+	}
+	return o
+}
+
+// mergeNode merges the container or list entry at path p, whose values b, o
+// and t are struct pointers of type typ, and returns a pointer to a new
+// struct containing the result, or a nil pointer where it is deleted.
+func (m *threeWayMerger) mergeNode(b, o, t reflect.Value, typ reflect.Type, p *gnmiPath) (reflect.Value, error) {
+	bNil, oNil, tNil := util.IsNilOrInvalidValue(b), util.IsNilOrInvalidValue(o), util.IsNilOrInvalidValue(t)
+	switch {
+	case oNil && tNil:
+		return reflect.Zero(typ), nil
+	case oNil || tNil:
+		present := o
+		if oNil {
+			present = t
+		}
+		switch {
+		case bNil:
+			// Added by only one of ours and theirs.
+			return cloneNode(present, typ)
+		case reflect.DeepEqual(present.Interface(), b.Interface()):
+			// Deleted by one and unmodified by the other.
+			return reflect.Zero(typ), nil
+		}
+		c, err := m.conflict(p, nodeConflictValue(b), nodeConflictValue(o), nodeConflictValue(t))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return cloneNode(choose(c, b, o, t), typ)
+	}
+
+	bs := reflect.New(typ.Elem()).Elem()
+	if !bNil {
+		bs = b.Elem()
+	}
+	return m.mergeStruct(bs, o.Elem(), t.Elem(), p)
+}
+
+// nodeConflictValue returns the value of the container or list entry v that
+// is reported in a MergeConflict.
+func nodeConflictValue(v reflect.Value) any {
+	if util.IsNilOrInvalidValue(v) {
+		return nil
+	}
+	return v.Interface()
+}
+
+// cloneNode returns a deep copy of the struct pointer v of type typ, or a nil
+// pointer if v is nil.
+func cloneNode(v reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	if util.IsNilOrInvalidValue(v) {
+		return reflect.Zero(typ), nil
+	}
+	n := reflect.New(typ.Elem())
+	if err := copyStruct(n.Elem(), v.Elem(), "", nil); err != nil {
+		return reflect.Value{}, err
+	}
+	return n, nil
+}
+
+// mergeMap merges the keyed list at path p, whose values b, o and t are maps
+// of struct pointers, by key, and returns a new map containing the result.
+func (m *threeWayMerger) mergeMap(b, o, t reflect.Value, p *gnmiPath) (reflect.Value, error) {
+	// The keys are merged in the order of their string representations,
+	// such that errors and conflicts are found in a deterministic order.
+	seen := map[any]bool{}
+	var keys []reflect.Value
+	var strs []string
+	for _, mv := range []reflect.Value{b, o, t} {
+		for _, k := range mv.MapKeys() {
+			if !seen[k.Interface()] {
+				seen[k.Interface()] = true
+				keys = append(keys, k)
+				strs = append(strs, fmt.Sprint(k.Interface()))
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return reflect.Value{}, nil
+	}
+	sort.Stable(mapKeysByString{keys: keys, strs: strs})
+
+	typ := o.Type()
+	out := reflect.MakeMapWithSize(typ, len(keys))
+	var errs errlist.List
+	for _, k := range keys {
+		bv, ov, tv := mapIndex(b, k), mapIndex(o, k), mapIndex(t, k)
+		ep, err := mapValuePath(k, firstNonNil(ov, tv, bv), p)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		v, err := m.mergeNode(bv, ov, tv, typ.Elem(), ep)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		if !v.IsNil() {
+			out.SetMapIndex(k, v)
+		}
+	}
+	if out.Len() == 0 {
+		return reflect.Value{}, errs.Err()
+	}
+	return out, errs.Err()
+}
+
+// mapKeysByString sorts the keys of a map by their string representations
+// strs.
+type mapKeysByString struct {
+	keys []reflect.Value
+	strs []string
+}
+
+func (s mapKeysByString) Len() int           { return len(s.keys) }
+func (s mapKeysByString) Less(i, j int) bool { return s.strs[i] < s.strs[j] }
+func (s mapKeysByString) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.strs[i], s.strs[j] = s.strs[j], s.strs[i]
+}
+
+// mapIndex returns the value of the key k within the map mv, which is invalid
+// if it is not present.
+func mapIndex(mv, k reflect.Value) reflect.Value {
+	if mv.IsNil() {
+		return reflect.Value{}
+	}
+	return mv.MapIndex(k)
+}
+
+// firstNonNil returns the first of vs that is not nil.
+func firstNonNil(vs ...reflect.Value) reflect.Value {
+	for _, v := range vs {
+		if !util.IsNilOrInvalidValue(v) {
+			return v
+		}
+	}
+	return reflect.Value{}
+}
+
+// orderedMapEntries returns the keys of the ordered map om in order, and its
+// entries keyed by key. om may be a nil pointer.
+func orderedMapEntries(om reflect.Value) ([]reflect.Value, map[any]reflect.Value, error) {
+	entries := map[any]reflect.Value{}
+	if util.IsNilOrInvalidValue(om) {
+		return nil, entries, nil
+	}
+	var keys []reflect.Value
+	if err := yreflect.RangeOrderedMap(om.Interface().(GoOrderedMap), func(k, v reflect.Value) bool {
+		keys = append(keys, k)
+		entries[k.Interface()] = v
+		return true
+	}); err != nil {
+		return nil, nil, err
+	}
+	return keys, entries, nil
+}
+
+// mergeOrderedMap merges the `ordered-by user` list at path p, whose values
+// b, o and t are ordered maps, and returns a new ordered map containing the
+// result.
+func (m *threeWayMerger) mergeOrderedMap(b, o, t reflect.Value, p *gnmiPath) (reflect.Value, error) {
+	bk, be, err := orderedMapEntries(b)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	ok, oe, err := orderedMapEntries(o)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	tk, te, err := orderedMapEntries(t)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if len(bk) == 0 && len(ok) == 0 && len(tk) == 0 {
+		return reflect.Value{}, nil
+	}
+
+	var elemType reflect.Type
+	for _, es := range []map[any]reflect.Value{oe, te, be} {
+		for _, v := range es {
+			elemType = v.Type()
+			break
+		}
+		if elemType != nil {
+			break
+		}
+	}
+
+	// Merge the entries by key.
+	merged := map[any]reflect.Value{}
+	var errs errlist.List
+	for _, keys := range [][]reflect.Value{ok, tk, bk} {
+		for _, k := range keys {
+			ki := k.Interface()
+			if _, done := merged[ki]; done {
+				continue
+			}
+			ep, err := mapValuePath(k, firstNonNil(oe[ki], te[ki], be[ki]), p)
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			v, err := m.mergeNode(be[ki], oe[ki], te[ki], elemType, ep)
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			merged[ki] = v
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return reflect.Value{}, err
+	}
+
+	// Determine the order of the entries, which is based on whichever of
+	// ours and theirs changes the relative order of the common entries.
+	inAll := func(k reflect.Value) bool {
+		ki := k.Interface()
+		_, inB := be[ki]
+		_, inO := oe[ki]
+		_, inT := te[ki]
+		return inB && inO && inT
+	}
+	bc, oc, tc := filterKeys(bk, inAll), filterKeys(ok, inAll), filterKeys(tk, inAll)
+	var order []reflect.Value
+	switch {
+	case keysEqual(oc, tc), keysEqual(tc, bc):
+		order = ok
+	case keysEqual(oc, bc):
+		order = tk
+	default:
+		c, err := m.conflict(p, keysAsAny(bk), keysAsAny(ok), keysAsAny(tk))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		order = choose(c, reflect.ValueOf(bk), reflect.ValueOf(ok), reflect.ValueOf(tk)).Interface().([]reflect.Value)
+	}
+
+	survives := func(k reflect.Value) bool {
+		v, ok := merged[k.Interface()]
+		return ok && !v.IsNil()
+	}
+	// The entries that theirs inserts follow those that ours inserts at
+	// the same position, whichever of them the order is based on.
+	oursInserted := func(k reflect.Value) bool {
+		ki := k.Interface()
+		_, inB := be[ki]
+		_, inO := oe[ki]
+		return inO && !inB
+	}
+	keys := filterKeys(order, survives)
+	keys = insertKeys(keys, ok, survives, nil)
+	keys = insertKeys(keys, tk, survives, oursInserted)
+	keys = insertKeys(keys, bk, survives, nil)
+	if len(keys) == 0 {
+		return reflect.Value{}, nil
+	}
+
+	out := reflect.New(o.Type().Elem())
+	om := out.Interface().(GoOrderedMap)
+	for _, k := range keys {
+		if err := yreflect.AppendIntoOrderedMap(om, merged[k.Interface()].Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return out, nil
+}
+
+// filterKeys returns the keys for which keep returns true, in order.
+func filterKeys(keys []reflect.Value, keep func(reflect.Value) bool) []reflect.Value {
+	var out []reflect.Value
+	for _, k := range keys {
+		if keep(k) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// keysEqual reports whether the keys a and b are the same and in the same
+// order.
+func keysEqual(a, b []reflect.Value) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Interface() != b[i].Interface() {
+			return false
+		}
+	}
+	return true
+}
+
+// keysAsAny returns the keys as a []any.
+func keysAsAny(keys []reflect.Value) []any {
+	out := []any{}
+	for _, k := range keys {
+		out = append(out, k.Interface())
+	}
+	return out
+}
+
+// insertKeys inserts each of the keys of seq for which include returns true,
+// and which are not already within keys, after the key that precedes it
+// within seq, or at the start of keys if there is no such key. Where skip is
+// non-nil, the keys for which it returns true that immediately follow that
+// position are skipped, such that the key is inserted after them. Since each
+// such key immediately follows the last key before it within seq that is in
+// the result, the keys inserted at each position are collected in a single
+// pass over seq, and then emitted along with keys.
+func insertKeys(keys, seq []reflect.Value, include, skip func(reflect.Value) bool) []reflect.Value {
+	// inserted[i] are the keys that are inserted after keys[i-1], or at the
+	// start for i = 0, and next[i] is the position at which the keys that
+	// follow keys[i-1] are inserted, after any keys that are skipped.
+	inserted := make([][]reflect.Value, len(keys)+1)
+	next := make([]int, len(keys)+1)
+	next[len(keys)] = len(keys)
+	for i := len(keys) - 1; i >= 0; i-- {
+		next[i] = i
+		if skip != nil && skip(keys[i]) {
+			next[i] = next[i+1]
+		}
+	}
+	// pos is the position at which the keys that follow each key of keys,
+	// or each inserted key, are inserted.
+	pos := make(map[any]int, len(keys))
+	for i, k := range keys {
+		pos[k.Interface()] = next[i+1]
+	}
+	at, n := next[0], 0
+	for _, k := range seq {
+		if i, ok := pos[k.Interface()]; ok {
+			at = i
+			continue
+		}
+		if !include(k) {
+			continue
+		}
+		inserted[at] = append(inserted[at], k)
+		pos[k.Interface()] = at
+		n++
+	}
+	if n == 0 {
+		return keys
+	}
+
+	out := make([]reflect.Value, 0, len(keys)+n)
+	out = append(out, inserted[0]...)
+	for i, k := range keys {
+		out = append(out, k)
+		out = append(out, inserted[i+1]...)
+	}
+	return out
+}
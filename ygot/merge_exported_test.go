@@ -0,0 +1,308 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/internal/ytestutil"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestMergeThreeWay(t *testing.T) {
+	orderedMap := func(keys ...string) *ctestschema.OrderedList_OrderedMap {
+		om := &ctestschema.OrderedList_OrderedMap{}
+		for _, k := range keys {
+			v, err := om.AppendNew(k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.Value = ygot.String(k + "-val")
+		}
+		return om
+	}
+	entry := func(k, v string) *ctestschema.UnorderedList {
+		return &ctestschema.UnorderedList{Key: ygot.String(k), Value: ygot.String(v)}
+	}
+	base := func() *ctestschema.Device {
+		return &ctestschema.Device{
+			OrderedList: orderedMap("foo", "bar"),
+			OtherData:   &ctestschema.OtherData{Motd: ygot.String("hello")},
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": entry("foo", "foo-val"),
+			},
+		}
+	}
+	with := func(f func(*ctestschema.Device)) *ctestschema.Device {
+		d := base()
+		f(d)
+		return d
+	}
+	chooseTheirs := &ygot.MergeConflictResolver{
+		Resolve: func(*ygot.MergeConflict) ygot.MergeChoice { return ygot.MergeChooseTheirs },
+	}
+
+	tests := []struct {
+		desc          string
+		inBase        ygot.GoStruct
+		inOurs        ygot.GoStruct
+		inTheirs      ygot.GoStruct
+		inOpts        []ygot.MergeOpt
+		want          ygot.GoStruct
+		wantConflicts []*ygot.MergeConflict
+		wantErr       string
+	}{{
+		desc:   "non-conflicting changes",
+		inBase: base(),
+		inOurs: with(func(d *ctestschema.Device) { d.OtherData.Motd = ygot.String("world") }),
+		inTheirs: with(func(d *ctestschema.Device) {
+			d.UnorderedList["bar"] = entry("bar", "bar-val")
+			d.UnorderedList["foo"].Value = ygot.String("new-val")
+		}),
+		want: with(func(d *ctestschema.Device) {
+			d.OtherData.Motd = ygot.String("world")
+			d.UnorderedList["bar"] = entry("bar", "bar-val")
+			d.UnorderedList["foo"].Value = ygot.String("new-val")
+		}),
+	}, {
+		desc:     "same change on both sides",
+		inBase:   base(),
+		inOurs:   with(func(d *ctestschema.Device) { d.OtherData.Motd = ygot.String("world") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.OtherData.Motd = ygot.String("world") }),
+		want:     with(func(d *ctestschema.Device) { d.OtherData.Motd = ygot.String("world") }),
+	}, {
+		desc:     "nil base",
+		inOurs:   with(func(d *ctestschema.Device) { d.OtherData.Motd = ygot.String("world") }),
+		inTheirs: &ctestschema.Device{UnorderedList: map[string]*ctestschema.UnorderedList{"bar": entry("bar", "bar-val")}},
+		want: with(func(d *ctestschema.Device) {
+			d.OtherData.Motd = ygot.String("world")
+			d.UnorderedList["bar"] = entry("bar", "bar-val")
+		}),
+	}, {
+		desc:     "leaf conflict resolved as ours by default",
+		inBase:   base(),
+		inOurs:   with(func(d *ctestschema.Device) { d.OtherData.Motd = ygot.String("ours") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.OtherData.Motd = ygot.String("theirs") }),
+		want:     with(func(d *ctestschema.Device) { d.OtherData.Motd = ygot.String("ours") }),
+		wantConflicts: []*ygot.MergeConflict{{
+			Path:       mustPath("other-data/config/motd"),
+			Base:       "hello",
+			Ours:       "ours",
+			Theirs:     "theirs",
+			Resolution: ygot.MergeChooseOurs,
+		}},
+	}, {
+		desc:     "leaf conflict resolved by resolver",
+		inBase:   base(),
+		inOurs:   with(func(d *ctestschema.Device) { d.OtherData.Motd = ygot.String("ours") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.OtherData.Motd = nil }),
+		inOpts:   []ygot.MergeOpt{chooseTheirs},
+		want:     with(func(d *ctestschema.Device) { d.OtherData.Motd = nil }),
+		wantConflicts: []*ygot.MergeConflict{{
+			Path:       mustPath("other-data/config/motd"),
+			Base:       "hello",
+			Ours:       "ours",
+			Resolution: ygot.MergeChooseTheirs,
+		}},
+	}, {
+		desc:     "list entry deleted and unmodified",
+		inBase:   base(),
+		inOurs:   with(func(d *ctestschema.Device) { delete(d.UnorderedList, "foo") }),
+		inTheirs: base(),
+		want:     with(func(d *ctestschema.Device) { d.UnorderedList = nil }),
+	}, {
+		desc:     "list entry deleted and modified",
+		inBase:   base(),
+		inOurs:   with(func(d *ctestschema.Device) { delete(d.UnorderedList, "foo") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.UnorderedList["foo"].Value = ygot.String("new-val") }),
+		inOpts:   []ygot.MergeOpt{chooseTheirs},
+		want:     with(func(d *ctestschema.Device) { d.UnorderedList["foo"].Value = ygot.String("new-val") }),
+		wantConflicts: []*ygot.MergeConflict{{
+			Path:       mustPath("unordered-lists/unordered-list[key=foo]"),
+			Base:       entry("foo", "foo-val"),
+			Theirs:     entry("foo", "new-val"),
+			Resolution: ygot.MergeChooseTheirs,
+		}},
+	}, {
+		desc:     "ordered list insertions on both sides",
+		inBase:   base(),
+		inOurs:   with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "bar", "baz") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("qux", "foo", "bar") }),
+		want:     with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("qux", "foo", "bar", "baz") }),
+	}, {
+		desc:     "ordered list insertions on both sides into empty list",
+		inBase:   with(func(d *ctestschema.Device) { d.OrderedList = nil }),
+		inOurs:   with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("a") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("b") }),
+		want:     with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("a", "b") }),
+	}, {
+		desc:     "ordered list insertions on both sides at the same position",
+		inBase:   base(),
+		inOurs:   with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "a", "c", "bar") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "b", "bar") }),
+		want:     with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "a", "c", "b", "bar") }),
+	}, {
+		desc:     "ordered list reordered by theirs with insertions on both sides at the same position",
+		inBase:   base(),
+		inOurs:   with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "a", "bar") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("bar", "foo", "b") }),
+		want:     with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("bar", "foo", "a", "b") }),
+	}, {
+		desc:     "ordered list reordered and appended",
+		inBase:   base(),
+		inOurs:   with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("bar", "foo") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "bar", "baz") }),
+		want:     with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("bar", "baz", "foo") }),
+	}, {
+		desc:     "ordered list entry deleted and reordered",
+		inBase:   with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "bar", "baz") }),
+		inOurs:   with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "baz") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("baz", "foo", "bar") }),
+		want:     with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("baz", "foo") }),
+	}, {
+		desc:     "ordered list order conflict",
+		inBase:   with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "bar", "baz") }),
+		inOurs:   with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("bar", "foo", "baz") }),
+		inTheirs: with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "baz", "bar", "qux") }),
+		inOpts:   []ygot.MergeOpt{chooseTheirs},
+		want:     with(func(d *ctestschema.Device) { d.OrderedList = orderedMap("foo", "baz", "bar", "qux") }),
+		wantConflicts: []*ygot.MergeConflict{{
+			Path:       mustPath("ordered-lists/ordered-list"),
+			Base:       []any{"foo", "bar", "baz"},
+			Ours:       []any{"bar", "foo", "baz"},
+			Theirs:     []any{"foo", "baz", "bar", "qux"},
+			Resolution: ygot.MergeChooseTheirs,
+		}},
+	}, {
+		desc:     "different types",
+		inBase:   base(),
+		inOurs:   base(),
+		inTheirs: &ctestschema.OtherData{},
+		wantErr:  "cannot merge structs that are not of matching types",
+	}, {
+		desc:     "nil ours",
+		inBase:   base(),
+		inOurs:   (*ctestschema.Device)(nil),
+		inTheirs: base(),
+		wantErr:  "cannot merge nil structs",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, conflicts, err := ygot.MergeThreeWay(tt.inBase, tt.inOurs, tt.inTheirs, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("MergeThreeWay: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Errorf("MergeThreeWay (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantConflicts, conflicts, protocmp.Transform()); diff != "" {
+				t.Errorf("MergeThreeWay conflicts (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// mergeListRoot is a GoStruct that contains an unkeyed list and a keyed list
+// whose entries cannot render their keys.
+type mergeListRoot struct {
+	UnkeyedList []*mergeListEntry          `path:"unkeyed-list" module:"m"`
+	KeyErrList  map[string]*mergeListEntry `path:"key-err-list" module:"m"`
+}
+
+func (*mergeListRoot) IsYANGGoStruct() {}
+
+// mergeListEntry is an entry of the lists of mergeListRoot.
+type mergeListEntry struct {
+	Val *string `path:"val" module:"m"`
+}
+
+func (*mergeListEntry) IsYANGGoStruct() {}
+
+// ΛListKeyMap returns an error that identifies the entry by its value.
+func (e *mergeListEntry) ΛListKeyMap() (map[string]any, error) {
+	return nil, fmt.Errorf("no key for %s", *e.Val)
+}
+
+func TestMergeThreeWayUnkeyedList(t *testing.T) {
+	base := &mergeListRoot{UnkeyedList: []*mergeListEntry{{Val: ygot.String("a")}}}
+	ours := &mergeListRoot{UnkeyedList: []*mergeListEntry{{Val: ygot.String("a")}}}
+	theirs := &mergeListRoot{UnkeyedList: []*mergeListEntry{{Val: ygot.String("b")}}}
+
+	got, conflicts, err := ygot.MergeThreeWay(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("MergeThreeWay: unexpected error: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("MergeThreeWay: unexpected conflicts: %v", conflicts)
+	}
+	want := &mergeListRoot{UnkeyedList: []*mergeListEntry{{Val: ygot.String("b")}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("MergeThreeWay (-want, +got):\n%s", diff)
+	}
+
+	// The entries of the result must not be shared with the inputs.
+	got.(*mergeListRoot).UnkeyedList[0].Val = ygot.String("c")
+	if v := *theirs.UnkeyedList[0].Val; v != "b" {
+		t.Errorf("MergeThreeWay: modifying the result modified theirs, got: %s, want: b", v)
+	}
+}
+
+// TestMergeThreeWayKeyOrder tests that the entries of keyed lists are merged
+// in the order of their keys, such that errors are reported deterministically.
+func TestMergeThreeWayKeyOrder(t *testing.T) {
+	entries := func(keys ...string) map[string]*mergeListEntry {
+		m := map[string]*mergeListEntry{}
+		for _, k := range keys {
+			m[k] = &mergeListEntry{Val: ygot.String(k)}
+		}
+		return m
+	}
+	base := &mergeListRoot{KeyErrList: entries("d", "b")}
+	ours := &mergeListRoot{KeyErrList: entries("c", "a")}
+	theirs := &mergeListRoot{KeyErrList: entries("e")}
+
+	var want string
+	for i := 0; i < 20; i++ {
+		_, _, err := ygot.MergeThreeWay(base, ours, theirs)
+		if err == nil {
+			t.Fatalf("MergeThreeWay: got nil error, want error")
+		}
+		if i == 0 {
+			want = err.Error()
+			for _, k := range []string{"a", "b", "c", "d", "e"} {
+				if !strings.Contains(want, "no key for "+k) {
+					t.Fatalf("MergeThreeWay: error %q does not report entry %s", want, k)
+				}
+			}
+			if a, e := strings.Index(want, "no key for a"), strings.Index(want, "no key for e"); a > e {
+				t.Fatalf("MergeThreeWay: error %q does not report entries in key order", want)
+			}
+			continue
+		}
+		if got := err.Error(); got != want {
+			t.Fatalf("MergeThreeWay: got error %q, want %q", got, want)
+		}
+	}
+}
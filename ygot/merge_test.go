@@ -0,0 +1,96 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInsertKeys(t *testing.T) {
+	values := func(ss ...string) []reflect.Value {
+		var out []reflect.Value
+		for _, s := range ss {
+			out = append(out, reflect.ValueOf(s))
+		}
+		return out
+	}
+
+	tests := []struct {
+		desc      string
+		inKeys    []string
+		inSeq     []string
+		inExclude []string
+		inSkip    []string
+		want      []string
+	}{{
+		desc:   "no keys to insert",
+		inKeys: []string{"a", "b"},
+		inSeq:  []string{"b", "a"},
+		want:   []string{"a", "b"},
+	}, {
+		desc:  "insert into empty keys",
+		inSeq: []string{"a", "b"},
+		want:  []string{"a", "b"},
+	}, {
+		desc:   "insert at start",
+		inKeys: []string{"c"},
+		inSeq:  []string{"a", "b", "c"},
+		want:   []string{"a", "b", "c"},
+	}, {
+		desc:   "insert after predecessors",
+		inKeys: []string{"a", "c", "e"},
+		inSeq:  []string{"a", "b", "e", "f", "c", "d"},
+		want:   []string{"a", "b", "c", "d", "e", "f"},
+	}, {
+		desc:      "excluded key is not a predecessor",
+		inKeys:    []string{"a", "c"},
+		inSeq:     []string{"c", "x", "b"},
+		inExclude: []string{"x"},
+		want:      []string{"a", "c", "b"},
+	}, {
+		desc:   "insert after skipped keys",
+		inKeys: []string{"x", "a", "y", "b"},
+		inSeq:  []string{"c", "a", "d", "b"},
+		inSkip: []string{"x", "y"},
+		want:   []string{"x", "c", "a", "y", "d", "b"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			exclude, skip := map[string]bool{}, map[string]bool{}
+			for _, k := range tt.inExclude {
+				exclude[k] = true
+			}
+			for _, k := range tt.inSkip {
+				skip[k] = true
+			}
+			got := insertKeys(values(tt.inKeys...), values(tt.inSeq...), func(k reflect.Value) bool {
+				return !exclude[k.String()]
+			}, func(k reflect.Value) bool {
+				return skip[k.String()]
+			})
+			var gotKeys []string
+			for _, k := range got {
+				gotKeys = append(gotKeys, k.String())
+			}
+			if diff := cmp.Diff(tt.want, gotKeys); diff != "" {
+				t.Errorf("insertKeys (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
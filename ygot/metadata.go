@@ -0,0 +1,129 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// MetadataAnnotation is an Annotation that is the value of an RFC 7952
+// metadata annotation, which is defined by an md:annotation statement of a
+// YANG module, e.g., the ietf-origin:origin annotation. Where the annotation
+// fields of a GoStruct, which are generated for the GoStruct itself and for
+// each of its fields, contain MetadataAnnotations, they are rendered as the
+// "@" and "@leaf" members of RFC 7951 JSON respectively, in which each
+// annotation is a member named by the Name of the annotation. ytypes.Unmarshal
+// populates the annotation fields with MetadataAnnotations from such members
+// where the schema contains the definitions of the annotations.
+type MetadataAnnotation struct {
+	// Name is the name of the annotation, prefixed with the name of the
+	// module that defines it, e.g., ietf-origin:origin.
+	Name string
+	// Value is the value of the annotation. Its Go type corresponds to
+	// the YANG type of the annotation as per the type of the leaves of a
+	// GoStruct, such that values of the integer types are the Go integer
+	// of the same size, decimal64 values are float64, boolean values are
+	// bool, and binary values are []byte. Values of string and enumeration
+	// types are a string of the value, and identityref values are the name
+	// of the identity prefixed with the name of its module. The value of
+	// an annotation of the empty type is nil.
+	Value any
+}
+
+// MarshalJSON marshals the annotation to a JSON object containing a single
+// member, named by its Name, whose value is its RFC 7951 JSON value.
+func (m *MetadataAnnotation) MarshalJSON() ([]byte, error) {
+	v, err := metadataValueJSON(m)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]any{m.Name: v})
+}
+
+// UnmarshalJSON unmarshals a JSON object containing a single member, as
+// output by MarshalJSON, into the annotation. Since the type of the
+// annotation is only known from its schema, the Value of the annotation is
+// the value decoded by encoding/json, such that ytypes.Unmarshal should be
+// used to unmarshal annotations of known types.
+func (m *MetadataAnnotation) UnmarshalJSON(b []byte) error {
+	var o map[string]any
+	if err := json.Unmarshal(b, &o); err != nil {
+		return err
+	}
+	if len(o) != 1 {
+		return fmt.Errorf("got %d members, metadata annotation must have one member", len(o))
+	}
+	for k, v := range o {
+		m.Name, m.Value = k, v
+	}
+	return nil
+}
+
+// metadataValueJSON returns the RFC 7951 JSON value of the metadata
+// annotation m, in which int64, uint64 and decimal64 values are strings,
+// binary values are base64 encoded, and an empty value is [null].
+func metadataValueJSON(m *MetadataAnnotation) (any, error) {
+	switch v := m.Value.(type) {
+	case nil:
+		return []any{nil}, nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int8, int16, int32, uint8, uint16, uint32, bool, string:
+		return v, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T of value of metadata annotation %s", m.Value, m.Name)
+}
+
+// metadataJSON returns the RFC 7952 JSON representation of v, which must be
+// an annotation field ([]ygot.Annotation), if its annotations are
+// MetadataAnnotations. It returns false if the annotations are not
+// MetadataAnnotations, and an error if only some are.
+func metadataJSON(v reflect.Value) (map[string]any, bool, error) {
+	var ms []*MetadataAnnotation
+	for i := 0; i < v.Len(); i++ {
+		if m, ok := v.Index(i).Interface().(*MetadataAnnotation); ok && m != nil {
+			ms = append(ms, m)
+		}
+	}
+	switch {
+	case len(ms) == 0:
+		return nil, false, nil
+	case len(ms) != v.Len():
+		return nil, true, fmt.Errorf("cannot marshal metadata annotations along with annotations of other types: %v", v.Interface())
+	}
+
+	out := map[string]any{}
+	for _, m := range ms {
+		if _, ok := out[m.Name]; ok {
+			return nil, true, fmt.Errorf("duplicate metadata annotation %s", m.Name)
+		}
+		jv, err := metadataValueJSON(m)
+		if err != nil {
+			return nil, true, err
+		}
+		out[m.Name] = jv
+	}
+	return out, true, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+type metadataExample struct {
+	ΛMetadata []Annotation `path:"@" ygotAnnotation:"true"`
+	Leaf      *string      `path:"leaf" module:"mt"`
+	ΛLeaf     []Annotation `path:"@leaf" ygotAnnotation:"true"`
+	Other     *string      `path:"config/other" module:"mt/other"`
+	ΛOther    []Annotation `path:"config/@other" ygotAnnotation:"true"`
+}
+
+func (*metadataExample) IsYANGGoStruct() {}
+
+func TestConstructIETFJSONMetadata(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       *metadataExample
+		inConfig *RFC7951JSONConfig
+		want     map[string]any
+		wantErr  string
+	}{{
+		desc: "annotations of struct and leaves",
+		in: &metadataExample{
+			ΛMetadata: []Annotation{
+				&MetadataAnnotation{Name: "mt:origin", Value: "ietf-origin:intended"},
+				&MetadataAnnotation{Name: "mt:big", Value: uint64(18446744073709551615)},
+			},
+			Leaf: String("l"),
+			ΛLeaf: []Annotation{
+				&MetadataAnnotation{Name: "mt:count", Value: uint32(42)},
+				&MetadataAnnotation{Name: "mt:flag"},
+				&MetadataAnnotation{Name: "mt:data", Value: []byte("data")},
+				&MetadataAnnotation{Name: "mt:decimal", Value: 4.2},
+			},
+			Other:  String("o"),
+			ΛOther: []Annotation{&MetadataAnnotation{Name: "mt:id", Value: int64(-1)}},
+		},
+		inConfig: &RFC7951JSONConfig{AppendModuleName: true},
+		want: map[string]any{
+			"@": map[string]any{
+				"mt:origin": "ietf-origin:intended",
+				"mt:big":    "18446744073709551615",
+			},
+			"mt:leaf": "l",
+			"@mt:leaf": map[string]any{
+				"mt:count":   float64(42),
+				"mt:flag":    []any{nil},
+				"mt:data":    "ZGF0YQ==",
+				"mt:decimal": "4.2",
+			},
+			"mt:config": map[string]any{
+				"other:other":  "o",
+				"@other:other": map[string]any{"mt:id": "-1"},
+			},
+		},
+	}, {
+		desc: "without module names",
+		in: &metadataExample{
+			Leaf:   String("l"),
+			ΛLeaf:  []Annotation{&MetadataAnnotation{Name: "mt:mode", Value: "a"}},
+			ΛOther: []Annotation{&MetadataAnnotation{Name: "mt:mode", Value: "b"}},
+		},
+		want: map[string]any{
+			"leaf":   "l",
+			"@leaf":  map[string]any{"mt:mode": "a"},
+			"config": map[string]any{"@other": map[string]any{"mt:mode": "b"}},
+		},
+	}, {
+		desc:    "mixed annotations",
+		in:      &metadataExample{ΛMetadata: []Annotation{&MetadataAnnotation{Name: "mt:mode"}, &testAnnotation{AnnotationFieldOne: "a"}}},
+		wantErr: "cannot marshal metadata annotations along with annotations of other types",
+	}, {
+		desc:    "duplicate annotations",
+		in:      &metadataExample{ΛMetadata: []Annotation{&MetadataAnnotation{Name: "mt:mode"}, &MetadataAnnotation{Name: "mt:mode"}}},
+		wantErr: "duplicate metadata annotation mt:mode",
+	}, {
+		desc:    "unsupported value",
+		in:      &metadataExample{ΛMetadata: []Annotation{&MetadataAnnotation{Name: "mt:mode", Value: struct{}{}}}},
+		wantErr: "unsupported type struct {} of value of metadata annotation mt:mode",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ConstructIETFJSON(tt.in, tt.inConfig)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("ConstructIETFJSON: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ConstructIETFJSON (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetadataAnnotationJSON(t *testing.T) {
+	b, err := json.Marshal(&MetadataAnnotation{Name: "mt:count", Value: int64(42)})
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+	if got, want := string(b), `{"mt:count":"42"}`; got != want {
+		t.Errorf("MarshalJSON: got %s, want %s", got, want)
+	}
+
+	got := &MetadataAnnotation{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if diff := cmp.Diff(&MetadataAnnotation{Name: "mt:count", Value: "42"}, got); diff != "" {
+		t.Errorf("UnmarshalJSON (-want, +got):\n%s", diff)
+	}
+
+	err = json.Unmarshal([]byte(`{"a:b": 1, "a:c": 2}`), &MetadataAnnotation{})
+	if diff := errdiff.Substring(err, "metadata annotation must have one member"); diff != "" {
+		t.Errorf("UnmarshalJSON of two members: %s", diff)
+	}
+}
@@ -0,0 +1,1552 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// This file implements the rendering of the differences between GoStructs
+// as YANG Patch (RFC 8072) and JSON Patch (RFC 6902) documents. Patches can
+// be applied to GoStructs using the ytypes package.
+
+// YANGPatchOperation is the operation of an edit of a YANG Patch.
+type YANGPatchOperation string
+
+const (
+	// YANGPatchCreate creates the target, which must not exist.
+	YANGPatchCreate YANGPatchOperation = "create"
+	// YANGPatchDelete deletes the target, which must exist.
+	YANGPatchDelete YANGPatchOperation = "delete"
+	// YANGPatchInsert inserts the target into an `ordered-by user` list or
+	// leaf-list, at the position specified by the where and point of the
+	// edit. The target must not exist.
+	YANGPatchInsert YANGPatchOperation = "insert"
+	// YANGPatchMerge merges the value into the target.
+	YANGPatchMerge YANGPatchOperation = "merge"
+	// YANGPatchMove moves the target within an `ordered-by user` list or
+	// leaf-list to the position specified by the where and point of the
+	// edit. The target must exist.
+	YANGPatchMove YANGPatchOperation = "move"
+	// YANGPatchReplace replaces the target with the value.
+	YANGPatchReplace YANGPatchOperation = "replace"
+	// YANGPatchRemove deletes the target if it exists.
+	YANGPatchRemove YANGPatchOperation = "remove"
+)
+
+// YANGPatchWhere is the position at which an insert or move edit of a YANG
+// Patch places its target.
+type YANGPatchWhere string
+
+const (
+	// YANGPatchBefore places the target before the point of the edit.
+	YANGPatchBefore YANGPatchWhere = "before"
+	// YANGPatchAfter places the target after the point of the edit.
+	YANGPatchAfter YANGPatchWhere = "after"
+	// YANGPatchFirst places the target first.
+	YANGPatchFirst YANGPatchWhere = "first"
+	// YANGPatchLast places the target last, which is the default.
+	YANGPatchLast YANGPatchWhere = "last"
+)
+
+// yangPatchName is the name of the member of the JSON encoding of a YANG
+// Patch document.
+const yangPatchName = "ietf-yang-patch:yang-patch"
+
+// YANGPatch is a YANG Patch document, as per RFC 8072. Its JSON encoding is
+// that of the "ietf-yang-patch:yang-patch" container.
+type YANGPatch struct {
+	// PatchID is the identifier of the patch.
+	PatchID string
+	// Comment is an optional description of the patch.
+	Comment string
+	// Edits are the edits of the patch, which are applied in order.
+	Edits []*YANGPatchEdit
+}
+
+// YANGPatchEdit is an edit of a YANG Patch.
+type YANGPatchEdit struct {
+	// EditID is the identifier of the edit within the patch.
+	EditID string `json:"edit-id"`
+	// Operation is the operation of the edit.
+	Operation YANGPatchOperation `json:"operation"`
+	// Target is the data resource identifier of the target of the edit, as
+	// per RFC 8040, relative to the root of the data tree.
+	Target string `json:"target"`
+	// Point is the data resource identifier of the list entry or
+	// leaf-list value relative to which the target is inserted or moved,
+	// where Where is YANGPatchBefore or YANGPatchAfter.
+	Point string `json:"point,omitempty"`
+	// Where is the position at which the target is inserted or moved.
+	Where YANGPatchWhere `json:"where,omitempty"`
+	// Value is the value of the edit, which is a JSON object containing
+	// the target, whose member name is module-qualified, as per RFC 7951.
+	// The target of a list entry or leaf-list value is a member whose
+	// value is an array containing the entry or value.
+	Value any `json:"value,omitempty"`
+}
+
+// yangPatchJSON is the JSON encoding of the contents of a YANG Patch.
+type yangPatchJSON struct {
+	PatchID string           `json:"patch-id"`
+	Comment string           `json:"comment,omitempty"`
+	Edits   []*YANGPatchEdit `json:"edit,omitempty"`
+}
+
+// MarshalJSON marshals the YANG Patch to JSON.
+func (p *YANGPatch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]*yangPatchJSON{
+		yangPatchName: {PatchID: p.PatchID, Comment: p.Comment, Edits: p.Edits},
+	})
+}
+
+// UnmarshalJSON unmarshals a YANG Patch from JSON.
+func (p *YANGPatch) UnmarshalJSON(b []byte) error {
+	var j map[string]*yangPatchJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	v, ok := j[yangPatchName]
+	if !ok || v == nil {
+		return fmt.Errorf("YANG Patch does not contain %s", yangPatchName)
+	}
+	*p = YANGPatch{PatchID: v.PatchID, Comment: v.Comment, Edits: v.Edits}
+	return nil
+}
+
+// JSONPatchOp is the operation of a JSON Patch operation.
+type JSONPatchOp string
+
+const (
+	// JSONPatchAdd adds a value.
+	JSONPatchAdd JSONPatchOp = "add"
+	// JSONPatchRemove removes a value.
+	JSONPatchRemove JSONPatchOp = "remove"
+	// JSONPatchReplace replaces a value.
+	JSONPatchReplace JSONPatchOp = "replace"
+	// JSONPatchMove moves a value.
+	JSONPatchMove JSONPatchOp = "move"
+	// JSONPatchCopy copies a value.
+	JSONPatchCopy JSONPatchOp = "copy"
+	// JSONPatchTest tests that a value is equal to the value of the
+	// operation.
+	JSONPatchTest JSONPatchOp = "test"
+)
+
+// JSONPatch is a JSON Patch document, as per RFC 6902.
+type JSONPatch []*JSONPatchOperation
+
+// JSONPatchOperation is an operation of a JSON Patch.
+type JSONPatchOperation struct {
+	// Op is the operation.
+	Op JSONPatchOp
+	// Path is the JSON Pointer, as per RFC 6901, of the target of the
+	// operation.
+	Path string
+	// From is the JSON Pointer of the value that is moved or copied.
+	From string
+	// Value is the value of an add, replace or test operation.
+	Value any
+}
+
+// MarshalJSON marshals the operation to JSON, including the members that are
+// used by its Op.
+func (o *JSONPatchOperation) MarshalJSON() ([]byte, error) {
+	j := map[string]any{"op": o.Op, "path": o.Path}
+	switch o.Op {
+	case JSONPatchMove, JSONPatchCopy:
+		j["from"] = o.From
+	case JSONPatchAdd, JSONPatchReplace, JSONPatchTest:
+		j["value"] = o.Value
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON unmarshals an operation from JSON.
+func (o *JSONPatchOperation) UnmarshalJSON(b []byte) error {
+	var j struct {
+		Op    JSONPatchOp     `json:"op"`
+		Path  *string         `json:"path"`
+		From  string          `json:"from"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if j.Path == nil {
+		return fmt.Errorf("JSON Patch operation %q does not have a path", j.Op)
+	}
+	*o = JSONPatchOperation{Op: j.Op, Path: *j.Path, From: j.From}
+	if j.Value != nil {
+		if err := json.Unmarshal(j.Value, &o.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DiffYANGPatch takes an original and modified GoStruct, which must be of the
+// same type and the root of the YANG schema tree, and returns a YANG Patch
+// with the supplied ID that, when applied to original, results in modified.
+//
+// The edits are built from the differences returned by DiffWithAtomic with the
+// OrderedMapEdits DiffOpt. Each deleted path is deleted at its highest
+// container or list entry that is absent from modified, and each updated path
+// is merged at its highest container or list entry that is absent from
+// original, or otherwise as a leaf. The entries of `ordered-by user` lists are
+// inserted and moved as per DiffOrderedMaps. The values of leaf-lists that are
+// only present in one of the GoStructs are merged or deleted individually.
+// The data is deleted first, then merged, and the entries of `ordered-by user`
+// lists are then inserted and moved.
+//
+// The WithDefaults DiffOpt is supported.
+func DiffYANGPatch(original, modified GoStruct, patchID string, opts ...DiffOpt) (*YANGPatch, error) {
+	original, modified, err := checkDiffInputs(original, modified, opts)
+	if err != nil {
+		return nil, err
+	}
+	edits, err := patchEdits(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	b := &yangPatchBuilder{}
+	for _, e := range edits {
+		if err := b.addEdit(e); err != nil {
+			return nil, err
+		}
+	}
+	return &YANGPatch{PatchID: patchID, Edits: b.edits}, nil
+}
+
+// DiffJSONPatch takes an original and modified GoStruct, which must be of the
+// same type and the root of the YANG schema tree, and returns a JSON Patch
+// that, when applied to the RFC 7951 JSON rendering of original, with module
+// names appended, results in that of modified.
+//
+// The operations are built from the same edits as those of DiffYANGPatch. The
+// members that are only present in the rendering of original are removed at
+// their highest ancestor that is absent from that of modified, and those that
+// are only present in the rendering of modified are added at their highest
+// ancestor that is absent from that of original. Other leaves are replaced.
+// The entries of keyed lists are matched by their keys, and the entries of
+// `ordered-by user` lists are added and moved to their position. The values
+// of leaf-lists are removed and added around the longest sequence of values
+// that are common to both, which is found in linear space.
+//
+// The WithDefaults DiffOpt is supported.
+func DiffJSONPatch(original, modified GoStruct, opts ...DiffOpt) (JSONPatch, error) {
+	original, modified, err := checkDiffInputs(original, modified, opts)
+	if err != nil {
+		return nil, err
+	}
+	edits, err := patchEdits(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	oj, err := rfc7951Document(original)
+	if err != nil {
+		return nil, fmt.Errorf("cannot render original struct: %v", err)
+	}
+	mj, err := rfc7951Document(modified)
+	if err != nil {
+		return nil, fmt.Errorf("cannot render modified struct: %v", err)
+	}
+	b := &jsonPatchBuilder{patch: JSONPatch{}, doc: oj, mod: mj, index: map[*any]map[string]int{}}
+	for _, e := range edits {
+		if err := b.addEdit(e); err != nil {
+			return nil, err
+		}
+	}
+	return b.patch, nil
+}
+
+// checkDiffInputs checks that original and modified can be diffed, and
+// returns them with the WithDefaults DiffOpt within opts applied.
+func checkDiffInputs(original, modified GoStruct, opts []DiffOpt) (GoStruct, GoStruct, error) {
+	if reflect.TypeOf(original) != reflect.TypeOf(modified) {
+		return nil, nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
+	}
+	if util.IsNilOrInvalidValue(reflect.ValueOf(original)) || util.IsNilOrInvalidValue(reflect.ValueOf(modified)) {
+		return nil, nil, fmt.Errorf("cannot diff nil structs, original: %v, modified: %v", original, modified)
+	}
+	if mode := hasWithDefaults(opts); mode != WithDefaultsExplicit {
+		var err error
+		if original, err = withDefaults(original, mode); err != nil {
+			return nil, nil, fmt.Errorf("could not apply with-defaults mode to original struct: %v", err)
+		}
+		if modified, err = withDefaults(modified, mode); err != nil {
+			return nil, nil, fmt.Errorf("could not apply with-defaults mode to modified struct: %v", err)
+		}
+	}
+	return original, modified, nil
+}
+
+// rfc7951Document returns the RFC 7951 JSON rendering of s, with module names
+// appended, as the values that result from unmarshalling it.
+func rfc7951Document(s GoStruct) (any, error) {
+	j, err := ConstructIETFJSON(s, &RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return nil, err
+	}
+	js, err := json.Marshal(j)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(js, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// patchNodeKind is the kind of the schema node of a patchNode.
+type patchNodeKind int
+
+const (
+	// patchContainer is a container that is represented by a GoStruct.
+	patchContainer patchNodeKind = iota
+	// patchCompressed is a container that is compressed out of the
+	// GoStructs, and hence is represented by the fields of its parent
+	// whose path is within it.
+	patchCompressed
+	// patchEntry is an entry of a keyed list.
+	patchEntry
+	// patchLeaf is a leaf.
+	patchLeaf
+	// patchLeafList is a leaf-list.
+	patchLeafList
+)
+
+// patchNode describes a data node within a patch, along with its values
+// within the original and modified GoStructs.
+type patchNode struct {
+	// target is the data resource identifier of the node.
+	target string
+	// mod is the module of the node.
+	mod string
+	// name is the module-qualified name of the node.
+	name string
+	// member is the name of the member of the node within the RFC 7951
+	// JSON object of its parent, which is module-qualified where the
+	// module of the node differs from that of its parent.
+	member string
+	// kind is the kind of the node.
+	kind patchNodeKind
+	// o and m are the values of the node within the original and
+	// modified GoStructs. They are the struct pointers of containers and
+	// list entries, which are nil where the node is absent, the struct
+	// pointers of the parents of compressed containers, and the fields of
+	// leaves and leaf-lists.
+	o, m reflect.Value
+	// inO and inM report whether the node contains data within the
+	// original and modified GoStructs.
+	inO, inM bool
+	// list is the data resource identifier of the list of an entry.
+	list string
+	// ordered reports whether an entry is that of an `ordered-by user`
+	// list.
+	ordered bool
+	// keys are the keys of an entry, keyed by the name of the key leaf.
+	keys map[string]string
+}
+
+// patchEdit is an edit of the data tree, which is rendered as an edit of a
+// YANG Patch or as the operations of a JSON Patch.
+type patchEdit struct {
+	// op is YANGPatchDelete, YANGPatchMerge, YANGPatchInsert or
+	// YANGPatchMove.
+	op YANGPatchOperation
+	// nodes are the nodes of the path of the target, from the child of the
+	// root to the target.
+	nodes []*patchNode
+	// where and point specify the position of an inserted or moved entry.
+	where YANGPatchWhere
+	point *patchNode
+}
+
+// target returns the node that is the target of the edit.
+func (e *patchEdit) target() *patchNode {
+	return e.nodes[len(e.nodes)-1]
+}
+
+// patchEdits returns the edits that modify original to modified, which are
+// built from the Notifications returned by DiffWithAtomic with the
+// OrderedMapEdits DiffOpt. The edits that delete data come first, followed by
+// those that merge data, and then the inserts and moves of the entries of
+// `ordered-by user` lists, grouped by list in the order returned by
+// DiffOrderedMaps. The edits are otherwise sorted by target.
+func patchEdits(original, modified GoStruct) ([]*patchEdit, error) {
+	notifs, err := DiffWithAtomic(original, modified, &OrderedMapEdits{})
+	if err != nil {
+		return nil, err
+	}
+	c := &patchEditCollector{
+		r: &patchResolver{
+			o:       reflect.ValueOf(original),
+			m:       reflect.ValueOf(modified),
+			entries: map[uintptr]map[string]patchListEntry{},
+		},
+		targets:  map[string]bool{},
+		inserted: map[string]*patchNode{},
+	}
+	for _, n := range notifs {
+		path := func(p *gnmipb.Path) *gnmipb.Path {
+			if n.GetPrefix() == nil {
+				return p
+			}
+			return joingNMIPaths(n.GetPrefix(), p)
+		}
+		for _, p := range n.GetDelete() {
+			if err := c.delete(path(p)); err != nil {
+				return nil, err
+			}
+		}
+		for _, u := range n.GetUpdate() {
+			if err := c.update(path(u.GetPath()), u.GetVal()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	phase := func(e *patchEdit) int {
+		switch e.op {
+		case YANGPatchDelete:
+			return 0
+		case YANGPatchMerge:
+			return 1
+		}
+		return 2
+	}
+	group := func(e *patchEdit) string {
+		if t := e.target(); t.kind == patchEntry && t.ordered && phase(e) == 2 {
+			return t.list
+		}
+		return e.target().target
+	}
+	sort.SliceStable(c.edits, func(i, j int) bool {
+		a, b := c.edits[i], c.edits[j]
+		if pa, pb := phase(a), phase(b); pa != pb {
+			return pa < pb
+		}
+		return group(a) < group(b)
+	})
+	return c.edits, nil
+}
+
+// patchEditCollector collects the edits of a patch from the deleted and
+// updated paths of a diff.
+type patchEditCollector struct {
+	r     *patchResolver
+	edits []*patchEdit
+	// targets are the targets of the edits.
+	targets map[string]bool
+	// inserted is the last entry inserted into each `ordered-by user`
+	// list that is absent from the original, keyed by the list.
+	inserted map[string]*patchNode
+}
+
+// add appends e to the edits, unless there is already an edit of its target.
+func (c *patchEditCollector) add(e *patchEdit) bool {
+	t := e.target().target
+	if c.targets[t] {
+		return false
+	}
+	c.targets[t] = true
+	c.edits = append(c.edits, e)
+	return true
+}
+
+// delete adds the edit that deletes the deleted path p, which is that of its
+// highest node that is absent from the modified GoStruct.
+func (c *patchEditCollector) delete(p *gnmipb.Path) error {
+	nodes, err := c.r.resolve(p)
+	if err != nil {
+		return err
+	}
+	for i, n := range nodes {
+		if !n.inM {
+			c.add(&patchEdit{op: YANGPatchDelete, nodes: nodes[:i+1]})
+			return nil
+		}
+	}
+	return nil
+}
+
+// update adds the edit that applies the update of path p to the value v. The
+// updates of the inserted and moved entries of `ordered-by user` lists are
+// inserts and moves, and other updates are merges of their highest container
+// or list entry that is absent from the original GoStruct, or otherwise of
+// their leaf. The entries of `ordered-by user` lists that are absent from the
+// original, and are not inserted by an explicit update, are inserted after
+// the entry that was last inserted into the list.
+func (c *patchEditCollector) update(p *gnmipb.Path, v *gnmipb.TypedValue) error {
+	if js := v.GetJsonIetfVal(); js != nil {
+		where, point, ok, err := orderedMapInsertion(js)
+		if err != nil {
+			return fmt.Errorf("%s: %v", patchPathString(p), err)
+		}
+		if ok {
+			return c.order(p, where, point)
+		}
+	}
+	nodes, err := c.r.resolve(p)
+	if err != nil {
+		return err
+	}
+	for i, n := range nodes {
+		if n.inO || n.kind == patchCompressed {
+			continue
+		}
+		e := &patchEdit{op: YANGPatchMerge, nodes: nodes[:i+1]}
+		if n.kind == patchEntry && n.ordered {
+			e.op, e.where = YANGPatchInsert, YANGPatchFirst
+			if prev, ok := c.inserted[n.list]; ok {
+				e.where, e.point = YANGPatchAfter, prev
+			}
+			if c.add(e) {
+				c.inserted[n.list] = n
+			}
+			return nil
+		}
+		c.add(e)
+		return nil
+	}
+	c.add(&patchEdit{op: YANGPatchMerge, nodes: nodes})
+	return nil
+}
+
+// order adds the edit that inserts or moves the entry of an `ordered-by user`
+// list whose path is p to the position specified by where, relative to the
+// entry whose keys are point.
+func (c *patchEditCollector) order(p *gnmipb.Path, where YANGPatchWhere, point map[string]string) error {
+	nodes, err := c.r.resolve(p)
+	if err != nil {
+		return err
+	}
+	n := nodes[len(nodes)-1]
+	if n.kind != patchEntry || !n.ordered {
+		return fmt.Errorf("%s: path is not that of an entry of an `ordered-by user` list", patchPathString(p))
+	}
+	e := &patchEdit{op: YANGPatchInsert, nodes: nodes, where: where}
+	if n.inO {
+		e.op = YANGPatchMove
+	}
+	if where == YANGPatchBefore || where == YANGPatchAfter {
+		pp := proto.Clone(p).(*gnmipb.Path)
+		pp.Elem[len(pp.Elem)-1].Key = point
+		pn, err := c.r.resolve(pp)
+		if err != nil {
+			return err
+		}
+		e.point = pn[len(pn)-1]
+	}
+	c.add(e)
+	return nil
+}
+
+// orderedMapInsertion returns the position of the entry of an `ordered-by
+// user` list whose JSON_IETF value is js, as specified by its
+// YANGInsertAnnotation and YANGKeyAnnotation metadata annotations, or false if
+// the value is not annotated.
+func orderedMapInsertion(js []byte) (YANGPatchWhere, map[string]string, bool, error) {
+	var j any
+	if err := json.Unmarshal(js, &j); err != nil {
+		return "", nil, false, fmt.Errorf("cannot decode JSON, %v", err)
+	}
+	obj, _ := j.(map[string]any)
+	md, _ := obj["@"].(map[string]any)
+	where, ok := md[YANGInsertAnnotation].(string)
+	if !ok {
+		return "", nil, false, nil
+	}
+	var point map[string]string
+	if k, ok := md[YANGKeyAnnotation].(string); ok {
+		var err error
+		if point, err = parseKeyPredicates(k); err != nil {
+			return "", nil, false, err
+		}
+	}
+	return YANGPatchWhere(where), point, true, nil
+}
+
+// patchPathString returns the string form of p for use within errors.
+func patchPathString(p *gnmipb.Path) string {
+	s, err := PathToString(p)
+	if err != nil {
+		return fmt.Sprint(p)
+	}
+	return s
+}
+
+// patchResolver resolves the paths of a diff to the nodes of the original
+// and modified GoStructs.
+type patchResolver struct {
+	o, m reflect.Value
+	// entries are the entries of the maps and ordered maps that have been
+	// resolved, keyed by the pointer of the map and the key predicates of
+	// the entry.
+	entries map[uintptr]map[string]patchListEntry
+}
+
+// resolve returns the nodes of the path p, from the child of the root to the
+// node that p identifies.
+func (r *patchResolver) resolve(p *gnmipb.Path) ([]*patchNode, error) {
+	elems := p.GetElem()
+	o, m := r.o, r.m
+	var target, mod string
+	var nodes []*patchNode
+	for len(elems) > 0 {
+		if !util.IsTypeStructPtr(o.Type()) {
+			return nil, fmt.Errorf("%s: path is not within a container or list entry", patchPathString(p))
+		}
+		i, names, mods, err := patchPathField(o.Type().Elem(), elems)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", patchPathString(p), err)
+		}
+		ft := o.Type().Elem().Field(i)
+
+		// The elements of the path of the field that are compressed
+		// out of the GoStructs.
+		for j, name := range names {
+			if j < len(mods) && mods[j] != mod {
+				mod = mods[j]
+				target += "/" + mod + ":" + name
+			} else {
+				target += "/" + name
+			}
+			n := &patchNode{target: target, mod: mod, name: name, member: target[strings.LastIndexByte(target, '/')+1:]}
+			if mod != "" {
+				n.name = mod + ":" + name
+			}
+			nodes = append(nodes, n)
+			if j == len(names)-1 && len(elems) >= len(names) {
+				break
+			}
+			n.kind, n.o, n.m = patchCompressed, o, m
+			n.inO = patchStructHasData(o, names[:j+1])
+			n.inM = patchStructHasData(m, names[:j+1])
+			if j == len(elems)-1 {
+				return nodes, nil
+			}
+		}
+
+		n := nodes[len(nodes)-1]
+		fo, fm := patchFieldValue(o, i), patchFieldValue(m, i)
+		switch {
+		case ft.Type.Implements(reflect.TypeOf((*GoOrderedMap)(nil)).Elem()) || ft.Type.Kind() == reflect.Map:
+			keys := elems[len(names)-1].GetKey()
+			if len(keys) == 0 {
+				return nil, fmt.Errorf("%s: path does not identify an entry of list %s", patchPathString(p), n.target)
+			}
+			ko, eo, inO, err := r.entry(fo, keys)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", n.target, err)
+			}
+			km, em, inM, err := r.entry(fm, keys)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", n.target, err)
+			}
+			switch {
+			case !inO && !inM:
+				return nil, fmt.Errorf("%s: entry is absent from both structs", patchPathString(p))
+			case !inO:
+				ko, eo = km, reflect.Zero(em.Type())
+			case !inM:
+				em = reflect.Zero(eo.Type())
+			}
+			if n.target, err = keyTarget(n.target, ko); err != nil {
+				return nil, err
+			}
+			n.kind, n.list, n.keys, n.inO, n.inM = patchEntry, target, keys, inO, inM
+			_, n.ordered = fo.Interface().(GoOrderedMap)
+			n.o, n.m = eo, em
+			o, m = eo, em
+			target = n.target
+		case util.IsTypeStructPtr(ft.Type):
+			n.kind, n.o, n.m = patchContainer, fo, fm
+			if util.IsYangPresence(ft) {
+				n.inO, n.inM = !fo.IsNil(), !fm.IsNil()
+			} else {
+				n.inO, n.inM = patchHasData(fo), patchHasData(fm)
+			}
+			o, m = fo, fm
+		default:
+			n.kind, n.o, n.m = patchLeaf, fo, fm
+			if ft.Type.Kind() == reflect.Slice && ft.Type.Name() != BinaryTypeName {
+				n.kind = patchLeafList
+			}
+			n.inO, n.inM = !leafUnset(fo), !leafUnset(fm)
+			if len(elems) > len(names) {
+				return nil, fmt.Errorf("%s: path continues beyond leaf %s", patchPathString(p), n.target)
+			}
+		}
+		elems = elems[len(names):]
+	}
+	return nodes, nil
+}
+
+// patchPathField returns the index of the field of the struct type st whose
+// path is at the start of elems, along with the names and modules of the
+// elements of its path. Where no path of a field is at the start of elems,
+// that of the field whose path starts with elems is returned.
+func patchPathField(st reflect.Type, elems []*gnmipb.PathElem) (int, []string, []string, error) {
+	match := func(names []string) bool {
+		for j, n := range names {
+			if j == len(elems) {
+				break
+			}
+			if util.StripModulePrefix(elems[j].GetName()) != n {
+				return false
+			}
+		}
+		return true
+	}
+	within := -1
+	var wNames, wMods []string
+	for i := 0; i < st.NumField(); i++ {
+		ft := st.Field(i)
+		if util.IsYgotAnnotation(ft) {
+			continue
+		}
+		fps, err := structTagToLibPaths(ft, newStringSliceGNMIPath(nil), false)
+		if err != nil {
+			return 0, nil, nil, fmt.Errorf("%s: %v", ft.Name, err)
+		}
+		fms, err := structTagToLibModules(ft, false)
+		if err != nil {
+			return 0, nil, nil, fmt.Errorf("%s: %v", ft.Name, err)
+		}
+		for a, fp := range fps {
+			names := fp.stringSlicePath
+			if len(names) == 0 || !match(names) {
+				continue
+			}
+			var mods []string
+			if a < len(fms) {
+				mods = fms[a].stringSlicePath
+			}
+			if len(names) <= len(elems) {
+				return i, names, mods, nil
+			}
+			if within < 0 {
+				within, wNames, wMods = i, names, mods
+			}
+		}
+	}
+	if within < 0 {
+		return 0, nil, nil, fmt.Errorf("no field of %s has the path %s", st.Name(), elems[0].GetName())
+	}
+	return within, wNames, wMods, nil
+}
+
+// patchFieldValue returns the field i of the struct pointer v, or its zero
+// value where v is nil.
+func patchFieldValue(v reflect.Value, i int) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem().Field(i).Type)
+	}
+	return v.Elem().Field(i)
+}
+
+// patchListEntry is an entry of a map or ordered map.
+type patchListEntry struct {
+	k, v reflect.Value
+}
+
+// entry returns the key and value of the entry of the map or ordered map
+// list, which may be nil, whose keys are keys, and whether it is present.
+func (r *patchResolver) entry(list reflect.Value, keys map[string]string) (reflect.Value, reflect.Value, bool, error) {
+	if util.IsNilOrInvalidValue(list) {
+		return reflect.Value{}, reflect.Value{}, false, nil
+	}
+	idx, ok := r.entries[list.Pointer()]
+	if !ok {
+		idx = map[string]patchListEntry{}
+		var errs []error
+		add := func(k, v reflect.Value) {
+			pk, err := PathKeyFromStruct(v)
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			idx[keyPredicates(pk)] = patchListEntry{k: k, v: v}
+		}
+		if om, ok := list.Interface().(GoOrderedMap); ok {
+			if err := yreflect.RangeOrderedMap(om, func(k, v reflect.Value) bool {
+				add(k, v)
+				return true
+			}); err != nil {
+				return reflect.Value{}, reflect.Value{}, false, err
+			}
+		} else {
+			for it := list.MapRange(); it.Next(); {
+				add(it.Key(), it.Value())
+			}
+		}
+		if len(errs) != 0 {
+			return reflect.Value{}, reflect.Value{}, false, errs[0]
+		}
+		r.entries[list.Pointer()] = idx
+	}
+	e, ok := idx[keyPredicates(keys)]
+	return e.k, e.v, ok, nil
+}
+
+// patchHasData reports whether the value v of a field of a GoStruct contains
+// data.
+func patchHasData(v reflect.Value) bool {
+	if util.IsNilOrInvalidValue(v) {
+		return false
+	}
+	if om, ok := v.Interface().(GoOrderedMap); ok {
+		return om.Len() != 0
+	}
+	switch {
+	case util.IsValueStructPtr(v):
+		return patchStructHasData(v, nil)
+	case v.Kind() == reflect.Map:
+		return v.Len() != 0
+	}
+	return !leafUnset(v)
+}
+
+// patchStructHasData reports whether the fields of the struct pointer v whose
+// path is within prefix contain data.
+func patchStructHasData(v reflect.Value, prefix []string) bool {
+	if v.IsNil() {
+		return false
+	}
+	st := v.Elem().Type()
+	for i := 0; i < st.NumField(); i++ {
+		ft := st.Field(i)
+		if util.IsYgotAnnotation(ft) {
+			continue
+		}
+		fps, err := structTagToLibPaths(ft, newStringSliceGNMIPath(nil), false)
+		if err != nil {
+			continue
+		}
+		for _, fp := range fps {
+			names := fp.stringSlicePath
+			if len(names) <= len(prefix) || !slices.Equal(names[:len(prefix)], prefix) {
+				continue
+			}
+			fv := v.Elem().Field(i)
+			if util.IsYangPresence(ft) && !fv.IsNil() || patchHasData(fv) {
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
+
+// yangPatchBuilder accumulates the edits of a YANG Patch.
+type yangPatchBuilder struct {
+	edits []*YANGPatchEdit
+}
+
+// add appends an edit to the patch.
+func (b *yangPatchBuilder) add(op YANGPatchOperation, target string, value any, where YANGPatchWhere, point string) {
+	b.edits = append(b.edits, &YANGPatchEdit{
+		EditID:    fmt.Sprintf("edit-%d", len(b.edits)+1),
+		Operation: op,
+		Target:    target,
+		Point:     point,
+		Where:     where,
+		Value:     value,
+	})
+}
+
+// jsonValue returns the RFC 7951 JSON value of v, a field of a GoStruct
+// within the module mod.
+func (b *yangPatchBuilder) jsonValue(v reflect.Value, mod string) (any, error) {
+	return jsonValue(v, mod, jsonOutputConfig{
+		jType:         RFC7951,
+		rfc7951Config: &RFC7951JSONConfig{AppendModuleName: true},
+	})
+}
+
+// addEdit appends the edits of the YANG Patch that apply e.
+func (b *yangPatchBuilder) addEdit(e *patchEdit) error {
+	n := e.target()
+	var point string
+	if e.point != nil {
+		point = e.point.target
+	}
+	switch {
+	case n.kind == patchLeafList:
+		return b.diffLeafList(n)
+	case e.op == YANGPatchDelete || e.op == YANGPatchMove:
+		b.add(e.op, n.target, nil, e.where, point)
+		return nil
+	}
+	j, err := b.jsonValue(n.m, n.mod)
+	if err != nil {
+		return fmt.Errorf("%s: %v", n.target, err)
+	}
+	if n.kind == patchEntry {
+		j = []any{j}
+	}
+	b.add(e.op, n.target, map[string]any{n.name: j}, e.where, point)
+	return nil
+}
+
+// diffLeafList appends the edits that delete the values of the leaf-list n
+// that are only present in the original GoStruct, and merge those that are
+// only present in the modified GoStruct.
+func (b *yangPatchBuilder) diffLeafList(n *patchNode) error {
+	values := func(v reflect.Value) ([]string, map[string]any, error) {
+		var keys []string
+		vals := map[string]any{}
+		for i := 0; i < v.Len(); i++ {
+			j, err := b.jsonValue(v.Slice(i, i+1), n.mod)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", n.target, err)
+			}
+			js, ok := j.([]any)
+			if !ok || len(js) != 1 {
+				return nil, nil, fmt.Errorf("%s: invalid leaf-list value %v", n.target, j)
+			}
+			k := fmt.Sprint(js[0])
+			if _, ok := vals[k]; !ok {
+				keys = append(keys, k)
+			}
+			vals[k] = js[0]
+		}
+		return keys, vals, nil
+	}
+	ok, ov, err := values(n.o)
+	if err != nil {
+		return err
+	}
+	mk, mv, err := values(n.m)
+	if err != nil {
+		return err
+	}
+	for _, k := range ok {
+		if _, ok := mv[k]; !ok {
+			b.add(YANGPatchDelete, n.target+"="+restconfEscape(k), nil, "", "")
+		}
+	}
+	for _, k := range mk {
+		if _, ok := ov[k]; !ok {
+			b.add(YANGPatchMerge, n.target+"="+restconfEscape(k), map[string]any{n.name: []any{mv[k]}}, "", "")
+		}
+	}
+	return nil
+}
+
+// keyTarget returns the data resource identifier of the list entry with key
+// k of the list whose data resource identifier is target.
+func keyTarget(target string, k reflect.Value) (string, error) {
+	var vs []reflect.Value
+	if k.Kind() == reflect.Struct {
+		for i := 0; i < k.NumField(); i++ {
+			vs = append(vs, k.Field(i))
+		}
+	} else {
+		vs = append(vs, k)
+	}
+	var ks []string
+	for _, v := range vs {
+		var s string
+		var err error
+		if _, ok := v.Interface().(GoEnum); ok {
+			s, _, err = enumFieldToString(v, true)
+		} else {
+			s, err = KeyValueAsString(v.Interface())
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s: cannot render key %v: %v", target, v.Interface(), err)
+		}
+		ks = append(ks, restconfEscape(s))
+	}
+	return target + "=" + strings.Join(ks, ","), nil
+}
+
+// restconfEscape percent-encodes s for use as a key value within a data
+// resource identifier.
+func restconfEscape(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), ",", "%2C")
+}
+
+// jsonPointerEscape escapes s for use as a reference token of a JSON Pointer.
+func jsonPointerEscape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// jsonPointer returns the JSON Pointer whose reference tokens are tokens.
+func jsonPointer(tokens []string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteString("/" + jsonPointerEscape(t))
+	}
+	return b.String()
+}
+
+// jsonSegment is a reference to a value within an RFC 7951 JSON document,
+// which is either a member of an object, or an entry of the array of a keyed
+// list.
+type jsonSegment struct {
+	// member is the name of the member.
+	member string
+	// entry reports whether the segment is an entry, which is identified
+	// by the JSON encoding of the values of its keys, whose names are
+	// keys, as per jsonEntryID.
+	entry bool
+	keys  []string
+	id    string
+}
+
+// jsonPatchBuilder accumulates the operations of a JSON Patch.
+type jsonPatchBuilder struct {
+	patch JSONPatch
+	// doc is the document that results from applying the patch to the
+	// rendering of the original GoStruct, and mod the rendering of the
+	// modified GoStruct.
+	doc, mod any
+	// index is the index of the entries of the arrays of keyed lists
+	// within mod, keyed by the address of their first element and their
+	// ID.
+	index map[*any]map[string]int
+}
+
+// segments returns the segments of the path of nodes.
+func (b *jsonPatchBuilder) segments(nodes []*patchNode) ([]jsonSegment, error) {
+	var segs []jsonSegment
+	for _, n := range nodes {
+		segs = append(segs, jsonSegment{member: n.member})
+		if n.kind != patchEntry {
+			continue
+		}
+		s, err := b.entrySegment(n)
+		if err != nil {
+			return nil, err
+		}
+		segs = append(segs, s)
+	}
+	return segs, nil
+}
+
+// entrySegment returns the segment of the list entry n, whose ID is the JSON
+// encoding of the RFC 7951 values of its keys.
+func (b *jsonPatchBuilder) entrySegment(n *patchNode) (jsonSegment, error) {
+	s := jsonSegment{entry: true}
+	for k := range n.keys {
+		s.keys = append(s.keys, k)
+	}
+	sort.Strings(s.keys)
+	v := n.m
+	if !n.inM {
+		v = n.o
+	}
+	st := v.Elem().Type()
+	vals := make([]any, len(s.keys))
+	for i := 0; i < st.NumField(); i++ {
+		ft := st.Field(i)
+		if util.IsYgotAnnotation(ft) {
+			continue
+		}
+		fps, err := structTagToLibPaths(ft, newStringSliceGNMIPath(nil), false)
+		if err != nil {
+			return s, fmt.Errorf("%s: %v", ft.Name, err)
+		}
+		for _, fp := range fps {
+			if len(fp.stringSlicePath) != 1 {
+				continue
+			}
+			if k := sort.SearchStrings(s.keys, fp.stringSlicePath[0]); k < len(s.keys) && s.keys[k] == fp.stringSlicePath[0] {
+				if vals[k], err = jsonValue(v.Elem().Field(i), n.mod, jsonOutputConfig{
+					jType:         RFC7951,
+					rfc7951Config: &RFC7951JSONConfig{AppendModuleName: true},
+				}); err != nil {
+					return s, fmt.Errorf("%s: %v", n.target, err)
+				}
+			}
+		}
+	}
+	js, err := json.Marshal(vals)
+	if err != nil {
+		return s, fmt.Errorf("%s: cannot encode keys: %v", n.target, err)
+	}
+	s.id = string(js)
+	return s, nil
+}
+
+// jsonEntryID returns the ID of the entry v of the array of a keyed list
+// whose keys are named keys, which is the JSON encoding of the values of its
+// keys.
+func jsonEntryID(v any, keys []string) string {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return ""
+	}
+	vals := make([]any, len(keys))
+	for i, k := range keys {
+		vals[i] = jsonMember(obj, k)
+	}
+	js, err := json.Marshal(vals)
+	if err != nil {
+		return ""
+	}
+	return string(js)
+}
+
+// jsonEntryIndex returns the index of the entry s within the array arr, or -1.
+func jsonEntryIndex(arr []any, s jsonSegment) int {
+	for i, v := range arr {
+		if jsonEntryID(v, s.keys) == s.id {
+			return i
+		}
+	}
+	return -1
+}
+
+// jsonLocate returns the reference tokens of the longest prefix of segs that
+// is present within doc, along with the values that they reference, where
+// the first value is doc.
+func jsonLocate(doc any, segs []jsonSegment) ([]string, []any) {
+	tokens := []string{}
+	vals := []any{doc}
+	for _, s := range segs {
+		cur := vals[len(vals)-1]
+		if s.entry {
+			arr, _ := cur.([]any)
+			i := jsonEntryIndex(arr, s)
+			if i < 0 {
+				break
+			}
+			tokens = append(tokens, strconv.Itoa(i))
+			vals = append(vals, arr[i])
+			continue
+		}
+		obj, _ := cur.(map[string]any)
+		v, ok := obj[s.member]
+		if !ok {
+			break
+		}
+		tokens = append(tokens, s.member)
+		vals = append(vals, v)
+	}
+	return tokens, vals
+}
+
+// jsonEdit returns v with the value that is referenced by tokens, which must
+// be present up to its parent, edited by f, which is called with its parent
+// and last token and returns the edited parent.
+func jsonEdit(v any, tokens []string, f func(parent any, token string) any) any {
+	if len(tokens) == 1 {
+		return f(v, tokens[0])
+	}
+	switch p := v.(type) {
+	case map[string]any:
+		p[tokens[0]] = jsonEdit(p[tokens[0]], tokens[1:], f)
+	case []any:
+		i, _ := strconv.Atoi(tokens[0])
+		p[i] = jsonEdit(p[i], tokens[1:], f)
+	}
+	return v
+}
+
+// jsonCopy returns a deep copy of the JSON value v.
+func jsonCopy(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(t))
+		for k, e := range t {
+			c[k] = jsonCopy(e)
+		}
+		return c
+	case []any:
+		c := make([]any, len(t))
+		for i, e := range t {
+			c[i] = jsonCopy(e)
+		}
+		return c
+	}
+	return v
+}
+
+// jsonLen returns the number of members or elements of the JSON object or
+// array v.
+func jsonLen(v any) int {
+	switch t := v.(type) {
+	case map[string]any:
+		return len(t)
+	case []any:
+		return len(t)
+	}
+	return 0
+}
+
+// remove appends the operation that removes the value referenced by tokens,
+// and applies it to b.doc.
+func (b *jsonPatchBuilder) remove(tokens []string) {
+	b.patch = append(b.patch, &JSONPatchOperation{Op: JSONPatchRemove, Path: jsonPointer(tokens)})
+	b.doc = jsonEdit(b.doc, tokens, func(parent any, t string) any {
+		switch p := parent.(type) {
+		case map[string]any:
+			delete(p, t)
+		case []any:
+			i, _ := strconv.Atoi(t)
+			return append(p[:i:i], p[i+1:]...)
+		}
+		return parent
+	})
+}
+
+// add appends the operation that adds v at tokens, or that replaces the
+// value referenced by tokens where replace is true, and applies it to b.doc.
+func (b *jsonPatchBuilder) add(tokens []string, v any, replace bool) {
+	op := JSONPatchAdd
+	if replace {
+		op = JSONPatchReplace
+	}
+	b.patch = append(b.patch, &JSONPatchOperation{Op: op, Path: jsonPointer(tokens), Value: v})
+	v = jsonCopy(v)
+	b.doc = jsonEdit(b.doc, tokens, func(parent any, t string) any {
+		switch p := parent.(type) {
+		case map[string]any:
+			p[t] = v
+		case []any:
+			i, _ := strconv.Atoi(t)
+			if replace {
+				p[i] = v
+				return p
+			}
+			return append(p[:i:i], append([]any{v}, p[i:]...)...)
+		}
+		return parent
+	})
+}
+
+// addEdit appends the operations of the JSON Patch that apply e.
+func (b *jsonPatchBuilder) addEdit(e *patchEdit) error {
+	segs, err := b.segments(e.nodes)
+	if err != nil {
+		return err
+	}
+	switch e.op {
+	case YANGPatchDelete:
+		b.removeEdit(segs)
+		return nil
+	case YANGPatchInsert, YANGPatchMove:
+		return b.orderEdit(e, segs)
+	}
+	return b.mergeEdit(e, segs)
+}
+
+// removeEdit removes the value referenced by segs, or its highest ancestor
+// that only contains it and is absent from b.mod.
+func (b *jsonPatchBuilder) removeEdit(segs []jsonSegment) {
+	tokens, vals := jsonLocate(b.doc, segs)
+	if len(tokens) < len(segs) {
+		return
+	}
+	k := len(segs)
+	for k > 1 && jsonLen(vals[k-1]) == 1 {
+		if mt, _ := jsonLocate(b.mod, segs[:k-1]); len(mt) == k-1 {
+			break
+		}
+		k--
+	}
+	b.remove(tokens[:k])
+}
+
+// mergeEdit adds the value referenced by segs within b.mod at its highest
+// ancestor that is absent from b.doc, or otherwise replaces it.
+func (b *jsonPatchBuilder) mergeEdit(e *patchEdit, segs []jsonSegment) error {
+	tokens, vals := jsonLocate(b.doc, segs)
+	mtokens, mvals := jsonLocate(b.mod, segs)
+	if len(mtokens) != len(segs) {
+		return fmt.Errorf("%s: absent from the rendering of the modified struct", e.target().target)
+	}
+	n := len(tokens)
+	if n == len(segs) {
+		cur, want := vals[n], mvals[n]
+		switch {
+		case e.target().kind == patchLeafList:
+			return b.diffLeafList(tokens, cur, want)
+		case !reflect.DeepEqual(cur, want):
+			b.add(tokens, want, true)
+		}
+		return nil
+	}
+	t := segs[n].member
+	if segs[n].entry {
+		i, err := b.entryPosition(vals[n], mvals[n], segs[n], mtokens[n])
+		if err != nil {
+			return fmt.Errorf("%s: %v", e.target().target, err)
+		}
+		t = strconv.Itoa(i)
+	}
+	b.add(append(tokens[:n:n], t), mvals[n+1], false)
+	return nil
+}
+
+// entryPosition returns the index within the array cur, which contains the
+// entries of an unordered keyed list in the order of the array want, at which
+// the entry s, whose index within want is the token wi, is added.
+func (b *jsonPatchBuilder) entryPosition(cur, want any, s jsonSegment, wi string) (int, error) {
+	ca, _ := cur.([]any)
+	wa, _ := want.([]any)
+	if len(wa) == 0 {
+		return 0, fmt.Errorf("invalid array of keyed list")
+	}
+	idx, ok := b.index[&wa[0]]
+	if !ok {
+		idx = make(map[string]int, len(wa))
+		for i, v := range wa {
+			idx[jsonEntryID(v, s.keys)] = i
+		}
+		b.index[&wa[0]] = idx
+	}
+	i, _ := strconv.Atoi(wi)
+	return sort.Search(len(ca), func(j int) bool { return idx[jsonEntryID(ca[j], s.keys)] > i }), nil
+}
+
+// orderEdit adds or moves the entry of an `ordered-by user` list referenced
+// by segs to the position specified by e.
+func (b *jsonPatchBuilder) orderEdit(e *patchEdit, segs []jsonSegment) error {
+	n := len(segs)
+	tokens, vals := jsonLocate(b.doc, segs)
+	if len(tokens) < n-1 {
+		return b.mergeEdit(e, segs)
+	}
+	mtokens, mvals := jsonLocate(b.mod, segs)
+	if len(mtokens) != n {
+		return fmt.Errorf("%s: absent from the rendering of the modified struct", e.target().target)
+	}
+	arr, _ := vals[n-1].([]any)
+	from := -1
+	if len(tokens) == n {
+		from, _ = strconv.Atoi(tokens[n-1])
+	}
+	to := 0
+	switch e.where {
+	case YANGPatchBefore, YANGPatchAfter:
+		ps, err := b.entrySegment(e.point)
+		if err != nil {
+			return err
+		}
+		p := jsonEntryIndex(arr, ps)
+		if p < 0 {
+			return fmt.Errorf("%s: point %s is absent", e.target().target, e.point.target)
+		}
+		if from >= 0 && p > from {
+			p--
+		}
+		to = p
+		if e.where == YANGPatchAfter {
+			to++
+		}
+	case YANGPatchLast:
+		to = len(arr)
+		if from >= 0 {
+			to--
+		}
+	}
+	list := tokens[: n-1 : n-1]
+	switch {
+	case from < 0:
+		b.add(append(list, strconv.Itoa(to)), mvals[n], false)
+	case from != to:
+		ft, tt := append(list, strconv.Itoa(from)), append(list, strconv.Itoa(to))
+		b.patch = append(b.patch, &JSONPatchOperation{Op: JSONPatchMove, Path: jsonPointer(tt), From: jsonPointer(ft)})
+		v := arr[from]
+		b.doc = jsonEdit(b.doc, ft, func(parent any, _ string) any {
+			p := parent.([]any)
+			p = append(p[:from:from], p[from+1:]...)
+			return append(p[:to:to], append([]any{v}, p[to:]...)...)
+		})
+	}
+	return nil
+}
+
+// diffLeafList appends the operations that modify the values cur of the
+// leaf-list referenced by tokens to want. The values that are not within the
+// longest sequence of values that are common to both are removed and added.
+func (b *jsonPatchBuilder) diffLeafList(tokens []string, cur, want any) error {
+	ca, cok := cur.([]any)
+	wa, wok := want.([]any)
+	if !cok || !wok {
+		if !reflect.DeepEqual(cur, want) {
+			b.add(tokens, want, true)
+		}
+		return nil
+	}
+	ids := func(vs []any) ([]string, error) {
+		var out []string
+		for _, v := range vs {
+			js, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: cannot encode value: %v", jsonPointer(tokens), err)
+			}
+			out = append(out, string(js))
+		}
+		return out, nil
+	}
+	ci, err := ids(ca)
+	if err != nil {
+		return err
+	}
+	wi, err := ids(wa)
+	if err != nil {
+		return err
+	}
+	inC, inW := commonSubsequence(ci, wi)
+	for i := len(ca) - 1; i >= 0; i-- {
+		if !inC[i] {
+			b.patch = append(b.patch, &JSONPatchOperation{Op: JSONPatchRemove, Path: jsonPointer(append(tokens, strconv.Itoa(i)))})
+		}
+	}
+	for i := range wa {
+		if !inW[i] {
+			b.patch = append(b.patch, &JSONPatchOperation{Op: JSONPatchAdd, Path: jsonPointer(append(tokens, strconv.Itoa(i))), Value: wa[i]})
+		}
+	}
+	b.doc = jsonEdit(b.doc, tokens, func(parent any, t string) any {
+		parent.(map[string]any)[t] = jsonCopy(want)
+		return parent
+	})
+	return nil
+}
+
+// commonSubsequence returns whether each element of a and of b is within a
+// longest common subsequence of a and b. It is found by the divide and
+// conquer algorithm of Myers, "An O(ND) Difference Algorithm and Its
+// Variations", 1986, which runs in O((n+m)D) time and O(n+m) space, where D
+// is the number of elements that are not within the subsequence.
+func commonSubsequence(a, b []string) ([]bool, []bool) {
+	d := &myersDiff{a: a, b: b, inA: make([]bool, len(a)), inB: make([]bool, len(b))}
+	d.compare(0, len(a), 0, len(b))
+	return d.inA, d.inB
+}
+
+// myersDiff finds a longest common subsequence of a and b.
+type myersDiff struct {
+	a, b     []string
+	inA, inB []bool
+}
+
+// match marks a[i] and b[j] as within the subsequence.
+func (d *myersDiff) match(i, j int) {
+	d.inA[i], d.inB[j] = true, true
+}
+
+// compare marks the longest common subsequence of a[a0:a1] and b[b0:b1].
+func (d *myersDiff) compare(a0, a1, b0, b1 int) {
+	for a0 < a1 && b0 < b1 && d.a[a0] == d.b[b0] {
+		d.match(a0, b0)
+		a0, b0 = a0+1, b0+1
+	}
+	for a0 < a1 && b0 < b1 && d.a[a1-1] == d.b[b1-1] {
+		d.match(a1-1, b1-1)
+		a1, b1 = a1-1, b1-1
+	}
+	if a0 == a1 || b0 == b1 {
+		return
+	}
+	x, y, u, v := d.middleSnake(a0, a1, b0, b1)
+	d.compare(a0, x, b0, y)
+	for i := x; i < u; i++ {
+		d.match(i, y+i-x)
+	}
+	d.compare(u, a1, v, b1)
+}
+
+// middleSnake returns the start (x, y) and end (u, v) of the middle snake of
+// a shortest edit script of a[a0:a1] into b[b0:b1], which is the sequence of
+// matching elements that the script crosses halfway through its edits.
+func (d *myersDiff) middleSnake(a0, a1, b0, b1 int) (int, int, int, int) {
+	n, m := a1-a0, b1-b0
+	delta := n - m
+	odd := delta%2 != 0
+	max := (n + m + 1) / 2
+	// vf[k+off] is the furthest x reached on diagonal k = x - y by the
+	// forward search from (0, 0), and vb[k-delta+off] the smallest x
+	// reached on diagonal k by the backward search from (n, m).
+	off := max + 1
+	vf := make([]int, 2*max+3)
+	vb := make([]int, 2*max+3)
+	vb[1+off] = n + 1
+	for e := 0; e <= max; e++ {
+		for k := -e; k <= e; k += 2 {
+			var x int
+			if k == -e || k != e && vf[k-1+off] < vf[k+1+off] {
+				x = vf[k+1+off]
+			} else {
+				x = vf[k-1+off] + 1
+			}
+			y := x - k
+			sx, sy := x, y
+			for x < n && y < m && d.a[a0+x] == d.b[b0+y] {
+				x, y = x+1, y+1
+			}
+			vf[k+off] = x
+			if c := k - delta; odd && c >= -(e-1) && c <= e-1 && vb[c+off] <= x {
+				return a0 + sx, b0 + sy, a0 + x, b0 + y
+			}
+		}
+		for c := -e; c <= e; c += 2 {
+			k := c + delta
+			var x int
+			if c == -e || c != e && vb[c+1+off]-1 < vb[c-1+off] {
+				x = vb[c+1+off] - 1
+			} else {
+				x = vb[c-1+off]
+			}
+			y := x - k
+			ex, ey := x, y
+			for x > 0 && y > 0 && d.a[a0+x-1] == d.b[b0+y-1] {
+				x, y = x-1, y-1
+			}
+			vb[c+off] = x
+			if !odd && k >= -e && k <= e && x <= vf[k+off] {
+				return a0 + x, b0 + y, a0 + ex, b0 + ey
+			}
+		}
+	}
+	// The searches always overlap by the time e reaches max.
+	return a0, b0, a0, b0
+}
+
+// jsonMember returns the value of the member of the JSON object obj whose
+// name, without its module, is name.
+func jsonMember(obj map[string]any, name string) any {
+	if v, ok := obj[name]; ok {
+		return v
+	}
+	for k, v := range obj {
+		if _, n, ok := strings.Cut(k, ":"); ok && n == name {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,435 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// patchTestRoot returns the original root used by the tests of patches.
+func patchTestRoot(t *testing.T) *ctestschema.Device {
+	return &ctestschema.Device{
+		OrderedList: ctestschema.GetOrderedMap(t),
+		OtherData:   &ctestschema.OtherData{Motd: ygot.String("hello")},
+		UnorderedList: map[string]*ctestschema.UnorderedList{
+			"foo": {Key: ygot.String("foo"), Value: ygot.String("foo-val")},
+		},
+	}
+}
+
+// orderedListValue returns the value of a YANG Patch edit of the ordered list
+// entry with key k, whose value is k-val.
+func orderedListValue(k string) map[string]any {
+	return map[string]any{
+		"ctestschema:ordered-list": []any{map[string]any{
+			"config": map[string]any{"key": k, "value": k + "-val"},
+			"key":    k,
+		}},
+	}
+}
+
+// patchOrderedMap returns an ordered list containing the entries with the
+// keys, in order, whose value is the key suffixed with -val.
+func patchOrderedMap(t *testing.T, keys ...string) *ctestschema.OrderedList_OrderedMap {
+	om := &ctestschema.OrderedList_OrderedMap{}
+	for _, k := range keys {
+		v, err := om.AppendNew(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v.Value = ygot.String(k + "-val")
+	}
+	return om
+}
+
+func TestDiffYANGPatch(t *testing.T) {
+	orderedMap := func(keys ...string) *ctestschema.OrderedList_OrderedMap {
+		return patchOrderedMap(t, keys...)
+	}
+	with := func(f func(*ctestschema.Device)) *ctestschema.Device {
+		d := patchTestRoot(t)
+		f(d)
+		return d
+	}
+
+	tests := []struct {
+		desc       string
+		inOrig     ygot.GoStruct
+		inMod      ygot.GoStruct
+		wantEdits  []*ygot.YANGPatchEdit
+		wantErrSub string
+	}{{
+		desc:   "no changes",
+		inOrig: patchTestRoot(t),
+		inMod:  patchTestRoot(t),
+	}, {
+		desc:   "modify and delete leaves",
+		inOrig: patchTestRoot(t),
+		inMod: with(func(d *ctestschema.Device) {
+			d.OtherData.Motd = ygot.String("world")
+			d.UnorderedList["foo"].Value = nil
+		}),
+		wantEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "edit-1",
+			Operation: ygot.YANGPatchDelete,
+			Target:    "/ctestschema:unordered-lists/unordered-list=foo/config/value",
+		}, {
+			EditID:    "edit-2",
+			Operation: ygot.YANGPatchMerge,
+			Target:    "/ctestschema:other-data/config/motd",
+			Value:     map[string]any{"ctestschema:motd": "world"},
+		}},
+	}, {
+		desc:   "add and delete container and list entries",
+		inOrig: patchTestRoot(t),
+		inMod: with(func(d *ctestschema.Device) {
+			d.OtherData = nil
+			d.UnorderedList = map[string]*ctestschema.UnorderedList{
+				"a/b": {Key: ygot.String("a/b")},
+			}
+		}),
+		wantEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "edit-1",
+			Operation: ygot.YANGPatchDelete,
+			Target:    "/ctestschema:other-data",
+		}, {
+			EditID:    "edit-2",
+			Operation: ygot.YANGPatchDelete,
+			Target:    "/ctestschema:unordered-lists/unordered-list=foo",
+		}, {
+			EditID:    "edit-3",
+			Operation: ygot.YANGPatchMerge,
+			Target:    "/ctestschema:unordered-lists/unordered-list=a%2Fb",
+			Value: map[string]any{
+				"ctestschema:unordered-list": []any{map[string]any{
+					"config": map[string]any{"key": "a/b"},
+					"key":    "a/b",
+				}},
+			},
+		}},
+	}, {
+		desc:   "empty container is absent",
+		inOrig: &ctestschema.Device{OtherData: &ctestschema.OtherData{}},
+		inMod:  &ctestschema.Device{},
+	}, {
+		desc:   "insert into ordered list",
+		inOrig: patchTestRoot(t),
+		inMod: with(func(d *ctestschema.Device) {
+			d.OrderedList = orderedMap("baz", "foo", "qux", "bar")
+		}),
+		wantEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "edit-1",
+			Operation: ygot.YANGPatchInsert,
+			Target:    "/ctestschema:ordered-lists/ordered-list=baz",
+			Where:     ygot.YANGPatchFirst,
+			Value:     orderedListValue("baz"),
+		}, {
+			EditID:    "edit-2",
+			Operation: ygot.YANGPatchInsert,
+			Target:    "/ctestschema:ordered-lists/ordered-list=qux",
+			Point:     "/ctestschema:ordered-lists/ordered-list=foo",
+			Where:     ygot.YANGPatchAfter,
+			Value:     orderedListValue("qux"),
+		}},
+	}, {
+		desc:   "reorder ordered list",
+		inOrig: &ctestschema.Device{OrderedList: orderedMap("a", "b", "c", "d")},
+		inMod:  &ctestschema.Device{OrderedList: orderedMap("d", "a", "c", "b")},
+		wantEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "edit-1",
+			Operation: ygot.YANGPatchMove,
+			Target:    "/ctestschema:ordered-lists/ordered-list=d",
+			Where:     ygot.YANGPatchFirst,
+		}, {
+			EditID:    "edit-2",
+			Operation: ygot.YANGPatchMove,
+			Target:    "/ctestschema:ordered-lists/ordered-list=b",
+			Point:     "/ctestschema:ordered-lists/ordered-list=c",
+			Where:     ygot.YANGPatchAfter,
+		}},
+	}, {
+		desc:   "delete from and modify ordered list",
+		inOrig: &ctestschema.Device{OrderedList: orderedMap("a", "b")},
+		inMod: func() *ctestschema.Device {
+			d := &ctestschema.Device{OrderedList: orderedMap("b")}
+			d.OrderedList.Get("b").Value = ygot.String("new")
+			return d
+		}(),
+		wantEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "edit-1",
+			Operation: ygot.YANGPatchDelete,
+			Target:    "/ctestschema:ordered-lists/ordered-list=a",
+		}, {
+			EditID:    "edit-2",
+			Operation: ygot.YANGPatchMerge,
+			Target:    "/ctestschema:ordered-lists/ordered-list=b/config/value",
+			Value:     map[string]any{"ctestschema:value": "new"},
+		}},
+	}, {
+		desc:   "multi-keyed list in another module",
+		inOrig: &ctestschema.Device{},
+		inMod: func() *ctestschema.Device {
+			d := &ctestschema.Device{OrderedMultikeyedList: &ctestschema.OrderedMultikeyedList_OrderedMap{}}
+			if _, err := d.OrderedMultikeyedList.AppendNew("foo", 42); err != nil {
+				t.Fatal(err)
+			}
+			return d
+		}(),
+		wantEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "edit-1",
+			Operation: ygot.YANGPatchInsert,
+			Target:    "/ctestschema-rootmod:ordered-multikeyed-lists/ctestschema:ordered-multikeyed-list=foo,42",
+			Where:     ygot.YANGPatchFirst,
+			Value: map[string]any{
+				"ctestschema:ordered-multikeyed-list": []any{map[string]any{
+					"config": map[string]any{"key1": "foo", "key2": "42"},
+					"key1":   "foo",
+					"key2":   "42",
+				}},
+			},
+		}},
+	}, {
+		desc:       "different types",
+		inOrig:     &ctestschema.Device{},
+		inMod:      &ctestschema.OtherData{},
+		wantErrSub: "cannot diff structs of different types",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.DiffYANGPatch(tt.inOrig, tt.inMod, "patch")
+			if diff := errdiff.Substring(err, tt.wantErrSub); diff != "" {
+				t.Fatalf("DiffYANGPatch: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			want := &ygot.YANGPatch{PatchID: "patch", Edits: tt.wantEdits}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("DiffYANGPatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffJSONPatch(t *testing.T) {
+	tests := []struct {
+		desc   string
+		inOrig ygot.GoStruct
+		inMod  ygot.GoStruct
+		want   ygot.JSONPatch
+	}{{
+		desc:   "no changes",
+		inOrig: patchTestRoot(t),
+		inMod:  patchTestRoot(t),
+		want:   ygot.JSONPatch{},
+	}, {
+		desc:   "replace, add and remove members",
+		inOrig: patchTestRoot(t),
+		inMod: func() *ctestschema.Device {
+			d := patchTestRoot(t)
+			d.OtherData.Motd = ygot.String("world")
+			d.UnorderedList["foo"].Value = nil
+			d.OrderedList.Get("bar").Value = ygot.String("a/b~c")
+			return d
+		}(),
+		want: ygot.JSONPatch{{
+			Op:   ygot.JSONPatchRemove,
+			Path: "/ctestschema:unordered-lists/unordered-list/0/config/value",
+		}, {
+			Op:    ygot.JSONPatchReplace,
+			Path:  "/ctestschema:ordered-lists/ordered-list/1/config/value",
+			Value: "a/b~c",
+		}, {
+			Op:    ygot.JSONPatchReplace,
+			Path:  "/ctestschema:other-data/config/motd",
+			Value: "world",
+		}},
+	}, {
+		desc:   "arrays of different lengths",
+		inOrig: &ctestschema.Device{OrderedList: ctestschema.GetOrderedMapLonger(t)},
+		inMod:  &ctestschema.Device{OrderedList: ctestschema.GetOrderedMap2(t)},
+		want: ygot.JSONPatch{{
+			Op:   ygot.JSONPatchRemove,
+			Path: "/ctestschema:ordered-lists/ordered-list/1",
+		}, {
+			Op:   ygot.JSONPatchRemove,
+			Path: "/ctestschema:ordered-lists/ordered-list/1",
+		}, {
+			Op:   ygot.JSONPatchRemove,
+			Path: "/ctestschema:ordered-lists/ordered-list/0",
+		}, {
+			Op:   ygot.JSONPatchAdd,
+			Path: "/ctestschema:ordered-lists/ordered-list/0",
+			Value: map[string]any{
+				"config": map[string]any{"key": "wee", "value": "wee-val"},
+				"key":    "wee",
+			},
+		}, {
+			Op:   ygot.JSONPatchAdd,
+			Path: "/ctestschema:ordered-lists/ordered-list/1",
+			Value: map[string]any{
+				"config": map[string]any{"key": "woo", "value": "woo-val"},
+				"key":    "woo",
+			},
+		}},
+	}, {
+		desc: "keyed list entries matched by key",
+		inOrig: &ctestschema.Device{UnorderedList: map[string]*ctestschema.UnorderedList{
+			"a": {Key: ygot.String("a"), Value: ygot.String("a-val")},
+			"c": {Key: ygot.String("c"), Value: ygot.String("c-val")},
+		}},
+		inMod: &ctestschema.Device{UnorderedList: map[string]*ctestschema.UnorderedList{
+			"b": {Key: ygot.String("b"), Value: ygot.String("b-val")},
+			"c": {Key: ygot.String("c"), Value: ygot.String("new")},
+		}},
+		want: ygot.JSONPatch{{
+			Op:   ygot.JSONPatchRemove,
+			Path: "/ctestschema:unordered-lists/unordered-list/0",
+		}, {
+			Op:   ygot.JSONPatchAdd,
+			Path: "/ctestschema:unordered-lists/unordered-list/0",
+			Value: map[string]any{
+				"config": map[string]any{"key": "b", "value": "b-val"},
+				"key":    "b",
+			},
+		}, {
+			Op:    ygot.JSONPatchReplace,
+			Path:  "/ctestschema:unordered-lists/unordered-list/1/config/value",
+			Value: "new",
+		}},
+	}, {
+		desc:   "move and add ordered list entries",
+		inOrig: &ctestschema.Device{OrderedList: patchOrderedMap(t, "a", "b", "c", "d")},
+		inMod:  &ctestschema.Device{OrderedList: patchOrderedMap(t, "d", "a", "x", "c", "b")},
+		want: ygot.JSONPatch{{
+			Op:   ygot.JSONPatchMove,
+			Path: "/ctestschema:ordered-lists/ordered-list/0",
+			From: "/ctestschema:ordered-lists/ordered-list/3",
+		}, {
+			Op:   ygot.JSONPatchAdd,
+			Path: "/ctestschema:ordered-lists/ordered-list/2",
+			Value: map[string]any{
+				"config": map[string]any{"key": "x", "value": "x-val"},
+				"key":    "x",
+			},
+		}, {
+			Op:   ygot.JSONPatchMove,
+			Path: "/ctestschema:ordered-lists/ordered-list/4",
+			From: "/ctestschema:ordered-lists/ordered-list/3",
+		}},
+	}, {
+		desc:   "remove last member",
+		inOrig: &ctestschema.Device{OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")}},
+		inMod:  &ctestschema.Device{},
+		want: ygot.JSONPatch{{
+			Op:   ygot.JSONPatchRemove,
+			Path: "/ctestschema:other-data",
+		}},
+	}, {
+		desc:   "add root member",
+		inOrig: &ctestschema.Device{},
+		inMod:  &ctestschema.Device{OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")}},
+		want: ygot.JSONPatch{{
+			Op:    ygot.JSONPatchAdd,
+			Path:  "/ctestschema:other-data",
+			Value: map[string]any{"config": map[string]any{"motd": "hello"}},
+		}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.DiffJSONPatch(tt.inOrig, tt.inMod)
+			if err != nil {
+				t.Fatalf("DiffJSONPatch: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DiffJSONPatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPatchJSON(t *testing.T) {
+	patch := &ygot.YANGPatch{
+		PatchID: "patch",
+		Comment: "comment",
+		Edits: []*ygot.YANGPatchEdit{{
+			EditID:    "edit-1",
+			Operation: ygot.YANGPatchMove,
+			Target:    "/ctestschema:ordered-lists/ordered-list=b",
+			Point:     "/ctestschema:ordered-lists/ordered-list=a",
+			Where:     ygot.YANGPatchBefore,
+		}, {
+			EditID:    "edit-2",
+			Operation: ygot.YANGPatchMerge,
+			Target:    "/ctestschema:other-data/config/motd",
+			Value:     map[string]any{"ctestschema:motd": "hello"},
+		}},
+	}
+	wantYANGPatch := `{"ietf-yang-patch:yang-patch":{"patch-id":"patch","comment":"comment","edit":[` +
+		`{"edit-id":"edit-1","operation":"move","target":"/ctestschema:ordered-lists/ordered-list=b","point":"/ctestschema:ordered-lists/ordered-list=a","where":"before"},` +
+		`{"edit-id":"edit-2","operation":"merge","target":"/ctestschema:other-data/config/motd","value":{"ctestschema:motd":"hello"}}]}}`
+
+	js, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("cannot marshal YANG Patch: %v", err)
+	}
+	if diff := cmp.Diff(wantYANGPatch, string(js)); diff != "" {
+		t.Errorf("YANG Patch JSON (-want, +got):\n%s", diff)
+	}
+	gotPatch := &ygot.YANGPatch{}
+	if err := json.Unmarshal(js, gotPatch); err != nil {
+		t.Fatalf("cannot unmarshal YANG Patch: %v", err)
+	}
+	if diff := cmp.Diff(patch, gotPatch); diff != "" {
+		t.Errorf("unmarshalled YANG Patch (-want, +got):\n%s", diff)
+	}
+	if err := json.Unmarshal([]byte(`{"patch-id":"patch"}`), gotPatch); err == nil {
+		t.Errorf("unmarshalling YANG Patch without yang-patch container: got nil error, want error")
+	}
+
+	jsonPatch := ygot.JSONPatch{
+		{Op: ygot.JSONPatchAdd, Path: "/a", Value: []any{"x"}},
+		{Op: ygot.JSONPatchRemove, Path: "/b"},
+		{Op: ygot.JSONPatchMove, Path: "/c", From: "/a"},
+		{Op: ygot.JSONPatchTest, Path: "/c", Value: nil},
+	}
+	wantJSONPatch := `[{"op":"add","path":"/a","value":["x"]},{"op":"remove","path":"/b"},` +
+		`{"from":"/a","op":"move","path":"/c"},{"op":"test","path":"/c","value":null}]`
+
+	if js, err = json.Marshal(jsonPatch); err != nil {
+		t.Fatalf("cannot marshal JSON Patch: %v", err)
+	}
+	if diff := cmp.Diff(wantJSONPatch, string(js)); diff != "" {
+		t.Errorf("JSON Patch JSON (-want, +got):\n%s", diff)
+	}
+	var gotJSONPatch ygot.JSONPatch
+	if err := json.Unmarshal(js, &gotJSONPatch); err != nil {
+		t.Fatalf("cannot unmarshal JSON Patch: %v", err)
+	}
+	if diff := cmp.Diff(jsonPatch, gotJSONPatch); diff != "" {
+		t.Errorf("unmarshalled JSON Patch (-want, +got):\n%s", diff)
+	}
+	if err := json.Unmarshal([]byte(`[{"op":"remove"}]`), &gotJSONPatch); err == nil {
+		t.Errorf("unmarshalling JSON Patch operation without path: got nil error, want error")
+	}
+}
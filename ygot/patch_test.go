@@ -0,0 +1,110 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// lcsLength returns the length of the longest common subsequence of a and b
+// by dynamic programming.
+func lcsLength(a, b []string) int {
+	l := make([]int, len(b)+1)
+	for i := range a {
+		prev := 0
+		for j := range b {
+			cur := l[j+1]
+			switch {
+			case a[i] == b[j]:
+				l[j+1] = prev + 1
+			case l[j] > l[j+1]:
+				l[j+1] = l[j]
+			}
+			prev = cur
+		}
+	}
+	return l[len(b)]
+}
+
+// TestCommonSubsequence tests that commonSubsequence marks a longest common
+// subsequence of random sequences, which may contain repeated elements.
+func TestCommonSubsequence(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	sequence := func() []string {
+		s := make([]string, r.Intn(20))
+		for i := range s {
+			s[i] = fmt.Sprint(r.Intn(5))
+		}
+		return s
+	}
+	for i := 0; i < 2000; i++ {
+		a, b := sequence(), sequence()
+		inA, inB := commonSubsequence(a, b)
+		var sa, sb []string
+		for i, in := range inA {
+			if in {
+				sa = append(sa, a[i])
+			}
+		}
+		for i, in := range inB {
+			if in {
+				sb = append(sb, b[i])
+			}
+		}
+		if fmt.Sprint(sa) != fmt.Sprint(sb) {
+			t.Fatalf("commonSubsequence(%v, %v): marked elements differ, %v and %v", a, b, sa, sb)
+		}
+		if want := lcsLength(a, b); len(sa) != want {
+			t.Fatalf("commonSubsequence(%v, %v): got subsequence %v of length %d, want length %d", a, b, sa, len(sa), want)
+		}
+	}
+}
+
+func TestDiffPatchLeafList(t *testing.T) {
+	orig := &renderExample{LeafList: []string{"a", "b", "c", "d"}}
+	mod := &renderExample{LeafList: []string{"b", "x", "c", "e"}}
+
+	gotYANG, err := DiffYANGPatch(orig, mod, "patch")
+	if err != nil {
+		t.Fatalf("DiffYANGPatch: %v", err)
+	}
+	wantYANG := &YANGPatch{PatchID: "patch", Edits: []*YANGPatchEdit{
+		{EditID: "edit-1", Operation: YANGPatchDelete, Target: "/leaf-list=a"},
+		{EditID: "edit-2", Operation: YANGPatchDelete, Target: "/leaf-list=d"},
+		{EditID: "edit-3", Operation: YANGPatchMerge, Target: "/leaf-list=x", Value: map[string]any{"leaf-list": []any{"x"}}},
+		{EditID: "edit-4", Operation: YANGPatchMerge, Target: "/leaf-list=e", Value: map[string]any{"leaf-list": []any{"e"}}},
+	}}
+	if diff := cmp.Diff(wantYANG, gotYANG); diff != "" {
+		t.Errorf("DiffYANGPatch (-want, +got):\n%s", diff)
+	}
+
+	gotJSON, err := DiffJSONPatch(orig, mod)
+	if err != nil {
+		t.Fatalf("DiffJSONPatch: %v", err)
+	}
+	wantJSON := JSONPatch{
+		{Op: JSONPatchRemove, Path: "/leaf-list/3"},
+		{Op: JSONPatchRemove, Path: "/leaf-list/0"},
+		{Op: JSONPatchAdd, Path: "/leaf-list/1", Value: "x"},
+		{Op: JSONPatchAdd, Path: "/leaf-list/3", Value: "e"},
+	}
+	if diff := cmp.Diff(wantJSON, gotJSON); diff != "" {
+		t.Errorf("DiffJSONPatch (-want, +got):\n%s", diff)
+	}
+}
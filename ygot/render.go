@@ -354,6 +354,29 @@ type GNMINotificationsConfig struct {
 	// prefix that concatenates the given prefix with the relative path of
 	// the ordered map from the given node.
 	PathElemPrefix []*gnmipb.PathElem
+	// WithDefaults specifies the RFC 6243 with-defaults mode in which
+	// leaves that have a default value are rendered. By default, only
+	// the leaves that are set are rendered.
+	WithDefaults WithDefaultsMode
+	// JSONIETFDepth, when positive, specifies that the containers and
+	// list entries that are JSONIETFDepth levels of GoStructs below the
+	// input GoStruct, e.g., its direct child containers and the entries
+	// of its direct child lists at depth 1, are each rendered as a single
+	// Update whose value is their JSON_IETF encoding, as output by
+	// ConstructIETFJSON with module names, rather than as an Update of
+	// each of their leaves.
+	JSONIETFDepth int
+	// JSONIETFPaths specifies the schema paths of the containers and
+	// lists whose containers and list entries are each rendered as a
+	// single Update with a JSON_IETF value, as per JSONIETFDepth. They
+	// are absolute paths, including the prefix, in which the keys of
+	// elements are ignored and "*" matches the name of any element. Used
+	// if UsePathElem is set.
+	//
+	// The contents of `ordered-by user` lists, which are marshalled within
+	// their own atomic Notification, are not rendered as JSON_IETF values
+	// unless they are within a container that is.
+	JSONIETFPaths []*gnmipb.Path
 }
 
 // TogNMINotifications takes an input GoStruct and renders it to slice of
@@ -381,8 +404,13 @@ func TogNMINotifications(s GoStruct, ts int64, cfg GNMINotificationsConfig) ([]*
 		pfx = newStringSliceGNMIPath(cfg.StringSlicePrefix)
 	}
 
+	s, err := withDefaults(s, cfg.WithDefaults)
+	if err != nil {
+		return nil, err
+	}
+
 	leaves := map[*path]any{}
-	if err := findUpdatedLeaves(leaves, s, pfx, false); err != nil {
+	if err := findLeaves(leaves, s, pfx, cfg); err != nil {
 		return nil, err
 	}
 
@@ -411,6 +439,8 @@ func findUpdatedOrderedListLeaves(leaves any, s GoOrderedMap, parent *gnmiPath,
 	switch leaves := leaves.(type) {
 	case map[*path]any:
 		leavesMap = leaves
+	case *notificationChunker:
+		return leaves.addOrderedMap(s, parent, preferShadowPath)
 	case *[]*pathval:
 		// TODO: Support nested ordered lists/atomic elements -- they should marshal in
 		// the regular way without creating a second []*pathval.
@@ -461,6 +491,8 @@ func findUpdatedLeaves(leaves any, s GoStruct, parent *gnmiPath, preferShadowPat
 				val:  value,
 			})
 		}
+	case *notificationChunker:
+		addLeaf = leaves.addLeaf
 	default:
 		return fmt.Errorf("internal ygot error: leaves is not an expected type: %T", leaves)
 	}
@@ -497,6 +529,11 @@ func findUpdatedLeaves(leaves any, s GoStruct, parent *gnmiPath, preferShadowPat
 			errs.Add(fmt.Errorf("%v->%s: %v", parent, ftype.Name, err))
 			continue
 		}
+		// Subtrees that are not within the paths to be rendered are
+		// skipped.
+		if c, ok := leaves.(*notificationChunker); ok && !c.inPaths(mapPaths...) {
+			continue
+		}
 
 		switch fval.Kind() {
 		case reflect.Map:
@@ -507,6 +544,9 @@ func findUpdatedLeaves(leaves any, s GoStruct, parent *gnmiPath, preferShadowPat
 					errs.Add(err)
 					continue
 				}
+				if c, ok := leaves.(*notificationChunker); ok && !c.inPaths(childPath) {
+					continue
+				}
 
 				goStruct, ok := fval.MapIndex(k).Interface().(GoStruct)
 				if !ok {
@@ -758,36 +798,47 @@ func sliceToScalarArray(v []any) (*gnmipb.ScalarArray, error) {
 // addToNotification adds the given path value pair to the given notification,
 // stripping the given prefix.
 func addToNotification(pk *path, value any, n *gnmipb.Notification, pfx *gnmiPath) error {
-	path, err := pk.p.StripPrefix(pfx)
+	u, err := leafUpdate(pk, value, pfx)
 	if err != nil {
 		return err
 	}
+	n.Update = append(n.Update, u)
+	return nil
+}
 
-	ppath, err := path.ToProto()
+// leafUpdate returns the gNMI Update of the leaf at path pk, relative to the
+// prefix pfx, with the specified value.
+func leafUpdate(pk *path, value any, pfx *gnmiPath) (*gnmipb.Update, error) {
+	path, err := pk.p.StripPrefix(pfx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	val, err := EncodeTypedValue(value, gnmipb.Encoding_JSON)
+	ppath, err := path.ToProto()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	n.Update = append(n.Update, &gnmipb.Update{
+	// Subtrees that are rendered as JSON_IETF values are already encoded.
+	var val *gnmipb.TypedValue
+	if st, ok := value.(*jsonIETFSubtree); ok {
+		val = st.val
+	} else if val, err = EncodeTypedValue(value, gnmipb.Encoding_JSON); err != nil {
+		return nil, err
+	}
+
+	return &gnmipb.Update{
 		Path: ppath,
 		Val:  val,
-	})
-	return nil
+	}, nil
 }
 
 // leavesToNotifications takes an input map of leaves, and outputs a slice of
 // notifications that corresponds to the leaf update, the supplied timestamp is
 // used in the set of notifications. If an error is encountered it is returned.
-// TODO(robjs): Currently, we return only a single Notification for all but
-// ordered lists, but this is likely to be suboptimal since it results in very
-// large Notifications for particular structs. There should be some
-// fragmentation of Updates across Notification messages in a future
-// implementation. We return a slice to keep the API stable.
+// A single Notification is returned for all but ordered lists, which may be
+// very large for particular structs. StreamgNMINotifications fragments the
+// Updates across Notification messages.
 func leavesToNotifications(leaves map[*path]any, ts int64, pfx *gnmiPath) ([]*gnmipb.Notification, error) {
 	var notifs []*gnmipb.Notification
 
@@ -1124,6 +1175,10 @@ type RFC7951JSONConfig struct {
 	// is to be rewritten FROM, and the value of the map is the name of the module
 	// it is to be rewritten TO.
 	RewriteModuleNames map[string]string
+	// WithDefaults specifies the RFC 6243 with-defaults mode in which
+	// leaves that have a default value are rendered. By default, only
+	// the leaves that are set are rendered.
+	WithDefaults WithDefaultsMode
 }
 
 // IsMarshal7951Arg marks the RFC7951JSONConfig struct as a valid argument to
@@ -1139,6 +1194,12 @@ func (*RFC7951JSONConfig) IsEncodeTypedValueOpt() {}
 // to JSON described by RFC7951. The supplied args control options corresponding
 // to the method by which JSON is marshalled.
 func ConstructIETFJSON(s GoStruct, args *RFC7951JSONConfig) (map[string]any, error) {
+	if args != nil {
+		var err error
+		if s, err = withDefaults(s, args.WithDefaults); err != nil {
+			return nil, err
+		}
+	}
 	return structJSON(s, "", jsonOutputConfig{
 		jType:         RFC7951,
 		rfc7951Config: args,
@@ -1188,6 +1249,12 @@ func Marshal7951(d any, args ...Marshal7951Arg) ([]byte, error) {
 			indent = string(v)
 		}
 	}
+	if s, ok := d.(GoStruct); ok && rfcCfg != nil {
+		var err error
+		if d, err = withDefaults(s, rfcCfg.WithDefaults); err != nil {
+			return nil, err
+		}
+	}
 	j, err := jsonValue(reflect.ValueOf(d), "", jsonOutputConfig{
 		jType:         RFC7951,
 		rfc7951Config: rfcCfg,
@@ -1221,6 +1288,10 @@ type jsonOutputConfig struct {
 	// rfc7951Config stores the configuration to be used when outputting RFC7951
 	// JSON.
 	rfc7951Config *RFC7951JSONConfig
+	// cbor specifies that the values of leaves and leaf-lists are to be
+	// output as the values of the YANG-CBOR encoding, as used by
+	// MarshalCBOR, rather than as JSON values.
+	cbor bool
 }
 
 // rewriteModName rewrites the module mod according to the specified rewrite rules.
@@ -1285,6 +1356,37 @@ func prependmodsJSON(fType reflect.StructField, parentMod string, args jsonOutpu
 	return prependmods, chMod, nil
 }
 
+// annotationPrependmodsJSON returns the module names to prepend to the
+// elements of the paths of the annotation field fType of the struct type
+// stype, as per prependmodsJSON, which are those of the field that it
+// annotates. It returns nil if there is no such field, as is the case for the
+// annotations of the struct itself.
+func annotationPrependmodsJSON(stype reflect.Type, fType reflect.StructField, parentMod string, args jsonOutputConfig) ([][]string, error) {
+	paths := strings.Split(fType.Tag.Get("path"), "|")
+	for i, p := range paths {
+		// The last element of the path of an annotation field is that of
+		// the annotated field, prefixed with "@".
+		j := strings.LastIndex(p, "/") + 1
+		paths[i] = p[:j] + strings.TrimPrefix(p[j:], "@")
+	}
+	annotated := strings.Join(paths, "|")
+	if annotated == "" {
+		return nil, nil
+	}
+	for i := 0; i < stype.NumField(); i++ {
+		if f := stype.Field(i); !util.IsYgotAnnotation(f) && f.Tag.Get("path") == annotated {
+			if _, ok := f.Tag.Lookup("shadow-path"); ok && args.rfc7951Config.PreferShadowPath {
+				// The annotation is of the field's path rather than
+				// its shadow path.
+				return nil, nil
+			}
+			prependmods, _, err := prependmodsJSON(f, parentMod, args)
+			return prependmods, err
+		}
+	}
+	return nil, nil
+}
+
 // structJSON marshals a GoStruct to a map[string]any which can be
 // handed to JSON marshal. parentMod specifies the module that the supplied
 // GoStruct is defined within such that RFC7951 format JSON is able to consider
@@ -1315,6 +1417,12 @@ func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string
 				errs.Add(err)
 				continue
 			}
+			if prependmods == nil && util.IsYgotAnnotation(fType) {
+				if prependmods, err = annotationPrependmodsJSON(stype, fType, parentMod, args); err != nil {
+					errs.Add(err)
+					continue
+				}
+			}
 		}
 
 		mapPaths, err := structTagToLibPaths(fType, newStringSliceGNMIPath([]string{}), args.rfc7951Config != nil && args.rfc7951Config.PreferShadowPath)
@@ -1389,10 +1497,17 @@ func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string
 				errs.Add(err)
 				continue
 			}
-			if prependmods != nil && prependmods[i][j] != "" {
+			switch {
+			case prependmods == nil || prependmods[i][j] == "":
+			case strings.HasPrefix(k, "@"):
+				// The module of an annotated node is prepended to its
+				// name within the name of its annotations, as per RFC
+				// 7952.
+				k = fmt.Sprintf("@%s:%s", prependmods[i][j], k[1:])
+			default:
 				k = fmt.Sprintf("%s:%s", prependmods[i][j], k)
 			}
-			if args.jType != Internal {
+			if args.jType != Internal && !args.cbor {
 				value, err = normalizeJSONValue(value)
 			}
 			if err != nil {
@@ -1591,6 +1706,12 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (an
 		}
 	}
 
+	if args.cbor {
+		if v, ok, err := cborLeafValue(field); ok || err != nil {
+			return v, err
+		}
+	}
+
 	prependModuleNameIref := args.rfc7951Config != nil && (args.rfc7951Config.AppendModuleName || args.rfc7951Config.PrependModuleNameIdentityref)
 
 	// When jsonValue is called using the output of reflect.ValueOf()
@@ -1834,12 +1955,17 @@ func jsonSlice(field reflect.Value, parentMod string, args jsonOutputConfig) (an
 
 // jsonAnnotationSlice takes a reflect.Value which must represent a
 // ygot Annotation field ([]ygot.Annotation), and marshals it to JSON to be
-// included in the output JSON.
+// included in the output JSON. Where the annotations are MetadataAnnotations,
+// they are output as an RFC 7952 object keyed by the names of the annotations.
 func jsonAnnotationSlice(v reflect.Value) (any, error) {
 	if v.Len() == 0 {
 		return nil, nil
 	}
 
+	if md, ok, err := metadataJSON(v); ok || err != nil {
+		return md, err
+	}
+
 	vals := []any{}
 	for i := 0; i < v.Len(); i++ {
 		fv := v.Index(i).Interface().(Annotation)
@@ -0,0 +1,210 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// This file implements the rendering of the containers and list entries of
+// a GoStruct as single gNMI Updates whose values are JSON_IETF encoded, as
+// specified by the JSONIETFDepth and JSONIETFPaths fields of
+// GNMINotificationsConfig.
+
+// jsonIETFSubtree is the value of a container or list entry that is
+// rendered as a single JSON_IETF value.
+type jsonIETFSubtree struct {
+	// s is the container or list entry.
+	s GoStruct
+	// val is its JSON_IETF value.
+	val *gnmipb.TypedValue
+}
+
+// hasJSONIETFBundles reports whether cfg specifies that any subtrees are to
+// be rendered as JSON_IETF values, and returns an error if it is invalid.
+func hasJSONIETFBundles(cfg GNMINotificationsConfig) (bool, error) {
+	switch {
+	case cfg.JSONIETFDepth < 0:
+		return false, fmt.Errorf("invalid JSON_IETF depth %d", cfg.JSONIETFDepth)
+	case len(cfg.JSONIETFPaths) != 0 && !cfg.UsePathElem:
+		return false, fmt.Errorf("JSON_IETF paths can only be specified for PathElem paths")
+	}
+	return cfg.JSONIETFDepth > 0 || len(cfg.JSONIETFPaths) != 0, nil
+}
+
+// findLeaves appends the leaves within the supplied GoStruct, rooted at
+// parent, to the supplied leaves as per findUpdatedLeaves, other than the
+// subtrees that cfg specifies are rendered as JSON_IETF values, which are
+// each added as a single leaf whose value is a jsonIETFSubtree.
+func findLeaves(leaves any, s GoStruct, parent *gnmiPath, cfg GNMINotificationsConfig) error {
+	bundle, err := hasJSONIETFBundles(cfg)
+	switch {
+	case err != nil:
+		return err
+	case !bundle:
+		return findUpdatedLeaves(leaves, s, parent, false)
+	}
+	return findBundledLeaves(leaves, s, parent, 0, cfg)
+}
+
+// findBundledLeaves appends the leaves of the GoStruct s, which is rooted at
+// parent and is depth levels of GoStructs below the input GoStruct, to the
+// supplied leaves. Its containers and list entries are either rendered as
+// JSON_IETF values, or recursed into. `ordered-by user` lists are rendered as
+// per findUpdatedLeaves, such that they remain within their own atomic
+// Notification.
+func findBundledLeaves(leaves any, s GoStruct, parent *gnmiPath, depth int, cfg GNMINotificationsConfig) error {
+	sval := reflect.ValueOf(s)
+	if s == nil || util.IsValueNil(sval) || !util.IsValueStructPtr(sval) {
+		return fmt.Errorf("input struct for %v was not valid", parent)
+	}
+	sval = sval.Elem()
+	stype := sval.Type()
+
+	// The leaves of s itself are those of a copy of s without any of its
+	// containers or lists.
+	leafCopy := reflect.New(stype)
+	leafCopy.Elem().Set(sval)
+	for i := 0; i < stype.NumField(); i++ {
+		if ft := stype.Field(i).Type; ft.Kind() == reflect.Map || util.IsTypeStructPtr(ft) {
+			leafCopy.Elem().Field(i).Set(reflect.Zero(ft))
+		}
+	}
+	gs, ok := leafCopy.Interface().(GoStruct)
+	if !ok {
+		return fmt.Errorf("%v: was not a valid GoStruct", parent)
+	}
+
+	var errs errlist.List
+	errs.Add(findUpdatedLeaves(leaves, gs, parent, false))
+
+	for i := 0; i < sval.NumField(); i++ {
+		fval, ftype := sval.Field(i), stype.Field(i)
+		if !(fval.Kind() == reflect.Map || util.IsTypeStructPtr(ftype.Type)) || fval.IsNil() {
+			continue
+		}
+
+		mapPaths, err := structTagToLibPaths(ftype, parent, false)
+		if err != nil {
+			errs.Add(fmt.Errorf("%v->%s: %v", parent, ftype.Name, err))
+			continue
+		}
+		if c, ok := leaves.(*notificationChunker); ok && !c.inPaths(mapPaths[0]) {
+			continue
+		}
+
+		if ol, ok := fval.Interface().(GoOrderedMap); ok {
+			errs.Add(findUpdatedOrderedListLeaves(leaves, ol, mapPaths[0], false))
+			continue
+		}
+
+		if fval.Kind() == reflect.Ptr {
+			errs.Add(findBundledChild(leaves, fval, mapPaths[0], depth+1, cfg))
+			continue
+		}
+		for _, k := range fval.MapKeys() {
+			childPath, err := mapValuePath(k, fval.MapIndex(k), mapPaths[0])
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			errs.Add(findBundledChild(leaves, fval.MapIndex(k), childPath, depth+1, cfg))
+		}
+	}
+	return errs.Err()
+}
+
+// findBundledChild appends the leaves of the container or list entry v, a
+// GoStruct that is rooted at p and is depth levels of GoStructs below the
+// input GoStruct, to the supplied leaves, as a single JSON_IETF value where
+// cfg specifies that it is to be bundled.
+func findBundledChild(leaves any, v reflect.Value, p *gnmiPath, depth int, cfg GNMINotificationsConfig) error {
+	gs, ok := v.Interface().(GoStruct)
+	if !ok {
+		return fmt.Errorf("%v: was not a valid GoStruct", p)
+	}
+	c, isChunker := leaves.(*notificationChunker)
+	if isChunker && !c.inPaths(p) {
+		return nil
+	}
+	// Where only some of the subtree is within the paths to be rendered,
+	// it is recursed into rather than bundled.
+	if !isJSONIETFBundle(p, depth, cfg) || isChunker && !c.leafInPaths(p) {
+		return findBundledLeaves(leaves, gs, p, depth, cfg)
+	}
+
+	j, err := ConstructIETFJSON(gs, &RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return fmt.Errorf("%v: %v", p, err)
+	}
+	if len(j) == 0 {
+		return nil
+	}
+	js, err := json.Marshal(j)
+	if err != nil {
+		return fmt.Errorf("%v: cannot encode JSON, %v", p, err)
+	}
+	st := &jsonIETFSubtree{
+		s:   gs,
+		val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: js}},
+	}
+
+	switch leaves := leaves.(type) {
+	case map[*path]any:
+		leaves[&path{p}] = st
+	case *notificationChunker:
+		leaves.addLeaf(&path{p}, st)
+	default:
+		return fmt.Errorf("internal ygot error: leaves is not an expected type: %T", leaves)
+	}
+	return nil
+}
+
+// isJSONIETFBundle reports whether the container or list entry at path p,
+// which is depth levels of GoStructs below the input GoStruct, is to be
+// rendered as a JSON_IETF value as per cfg.
+func isJSONIETFBundle(p *gnmiPath, depth int, cfg GNMINotificationsConfig) bool {
+	if cfg.JSONIETFDepth > 0 && depth >= cfg.JSONIETFDepth {
+		return true
+	}
+	for _, bp := range cfg.JSONIETFPaths {
+		if pathElemNamesMatch(p.pathElemPath, bp.GetElem()) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathElemNamesMatch reports whether the names of the elements of the path
+// elems are those of the schema path sp, in which "*" matches any name. The
+// keys of the elements are ignored.
+func pathElemNamesMatch(elems, sp []*gnmipb.PathElem) bool {
+	if len(elems) != len(sp) {
+		return false
+	}
+	for i, e := range sp {
+		if e.GetName() != "*" && e.GetName() != elems[i].GetName() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,316 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/ygot/internal/ycbor"
+	"github.com/openconfig/ygot/util"
+)
+
+// CBORConfig is used to control the behaviour of how CBOR is output by
+// MarshalCBOR.
+type CBORConfig struct {
+	// SIDs specifies that the output is to use SID-based rather than
+	// name-based keys, using the SIDs in the supplied SIDMap, such that it
+	// must contain the SID of each data node that is output, along with
+	// that of each identity that is the value of an identityref.
+	SIDs *SIDMap
+	// Path is the absolute schema node identifier of the node that is
+	// represented by the marshalled GoStruct, in the format of the
+	// identifiers of the data nodes of a SID file, e.g.,
+	// /ietf-system:system/clock. The SIDs of the keys of the output are
+	// relative to the SID of this node. The empty string, which is the
+	// default, indicates the root of the schema tree.
+	Path string
+	// PreferShadowPath uses the name of the "shadow-path" tag of a
+	// GoStruct to determine the marshalled keys instead of the "path" tag,
+	// whenever the former is present.
+	PreferShadowPath bool
+	// RewriteModuleNames specifies that any data node that is found within
+	// module A should be assumed to be in module B, as per the field of the
+	// same name of RFC7951JSONConfig.
+	RewriteModuleNames map[string]string
+}
+
+// IsMarshalCBORArg marks the CBORConfig struct as a valid argument to
+// MarshalCBOR.
+func (*CBORConfig) IsMarshalCBORArg() {}
+
+// MarshalCBORArg is an interface implemented by arguments to the MarshalCBOR
+// function.
+type MarshalCBORArg interface {
+	// IsMarshalCBORArg is a marker method.
+	IsMarshalCBORArg()
+}
+
+// MarshalCBOR renders the supplied GoStruct to CBOR as per the YANG-CBOR
+// encoding of RFC 9254. The output is a CBOR map of the populated fields of
+// the GoStruct, which has the same structure as the RFC7951 JSON output by
+// Marshal7951. By default, its keys are the names of the data nodes,
+// prefixed with the name of their module where it differs from that of
+// their parent, and identityref values are the name of the identity
+// prefixed with the name of its module. If SIDs are specified in the
+// supplied CBORConfig, the keys are the delta between the SID of each data
+// node and that of its parent, and identityref values are the SID of the
+// identity. Enumeration values are output as their integer value, decimal64
+// values as decimal fractions and binary values as byte strings, with
+// enumeration and identityref values within a union being tagged as such.
+// Bits values, which are not supported by generated GoStructs, are not
+// supported.
+func MarshalCBOR(s GoStruct, args ...MarshalCBORArg) ([]byte, error) {
+	cfg := &CBORConfig{}
+	for _, a := range args {
+		if v, ok := a.(*CBORConfig); ok {
+			cfg = v
+		}
+	}
+	if util.IsValueNil(s) {
+		return nil, fmt.Errorf("cannot marshal nil GoStruct to CBOR")
+	}
+
+	// The module of the node that is represented by s is that of the last
+	// prefixed element of its path.
+	var parentMod string
+	if i := strings.LastIndex(cfg.Path, ":"); i != -1 {
+		parentMod = cfg.Path[strings.LastIndex(cfg.Path[:i], "/")+1 : i]
+	}
+
+	j, err := structJSON(s, parentMod, jsonOutputConfig{
+		jType: RFC7951,
+		rfc7951Config: &RFC7951JSONConfig{
+			AppendModuleName:   true,
+			PreferShadowPath:   cfg.PreferShadowPath,
+			RewriteModuleNames: cfg.RewriteModuleNames,
+		},
+		cbor: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var parentSID uint64
+	if cfg.SIDs != nil && cfg.Path != "" {
+		var ok bool
+		if parentSID, ok = cfg.SIDs.SID(SIDNamespaceData, cfg.Path); !ok {
+			return nil, fmt.Errorf("no SID found for data node %s", cfg.Path)
+		}
+	}
+	v, err := cborTree(j, cfg.Path, parentSID, cfg.SIDs)
+	if err != nil {
+		return nil, err
+	}
+	return ycbor.Marshal(v)
+}
+
+// cborIdentityref is the value of an identityref leaf in the tree that is
+// output by structJSON in CBOR mode, which is resolved to its name or SID
+// by cborTree.
+type cborIdentityref struct {
+	// name is the name of the identity, prefixed with its module.
+	name string
+	// inUnion specifies whether the leaf is a union.
+	inUnion bool
+}
+
+// cborTree returns the CBOR representation of the tree v that is output by
+// structJSON in CBOR mode for the data node with the schema node identifier
+// path. If sids is nil, the keys of the output are the names of the data
+// nodes of the tree, otherwise they are their SIDs relative to parentSID,
+// which is the SID of the data node that v represents.
+func cborTree(v any, path string, parentSID uint64, sids *SIDMap) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		if sids == nil {
+			out := map[string]any{}
+			for k, c := range v {
+				cv, err := cborTree(c, fmt.Sprintf("%s/%s", path, k), 0, nil)
+				if err != nil {
+					return nil, err
+				}
+				out[k] = cv
+			}
+			return out, nil
+		}
+		out := map[int64]any{}
+		for k, c := range v {
+			p := fmt.Sprintf("%s/%s", path, k)
+			sid, ok := sids.SID(SIDNamespaceData, p)
+			if !ok {
+				return nil, fmt.Errorf("no SID found for data node %s", p)
+			}
+			cv, err := cborTree(c, p, sid, sids)
+			if err != nil {
+				return nil, err
+			}
+			out[int64(sid-parentSID)] = cv
+		}
+		return out, nil
+	case []any:
+		// The entries of lists and values of leaf-lists have the same path
+		// as the list or leaf-list.
+		out := make([]any, 0, len(v))
+		for _, c := range v {
+			cv, err := cborTree(c, path, parentSID, sids)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, cv)
+		}
+		return out, nil
+	case cborIdentityref:
+		if sids == nil {
+			return v.name, nil
+		}
+		sid, ok := sids.SID(SIDNamespaceIdentity, v.name)
+		if !ok {
+			return nil, fmt.Errorf("no SID found for identity %s", v.name)
+		}
+		if v.inUnion {
+			return ycbor.Tag{Number: ycbor.TagIdentityref, Content: sid}, nil
+		}
+		return sid, nil
+	}
+	return v, nil
+}
+
+// cborLeafValue returns the YANG-CBOR value of field, which is a field of a
+// GoStruct, if it is a leaf or leaf-list. It returns false if field is not
+// a leaf or leaf-list, and is hence to be rendered by jsonValue.
+func cborLeafValue(field reflect.Value) (any, bool, error) {
+	switch field.Kind() {
+	case reflect.Map:
+		return nil, false, nil
+	case reflect.Ptr:
+		if field.Elem().Kind() == reflect.Struct {
+			return nil, false, nil
+		}
+		v, err := cborScalar(field.Elem(), false)
+		return v, true, err
+	case reflect.Slice:
+		annoT := reflect.TypeOf((*Annotation)(nil)).Elem()
+		if field.Type().Name() != BinaryTypeName && (util.IsTypeStructPtr(field.Type().Elem()) || field.Type().Elem().Implements(annoT)) {
+			return nil, false, nil
+		}
+		if field.Type().Name() == BinaryTypeName {
+			v, err := cborScalar(field, false)
+			return v, true, err
+		}
+		vals := []any{}
+		for i := 0; i < field.Len(); i++ {
+			v, err := cborScalar(field.Index(i), false)
+			if err != nil {
+				return nil, true, fmt.Errorf("could not map leaf-list: %v", err)
+			}
+			vals = append(vals, v)
+		}
+		return vals, true, nil
+	}
+	v, err := cborScalar(field, false)
+	return v, true, err
+}
+
+// cborScalar returns the YANG-CBOR value of v, which is the value of a leaf
+// or an element of a leaf-list. inUnion specifies whether v is a value of a
+// union, such that enumeration and identityref values are tagged. It returns
+// nil if v is an unset value.
+func cborScalar(v reflect.Value, inUnion bool) (any, error) {
+	if _, isEnum := v.Interface().(GoEnum); isEnum && v.Kind() != reflect.Interface {
+		return cborEnum(v, inUnion)
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		// Unions with more than one type are either the union value itself,
+		// or a wrapper struct containing it, as per
+		// unwrapUnionInterfaceValue.
+		if util.IsValueInterfaceToStructPtr(v) {
+			s := v.Elem().Elem()
+			if !util.IsStructValueWithNFields(s, 1) {
+				return nil, fmt.Errorf("received a union type which did not have one field, had: %v", s.NumField())
+			}
+			return cborScalar(s.Field(0), true)
+		}
+		return cborScalar(v.Elem(), true)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return nil, fmt.Errorf("got unexpected field type, was: %v (%s)", v.Kind(), v.Type().Name())
+		}
+		return append([]byte{}, v.Bytes()...), nil
+	case reflect.Bool:
+		if v.Type().Name() != EmptyTypeName {
+			return v.Bool(), nil
+		}
+		// An empty leaf is represented as null when it is set.
+		if v.Bool() {
+			return ycbor.Null{}, nil
+		}
+		return nil, nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return cborDecimal(v.Float())
+	}
+	return nil, fmt.Errorf("got unexpected field type, was: %v (%s)", v.Kind(), v.Type().Name())
+}
+
+// cborEnum returns the YANG-CBOR value of the enumerated value v, which is
+// a cborIdentityref if it is an identity, or otherwise the integer value of
+// the YANG enumeration, or its name tagged as an enumeration if it is within
+// a union. It returns nil if v is unset.
+func cborEnum(v reflect.Value, inUnion bool) (any, error) {
+	name, set, err := enumFieldToString(v, false)
+	if err != nil || !set {
+		return nil, err
+	}
+
+	def := v.Interface().(GoEnum).ΛMap()[v.Type().Name()][v.Int()]
+	switch {
+	case def.DefiningModule != "":
+		return cborIdentityref{name: fmt.Sprintf("%s:%s", def.DefiningModule, name), inUnion: inUnion}, nil
+	case inUnion:
+		return ycbor.Tag{Number: ycbor.TagEnumeration, Content: name}, nil
+	}
+	// The values of generated enumerated types are offset by one from the
+	// values of the YANG enumeration, such that 0 is the unset value.
+	return v.Int() - 1, nil
+}
+
+// cborDecimal returns the decimal64 value f as a CBOR decimal fraction,
+// using the shortest decimal representation of f.
+func cborDecimal(f float64) (any, error) {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	var exp int64
+	if i := strings.Index(s, "."); i != -1 {
+		exp = -int64(len(s) - i - 1)
+		s = s[:i] + s[i+1:]
+	}
+	mant, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("cannot represent %v as a decimal64 value: %v", f, err)
+	}
+	return ycbor.Tag{Number: ycbor.TagDecimalFraction, Content: []any{exp, mant}}, nil
+}
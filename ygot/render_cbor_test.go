@@ -0,0 +1,339 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/internal/ycbor"
+	"github.com/openconfig/ygot/testutil"
+)
+
+// cborExample is used within TestMarshalCBOR as a GoStruct.
+type cborExample struct {
+	Str      *string                    `path:"str" module:"foo"`
+	Int8Val  *int8                      `path:"int8-val" module:"foo"`
+	Int64Val *int64                     `path:"int64-val" module:"foo"`
+	Decimal  *float64                   `path:"decimal" module:"foo"`
+	Enum     cborTestEnum               `path:"enum" module:"foo"`
+	Identity EnumTest                   `path:"identity" module:"foo"`
+	Union    exampleUnion               `path:"union" module:"foo"`
+	Empty    YANGEmpty                  `path:"empty" module:"foo"`
+	Bin      Binary                     `path:"bin" module:"foo"`
+	LeafList []EnumTest                 `path:"leaf-list" module:"foo"`
+	List     map[uint32]*xmlExampleList `path:"lists/list" module:"foo/foo"`
+	Child    *xmlExampleChild           `path:"child" module:"bar"`
+}
+
+func (*cborExample) IsYANGGoStruct()                         {}
+func (*cborExample) ΛValidate(...ValidationOption) error     { return nil }
+func (*cborExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*cborExample) ΛBelongingModule() string                { return "foo" }
+
+// cborTestEnum is an enumeration, whose YANG values are 0 and 10.
+type cborTestEnum int64
+
+func (cborTestEnum) IsYANGGoEnum()   {}
+func (cborTestEnum) IsExampleUnion() {}
+
+func (cborTestEnum) ΛMap() map[string]map[int64]EnumDefinition {
+	return map[string]map[int64]EnumDefinition{
+		"cborTestEnum": {
+			1:  EnumDefinition{Name: "ZERO"},
+			11: EnumDefinition{Name: "TEN"},
+		},
+	}
+}
+
+func (e cborTestEnum) String() string {
+	return EnumLogString(e, int64(e), "cborTestEnum")
+}
+
+// cborTestSIDs returns the SIDMap of the data nodes of cborExample.
+func cborTestSIDs(t *testing.T) *SIDMap {
+	f, err := ParseSIDFile([]byte(`{
+  "ietf-sid-file:sid-file": {
+    "module-name": "foo",
+    "item": [
+      {"namespace": "module", "identifier": "foo", "sid": "1000"},
+      {"namespace": "identity", "identifier": "VAL_ONE", "sid": "1001"},
+      {"namespace": "data", "identifier": "/foo:str", "sid": "1010"},
+      {"namespace": "data", "identifier": "/foo:identity", "sid": "1011"},
+      {"namespace": "data", "identifier": "/foo:union", "sid": "1012"},
+      {"namespace": "data", "identifier": "/foo:lists", "sid": "1020"},
+      {"namespace": "data", "identifier": "/foo:lists/list", "sid": "1021"},
+      {"namespace": "data", "identifier": "/foo:lists/list/key", "sid": "1022"},
+      {"namespace": "data", "identifier": "/foo:lists/list/config", "sid": "1023"},
+      {"namespace": "data", "identifier": "/foo:lists/list/config/key", "sid": "1024"},
+      {"namespace": "data", "identifier": "/foo:lists/list/config/value", "sid": 1025}
+    ]
+  }
+}`))
+	if err != nil {
+		t.Fatalf("cannot parse SID file: %v", err)
+	}
+	g, err := ParseSIDFile([]byte(`{
+  "module-name": "bar",
+  "item": [
+    {"namespace": "identity", "identifier": "bar:VAL_TWO", "sid": "2001"},
+    {"namespace": "data", "identifier": "/bar:child", "sid": "2010"},
+    {"namespace": "data", "identifier": "/bar:child/val", "sid": "2011"},
+    {"namespace": "data", "identifier": "/bar:child/foo:text", "sid": "1030"}
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("cannot parse SID file: %v", err)
+	}
+	m, err := NewSIDMap(f, g)
+	if err != nil {
+		t.Fatalf("cannot create SIDMap: %v", err)
+	}
+	return m
+}
+
+func TestMarshalCBOR(t *testing.T) {
+	sids := cborTestSIDs(t)
+	noIdentitySIDs, err := NewSIDMap(&SIDFile{
+		ModuleName: "foo",
+		Items:      []*SIDItem{{Namespace: SIDNamespaceData, Identifier: "/foo:identity", SID: 1}},
+	})
+	if err != nil {
+		t.Fatalf("cannot create SIDMap: %v", err)
+	}
+
+	tests := []struct {
+		desc   string
+		in     GoStruct
+		inArgs []MarshalCBORArg
+		// want is the expected output, as decoded by ycbor.Unmarshal.
+		want             any
+		wantErrSubstring string
+	}{{
+		desc: "leaves",
+		in: &cborExample{
+			Str:      String("a"),
+			Int8Val:  Int8(-42),
+			Int64Val: Int64(42),
+			Decimal:  Float64(273.15),
+			Empty:    true,
+			Bin:      Binary{1, 2},
+		},
+		want: map[any]any{
+			"foo:str":       "a",
+			"foo:int8-val":  int64(-42),
+			"foo:int64-val": int64(42),
+			"foo:decimal":   ycbor.Tag{Number: ycbor.TagDecimalFraction, Content: []any{int64(-2), int64(27315)}},
+			"foo:empty":     nil,
+			"foo:bin":       []byte{1, 2},
+		},
+	}, {
+		desc: "enumeration and identityrefs",
+		in:   &cborExample{Enum: 11, Identity: EnumTestVALTWO, LeafList: []EnumTest{EnumTestVALONE}},
+		want: map[any]any{
+			"foo:enum":      int64(10),
+			"foo:identity":  "bar:VAL_TWO",
+			"foo:leaf-list": []any{"foo:VAL_ONE"},
+		},
+	}, {
+		desc: "enumeration within union",
+		in:   &cborExample{Union: cborTestEnum(1)},
+		want: map[any]any{
+			"foo:union": ycbor.Tag{Number: ycbor.TagEnumeration, Content: "ZERO"},
+		},
+	}, {
+		desc: "integer within union",
+		in:   &cborExample{Union: testutil.UnionInt64(-1)},
+		want: map[any]any{"foo:union": int64(-1)},
+	}, {
+		desc: "list and child in other module",
+		in: &cborExample{
+			List:  map[uint32]*xmlExampleList{1: {Key: Uint32(1), Value: String("one")}},
+			Child: &xmlExampleChild{Val: String("v"), Text: String("t")},
+		},
+		want: map[any]any{
+			"foo:lists": map[any]any{
+				"list": []any{map[any]any{
+					"key":    int64(1),
+					"config": map[any]any{"key": int64(1), "value": "one"},
+				}},
+			},
+			"bar:child": map[any]any{"val": "v", "foo:text": "t"},
+		},
+	}, {
+		desc: "SIDs",
+		in: &cborExample{
+			Str:      String("a"),
+			Identity: EnumTestVALONE,
+			Union:    EnumTestVALTWO,
+			List:     map[uint32]*xmlExampleList{1: {Key: Uint32(1), Value: String("one")}},
+			Child:    &xmlExampleChild{Val: String("v"), Text: String("t")},
+		},
+		inArgs: []MarshalCBORArg{&CBORConfig{SIDs: sids}},
+		want: map[any]any{
+			int64(1010): "a",
+			int64(1011): int64(1001),
+			int64(1012): ycbor.Tag{Number: ycbor.TagIdentityref, Content: int64(2001)},
+			int64(1020): map[any]any{
+				int64(1): []any{map[any]any{
+					int64(1): int64(1),
+					int64(2): map[any]any{int64(1): int64(1), int64(2): "one"},
+				}},
+			},
+			int64(2010): map[any]any{int64(1): "v", int64(-980): "t"},
+		},
+	}, {
+		desc:   "SIDs relative to path",
+		in:     &xmlExampleChild{Val: String("v")},
+		inArgs: []MarshalCBORArg{&CBORConfig{SIDs: sids, Path: "/bar:child"}},
+		want:   map[any]any{int64(1): "v"},
+	}, {
+		desc:             "missing SID of data node",
+		in:               &cborExample{Int8Val: Int8(1)},
+		inArgs:           []MarshalCBORArg{&CBORConfig{SIDs: sids}},
+		wantErrSubstring: "no SID found for data node /foo:int8-val",
+	}, {
+		desc:             "missing SID of path",
+		in:               &xmlExampleChild{Val: String("v")},
+		inArgs:           []MarshalCBORArg{&CBORConfig{SIDs: sids, Path: "/bar:other"}},
+		wantErrSubstring: "no SID found for data node /bar:other",
+	}, {
+		desc:             "missing SID of identity",
+		in:               &cborExample{Identity: EnumTestVALTWO},
+		inArgs:           []MarshalCBORArg{&CBORConfig{SIDs: noIdentitySIDs}},
+		wantErrSubstring: "no SID found for identity bar:VAL_TWO",
+	}, {
+		desc:             "nil GoStruct",
+		in:               (*cborExample)(nil),
+		wantErrSubstring: "cannot marshal nil GoStruct",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := MarshalCBOR(tt.in, tt.inArgs...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MarshalCBOR(%v): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			decoded, err := ycbor.Unmarshal(got)
+			if err != nil {
+				t.Fatalf("MarshalCBOR(%v): cannot decode output %x: %v", tt.in, got, err)
+			}
+			if diff := cmp.Diff(tt.want, decoded); diff != "" {
+				t.Errorf("MarshalCBOR(%v): did not get expected output, diff(-want,+got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestNewSIDMap(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               []*SIDFile
+		wantErrSubstring string
+	}{{
+		desc: "valid files",
+		in: []*SIDFile{{
+			ModuleName: "a",
+			Items:      []*SIDItem{{Namespace: SIDNamespaceData, Identifier: "/a:x", SID: 1}},
+		}, {
+			ModuleName: "b",
+			Items:      []*SIDItem{{Namespace: SIDNamespaceData, Identifier: "/b:x", SID: 2}},
+		}},
+	}, {
+		desc: "SID assigned twice",
+		in: []*SIDFile{{
+			ModuleName: "a",
+			Items: []*SIDItem{
+				{Namespace: SIDNamespaceData, Identifier: "/a:x", SID: 1},
+				{Namespace: SIDNamespaceIdentity, Identifier: "a:x", SID: 1},
+			},
+		}},
+		wantErrSubstring: "SID 1 is assigned to both data /a:x and identity a:x",
+	}, {
+		desc: "item assigned two SIDs",
+		in: []*SIDFile{{
+			ModuleName: "a",
+			Items: []*SIDItem{
+				{Namespace: SIDNamespaceData, Identifier: "/a:x", SID: 1},
+				{Namespace: SIDNamespaceData, Identifier: "/a:x", SID: 2},
+			},
+		}},
+		wantErrSubstring: "data /a:x is assigned both SID 1 and 2",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := NewSIDMap(tt.in...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("NewSIDMap: did not get expected error, %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseSIDFile(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               string
+		want             *SIDFile
+		wantErrSubstring string
+	}{{
+		desc: "wrapped file",
+		in: `{"ietf-sid-file:sid-file": {"module-name": "a", "module-revision": "2026-01-01", "item": [
+			{"namespace": "identity", "identifier": "x", "sid": "5"}
+		]}}`,
+		want: &SIDFile{
+			ModuleName:     "a",
+			ModuleRevision: "2026-01-01",
+			Items:          []*SIDItem{{Namespace: SIDNamespaceIdentity, Identifier: "a:x", SID: 5}},
+		},
+	}, {
+		desc: "unwrapped file with numeric SID",
+		in:   `{"module-name": "a", "item": [{"namespace": "data", "identifier": "/a:x", "sid": 6}]}`,
+		want: &SIDFile{
+			ModuleName: "a",
+			Items:      []*SIDItem{{Namespace: SIDNamespaceData, Identifier: "/a:x", SID: 6}},
+		},
+	}, {
+		desc:             "invalid JSON",
+		in:               `{`,
+		wantErrSubstring: "invalid SID file",
+	}, {
+		desc:             "missing module name",
+		in:               `{"item": []}`,
+		wantErrSubstring: "no module-name specified",
+	}, {
+		desc:             "invalid SID",
+		in:               `{"module-name": "a", "item": [{"namespace": "data", "identifier": "/a:x", "sid": "-1"}]}`,
+		wantErrSubstring: "invalid SID",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseSIDFile([]byte(tt.in))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ParseSIDFile: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseSIDFile: did not get expected output, diff(-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -24,7 +24,10 @@ import (
 	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
 	"github.com/openconfig/ygot/integration_tests/schemaops/utestschema"
 	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -295,6 +298,83 @@ func TestTogNMINotificationsOrderedMap(t *testing.T) {
 	}
 }
 
+func TestStreamgNMINotificationsOrderedMap(t *testing.T) {
+	in := &ctestschema.Device{
+		OrderedList: ctestschema.GetOrderedMap(t),
+		OtherData: &ctestschema.OtherData{
+			Motd: ygot.String("abc -> def"),
+		},
+	}
+	wantAtomic := &gnmipb.Notification{
+		Timestamp: 42,
+		Atomic:    true,
+		Prefix:    mustPath("ordered-lists"),
+		Update: []*gnmipb.Update{{
+			Path: mustPath(`ordered-list[key=foo]/config/key`),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+		}, {
+			Path: mustPath(`ordered-list[key=foo]/key`),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+		}, {
+			Path: mustPath(`ordered-list[key=foo]/config/value`),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo-val"}},
+		}, {
+			Path: mustPath(`ordered-list[key=bar]/config/key`),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar"}},
+		}, {
+			Path: mustPath(`ordered-list[key=bar]/key`),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar"}},
+		}, {
+			Path: mustPath(`ordered-list[key=bar]/config/value`),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar-val"}},
+		}},
+	}
+	wantMotd := &gnmipb.Notification{
+		Timestamp: 42,
+		Update: []*gnmipb.Update{{
+			Path: mustPath(`other-data/config/motd`),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "abc -> def"}},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		inStream ygot.NotificationStreamConfig
+		want     []*gnmipb.Notification
+	}{{
+		name:     "atomic notification is not split",
+		inStream: ygot.NotificationStreamConfig{MaxUpdates: 1, MaxBytes: 32},
+		want:     []*gnmipb.Notification{wantAtomic, wantMotd},
+	}, {
+		name: "ordered list within paths",
+		inStream: ygot.NotificationStreamConfig{
+			Paths: []*gnmipb.Path{mustPath("ordered-lists/ordered-list[key=*]")},
+		},
+		want: []*gnmipb.Notification{wantAtomic},
+	}, {
+		name: "ordered list not within paths",
+		inStream: ygot.NotificationStreamConfig{
+			Paths: []*gnmipb.Path{mustPath("other-data")},
+		},
+		want: []*gnmipb.Notification{wantMotd},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*gnmipb.Notification
+			if err := ygot.StreamgNMINotifications(in, 42, ygot.GNMINotificationsConfig{UsePathElem: true}, tt.inStream, func(n *gnmipb.Notification) error {
+				got = append(got, n)
+				return nil
+			}); err != nil {
+				t.Fatalf("StreamgNMINotifications: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.SortSlices(testutil.NotificationLess), protocmp.Transform()); diff != "" {
+				t.Errorf("StreamgNMINotifications (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestConstructJSONOrderedMap(t *testing.T) {
 	tests := []struct {
 		name                     string
@@ -665,3 +745,156 @@ func TestMarshal7951OrderedMap(t *testing.T) {
 		})
 	}
 }
+
+func TestTogNMINotificationsJSONIETF(t *testing.T) {
+	in := &ctestschema.Device{
+		OrderedList: ctestschema.GetOrderedMap(t),
+		OtherData: &ctestschema.OtherData{
+			Motd: ygot.String("abc -> def"),
+		},
+		UnorderedList: map[string]*ctestschema.UnorderedList{
+			"foo": {Key: ygot.String("foo"), Value: ygot.String("foo-val")},
+			"bar": {Key: ygot.String("bar"), Value: ygot.String("bar-val")},
+		},
+	}
+
+	jsonUpd := func(p, j string) *gnmipb.Update {
+		return &gnmipb.Update{
+			Path: mustPath(p),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(j)}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		inConfig ygot.GNMINotificationsConfig
+		want     []*gnmipb.Update
+		wantErr  string
+	}{{
+		name:     "depth",
+		inConfig: ygot.GNMINotificationsConfig{UsePathElem: true, JSONIETFDepth: 1},
+		want: []*gnmipb.Update{
+			jsonUpd("other-data", `{"ctestschema:config":{"motd":"abc -\u003e def"}}`),
+			jsonUpd("unordered-lists/unordered-list[key=bar]", `{"ctestschema:config":{"key":"bar","value":"bar-val"},"ctestschema:key":"bar"}`),
+			jsonUpd("unordered-lists/unordered-list[key=foo]", `{"ctestschema:config":{"key":"foo","value":"foo-val"},"ctestschema:key":"foo"}`),
+		},
+	}, {
+		name: "paths",
+		inConfig: ygot.GNMINotificationsConfig{
+			UsePathElem:   true,
+			JSONIETFPaths: []*gnmipb.Path{mustPath("unordered-lists/unordered-list")},
+		},
+		want: []*gnmipb.Update{{
+			Path: mustPath("other-data/config/motd"),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "abc -> def"}},
+		},
+			jsonUpd("unordered-lists/unordered-list[key=bar]", `{"ctestschema:config":{"key":"bar","value":"bar-val"},"ctestschema:key":"bar"}`),
+			jsonUpd("unordered-lists/unordered-list[key=foo]", `{"ctestschema:config":{"key":"foo","value":"foo-val"},"ctestschema:key":"foo"}`),
+		},
+	}, {
+		name:     "invalid depth",
+		inConfig: ygot.GNMINotificationsConfig{UsePathElem: true, JSONIETFDepth: -1},
+		wantErr:  "invalid JSON_IETF depth -1",
+	}, {
+		name:     "paths with string slice paths",
+		inConfig: ygot.GNMINotificationsConfig{JSONIETFPaths: []*gnmipb.Path{mustPath("other-data")}},
+		wantErr:  "JSON_IETF paths can only be specified for PathElem paths",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ygot.TogNMINotifications(in, 42, tt.inConfig)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("TogNMINotifications: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			// The ordered list remains within its own atomic notification.
+			if len(got) != 2 || !got[1].GetAtomic() {
+				t.Fatalf("TogNMINotifications: got %v, want a non-atomic and an atomic notification", got)
+			}
+			if diff := cmp.Diff(tt.want, got[0].GetUpdate(), protocmp.Transform(), cmpopts.SortSlices(func(a, b *gnmipb.Update) bool {
+				return prototext.Format(a) < prototext.Format(b)
+			})); diff != "" {
+				t.Errorf("TogNMINotifications (-want, +got):\n%s", diff)
+			}
+
+			// The notifications must unmarshal to the original GoStruct.
+			schema, err := ctestschema.Schema()
+			if err != nil {
+				t.Fatalf("cannot get schema: %v", err)
+			}
+			if err := ytypes.UnmarshalNotifications(schema, got); err != nil {
+				t.Fatalf("UnmarshalNotifications: %v", err)
+			}
+			if diff := cmp.Diff(in, schema.Root, cmp.AllowUnexported(ctestschema.OrderedList_OrderedMap{})); diff != "" {
+				t.Errorf("UnmarshalNotifications (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarshalXMLOrderedMap(t *testing.T) {
+	schema, err := ctestschema.Schema()
+	if err != nil {
+		t.Fatalf("cannot get schema: %v", err)
+	}
+	cfg := &ygot.XMLConfig{Namespaces: util.ModuleNamespaces(schema.RootSchema())}
+
+	tests := []struct {
+		desc             string
+		in               ygot.GoStruct
+		want             string
+		wantErrSubstring string
+	}{{
+		desc: "container with ordered list",
+		in: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap(t),
+		},
+		want: `<ordered-lists xmlns="urn:cts">` +
+			`<ordered-list><key>foo</key><config><key>foo</key><value>foo-val</value></config></ordered-list>` +
+			`<ordered-list><key>bar</key><config><key>bar</key><value>bar-val</value></config></ordered-list>` +
+			`</ordered-lists>`,
+	}, {
+		desc: "multi-keyed ordered list in augmenting module",
+		in: &ctestschema.Device{
+			OrderedMultikeyedList: func() *ctestschema.OrderedMultikeyedList_OrderedMap {
+				om := &ctestschema.OrderedMultikeyedList_OrderedMap{}
+				if _, err := om.AppendNew("foo", 42); err != nil {
+					t.Fatal(err)
+				}
+				return om
+			}(),
+		},
+		want: `<ordered-multikeyed-lists xmlns="urn:ctsr">` +
+			`<ordered-multikeyed-list xmlns="urn:cts"><key1>foo</key1><key2>42</key2><config><key1>foo</key1><key2>42</key2></config></ordered-multikeyed-list>` +
+			`</ordered-multikeyed-lists>`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.MarshalXML(tt.in, cfg)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(string(got), tt.want); diff != "" {
+				t.Fatalf("did not get expected return value, diff(-got,+want):\n%s", diff)
+			}
+
+			// The XML must unmarshal to the original GoStruct.
+			rt := &ctestschema.Device{}
+			if err := ytypes.UnmarshalXML(schema.RootSchema(), rt, got); err != nil {
+				t.Fatalf("cannot unmarshal XML: %v", err)
+			}
+			if diff := cmp.Diff(tt.in, rt, cmp.AllowUnexported(ctestschema.OrderedList_OrderedMap{}, ctestschema.OrderedMultikeyedList_OrderedMap{})); diff != "" {
+				t.Errorf("did not get original GoStruct following round trip, diff(-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
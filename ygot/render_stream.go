@@ -0,0 +1,276 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+
+	"github.com/openconfig/ygot/util"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// NotificationStreamConfig specifies how the Notifications that are emitted
+// by StreamgNMINotifications are bounded in size, and which parts of the
+// input GoStruct they contain.
+type NotificationStreamConfig struct {
+	// MaxUpdates is the maximum number of Updates within each
+	// Notification. It is unbounded if zero.
+	MaxUpdates int
+	// MaxBytes is the maximum size in bytes of each encoded Notification,
+	// e.g., to keep the messages of a gRPC stream within the maximum
+	// message size of the receiver. It is unbounded if zero. A
+	// Notification containing a single Update that is larger than
+	// MaxBytes is still emitted.
+	MaxBytes int
+	// Paths are the gNMI paths, such as the paths of the subscriptions of
+	// a SubscribeRequest, of the subtrees of the GoStruct that are
+	// rendered. They are absolute paths, including the prefix specified
+	// by the GNMINotificationsConfig, and may contain wildcard names and
+	// keys. Multi-level wildcards ("...") are not supported. All of the
+	// GoStruct is rendered if Paths is empty. Paths are only supported
+	// for PathElem paths.
+	Paths []*gnmipb.Path
+}
+
+// StreamgNMINotifications renders the input GoStruct to Notification
+// messages as per TogNMINotifications, marked with the specified timestamp,
+// and calls emit with each of them in turn rather than returning them, such
+// that the whole tree is never held in a single message. The Updates of the
+// leaves of the GoStruct are split across Notifications as per the bounds
+// specified by scfg, and are rendered only where they are within the paths
+// specified by scfg.
+//
+// Each `ordered-by user` list is emitted within a single "telemetry-atomic"
+// Notification as soon as it is found, regardless of the bounds specified, and
+// is rendered in its entirety where its path is within the paths specified.
+// As such, atomic Notifications may be interleaved with those containing
+// non-atomic updates. If there are no updates to be rendered, a single
+// Notification without any Updates is emitted.
+//
+// If emit returns an error, no further Notifications are emitted and the
+// error is returned.
+func StreamgNMINotifications(s GoStruct, ts int64, cfg GNMINotificationsConfig, scfg NotificationStreamConfig, emit func(*gnmipb.Notification) error) error {
+	var pfx *gnmiPath
+	if cfg.UsePathElem {
+		pfx = newPathElemGNMIPath(cfg.PathElemPrefix)
+	} else {
+		pfx = newStringSliceGNMIPath(cfg.StringSlicePrefix)
+	}
+
+	if len(scfg.Paths) != 0 && !cfg.UsePathElem {
+		return fmt.Errorf("paths can only be specified for PathElem paths")
+	}
+	for _, p := range scfg.Paths {
+		for _, e := range p.GetElem() {
+			if e.GetName() == "..." {
+				return fmt.Errorf("multi-level wildcards are not supported, got path %v", p)
+			}
+		}
+	}
+
+	s, err := withDefaults(s, cfg.WithDefaults)
+	if err != nil {
+		return err
+	}
+
+	c, err := newNotificationChunker(ts, pfx, scfg, emit)
+	if err != nil {
+		return err
+	}
+	if err := findLeaves(c, s, pfx, cfg); err != nil {
+		return err
+	}
+	return c.finish()
+}
+
+// notificationChunker is used as the cache of leaves within
+// findUpdatedLeaves by StreamgNMINotifications, such that each leaf is
+// added to the current Notification as it is found, which is emitted once it
+// is full.
+type notificationChunker struct {
+	// ts is the timestamp of the Notifications.
+	ts int64
+	// pfx is the prefix of the Notifications.
+	pfx *gnmiPath
+	// cfg specifies the bounds of the Notifications, and the paths that
+	// are rendered.
+	cfg NotificationStreamConfig
+	// emit is called with each Notification.
+	emit func(*gnmipb.Notification) error
+
+	// n is the Notification to which non-atomic updates are added, and
+	// size is its encoded size.
+	n    *gnmipb.Notification
+	size int
+	// emitted is set once any Notification has been emitted.
+	emitted bool
+	// err is the first error encountered, after which no further
+	// Notifications are emitted.
+	err error
+}
+
+// newNotificationChunker returns a notificationChunker whose first
+// Notification is empty.
+func newNotificationChunker(ts int64, pfx *gnmiPath, cfg NotificationStreamConfig, emit func(*gnmipb.Notification) error) (*notificationChunker, error) {
+	c := &notificationChunker{ts: ts, pfx: pfx, cfg: cfg, emit: emit}
+	if err := c.reset(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// reset replaces the current Notification with an empty Notification.
+func (c *notificationChunker) reset() error {
+	p, err := c.pfx.ToProto()
+	if err != nil {
+		return err
+	}
+	c.n = &gnmipb.Notification{Timestamp: c.ts, Prefix: p}
+	c.size = proto.Size(c.n)
+	return nil
+}
+
+// flush emits the current Notification if it contains any updates.
+func (c *notificationChunker) flush() error {
+	if len(c.n.Update) == 0 {
+		return nil
+	}
+	if err := c.send(c.n); err != nil {
+		return err
+	}
+	return c.reset()
+}
+
+// send emits n.
+func (c *notificationChunker) send(n *gnmipb.Notification) error {
+	c.emitted = true
+	return c.emit(n)
+}
+
+// addLeaf adds the update of the leaf at path p with value v to the current
+// Notification if it is within the paths to be rendered, first emitting the
+// current Notification if the update would exceed its bounds. Errors are
+// recorded in c.err.
+func (c *notificationChunker) addLeaf(p *path, v any) {
+	if c.err != nil || !c.leafInPaths(p.p) {
+		return
+	}
+	u, err := leafUpdate(p, v, c.pfx)
+	if err != nil {
+		c.err = err
+		return
+	}
+
+	// An Update is encoded as a length-delimited repeated field with a
+	// single byte tag.
+	us := 1 + protowire.SizeBytes(proto.Size(u))
+	if n := len(c.n.Update); n != 0 && (c.cfg.MaxUpdates > 0 && n >= c.cfg.MaxUpdates || c.cfg.MaxBytes > 0 && c.size+us > c.cfg.MaxBytes) {
+		if c.err = c.flush(); c.err != nil {
+			return
+		}
+	}
+	c.n.Update = append(c.n.Update, u)
+	c.size += us
+}
+
+// addOrderedMap emits the atomic Notification of the ordered map om, whose
+// path is parent.
+func (c *notificationChunker) addOrderedMap(om GoOrderedMap, parent *gnmiPath, preferShadowPath bool) error {
+	if c.err != nil {
+		return nil
+	}
+	n, err := orderedMapNotif(om, parent, c.ts, preferShadowPath)
+	if err != nil {
+		return err
+	}
+	if n == nil {
+		return nil
+	}
+	c.err = c.send(n)
+	return nil
+}
+
+// finish emits the current Notification, or an empty Notification if none
+// have been emitted, and returns the first error encountered.
+func (c *notificationChunker) finish() error {
+	if c.err != nil {
+		return c.err
+	}
+	if !c.emitted {
+		return c.send(c.n)
+	}
+	return c.flush()
+}
+
+// inPaths reports whether any of the paths ps may contain data that is
+// within the paths to be rendered, i.e., whether it is either within one of
+// them, or one of its ancestors. The paths of lists without their keys are
+// considered to contain any of their elements.
+func (c *notificationChunker) inPaths(ps ...*gnmiPath) bool {
+	if len(c.cfg.Paths) == 0 {
+		return true
+	}
+	for _, p := range ps {
+		for _, q := range c.cfg.Paths {
+			if pathMayMatchQuery(p.pathElemPath, q) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// leafInPaths reports whether the leaf at path p is within the paths to be
+// rendered.
+func (c *notificationChunker) leafInPaths(p *gnmiPath) bool {
+	if len(c.cfg.Paths) == 0 {
+		return true
+	}
+	pp := &gnmipb.Path{Elem: p.pathElemPath}
+	for _, q := range c.cfg.Paths {
+		if util.PathMatchesQuery(pp, q) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathMayMatchQuery reports whether the elements of the path elems match
+// those of the query up to the length of the shorter of the two, such that
+// the data at the path may be within the query. The keys of the query that
+// are not specified by an element of the path match any value.
+func pathMayMatchQuery(elems []*gnmipb.PathElem, query *gnmipb.Path) bool {
+	if o := query.GetOrigin(); o != "" && o != "openconfig" {
+		return false
+	}
+	for i, qe := range query.GetElem() {
+		if i == len(elems) {
+			break
+		}
+		pe := elems[i]
+		if qe.GetName() != "*" && qe.GetName() != pe.GetName() {
+			return false
+		}
+		for qk, qv := range qe.GetKey() {
+			if pv, ok := pe.GetKey()[qk]; ok && qv != "*" && qv != pv {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// streamPath returns the gNMI path of the string s.
+func streamPath(s string) *gnmipb.Path {
+	return &gnmipb.Path{Elem: mustPathElem(s)}
+}
+
+func TestStreamgNMINotifications(t *testing.T) {
+	in := &renderExample{
+		Str:    String("hello"),
+		IntVal: Int32(42),
+		Ch:     &renderExampleChild{Val: Uint64(21)},
+		List: map[uint32]*renderExampleList{
+			1: {Val: String("one")},
+			2: {Val: String("two")},
+		},
+	}
+
+	strUpd := &gnmipb.Update{
+		Path: streamPath("str"),
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "hello"}},
+	}
+	intUpd := &gnmipb.Update{
+		Path: streamPath("int-val"),
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 42}},
+	}
+	chUpd := &gnmipb.Update{
+		Path: streamPath("ch/val"),
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 21}},
+	}
+	listUpds := func(v string) []*gnmipb.Update {
+		return []*gnmipb.Update{{
+			Path: streamPath(fmt.Sprintf("list[val=%s]/val", v)),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}},
+		}, {
+			Path: streamPath(fmt.Sprintf("list[val=%s]/state/val", v)),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}},
+		}}
+	}
+	allUpds := append(append([]*gnmipb.Update{strUpd, intUpd, chUpd}, listUpds("one")...), listUpds("two")...)
+
+	tests := []struct {
+		desc     string
+		inStruct GoStruct
+		inConfig GNMINotificationsConfig
+		inStream NotificationStreamConfig
+		// wantCount is the number of notifications, which is not
+		// checked if it is negative.
+		wantCount int
+		wantUpds  []*gnmipb.Update
+		wantErr   string
+	}{{
+		desc:      "unbounded",
+		inStruct:  in,
+		inConfig:  GNMINotificationsConfig{UsePathElem: true},
+		wantCount: 1,
+		wantUpds:  allUpds,
+	}, {
+		desc:      "bounded by number of updates",
+		inStruct:  in,
+		inConfig:  GNMINotificationsConfig{UsePathElem: true},
+		inStream:  NotificationStreamConfig{MaxUpdates: 2},
+		wantCount: 4,
+		wantUpds:  allUpds,
+	}, {
+		desc:      "bounded by size",
+		inStruct:  in,
+		inConfig:  GNMINotificationsConfig{UsePathElem: true, PathElemPrefix: mustPathElem("a/b")},
+		inStream:  NotificationStreamConfig{MaxBytes: 64},
+		wantCount: -1,
+		wantUpds:  allUpds,
+	}, {
+		desc:      "update larger than bound",
+		inStruct:  &renderExample{Str: String("hello")},
+		inConfig:  GNMINotificationsConfig{UsePathElem: true},
+		inStream:  NotificationStreamConfig{MaxBytes: 1},
+		wantCount: 1,
+		wantUpds:  []*gnmipb.Update{strUpd},
+	}, {
+		desc:     "paths",
+		inStruct: in,
+		inConfig: GNMINotificationsConfig{UsePathElem: true},
+		inStream: NotificationStreamConfig{Paths: []*gnmipb.Path{
+			streamPath("str"),
+			streamPath("list[val=two]"),
+		}},
+		wantCount: 1,
+		wantUpds:  append([]*gnmipb.Update{strUpd}, listUpds("two")...),
+	}, {
+		desc:     "wildcard paths",
+		inStruct: in,
+		inConfig: GNMINotificationsConfig{UsePathElem: true},
+		inStream: NotificationStreamConfig{Paths: []*gnmipb.Path{
+			streamPath("*/val"),
+			streamPath("list[val=*]/state"),
+		}},
+		wantCount: 1,
+		wantUpds:  append(listUpds("one"), append(listUpds("two"), chUpd)...),
+	}, {
+		desc:     "paths with prefix",
+		inStruct: in,
+		inConfig: GNMINotificationsConfig{UsePathElem: true, PathElemPrefix: mustPathElem("a/b")},
+		inStream: NotificationStreamConfig{Paths: []*gnmipb.Path{
+			streamPath("a/b/ch"),
+		}},
+		wantCount: 1,
+		wantUpds:  []*gnmipb.Update{chUpd},
+	}, {
+		desc:     "JSON_IETF subtree within paths",
+		inStruct: in,
+		inConfig: GNMINotificationsConfig{UsePathElem: true, JSONIETFDepth: 1},
+		inStream: NotificationStreamConfig{Paths: []*gnmipb.Path{
+			streamPath("list[val=two]"),
+		}},
+		wantCount: 1,
+		wantUpds: []*gnmipb.Update{{
+			Path: streamPath("list[val=two]"),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"state":{"val":"two"},"val":"two"}`)}},
+		}},
+	}, {
+		desc:     "JSON_IETF subtree partially within paths",
+		inStruct: in,
+		inConfig: GNMINotificationsConfig{UsePathElem: true, JSONIETFDepth: 1},
+		inStream: NotificationStreamConfig{Paths: []*gnmipb.Path{
+			streamPath("list[val=two]/state"),
+		}},
+		wantCount: 1,
+		wantUpds:  listUpds("two")[1:],
+	}, {
+		desc:     "no matching paths",
+		inStruct: in,
+		inConfig: GNMINotificationsConfig{UsePathElem: true},
+		inStream: NotificationStreamConfig{Paths: []*gnmipb.Path{
+			{Origin: "other", Elem: mustPathElem("str")},
+		}},
+		wantCount: 1,
+	}, {
+		desc:     "multi-level wildcard",
+		inStruct: in,
+		inConfig: GNMINotificationsConfig{UsePathElem: true},
+		inStream: NotificationStreamConfig{Paths: []*gnmipb.Path{
+			streamPath(".../val"),
+		}},
+		wantErr: "multi-level wildcards are not supported",
+	}, {
+		desc:     "paths with string slice paths",
+		inStruct: in,
+		inStream: NotificationStreamConfig{Paths: []*gnmipb.Path{
+			streamPath("str"),
+		}},
+		wantErr: "paths can only be specified for PathElem paths",
+	}, {
+		desc:     "invalid struct",
+		inStruct: &renderExample{InvalidPtr: &invalidGoStruct{Value: String("foo")}},
+		inConfig: GNMINotificationsConfig{UsePathElem: true},
+		wantErr:  "was not a valid GoStruct",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []*gnmipb.Notification
+			err := StreamgNMINotifications(tt.inStruct, 42, tt.inConfig, tt.inStream, func(n *gnmipb.Notification) error {
+				got = append(got, n)
+				return nil
+			})
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("StreamgNMINotifications: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if tt.wantCount >= 0 && len(got) != tt.wantCount {
+				t.Errorf("StreamgNMINotifications: got %d notifications, want %d: %v", len(got), tt.wantCount, got)
+			}
+			var gotUpds []*gnmipb.Update
+			for _, n := range got {
+				if n.GetTimestamp() != 42 {
+					t.Errorf("StreamgNMINotifications: got timestamp %d, want 42", n.GetTimestamp())
+				}
+				if tt.inStream.MaxUpdates > 0 && len(n.Update) > tt.inStream.MaxUpdates {
+					t.Errorf("StreamgNMINotifications: got %d updates, want at most %d", len(n.Update), tt.inStream.MaxUpdates)
+				}
+				if tt.inStream.MaxBytes > 0 && len(n.Update) > 1 && proto.Size(n) > tt.inStream.MaxBytes {
+					t.Errorf("StreamgNMINotifications: got notification of %d bytes, want at most %d", proto.Size(n), tt.inStream.MaxBytes)
+				}
+				gotUpds = append(gotUpds, n.Update...)
+			}
+			if diff := cmp.Diff(tt.wantUpds, gotUpds, protocmp.Transform(), cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b *gnmipb.Update) bool {
+				return prototext.Format(a) < prototext.Format(b)
+			})); diff != "" {
+				t.Errorf("StreamgNMINotifications (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamgNMINotificationsEmitError(t *testing.T) {
+	in := &renderExample{Str: String("hello"), IntVal: Int32(42)}
+	var calls int
+	err := StreamgNMINotifications(in, 42, GNMINotificationsConfig{UsePathElem: true}, NotificationStreamConfig{MaxUpdates: 1}, func(*gnmipb.Notification) error {
+		calls++
+		return fmt.Errorf("stream closed")
+	})
+	if diff := errdiff.Substring(err, "stream closed"); diff != "" {
+		t.Fatalf("StreamgNMINotifications: %s", diff)
+	}
+	if calls != 1 {
+		t.Errorf("StreamgNMINotifications: got %d calls of emit after error, want 1", calls)
+	}
+}
@@ -0,0 +1,537 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+	"golang.org/x/exp/slices"
+)
+
+// XMLConfig is used to control the behaviour of how XML is output by
+// MarshalXML.
+type XMLConfig struct {
+	// Namespaces maps the name of each YANG module to its XML namespace.
+	// It must contain each module that is named in the module tags of
+	// the marshalled GoStruct, along with the module that defines each
+	// identity that is the value of a marshalled identityref. The
+	// namespaces of the modules of a generated schema are returned by
+	// util.ModuleNamespaces.
+	Namespaces map[string]string
+	// PreferShadowPath uses the name of the "shadow-path" tag of a
+	// GoStruct to determine the marshalled elements instead of the
+	// "path" tag, whenever the former is present.
+	PreferShadowPath bool
+	// RewriteModuleNames specifies that any element that is found within
+	// module A should be assumed to be in module B, as per the field of
+	// the same name of RFC7951JSONConfig.
+	RewriteModuleNames map[string]string
+}
+
+// IsMarshalXMLArg marks the XMLConfig struct as a valid argument to
+// MarshalXML.
+func (*XMLConfig) IsMarshalXMLArg() {}
+
+// MarshalXMLArg is an interface implemented by arguments to the MarshalXML
+// function.
+type MarshalXMLArg interface {
+	// IsMarshalXMLArg is a marker method.
+	IsMarshalXMLArg()
+}
+
+// XMLIndent is a string that specifies the indentation that should be used
+// for XML output.
+type XMLIndent string
+
+// IsMarshalXMLArg marks XMLIndent as a valid MarshalXML argument.
+func (XMLIndent) IsMarshalXMLArg() {}
+
+// MarshalXML renders the supplied GoStruct to XML as per the encoding rules
+// of RFC7950 used by NETCONF and RESTCONF. The output contains an element for
+// each of the populated fields of the GoStruct, and is hence an XML fragment
+// corresponding to the contents of the container, list entry or root that is
+// represented by the GoStruct, suitable for wrapping in, for example, the
+// <config> element of a NETCONF edit-config. Elements are qualified with the
+// namespace of the module that defines them, which is declared as the default
+// namespace of an element whose module differs from that of its parent, and
+// identityref values are qualified with a prefix that is bound to the
+// namespace of the module that defines the identity. The entries of keyed
+// lists are output in the order of their keys, other than those of lists
+// that are ordered-by user, which retain their order, and the keys of each
+// entry are output before its other elements.
+func MarshalXML(s GoStruct, args ...MarshalXMLArg) ([]byte, error) {
+	cfg := &XMLConfig{}
+	var indent string
+	for _, a := range args {
+		switch v := a.(type) {
+		case *XMLConfig:
+			cfg = v
+		case XMLIndent:
+			indent = string(v)
+		}
+	}
+	if util.IsValueNil(s) {
+		return nil, fmt.Errorf("cannot marshal nil GoStruct to XML")
+	}
+
+	elems, err := xmlStruct(s, "", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &xmlWriter{cfg: cfg, indent: indent}
+	for i, e := range elems {
+		if i != 0 && indent != "" {
+			w.buf.WriteByte('\n')
+		}
+		if err := w.element(e, "", 0); err != nil {
+			return nil, err
+		}
+	}
+	return w.buf.Bytes(), nil
+}
+
+// xmlElement is an element of the XML representation of a GoStruct.
+type xmlElement struct {
+	// name is the local name of the element.
+	name string
+	// module is the name of the module that defines the element, or the
+	// empty string if it is not known.
+	module string
+	// text is the character data of the element, which is set only for
+	// leaves and leaf-lists.
+	text string
+	// identityModule is the module that defines the identity that is the
+	// value of the element, where the element is an identityref.
+	identityModule string
+	// children are the child elements of the element.
+	children []*xmlElement
+}
+
+// xmlChild returns the element within elems with the supplied name and module
+// that has child elements, appending it to elems if it does not exist.
+func xmlChild(elems *[]*xmlElement, name, module string) *xmlElement {
+	for _, c := range *elems {
+		if c.name == name && c.module == module && c.children != nil {
+			return c
+		}
+	}
+	c := &xmlElement{name: name, module: module, children: []*xmlElement{}}
+	*elems = append(*elems, c)
+	return c
+}
+
+// xmlStruct returns the XML elements that correspond to the fields of the
+// GoStruct s, which is defined within the module parentMod.
+func xmlStruct(s GoStruct, parentMod string, cfg *XMLConfig) ([]*xmlElement, error) {
+	var errs errlist.List
+	elems := []*xmlElement{}
+
+	sval := reflect.ValueOf(s).Elem()
+	stype := sval.Type()
+	for i := 0; i < sval.NumField(); i++ {
+		field := sval.Field(i)
+		fType := stype.Field(i)
+		// Metadata annotations have no representation as XML elements.
+		if util.IsYgotAnnotation(fType) {
+			continue
+		}
+
+		mapPaths, err := structTagToLibPaths(fType, newStringSliceGNMIPath([]string{}), cfg.PreferShadowPath)
+		if err != nil {
+			errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+			continue
+		}
+		mapModules, err := structTagToLibModules(fType, cfg.PreferShadowPath)
+		if err != nil {
+			errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+			continue
+		}
+		if mapModules != nil && len(mapModules) != len(mapPaths) {
+			errs.Add(fmt.Errorf("%s: number of paths and modules in struct tag not the same: (paths: %v, modules: %v)", fType.Name, len(mapPaths), len(mapModules)))
+			continue
+		}
+
+		// s is the fake root if its path tag is empty. In this case,
+		// the children of the field are children of s.
+		if len(mapPaths) == 1 && mapPaths[0].Len() == 0 {
+			gs, ok := field.Interface().(GoStruct)
+			if !ok || util.IsValueNil(gs) {
+				continue
+			}
+			ch, err := xmlStruct(gs, parentMod, cfg)
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			elems = append(elems, ch...)
+			continue
+		}
+
+		for j, p := range mapPaths {
+			mods := make([]string, p.Len())
+			for k := range mods {
+				mods[k] = parentMod
+			}
+			if mapModules != nil {
+				if mapModules[j].Len() != p.Len() {
+					errs.Add(fmt.Errorf("%s: number of paths and modules elements not the same: (paths: %v, modules: %v)", fType.Name, p, mapModules[j]))
+					continue
+				}
+				for k := range mods {
+					m, err := mapModules[j].StringElemAt(k)
+					if err != nil {
+						errs.Add(err)
+						continue
+					}
+					mods[k] = rewriteModName(m, cfg.RewriteModuleNames)
+				}
+			}
+
+			name, err := p.LastStringElem()
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			values, err := xmlFieldElements(field, fType, name, mods[len(mods)-1], cfg)
+			if err != nil {
+				errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+				continue
+			}
+			if len(values) == 0 {
+				continue
+			}
+
+			parent := &elems
+			for k := 0; k < p.Len()-1; k++ {
+				n, err := p.StringElemAt(k)
+				if err != nil {
+					errs.Add(err)
+					break
+				}
+				parent = &xmlChild(parent, n, mods[k]).children
+			}
+			*parent = append(*parent, values...)
+		}
+	}
+
+	if errs.Err() != nil {
+		return nil, errs.Err()
+	}
+	return elems, nil
+}
+
+// xmlFieldElements returns the XML elements with the supplied name and module
+// that correspond to the value of the struct field field, whose type is fType.
+// It returns an element for each entry of a list or leaf-list, and no elements
+// where the field is unset.
+func xmlFieldElements(field reflect.Value, fType reflect.StructField, name, mod string, cfg *XMLConfig) ([]*xmlElement, error) {
+	switch field.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
+		if field.IsNil() {
+			return nil, nil
+		}
+	}
+
+	switch {
+	case field.Kind() == reflect.Map:
+		type entry struct {
+			k string
+			v reflect.Value
+		}
+		var entries []entry
+		iter := field.MapRange()
+		for iter.Next() {
+			k, err := mapKeyToJSONString(iter.Key(), jsonOutputConfig{jType: RFC7951})
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry{k: k, v: iter.Value()})
+		}
+		slices.SortFunc(entries, func(a, b entry) int { return strings.Compare(a.k, b.k) })
+
+		var vals []reflect.Value
+		for _, e := range entries {
+			vals = append(vals, e.v)
+		}
+		return xmlListEntries(vals, field.Type().Key(), name, mod, cfg)
+	case field.Kind() == reflect.Ptr && field.Type().Implements(reflect.TypeOf((*GoOrderedMap)(nil)).Elem()):
+		om := field.Interface().(GoOrderedMap)
+		var vals []reflect.Value
+		if err := yreflect.RangeOrderedMap(om, func(_ reflect.Value, v reflect.Value) bool {
+			vals = append(vals, v)
+			return true
+		}); err != nil {
+			return nil, err
+		}
+		kt, err := yreflect.OrderedMapKeyType(om)
+		if err != nil {
+			return nil, err
+		}
+		return xmlListEntries(vals, kt, name, mod, cfg)
+	case util.IsValueStructPtr(field):
+		gs, ok := field.Interface().(GoStruct)
+		if !ok {
+			return nil, fmt.Errorf("cannot map struct %T, invalid GoStruct", field.Interface())
+		}
+		ch, err := xmlStruct(gs, mod, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if len(ch) == 0 && !util.IsYangPresence(fType) {
+			return nil, nil
+		}
+		return []*xmlElement{{name: name, module: mod, children: ch}}, nil
+	case field.Kind() == reflect.Slice && util.IsTypeStructPtr(field.Type().Elem()):
+		// An unkeyed list.
+		var vals []reflect.Value
+		for i := 0; i < field.Len(); i++ {
+			vals = append(vals, field.Index(i))
+		}
+		return xmlListEntries(vals, nil, name, mod, cfg)
+	}
+
+	// The value is that of a leaf or leaf-list, whose RFC7951 JSON
+	// representation is also its XML representation, other than for empty
+	// leaves.
+	value, err := jsonValue(field, mod, jsonOutputConfig{
+		jType:         RFC7951,
+		rfc7951Config: &RFC7951JSONConfig{PrependModuleNameIdentityref: true},
+	})
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	if field.Kind() == reflect.Slice && field.Type().Name() != BinaryTypeName {
+		vals, ok := value.([]any)
+		if !ok || len(vals) != field.Len() {
+			return nil, fmt.Errorf("invalid leaf-list value %v", value)
+		}
+		var elems []*xmlElement
+		for i, v := range vals {
+			elems = append(elems, &xmlElement{
+				name:           name,
+				module:         mod,
+				text:           fmt.Sprint(v),
+				identityModule: xmlIdentityModule(field.Index(i)),
+			})
+		}
+		return elems, nil
+	}
+
+	if v, ok := value.([]any); ok && len(v) == 1 && v[0] == nil {
+		// An empty leaf is represented by an element without content.
+		return []*xmlElement{{name: name, module: mod}}, nil
+	}
+	return []*xmlElement{{
+		name:           name,
+		module:         mod,
+		text:           fmt.Sprint(value),
+		identityModule: xmlIdentityModule(field),
+	}}, nil
+}
+
+// xmlListEntries returns the XML elements with the supplied name and module
+// that correspond to the supplied entries of a list, in order. keyType is the
+// type of the keys of the map storing the list, or nil if it is unkeyed.
+func xmlListEntries(vals []reflect.Value, keyType reflect.Type, name, mod string, cfg *XMLConfig) ([]*xmlElement, error) {
+	var errs errlist.List
+	var elems []*xmlElement
+	for _, v := range vals {
+		gs, ok := v.Interface().(GoStruct)
+		if !ok {
+			errs.Add(fmt.Errorf("cannot map struct %v, invalid GoStruct", v.Interface()))
+			continue
+		}
+		ch, err := xmlStruct(gs, mod, cfg)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		if keyType != nil {
+			keys, err := xmlListKeyNames(gs, keyType)
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			ch = xmlKeysFirst(ch, keys)
+		}
+		elems = append(elems, &xmlElement{name: name, module: mod, children: ch})
+	}
+	if errs.Err() != nil {
+		return nil, errs.Err()
+	}
+	return elems, nil
+}
+
+// xmlListKeyNames returns the names of the keys of the list entry gs, in the
+// order in which they are specified in the list's key statement. keyType is
+// the type of the keys of the map storing the list, whose fields are in the
+// order of the key statement where the list has multiple keys.
+func xmlListKeyNames(gs GoStruct, keyType reflect.Type) ([]string, error) {
+	if keyType.Kind() == reflect.Struct {
+		var keys []string
+		for i := 0; i < keyType.NumField(); i++ {
+			p, ok := keyType.Field(i).Tag.Lookup("path")
+			if !ok {
+				return nil, fmt.Errorf("key field %s of %v has no path tag", keyType.Field(i).Name, keyType)
+			}
+			keys = append(keys, p)
+		}
+		return keys, nil
+	}
+
+	kh, ok := gs.(KeyHelperGoStruct)
+	if !ok {
+		// The key cannot be determined, hence the elements are output
+		// in the order of the fields of the struct.
+		return nil, nil
+	}
+	km, err := kh.ΛListKeyMap()
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for k := range km {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// xmlKeysFirst returns elems with the leaves named by keys moved to the
+// start, in the order of keys, as required by RFC7950 section 7.8.5.
+func xmlKeysFirst(elems []*xmlElement, keys []string) []*xmlElement {
+	out := make([]*xmlElement, 0, len(elems))
+	moved := map[*xmlElement]bool{}
+	for _, k := range keys {
+		for _, e := range elems {
+			if e.name == k && e.children == nil && !moved[e] {
+				out = append(out, e)
+				moved[e] = true
+				break
+			}
+		}
+	}
+	for _, e := range elems {
+		if !moved[e] {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// xmlIdentityModule returns the name of the module that defines the identity
+// that is the value of v, or the empty string if v is not an identityref.
+// v may be an enumerated value, or a union that contains one.
+func xmlIdentityModule(v reflect.Value) string {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct && v.NumField() == 1 {
+		// A union wrapper struct.
+		v = v.Field(0)
+	}
+	if !v.CanInterface() {
+		return ""
+	}
+	e, ok := v.Interface().(GoEnum)
+	if !ok {
+		return ""
+	}
+	return e.ΛMap()[v.Type().Name()][v.Int()].DefiningModule
+}
+
+// xmlWriter writes XML elements to a buffer.
+type xmlWriter struct {
+	buf    bytes.Buffer
+	cfg    *XMLConfig
+	indent string
+}
+
+// namespace returns the XML namespace of the module mod.
+func (w *xmlWriter) namespace(mod string) (string, error) {
+	ns, ok := w.cfg.Namespaces[mod]
+	if !ok {
+		return "", fmt.Errorf("no XML namespace specified for module %s", mod)
+	}
+	return ns, nil
+}
+
+// attr writes an attribute with the supplied name and value.
+func (w *xmlWriter) attr(name, value string) {
+	w.buf.WriteString(" " + name + `="`)
+	xml.EscapeText(&w.buf, []byte(value))
+	w.buf.WriteByte('"')
+}
+
+// element writes the element e, whose parent is defined within the module
+// parentMod, at the supplied depth.
+func (w *xmlWriter) element(e *xmlElement, parentMod string, depth int) error {
+	w.buf.WriteString(strings.Repeat(w.indent, depth))
+	w.buf.WriteString("<" + e.name)
+	if e.module != "" && e.module != parentMod {
+		ns, err := w.namespace(e.module)
+		if err != nil {
+			return err
+		}
+		w.attr("xmlns", ns)
+	}
+	if e.identityModule != "" {
+		ns, err := w.namespace(e.identityModule)
+		if err != nil {
+			return err
+		}
+		// The prefix of the identity is the name of its module, as
+		// per its JSON representation.
+		w.attr("xmlns:"+e.identityModule, ns)
+	}
+
+	switch {
+	case len(e.children) != 0:
+		w.buf.WriteByte('>')
+		for _, c := range e.children {
+			if w.indent != "" {
+				w.buf.WriteByte('\n')
+			}
+			if err := w.element(c, e.module, depth+1); err != nil {
+				return err
+			}
+		}
+		if w.indent != "" {
+			w.buf.WriteByte('\n')
+			w.buf.WriteString(strings.Repeat(w.indent, depth))
+		}
+	case e.text != "":
+		w.buf.WriteByte('>')
+		xml.EscapeText(&w.buf, []byte(e.text))
+	default:
+		w.buf.WriteString("/>")
+		return nil
+	}
+	w.buf.WriteString("</" + e.name + ">")
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/testutil"
+)
+
+// xmlExample is used within TestMarshalXML as a GoStruct.
+type xmlExample struct {
+	Str       *string                        `path:"config/str" module:"foo/foo"`
+	Int32Val  *int32                         `path:"config/int32-val" module:"foo/foo"`
+	Uint64Val *uint64                        `path:"config/uint64-val" module:"foo/foo"`
+	Enum      EnumTest                       `path:"config/enum" module:"foo/foo"`
+	Empty     YANGEmpty                      `path:"config/empty" module:"foo/foo"`
+	LeafList  []string                       `path:"config/leaf-list" module:"foo/foo"`
+	Union     exampleUnion                   `path:"config/union" module:"foo/foo"`
+	Augmented *string                        `path:"config/augmented" module:"foo/bar"`
+	List      map[uint32]*xmlExampleList     `path:"lists/list" module:"foo/foo"`
+	MultiList map[xmlExampleKey]*xmlExample2 `path:"multi-lists/multi-list" module:"foo/foo"`
+	Child     *xmlExampleChild               `path:"child" module:"bar"`
+	Presence  *xmlExampleChild               `path:"presence" module:"foo" yangPresence:"true"`
+}
+
+func (*xmlExample) IsYANGGoStruct()                             {}
+func (*xmlExample) ΛValidate(...ValidationOption) error         { return nil }
+func (*xmlExample) ΛEnumTypeMap() map[string][]reflect.Type     { return nil }
+func (*xmlExample) ΛBelongingModule() string                    { return "foo" }
+func (*xmlExample) To_exampleUnion(i any) (exampleUnion, error) { return nil, nil }
+
+// xmlExampleList is a keyed list within xmlExample.
+type xmlExampleList struct {
+	Value *string `path:"config/value" module:"foo/foo"`
+	Key   *uint32 `path:"config/key|key" module:"foo/foo|foo"`
+}
+
+func (*xmlExampleList) IsYANGGoStruct()                         {}
+func (*xmlExampleList) ΛValidate(...ValidationOption) error     { return nil }
+func (*xmlExampleList) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*xmlExampleList) ΛBelongingModule() string                { return "foo" }
+
+func (t *xmlExampleList) ΛListKeyMap() (map[string]any, error) {
+	return map[string]any{"key": *t.Key}, nil
+}
+
+// xmlExampleKey is the key of the multi-keyed list within xmlExample.
+type xmlExampleKey struct {
+	Name string `path:"name"`
+	Id   uint32 `path:"id"`
+}
+
+// xmlExample2 is a multi-keyed list within xmlExample.
+type xmlExample2 struct {
+	Id   *uint32 `path:"id" module:"foo"`
+	Name *string `path:"name" module:"foo"`
+}
+
+func (*xmlExample2) IsYANGGoStruct()                         {}
+func (*xmlExample2) ΛValidate(...ValidationOption) error     { return nil }
+func (*xmlExample2) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*xmlExample2) ΛBelongingModule() string                { return "foo" }
+
+// xmlExampleChild is a container within xmlExample.
+type xmlExampleChild struct {
+	Val  *string `path:"val" module:"bar"`
+	Text *string `path:"text" module:"foo"`
+}
+
+func (*xmlExampleChild) IsYANGGoStruct()                         {}
+func (*xmlExampleChild) ΛValidate(...ValidationOption) error     { return nil }
+func (*xmlExampleChild) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*xmlExampleChild) ΛBelongingModule() string                { return "bar" }
+
+func TestMarshalXML(t *testing.T) {
+	namespaces := map[string]string{"foo": "urn:foo", "bar": "urn:bar"}
+
+	tests := []struct {
+		desc             string
+		in               GoStruct
+		inArgs           []MarshalXMLArg
+		want             string
+		wantErrSubstring string
+	}{{
+		desc:   "leaves",
+		in:     &xmlExample{Str: String("a<b"), Int32Val: Int32(-42), Uint64Val: Uint64(42)},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}},
+		want:   `<config xmlns="urn:foo"><str>a&lt;b</str><int32-val>-42</int32-val><uint64-val>42</uint64-val></config>`,
+	}, {
+		desc:   "identityref",
+		in:     &xmlExample{Enum: EnumTestVALTWO},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}},
+		want:   `<config xmlns="urn:foo"><enum xmlns:bar="urn:bar">bar:VAL_TWO</enum></config>`,
+	}, {
+		desc:   "identityref within union",
+		in:     &xmlExample{Union: EnumTestVALONE},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}},
+		want:   `<config xmlns="urn:foo"><union xmlns:foo="urn:foo">foo:VAL_ONE</union></config>`,
+	}, {
+		desc:   "union",
+		in:     &xmlExample{Union: testutil.UnionInt64(42)},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}},
+		want:   `<config xmlns="urn:foo"><union>42</union></config>`,
+	}, {
+		desc:   "empty leaf and leaf-list",
+		in:     &xmlExample{Empty: true, LeafList: []string{"b", "a"}},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}},
+		want:   `<config xmlns="urn:foo"><empty/><leaf-list>b</leaf-list><leaf-list>a</leaf-list></config>`,
+	}, {
+		desc:   "augmented leaf",
+		in:     &xmlExample{Augmented: String("x")},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}},
+		want:   `<config xmlns="urn:foo"><augmented xmlns="urn:bar">x</augmented></config>`,
+	}, {
+		desc: "keyed list",
+		in: &xmlExample{List: map[uint32]*xmlExampleList{
+			2: {Key: Uint32(2), Value: String("two")},
+			1: {Key: Uint32(1)},
+		}},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}},
+		want: `<lists xmlns="urn:foo">` +
+			`<list><key>1</key><config><key>1</key></config></list>` +
+			`<list><key>2</key><config><value>two</value><key>2</key></config></list>` +
+			`</lists>`,
+	}, {
+		desc: "multi-keyed list",
+		in: &xmlExample{MultiList: map[xmlExampleKey]*xmlExample2{
+			{Name: "a", Id: 1}: {Id: Uint32(1), Name: String("a")},
+		}},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}},
+		want:   `<multi-lists xmlns="urn:foo"><multi-list><name>a</name><id>1</id></multi-list></multi-lists>`,
+	}, {
+		desc:   "child in other module",
+		in:     &xmlExample{Child: &xmlExampleChild{Val: String("v"), Text: String("t")}},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}},
+		want:   `<child xmlns="urn:bar"><val>v</val><text xmlns="urn:foo">t</text></child>`,
+	}, {
+		desc:   "empty presence container",
+		in:     &xmlExample{Presence: &xmlExampleChild{}, Child: &xmlExampleChild{}},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}},
+		want:   `<presence xmlns="urn:foo"/>`,
+	}, {
+		desc: "rewrite module names",
+		in:   &xmlExample{Augmented: String("x")},
+		inArgs: []MarshalXMLArg{&XMLConfig{
+			Namespaces:         map[string]string{"baz": "urn:baz"},
+			RewriteModuleNames: map[string]string{"foo": "baz", "bar": "baz"},
+		}},
+		want: `<config xmlns="urn:baz"><augmented>x</augmented></config>`,
+	}, {
+		desc:   "indentation",
+		in:     &xmlExample{Str: String("a"), Child: &xmlExampleChild{Val: String("v")}},
+		inArgs: []MarshalXMLArg{&XMLConfig{Namespaces: namespaces}, XMLIndent("  ")},
+		want: "<config xmlns=\"urn:foo\">\n" +
+			"  <str>a</str>\n" +
+			"</config>\n" +
+			"<child xmlns=\"urn:bar\">\n" +
+			"  <val>v</val>\n" +
+			"</child>",
+	}, {
+		desc:             "missing namespace",
+		in:               &xmlExample{Str: String("a")},
+		wantErrSubstring: "no XML namespace specified for module foo",
+	}, {
+		desc:             "nil GoStruct",
+		in:               (*xmlExample)(nil),
+		wantErrSubstring: "cannot marshal nil GoStruct",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := MarshalXML(tt.in, tt.inArgs...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MarshalXML(%v): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("MarshalXML(%v): did not get expected output, diff(-want,+got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// SetRequestOpt is a DiffOpt that specifies how DiffSetRequest renders the
+// differences between the original and modified GoStructs.
+type SetRequestOpt struct {
+	// ReplaceDepth, when positive, specifies that the containers and list
+	// entries that are ReplaceDepth levels of GoStructs below the input
+	// GoStructs, e.g., their direct child containers and the entries of
+	// their direct child lists at depth 1, are replaced in their entirety
+	// where they differ, rather than by updating and deleting their
+	// individual leaves.
+	ReplaceDepth int
+	// ReplacePaths specifies the schema paths of the containers and lists
+	// whose containers and list entries are replaced in their entirety
+	// where they differ, as per ReplaceDepth. They are absolute paths in
+	// which the keys of elements are ignored and "*" matches the name of
+	// any element.
+	ReplacePaths []*gnmipb.Path
+	// JSONIETF specifies that the values of the SetRequest are JSON_IETF
+	// encoded, such that a container or list entry that is replaced is a
+	// single Replace. Otherwise, values are scalar TypedValues, and a
+	// container or list entry that is replaced is rendered as a Delete of
+	// its path along with an Update of each of its leaves.
+	JSONIETF bool
+}
+
+// IsDiffOpt marks SetRequestOpt as a diff option.
+func (*SetRequestOpt) IsDiffOpt() {}
+
+// hasSetRequestOpt returns the first SetRequestOpt from an opts slice, or
+// nil if there isn't one.
+func hasSetRequestOpt(opts []DiffOpt) *SetRequestOpt {
+	for _, o := range opts {
+		if v, ok := o.(*SetRequestOpt); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// setRequestLeaf is a leaf, a replaced subtree, or an ordered map of a
+// GoStruct rendered by DiffSetRequest.
+type setRequestLeaf struct {
+	// path is the absolute path of the leaf.
+	path *gnmiPath
+	// val is the value of the leaf, a *jsonIETFSubtree for a replaced
+	// subtree, or a []*pathval for an ordered map, whose path is that of
+	// the container surrounding it.
+	val any
+}
+
+// DiffSetRequest takes an original and modified GoStruct, which must be of
+// the same type, and returns a gNMI SetRequest that, when applied to
+// original, results in modified, such as by ytypes.UnmarshalSetRequest. The
+// paths of the SetRequest are absolute where the supplied GoStructs are the
+// root of the YANG schema tree, and it does not specify a prefix.
+//
+// By default, the leaves that are set in modified and are not set to the same
+// value in original are Updates, and the leaves that are only set in original
+// are Deletes, as per Diff. The granularity at which subtrees are replaced,
+// and the encoding of values, is specified by a SetRequestOpt. The entries
+// of `ordered-by user` lists, which are atomic, are always replaced in their
+// entirety, in order, where any of them differ.
+//
+// The WithDefaults and IgnoreAdditions DiffOpts are supported.
+func DiffSetRequest(original, modified GoStruct, opts ...DiffOpt) (*gnmipb.SetRequest, error) {
+	if reflect.TypeOf(original) != reflect.TypeOf(modified) {
+		return nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
+	}
+	so := hasSetRequestOpt(opts)
+	if so == nil {
+		so = &SetRequestOpt{}
+	}
+
+	if mode := hasWithDefaults(opts); mode != WithDefaultsExplicit {
+		var err error
+		if original, err = withDefaults(original, mode); err != nil {
+			return nil, fmt.Errorf("could not apply with-defaults mode to original struct: %v", err)
+		}
+		if modified, err = withDefaults(modified, mode); err != nil {
+			return nil, fmt.Errorf("could not apply with-defaults mode to modified struct: %v", err)
+		}
+	}
+
+	cfg := GNMINotificationsConfig{
+		UsePathElem:   true,
+		JSONIETFDepth: so.ReplaceDepth,
+		JSONIETFPaths: so.ReplacePaths,
+	}
+	origLeaves, err := setRequestLeaves(original, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract set leaves from original struct: %v", err)
+	}
+	modLeaves, err := setRequestLeaves(modified, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract set leaves from modified struct: %v", err)
+	}
+
+	var deletes, replaces, updates, orderedUpdates []string
+	for p := range origLeaves {
+		if _, ok := modLeaves[p]; !ok {
+			deletes = append(deletes, p)
+		}
+	}
+	for p, m := range modLeaves {
+		o, ok := origLeaves[p]
+		switch {
+		case !ok && hasIgnoreAdditions(opts) != nil:
+			continue
+		case !ok:
+		default:
+			eq, err := setRequestLeafEqual(o, m)
+			if err != nil {
+				return nil, err
+			}
+			if eq {
+				continue
+			}
+		}
+
+		switch m.val.(type) {
+		case *jsonIETFSubtree:
+			if so.JSONIETF {
+				replaces = append(replaces, p)
+				continue
+			}
+			if ok {
+				deletes = append(deletes, p)
+			}
+			orderedUpdates = append(orderedUpdates, p)
+		case []*pathval:
+			if ok {
+				deletes = append(deletes, p)
+			}
+			orderedUpdates = append(orderedUpdates, p)
+		default:
+			updates = append(updates, p)
+		}
+	}
+	for _, ps := range [][]string{deletes, replaces, updates, orderedUpdates} {
+		sort.Strings(ps)
+	}
+
+	req := &gnmipb.SetRequest{}
+	for _, p := range deletes {
+		dp, err := origOrModLeaf(origLeaves, modLeaves, p).path.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		req.Delete = append(req.Delete, dp)
+	}
+	for _, p := range replaces {
+		l := modLeaves[p]
+		rp, err := l.path.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		req.Replace = append(req.Replace, &gnmipb.Update{Path: rp, Val: l.val.(*jsonIETFSubtree).val})
+	}
+	for _, p := range updates {
+		u, err := setRequestUpdate(modLeaves[p].path, modLeaves[p].val, so.JSONIETF)
+		if err != nil {
+			return nil, err
+		}
+		req.Update = append(req.Update, u)
+	}
+	// The leaves of subtrees and ordered maps are updated in order, such
+	// that the entries of ordered maps are appended in their order.
+	for _, p := range orderedUpdates {
+		us, err := subtreeUpdates(modLeaves[p], so.JSONIETF)
+		if err != nil {
+			return nil, err
+		}
+		req.Update = append(req.Update, us...)
+	}
+	return req, nil
+}
+
+// origOrModLeaf returns the leaf at the path p within the original leaves, or
+// within the modified leaves if it is not set in the original.
+func origOrModLeaf(orig, mod map[string]*setRequestLeaf, p string) *setRequestLeaf {
+	if l, ok := orig[p]; ok {
+		return l
+	}
+	return mod[p]
+}
+
+// setRequestLeaves returns the leaves of s, keyed by the string
+// representation of their path, with the subtrees that cfg specifies are
+// replaced in their entirety as single leaves.
+func setRequestLeaves(s GoStruct, cfg GNMINotificationsConfig) (map[string]*setRequestLeaf, error) {
+	leaves := map[*path]any{}
+	if err := findLeaves(leaves, s, newPathElemGNMIPath(nil), cfg); err != nil {
+		return nil, err
+	}
+	out := map[string]*setRequestLeaf{}
+	for p, v := range leaves {
+		pp, err := p.p.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		ps, err := PathToString(pp)
+		if err != nil {
+			return nil, err
+		}
+		out[ps] = &setRequestLeaf{path: p.p, val: v}
+	}
+	return out, nil
+}
+
+// setRequestLeafEqual reports whether the leaves o and m, which have the
+// same path, have the same value.
+func setRequestLeafEqual(o, m *setRequestLeaf) (bool, error) {
+	switch mv := m.val.(type) {
+	case *jsonIETFSubtree:
+		ov, ok := o.val.(*jsonIETFSubtree)
+		return ok && proto.Equal(ov.val, mv.val), nil
+	case []*pathval:
+		ov, ok := o.val.([]*pathval)
+		if !ok || len(ov) != len(mv) {
+			return false, nil
+		}
+		on, err := createAtomicNotif(ov, 0, o.path)
+		if err != nil {
+			return false, err
+		}
+		mn, err := createAtomicNotif(mv, 0, m.path)
+		if err != nil {
+			return false, err
+		}
+		return proto.Equal(on, mn), nil
+	}
+	return reflect.DeepEqual(o.val, m.val), nil
+}
+
+// subtreeUpdates returns the Updates of each of the leaves of the replaced
+// subtree or ordered map l, in order.
+func subtreeUpdates(l *setRequestLeaf, jsonIETF bool) ([]*gnmipb.Update, error) {
+	var pvs []*pathval
+	switch v := l.val.(type) {
+	case []*pathval:
+		pvs = v
+	case *jsonIETFSubtree:
+		leaves := map[*path]any{}
+		if err := findUpdatedLeaves(leaves, v.s, l.path, false); err != nil {
+			return nil, err
+		}
+		var ps []string
+		byPath := map[string]*pathval{}
+		for p, lv := range leaves {
+			ps = append(ps, p.String())
+			byPath[p.String()] = &pathval{path: p, val: lv}
+		}
+		sort.Strings(ps)
+		for _, p := range ps {
+			pv := byPath[p]
+			// Ordered maps within the subtree are expanded in order.
+			if opvs, ok := pv.val.([]*pathval); ok {
+				pvs = append(pvs, opvs...)
+				continue
+			}
+			pvs = append(pvs, pv)
+		}
+	default:
+		return nil, fmt.Errorf("internal ygot error: %v is not a subtree, got %T", l.path, l.val)
+	}
+
+	var us []*gnmipb.Update
+	for _, pv := range pvs {
+		u, err := setRequestUpdate(pv.path.p, pv.val, jsonIETF)
+		if err != nil {
+			return nil, err
+		}
+		us = append(us, u)
+	}
+	return us, nil
+}
+
+// setRequestUpdate returns the Update of the leaf at path p with value v,
+// whose value is JSON_IETF encoded if jsonIETF is set, or a scalar
+// TypedValue otherwise.
+func setRequestUpdate(p *gnmiPath, v any, jsonIETF bool) (*gnmipb.Update, error) {
+	pp, err := p.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	if !jsonIETF {
+		tv, err := EncodeTypedValue(v, gnmipb.Encoding_PROTO)
+		if err != nil {
+			return nil, fmt.Errorf("cannot represent field value %v as TypedValue for path %v: %v", v, p, err)
+		}
+		return &gnmipb.Update{Path: pp, Val: tv}, nil
+	}
+
+	j, err := jsonValue(reflect.ValueOf(v), "", jsonOutputConfig{
+		jType:         RFC7951,
+		rfc7951Config: &RFC7951JSONConfig{AppendModuleName: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot represent field value %v as JSON for path %v: %v", v, p, err)
+	}
+	js, err := json.Marshal(j)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode JSON for path %v: %v", p, err)
+	}
+	return &gnmipb.Update{
+		Path: pp,
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: js}},
+	}, nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/internal/ytestutil"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestDiffSetRequest(t *testing.T) {
+	strVal := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}
+	}
+	jsonVal := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(s)}}
+	}
+
+	orig := func() *ctestschema.Device {
+		return &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap(t),
+			OtherData:   &ctestschema.OtherData{Motd: ygot.String("hello")},
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": {Key: ygot.String("foo"), Value: ygot.String("foo-val")},
+				"bar": {Key: ygot.String("bar"), Value: ygot.String("bar-val")},
+			},
+		}
+	}
+	mod := func() *ctestschema.Device {
+		om := &ctestschema.OrderedList_OrderedMap{}
+		for _, k := range []string{"bar", "foo"} {
+			v, err := om.AppendNew(k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.Value = ygot.String(k + "-val")
+		}
+		return &ctestschema.Device{
+			OrderedList: om,
+			OtherData:   &ctestschema.OtherData{Motd: ygot.String("world")},
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": {Key: ygot.String("foo")},
+				"baz": {Key: ygot.String("baz"), Value: ygot.String("baz-val")},
+			},
+		}
+	}
+
+	orderedUpdates := func(enc func(string) *gnmipb.TypedValue, quote bool) []*gnmipb.Update {
+		v := func(s string) *gnmipb.TypedValue {
+			if quote {
+				s = `"` + s + `"`
+			}
+			return enc(s)
+		}
+		var us []*gnmipb.Update
+		for _, k := range []string{"bar", "foo"} {
+			us = append(us, &gnmipb.Update{
+				Path: mustPath("ordered-lists/ordered-list[key=" + k + "]/config/key"),
+				Val:  v(k),
+			}, &gnmipb.Update{
+				Path: mustPath("ordered-lists/ordered-list[key=" + k + "]/key"),
+				Val:  v(k),
+			}, &gnmipb.Update{
+				Path: mustPath("ordered-lists/ordered-list[key=" + k + "]/config/value"),
+				Val:  v(k + "-val"),
+			})
+		}
+		return us
+	}
+
+	tests := []struct {
+		desc    string
+		inOrig  ygot.GoStruct
+		inMod   ygot.GoStruct
+		inOpts  []ygot.DiffOpt
+		want    *gnmipb.SetRequest
+		wantErr string
+	}{{
+		desc:   "leaf-level",
+		inOrig: orig(),
+		inMod:  mod(),
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{
+				mustPath("ordered-lists"),
+				mustPath("unordered-lists/unordered-list[key=bar]/config/key"),
+				mustPath("unordered-lists/unordered-list[key=bar]/config/value"),
+				mustPath("unordered-lists/unordered-list[key=bar]/key"),
+				mustPath("unordered-lists/unordered-list[key=foo]/config/value"),
+			},
+			Update: append([]*gnmipb.Update{{
+				Path: mustPath("other-data/config/motd"),
+				Val:  strVal("world"),
+			}, {
+				Path: mustPath("unordered-lists/unordered-list[key=baz]/config/key"),
+				Val:  strVal("baz"),
+			}, {
+				Path: mustPath("unordered-lists/unordered-list[key=baz]/config/value"),
+				Val:  strVal("baz-val"),
+			}, {
+				Path: mustPath("unordered-lists/unordered-list[key=baz]/key"),
+				Val:  strVal("baz"),
+			}}, orderedUpdates(strVal, false)...),
+		},
+	}, {
+		desc:   "replace list entries with JSON_IETF",
+		inOrig: orig(),
+		inMod:  mod(),
+		inOpts: []ygot.DiffOpt{&ygot.SetRequestOpt{
+			ReplacePaths: []*gnmipb.Path{mustPath("unordered-lists/unordered-list")},
+			JSONIETF:     true,
+		}},
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{
+				mustPath("ordered-lists"),
+				mustPath("unordered-lists/unordered-list[key=bar]"),
+			},
+			Replace: []*gnmipb.Update{{
+				Path: mustPath("unordered-lists/unordered-list[key=baz]"),
+				Val:  jsonVal(`{"ctestschema:config":{"key":"baz","value":"baz-val"},"ctestschema:key":"baz"}`),
+			}, {
+				Path: mustPath("unordered-lists/unordered-list[key=foo]"),
+				Val:  jsonVal(`{"ctestschema:config":{"key":"foo"},"ctestschema:key":"foo"}`),
+			}},
+			Update: append([]*gnmipb.Update{{
+				Path: mustPath("other-data/config/motd"),
+				Val:  jsonVal(`"world"`),
+			}}, orderedUpdates(jsonVal, true)...),
+		},
+	}, {
+		desc:   "replace containers and list entries with scalar values",
+		inOrig: orig(),
+		inMod:  mod(),
+		inOpts: []ygot.DiffOpt{&ygot.SetRequestOpt{ReplaceDepth: 1}},
+	}, {
+		desc:   "replace with JSON_IETF at depth",
+		inOrig: orig(),
+		inMod:  mod(),
+		inOpts: []ygot.DiffOpt{&ygot.SetRequestOpt{ReplaceDepth: 1, JSONIETF: true}},
+	}, {
+		desc:   "from empty struct",
+		inOrig: &ctestschema.Device{},
+		inMod:  mod(),
+		inOpts: []ygot.DiffOpt{&ygot.SetRequestOpt{ReplaceDepth: 1, JSONIETF: true}},
+	}, {
+		desc:   "to empty struct",
+		inOrig: orig(),
+		inMod:  &ctestschema.Device{},
+		inOpts: []ygot.DiffOpt{&ygot.SetRequestOpt{ReplaceDepth: 1}},
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{
+				mustPath("ordered-lists"),
+				mustPath("other-data"),
+				mustPath("unordered-lists/unordered-list[key=bar]"),
+				mustPath("unordered-lists/unordered-list[key=foo]"),
+			},
+		},
+	}, {
+		desc:   "no differences",
+		inOrig: orig(),
+		inMod:  orig(),
+		inOpts: []ygot.DiffOpt{&ygot.SetRequestOpt{ReplaceDepth: 1, JSONIETF: true}},
+		want:   &gnmipb.SetRequest{},
+	}, {
+		desc:    "different types",
+		inOrig:  orig(),
+		inMod:   &ctestschema.OtherData{},
+		wantErr: "cannot diff structs of different types",
+	}, {
+		desc:    "invalid depth",
+		inOrig:  orig(),
+		inMod:   mod(),
+		inOpts:  []ygot.DiffOpt{&ygot.SetRequestOpt{ReplaceDepth: -1}},
+		wantErr: "invalid JSON_IETF depth -1",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.DiffSetRequest(tt.inOrig, tt.inMod, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("DiffSetRequest: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if tt.want != nil {
+				if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+					t.Errorf("DiffSetRequest (-want, +got):\n%s", diff)
+				}
+			}
+
+			// The SetRequest must unmarshal into original to reproduce
+			// modified.
+			schema, err := ctestschema.Schema()
+			if err != nil {
+				t.Fatalf("cannot get schema: %v", err)
+			}
+			schema.Root = tt.inOrig
+			if err := ytypes.UnmarshalSetRequest(schema, got); err != nil {
+				t.Fatalf("UnmarshalSetRequest: %v", err)
+			}
+			if diff := cmp.Diff(tt.inMod, schema.Root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Errorf("UnmarshalSetRequest (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Namespaces of the items of a YANG SID file, as defined in RFC 9595.
+const (
+	// SIDNamespaceModule is the namespace of the SIDs of modules and
+	// submodules.
+	SIDNamespaceModule = "module"
+	// SIDNamespaceIdentity is the namespace of the SIDs of identities.
+	SIDNamespaceIdentity = "identity"
+	// SIDNamespaceFeature is the namespace of the SIDs of features.
+	SIDNamespaceFeature = "feature"
+	// SIDNamespaceData is the namespace of the SIDs of data nodes.
+	SIDNamespaceData = "data"
+)
+
+// SIDItem is an item of a YANG SID file, which assigns a YANG Schema Item
+// iDentifier (SID) to a YANG item.
+type SIDItem struct {
+	// Namespace is the namespace of the item, e.g., SIDNamespaceData.
+	Namespace string
+	// Identifier identifies the item within its namespace. Data nodes are
+	// identified by their absolute schema node identifier, in which the
+	// name of each node is prefixed with the name of its module where it
+	// differs from that of its parent, e.g., /ietf-system:system/clock.
+	// Identities are identified by the name of their module and their
+	// name, separated by a colon.
+	Identifier string
+	// SID is the SID that is assigned to the item.
+	SID uint64
+}
+
+// SIDFile is a YANG SID file, as defined in RFC 9595, which lists the SIDs
+// assigned to the items of a YANG module.
+type SIDFile struct {
+	// ModuleName is the name of the module that the SID file is for.
+	ModuleName string
+	// ModuleRevision is the revision of the module that the SID file is
+	// for.
+	ModuleRevision string
+	// Items are the SIDs assigned to the items of the module.
+	Items []*SIDItem
+}
+
+// sidFileJSON is the JSON representation of a SID file.
+type sidFileJSON struct {
+	ModuleName     string `json:"module-name"`
+	ModuleRevision string `json:"module-revision"`
+	Item           []struct {
+		Namespace  string          `json:"namespace"`
+		Identifier string          `json:"identifier"`
+		SID        json.RawMessage `json:"sid"`
+	} `json:"item"`
+}
+
+// ParseSIDFile parses the JSON encoded YANG SID file data, as defined in
+// RFC 9595. The contents of the file may be wrapped in the
+// "ietf-sid-file:sid-file" container. Where the identifier of an identity
+// is not prefixed with the name of its module, the name of the module of
+// the SID file is prepended.
+func ParseSIDFile(data []byte) (*SIDFile, error) {
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("invalid SID file: %v", err)
+	}
+	if c, ok := wrapper["ietf-sid-file:sid-file"]; ok {
+		data = c
+	}
+
+	var sf sidFileJSON
+	if err := json.Unmarshal(data, &sf); err != nil {
+		return nil, fmt.Errorf("invalid SID file: %v", err)
+	}
+	if sf.ModuleName == "" {
+		return nil, fmt.Errorf("invalid SID file: no module-name specified")
+	}
+
+	f := &SIDFile{ModuleName: sf.ModuleName, ModuleRevision: sf.ModuleRevision}
+	for _, i := range sf.Item {
+		// SIDs are uint64 values, which are strings in RFC7951 JSON, but
+		// are also accepted as numbers.
+		sid, err := strconv.ParseUint(strings.Trim(string(i.SID), `"`), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SID file: invalid SID %s for %s: %v", i.SID, i.Identifier, err)
+		}
+		id := i.Identifier
+		if i.Namespace == SIDNamespaceIdentity && !strings.Contains(id, ":") {
+			id = fmt.Sprintf("%s:%s", sf.ModuleName, id)
+		}
+		f.Items = append(f.Items, &SIDItem{Namespace: i.Namespace, Identifier: id, SID: sid})
+	}
+	return f, nil
+}
+
+// SIDMap maps the identifiers of YANG items to their SIDs, and vice versa,
+// for the items listed in a set of SID files.
+type SIDMap struct {
+	// items maps a SID to the item it is assigned to.
+	items map[uint64]*SIDItem
+	// sids maps the namespace and identifier of an item to its SID.
+	sids map[string]map[string]uint64
+}
+
+// NewSIDMap returns a SIDMap containing the items of the supplied SID files.
+// It returns an error if a SID is assigned to more than one item, or an item
+// is assigned more than one SID.
+func NewSIDMap(files ...*SIDFile) (*SIDMap, error) {
+	m := &SIDMap{items: map[uint64]*SIDItem{}, sids: map[string]map[string]uint64{}}
+	for _, f := range files {
+		for _, i := range f.Items {
+			if e, ok := m.items[i.SID]; ok && (e.Namespace != i.Namespace || e.Identifier != i.Identifier) {
+				return nil, fmt.Errorf("SID %d is assigned to both %s %s and %s %s", i.SID, e.Namespace, e.Identifier, i.Namespace, i.Identifier)
+			}
+			if m.sids[i.Namespace] == nil {
+				m.sids[i.Namespace] = map[string]uint64{}
+			}
+			if sid, ok := m.sids[i.Namespace][i.Identifier]; ok && sid != i.SID {
+				return nil, fmt.Errorf("%s %s is assigned both SID %d and %d", i.Namespace, i.Identifier, sid, i.SID)
+			}
+			m.items[i.SID] = i
+			m.sids[i.Namespace][i.Identifier] = i.SID
+		}
+	}
+	return m, nil
+}
+
+// SID returns the SID of the item with the given namespace and identifier,
+// and true if it is found.
+func (m *SIDMap) SID(namespace, identifier string) (uint64, bool) {
+	sid, ok := m.sids[namespace][identifier]
+	return sid, ok
+}
+
+// Item returns the item that is assigned the SID sid, or nil if there is no
+// such item.
+func (m *SIDMap) Item(sid uint64) *SIDItem {
+	return m.items[sid]
+}
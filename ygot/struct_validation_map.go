@@ -32,6 +32,8 @@ import (
 	"github.com/openconfig/gnmi/errlist"
 	"github.com/openconfig/ygot/internal/yreflect"
 	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 const (
@@ -572,6 +574,119 @@ type MergeEmptyMaps struct{}
 // IsMergeOpt marks MergeEmptyMaps as a MergeOpt.
 func (*MergeEmptyMaps) IsMergeOpt() {}
 
+// ListMergeStrategy specifies how the contents of a list or leaf-list that is
+// populated in the source struct are merged into the destination struct.
+type ListMergeStrategy int
+
+const (
+	// ListMergeByKey specifies that the entries of keyed lists are merged
+	// by key, where the contents of the entries that are present in both
+	// are merged. The entries of `ordered-by user` lists are merged by key
+	// where their order does not contradict, and an error is returned
+	// otherwise. The values of leaf-lists are concatenated, and an error is
+	// returned where they overlap but are not equal. It is the default
+	// strategy.
+	ListMergeByKey ListMergeStrategy = iota
+	// ListMergeReplace specifies that the list or leaf-list in the
+	// destination is replaced with that in the source.
+	ListMergeReplace
+	// ListMergeUnion specifies that the values of leaf-lists that are not
+	// present in the destination are appended to it, such that it does not
+	// contain duplicates. The entries of keyed lists are merged by key, and
+	// the entries of `ordered-by user` lists are merged by key, where the
+	// entries that are not present in the destination are appended to it,
+	// irrespective of the order of those that are.
+	ListMergeUnion
+	// ListMergeAppend specifies that the values of leaf-lists in the source
+	// are appended to those in the destination, even where they are
+	// duplicated. The entries of keyed and `ordered-by user` lists are
+	// appended, and an error is returned where an entry is present in both.
+	ListMergeAppend
+)
+
+// MergeListStrategy is a MergeOpt that specifies the ListMergeStrategy of
+// MergeStructs and MergeStructInto for a set of lists and leaf-lists, which
+// are matched by their schema path or their type. Where more than one
+// MergeListStrategy matches a list or leaf-list, the last one supplied is
+// used, and where none match, ListMergeByKey is used.
+type MergeListStrategy struct {
+	// Paths are the schema paths of the lists and leaf-lists, as per the
+	// path tags of the fields of the GoStructs. They are absolute paths,
+	// where the GoStructs being merged are the root, in which the keys of
+	// elements are ignored and "*" matches the name of any element.
+	Paths []*gnmipb.Path
+	// Types are the types of the lists and leaf-lists, which match either
+	// the type of the field of a GoStruct, such as []string for a leaf-list,
+	// or the type of the entries of a list, such as *oc.Interface.
+	Types []reflect.Type
+	// Strategy is the strategy used for the matched lists and leaf-lists.
+	Strategy ListMergeStrategy
+}
+
+// IsMergeOpt marks MergeListStrategy as a MergeOpt.
+func (*MergeListStrategy) IsMergeOpt() {}
+
+// matches reports whether m matches the list or leaf-list field of type t,
+// whose schema path is p. p is nil where the schema paths of fields are not
+// tracked.
+func (m *MergeListStrategy) matches(p *gnmiPath, t reflect.Type) bool {
+	et := listEntryType(t)
+	for _, mt := range m.Types {
+		if mt == t || et != nil && mt == et {
+			return true
+		}
+	}
+	if p == nil {
+		return false
+	}
+	for _, mp := range m.Paths {
+		if pathElemNamesMatch(p.pathElemPath, mp.GetElem()) {
+			return true
+		}
+	}
+	return false
+}
+
+// listEntryType returns the type of the entries of the keyed or
+// `ordered-by user` list field of type t, or nil if t is not a list.
+func listEntryType(t reflect.Type) reflect.Type {
+	switch {
+	case t.Kind() == reflect.Map:
+		return t.Elem()
+	case t.Implements(reflect.TypeOf((*GoOrderedMap)(nil)).Elem()):
+		et, err := yreflect.UnaryMethodArgType(t, "Append")
+		if err != nil {
+			return nil
+		}
+		return et
+	}
+	return nil
+}
+
+// listMergeStrategy returns the ListMergeStrategy specified by opts for the
+// list or leaf-list field of type t, whose schema path is p.
+func listMergeStrategy(opts []MergeOpt, p *gnmiPath, t reflect.Type) ListMergeStrategy {
+	s := ListMergeByKey
+	for _, o := range opts {
+		if m, ok := o.(*MergeListStrategy); ok && m.matches(p, t) {
+			s = m.Strategy
+		}
+	}
+	return s
+}
+
+// mergeSchemaRoot returns the schema path of the root of the structs being
+// merged, which is nil where no MergeListStrategy matches by path, such that
+// the schema paths of fields are not tracked.
+func mergeSchemaRoot(opts []MergeOpt) *gnmiPath {
+	for _, o := range opts {
+		if m, ok := o.(*MergeListStrategy); ok && len(m.Paths) != 0 {
+			return newPathElemGNMIPath(nil)
+		}
+	}
+	return nil
+}
+
 // MergeStructs takes two input GoStruct and merges their contents,
 // returning a new GoStruct. If the input structs a and b are of
 // different types, an error is returned.
@@ -580,7 +695,8 @@ func (*MergeEmptyMaps) IsMergeOpt() {}
 // merge is skipped if their contents are equal, and their contents are merged
 // if unequal; however, an error is returned for slices if their elements are
 // overlapping but not equal. If a leaf is populated in both a and b, an error
-// is returned if the value of the leaf is not equal.
+// is returned if the value of the leaf is not equal. The merge semantics of
+// lists and leaf-lists can be specified by MergeListStrategy options.
 func MergeStructs(a, b GoStruct, opts ...MergeOpt) (GoStruct, error) {
 	if reflect.TypeOf(a) != reflect.TypeOf(b) {
 		return nil, fmt.Errorf("cannot merge structs that are not of matching types, %T != %T", a, b)
@@ -607,7 +723,7 @@ func MergeStructInto(dst, src GoStruct, opts ...MergeOpt) error {
 		return fmt.Errorf("cannot merge structs that are not of matching types, %T != %T", dst, src)
 	}
 
-	return copyStruct(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem(), "", opts...)
+	return copyStruct(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem(), "", mergeSchemaRoot(opts), opts...)
 }
 
 // DeepCopy returns a deep copy of the supplied GoStruct. A new copy
@@ -629,7 +745,7 @@ func deepCopy(s GoStruct, keepEmptyMaps bool) (GoStruct, error) {
 	if keepEmptyMaps {
 		opts = append(opts, &MergeEmptyMaps{})
 	}
-	if err := copyStruct(n.Elem(), reflect.ValueOf(s).Elem(), "", opts...); err != nil {
+	if err := copyStruct(n.Elem(), reflect.ValueOf(s).Elem(), "", nil, opts...); err != nil {
 		return nil, fmt.Errorf("cannot DeepCopy struct: %v", err)
 	}
 	return n.Interface().(GoStruct), nil
@@ -664,9 +780,12 @@ func mergeEmptyMapsEnabled(opts []MergeOpt) bool {
 // - accessPath is the programmatic access path to the struct. It is used for
 // generating a more usable error message. (e.g. Field1.Map2["foo"].Field3)
 // When calling at the top level, "" should be used.
+// - schemaPath is the schema path of the struct, which is used to determine
+// the MergeListStrategy of its lists. It is nil where schema paths are not
+// tracked, as per mergeSchemaRoot.
 //
 // It fails-slow: accumulates errors prior to return.
-func copyStruct(dstVal, srcVal reflect.Value, accessPath string, opts ...MergeOpt) error {
+func copyStruct(dstVal, srcVal reflect.Value, accessPath string, schemaPath *gnmiPath, opts ...MergeOpt) error {
 	if srcVal.Type() != dstVal.Type() {
 		return fmt.Errorf("cannot copy %s to %s", srcVal.Type().Name(), dstVal.Type().Name())
 	}
@@ -682,20 +801,30 @@ func copyStruct(dstVal, srcVal reflect.Value, accessPath string, opts ...MergeOp
 		dstField := dstVal.Field(i)
 		accessPath := accessPath + "." + srcVal.Type().Field(i).Name
 
+		var fieldPath *gnmiPath
+		if ft := srcVal.Type().Field(i); schemaPath != nil && !util.IsYgotAnnotation(ft) {
+			fps, err := structTagToLibPaths(ft, schemaPath, false)
+			if err != nil {
+				errs.Add(fmt.Errorf("%s: %v", accessPath, err))
+				continue
+			}
+			fieldPath = fps[0]
+		}
+
 		orderedMap, isOrderedMap := srcField.Interface().(GoOrderedMap)
 		switch srcField.Kind() {
 		case reflect.Ptr:
 			if isOrderedMap {
-				errs.Add(copyOrderedMap(dstField, orderedMap, accessPath, opts...))
+				errs.Add(copyOrderedMap(dstField, orderedMap, accessPath, fieldPath, opts...))
 			} else {
-				errs.Add(copyPtrField(dstField, srcField, accessPath, opts...))
+				errs.Add(copyPtrField(dstField, srcField, accessPath, fieldPath, opts...))
 			}
 		case reflect.Interface:
 			errs.Add(copyInterfaceField(dstField, srcField, accessPath, opts...))
 		case reflect.Map:
-			errs.Add(copyMapField(dstField, srcField, accessPath, opts...))
+			errs.Add(copyMapField(dstField, srcField, accessPath, fieldPath, opts...))
 		case reflect.Slice:
-			errs.Add(copySliceField(dstField, srcField, accessPath, opts...))
+			errs.Add(copySliceField(dstField, srcField, accessPath, fieldPath, opts...))
 		case reflect.Int64:
 			// In the case of an int64 field, which represents a YANG enumeration
 			// we should only set the value in the destination if it is not set
@@ -725,7 +854,7 @@ func copyStruct(dstVal, srcVal reflect.Value, accessPath string, opts ...MergeOp
 // is returned. If the source and destination both have a pointer field, which is
 // populated then an error is returned unless the value of the field is
 // equal in both structs.
-func copyPtrField(dstField, srcField reflect.Value, accessPath string, opts ...MergeOpt) error {
+func copyPtrField(dstField, srcField reflect.Value, accessPath string, schemaPath *gnmiPath, opts ...MergeOpt) error {
 
 	if util.IsNilOrInvalidValue(srcField) {
 		return nil
@@ -748,7 +877,7 @@ func copyPtrField(dstField, srcField reflect.Value, accessPath string, opts ...M
 			d = dstField
 		}
 
-		if err := copyStruct(d.Elem(), srcField.Elem(), accessPath, opts...); err != nil {
+		if err := copyStruct(d.Elem(), srcField.Elem(), accessPath, schemaPath, opts...); err != nil {
 			return err
 		}
 		dstField.Set(d)
@@ -791,7 +920,7 @@ func copyInterfaceField(dstField, srcField reflect.Value, accessPath string, opt
 		}
 
 		d := reflect.New(s.Type())
-		if err := copyStruct(d.Elem(), s, accessPath, opts...); err != nil {
+		if err := copyStruct(d.Elem(), s, accessPath, nil, opts...); err != nil {
 			return err
 		}
 		dstField.Set(d)
@@ -828,8 +957,10 @@ func copyInterfaceField(dstField, srcField reflect.Value, accessPath string, opt
 // reflect.Value structs which contain a map value. If both srcField and dstField
 // are populated, and have non-overlapping keys, they are merged. If the same
 // key is populated in srcField and dstField, their contents are merged if they
-// do not overlap, otherwise an error is returned.
-func copyMapField(dstField, srcField reflect.Value, accessPath string, opts ...MergeOpt) error {
+// do not overlap, otherwise an error is returned. The ListMergeStrategy of the
+// map, whose schema path is schemaPath, may specify that it is replaced
+// instead, or that keys populated in both are an error.
+func copyMapField(dstField, srcField reflect.Value, accessPath string, schemaPath *gnmiPath, opts ...MergeOpt) error {
 	if !util.IsValueMap(srcField) {
 		return fmt.Errorf("received a non-map type in src map field: %v", srcField.Kind())
 	}
@@ -851,7 +982,8 @@ func copyMapField(dstField, srcField reflect.Value, accessPath string, opts ...M
 		return err
 	}
 
-	if dstField.Len() == 0 {
+	strategy := listMergeStrategy(opts, schemaPath, dstField.Type())
+	if dstField.Len() == 0 || strategy == ListMergeReplace && srcField.Len() != 0 {
 		dstField.Set(reflect.MakeMapWithSize(reflect.MapOf(m.key, m.value), srcField.Len()))
 	}
 
@@ -870,9 +1002,13 @@ func copyMapField(dstField, srcField reflect.Value, accessPath string, opts ...M
 		}
 		d := reflect.New(v.Elem().Type())
 		if _, ok := dstKeys[k.Interface()]; ok {
+			if strategy == ListMergeAppend {
+				errs.Add(fmt.Errorf("%s: map key %v was present in both src and dst when appending", accessPath, k.Interface()))
+				continue
+			}
 			d = dstField.MapIndex(k)
 		}
-		if err := copyStruct(d.Elem(), v.Elem(), fmt.Sprintf("%s[%#v]", accessPath, k.Interface()), opts...); err != nil {
+		if err := copyStruct(d.Elem(), v.Elem(), fmt.Sprintf("%s[%#v]", accessPath, k.Interface()), schemaPath, opts...); err != nil {
 			errs.Add(err)
 			continue
 		}
@@ -971,8 +1107,11 @@ func orderedMapKeysMergeable(dstOrderedMap, srcOrderedMap GoOrderedMap) error {
 // keys, then the keys in the src are appended to the dst. If there are
 // overlapping values, then if src is a subset of dst and is in the same order,
 // merge is done; otherwise an error is returned since the behaviour is not
-// well-defined.
-func copyOrderedMap(dstField reflect.Value, srcOrderedMap GoOrderedMap, accessPath string, opts ...MergeOpt) error {
+// well-defined. The ListMergeStrategy of the ordered map, whose schema path
+// is schemaPath, may specify that it is replaced instead, that the entries
+// that are not in dst are appended irrespective of order, or that keys
+// populated in both are an error.
+func copyOrderedMap(dstField reflect.Value, srcOrderedMap GoOrderedMap, accessPath string, schemaPath *gnmiPath, opts ...MergeOpt) error {
 	dstOrderedMap, dstIsOrderedMap := dstField.Interface().(GoOrderedMap)
 	srcField := reflect.ValueOf(srcOrderedMap)
 	if dstType, srcType := srcField.Type(), dstField.Type(); dstType != srcType || !dstIsOrderedMap {
@@ -987,13 +1126,16 @@ func copyOrderedMap(dstField reflect.Value, srcOrderedMap GoOrderedMap, accessPa
 		}
 	}
 
-	if dstOrderedMap.Len() == 0 {
+	strategy := listMergeStrategy(opts, schemaPath, dstField.Type())
+	if dstOrderedMap.Len() == 0 || strategy == ListMergeReplace && srcOrderedMap.Len() != 0 {
 		dstField.Set(reflect.New(dstField.Type().Elem()))
 		dstOrderedMap = dstField.Interface().(GoOrderedMap)
 	}
 
-	if err := orderedMapKeysMergeable(dstOrderedMap, srcOrderedMap); err != nil {
-		return err
+	if strategy == ListMergeByKey {
+		if err := orderedMapKeysMergeable(dstOrderedMap, srcOrderedMap); err != nil {
+			return err
+		}
 	}
 
 	elemType, err := yreflect.OrderedMapElementType(dstOrderedMap)
@@ -1012,11 +1154,14 @@ func copyOrderedMap(dstField reflect.Value, srcOrderedMap GoOrderedMap, accessPa
 		switch {
 		case !ok:
 			d = reflect.New(elemType.Elem())
+		case strategy == ListMergeAppend:
+			errs.Add(fmt.Errorf("%s: ordered map key %v was present in both src and dst when appending", accessPath, k.Interface()))
+			return true
 		case d.IsZero():
 			errs.Add(fmt.Errorf("dst ordered map has a key whose value is nil: %v", k.Interface()))
 			return true
 		}
-		if err := copyStruct(d.Elem(), v.Elem(), fmt.Sprintf("%s[%#v]", accessPath, k.Interface()), opts...); err != nil {
+		if err := copyStruct(d.Elem(), v.Elem(), fmt.Sprintf("%s[%#v]", accessPath, k.Interface()), schemaPath, opts...); err != nil {
 			errs.Add(err)
 			return true
 		}
@@ -1035,13 +1180,27 @@ func copyOrderedMap(dstField reflect.Value, srcOrderedMap GoOrderedMap, accessPa
 
 // copySliceField copies srcField into dstField. Both srcField and dstField
 // must have a kind of reflect.Slice kind and contain pointers to structs. If
-// the slice in dstField is populated an error is returned.
-func copySliceField(dstField, srcField reflect.Value, accessPath string, opts ...MergeOpt) error {
+// the slice in dstField is populated an error is returned. The ListMergeStrategy
+// of the slice, whose schema path is schemaPath, may specify that it is
+// replaced instead, or that its values are merged without duplicates, or
+// appended irrespective of duplicates.
+func copySliceField(dstField, srcField reflect.Value, accessPath string, schemaPath *gnmiPath, opts ...MergeOpt) error {
 	if dstField.Len() == 0 && srcField.Len() == 0 {
 		return nil
 	}
 
-	if _, ok := srcField.Interface().([]Annotation); !ok {
+	_, isAnnotation := srcField.Interface().([]Annotation)
+	switch strategy := listMergeStrategy(opts, schemaPath, dstField.Type()); {
+	case isAnnotation:
+	case strategy == ListMergeReplace:
+		if srcField.Len() == 0 {
+			return nil
+		}
+		dstField.Set(reflect.Zero(dstField.Type()))
+	case strategy == ListMergeUnion:
+		srcField = sliceDifference(srcField, dstField)
+	case strategy == ListMergeAppend:
+	default:
 		if reflect.DeepEqual(srcField.Interface(), dstField.Interface()) {
 			return nil
 		}
@@ -1070,7 +1229,7 @@ func copySliceField(dstField, srcField reflect.Value, accessPath string, opts ..
 	for i := 0; i < srcField.Len(); i++ {
 		v := srcField.Index(i)
 		d := reflect.New(v.Type().Elem())
-		if err := copyStruct(d.Elem(), v.Elem(), fmt.Sprintf("%s[%v]", accessPath, i), opts...); err != nil {
+		if err := copyStruct(d.Elem(), v.Elem(), fmt.Sprintf("%s[%v]", accessPath, i), schemaPath, opts...); err != nil {
 			errs.Add(err)
 			continue
 		}
@@ -1079,6 +1238,26 @@ func copySliceField(dstField, srcField reflect.Value, accessPath string, opts ..
 	return errs.Err()
 }
 
+// sliceDifference returns the values of the slice a that are not within the
+// slice b, without duplicates.
+func sliceDifference(a, b reflect.Value) reflect.Value {
+	out := reflect.MakeSlice(a.Type(), 0, a.Len())
+	contains := func(s reflect.Value, v any) bool {
+		for i := 0; i < s.Len(); i++ {
+			if reflect.DeepEqual(s.Index(i).Interface(), v) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if v := a.Index(i).Interface(); !contains(b, v) && !contains(out, v) {
+			out = reflect.Append(out, a.Index(i))
+		}
+	}
+	return out
+}
+
 // uniqueSlices takes two reflect.Values which must represent slices, and determines
 // whether a and b are disjoint. It returns true if the slices have unique
 // members, and false if not.
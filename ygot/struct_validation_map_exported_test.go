@@ -30,6 +30,8 @@ import (
 	"github.com/openconfig/ygot/internal/ytestutil"
 	"github.com/openconfig/ygot/testutil"
 	"github.com/openconfig/ygot/ygot"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 const (
@@ -699,6 +701,49 @@ func TestMergeStructsOrderedMap(t *testing.T) {
 			}(),
 		},
 		wantErrSubstr: "src ordered map partially overlaps with dst ordered map -- merge behaviour is not well defined",
+	}, {
+		name: "union of partially overlapping ordered lists",
+		inA: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap(t),
+		},
+		inB: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMapLonger(t),
+		},
+		inOpts: []ygot.MergeOpt{&ygot.MergeListStrategy{
+			Paths:    []*gnmipb.Path{mustPath("ordered-lists/ordered-list")},
+			Strategy: ygot.ListMergeUnion,
+		}},
+		want: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMapLonger(t),
+		},
+	}, {
+		name: "replace ordered list by entry type",
+		inA: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap(t),
+		},
+		inB: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap2(t),
+		},
+		inOpts: []ygot.MergeOpt{&ygot.MergeListStrategy{
+			Types:    []reflect.Type{reflect.TypeOf(&ctestschema.OrderedList{})},
+			Strategy: ygot.ListMergeReplace,
+		}},
+		want: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap2(t),
+		},
+	}, {
+		name: "append ordered list with overlapping keys",
+		inA: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap(t),
+		},
+		inB: &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMapLonger(t),
+		},
+		inOpts: []ygot.MergeOpt{&ygot.MergeListStrategy{
+			Types:    []reflect.Type{reflect.TypeOf(&ctestschema.OrderedList_OrderedMap{})},
+			Strategy: ygot.ListMergeAppend,
+		}},
+		wantErrSubstr: "ordered map key foo was present in both src and dst when appending",
 	}}
 
 	for _, tt := range tests {
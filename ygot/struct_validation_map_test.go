@@ -1132,7 +1132,7 @@ func TestCopyStructError(t *testing.T) {
 	}}
 
 	for _, tt := range tests {
-		if err := copyStruct(tt.inA, tt.inB, ""); err == nil {
+		if err := copyStruct(tt.inA, tt.inB, "", nil); err == nil {
 			t.Errorf("%s: copyStruct(%v, %v): did not get nil error, got: %v, want: nil", tt.name, tt.inA, tt.inB, err)
 		}
 	}
@@ -1635,7 +1635,7 @@ func TestCopyStruct(t *testing.T) {
 			wantDst = reflect.ValueOf(tt.wantDst).Elem()
 		}
 
-		err := copyStruct(dst, src, "", tt.inOpts...)
+		err := copyStruct(dst, src, "", nil, tt.inOpts...)
 		if (err != nil) != tt.wantErr {
 			t.Fatalf("%s: copyStruct(%v, %v): did not get expected error, got: %v, wantErr: %v", tt.name, tt.inSrc, tt.inDst, err, tt.wantErr)
 		}
@@ -2154,6 +2154,86 @@ var mergeStructTests = []struct {
 	want: &validatedMergeTest{
 		UnionField: &copyUnionI{42},
 	},
+}, {
+	name: "replace leaf-list by path",
+	inA:  &mergeTest{LeafList: []string{"alpha", "bravo"}},
+	inB:  &mergeTest{LeafList: []string{"charlie"}},
+	inOpts: []MergeOpt{
+		&MergeListStrategy{Paths: []*gnmipb.Path{{Elem: mustPathElem("leaf-list")}}, Strategy: ListMergeReplace},
+	},
+	want: &mergeTest{LeafList: []string{"charlie"}},
+}, {
+	name: "replace leaf-list with unpopulated src",
+	inA:  &mergeTest{LeafList: []string{"alpha", "bravo"}},
+	inB:  &mergeTest{FieldOne: String("delta")},
+	inOpts: []MergeOpt{
+		&MergeListStrategy{Types: []reflect.Type{reflect.TypeOf([]string{})}, Strategy: ListMergeReplace},
+	},
+	want: &mergeTest{FieldOne: String("delta"), LeafList: []string{"alpha", "bravo"}},
+}, {
+	name: "union leaf-list by type",
+	inA:  &mergeTest{LeafList: []string{"alpha", "bravo"}},
+	inB:  &mergeTest{LeafList: []string{"bravo", "charlie", "charlie"}},
+	inOpts: []MergeOpt{
+		&MergeListStrategy{Types: []reflect.Type{reflect.TypeOf([]string{})}, Strategy: ListMergeUnion},
+	},
+	want: &mergeTest{LeafList: []string{"alpha", "bravo", "charlie"}},
+}, {
+	name:    "overlapping leaf-list without strategy",
+	inA:     &mergeTest{LeafList: []string{"alpha", "bravo"}},
+	inB:     &mergeTest{LeafList: []string{"bravo"}},
+	wantErr: "source and destination lists must be unique",
+}, {
+	name: "append leaf-list",
+	inA:  &mergeTest{LeafList: []string{"alpha", "bravo"}},
+	inB:  &mergeTest{LeafList: []string{"bravo"}},
+	inOpts: []MergeOpt{
+		&MergeListStrategy{Paths: []*gnmipb.Path{{Elem: mustPathElem("*")}}, Strategy: ListMergeAppend},
+	},
+	want: &mergeTest{LeafList: []string{"alpha", "bravo", "bravo"}},
+}, {
+	name: "replace keyed list by path",
+	inA: &mergeTest{List: map[string]*mergeTestListChild{
+		"alpha": {Val: String("alpha")},
+		"bravo": {Val: String("bravo")},
+	}},
+	inB: &mergeTest{List: map[string]*mergeTestListChild{
+		"charlie": {Val: String("charlie")},
+	}},
+	inOpts: []MergeOpt{
+		&MergeListStrategy{Paths: []*gnmipb.Path{{Elem: mustPathElem("list")}}, Strategy: ListMergeReplace},
+	},
+	want: &mergeTest{List: map[string]*mergeTestListChild{
+		"charlie": {Val: String("charlie")},
+	}},
+}, {
+	name: "append keyed list with overlapping keys",
+	inA: &mergeTest{List: map[string]*mergeTestListChild{
+		"alpha": {Val: String("alpha")},
+	}},
+	inB: &mergeTest{List: map[string]*mergeTestListChild{
+		"alpha": {Val: String("alpha")},
+	}},
+	inOpts: []MergeOpt{
+		&MergeListStrategy{Types: []reflect.Type{reflect.TypeOf(&mergeTestListChild{})}, Strategy: ListMergeAppend},
+	},
+	wantErr: "map key alpha was present in both src and dst when appending",
+}, {
+	name: "last matching strategy is used",
+	inA: &mergeTest{List: map[string]*mergeTestListChild{
+		"alpha": {Val: String("alpha")},
+	}},
+	inB: &mergeTest{List: map[string]*mergeTestListChild{
+		"bravo": {Val: String("bravo")},
+	}},
+	inOpts: []MergeOpt{
+		&MergeListStrategy{Paths: []*gnmipb.Path{{Elem: mustPathElem("list")}}, Strategy: ListMergeReplace},
+		&MergeListStrategy{Types: []reflect.Type{reflect.TypeOf(&mergeTestListChild{})}, Strategy: ListMergeByKey},
+	},
+	want: &mergeTest{List: map[string]*mergeTestListChild{
+		"alpha": {Val: String("alpha")},
+		"bravo": {Val: String("bravo")},
+	}},
 }}
 
 func TestMergeStructs(t *testing.T) {
@@ -2277,7 +2357,7 @@ func TestCopyErrorCases(t *testing.T) {
 		{"bad dst", reflect.ValueOf(map[string]string{}), reflect.ValueOf(uint32(42)), "received a non-map type in dst map field: uint32"},
 	}
 	for _, tt := range mapErrs {
-		if err := copyMapField(tt.inDst, tt.inSrc, ""); err == nil || err.Error() != tt.wantErr {
+		if err := copyMapField(tt.inDst, tt.inSrc, "", nil); err == nil || err.Error() != tt.wantErr {
 			t.Errorf("%s: copyMapField(%v, %v): did not get expected error, got: %v, want: %v", tt.name, tt.inSrc, tt.inDst, err, tt.wantErr)
 		}
 	}
@@ -2286,7 +2366,7 @@ func TestCopyErrorCases(t *testing.T) {
 		{"non-ptr", reflect.ValueOf(""), reflect.ValueOf(""), "received non-ptr type: string"},
 	}
 	for _, tt := range ptrErrs {
-		if err := copyPtrField(tt.inDst, tt.inSrc, ""); err == nil || err.Error() != tt.wantErr {
+		if err := copyPtrField(tt.inDst, tt.inSrc, "", nil); err == nil || err.Error() != tt.wantErr {
 			t.Errorf("%s: copyPtrField(%v, %v): did not get expected error, got: %v, want: %v", tt.name, tt.inSrc, tt.inDst, err, tt.wantErr)
 		}
 	}
@@ -0,0 +1,212 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+)
+
+// WithDefaultsMode specifies how leaves that have a default value in the
+// YANG schema are reported when a GoStruct is rendered or compared, as per
+// the with-defaults retrieval modes of RFC 6243. The default values of the
+// leaves of a GoStruct are those set by its PopulateDefaults method, which is
+// generated when the generate_populate_defaults flag is specified, such that
+// the leaves of GoStructs that do not have the method have no defaults.
+type WithDefaultsMode int
+
+const (
+	// WithDefaultsExplicit reports the leaves that are set, including
+	// those that are set to their default value, and does not report
+	// unset leaves. It corresponds to the "explicit" mode of RFC 6243,
+	// and is the default behaviour.
+	WithDefaultsExplicit WithDefaultsMode = iota
+	// WithDefaultsReportAll reports unset leaves that have a default
+	// value as being set to it, along with the non-presence containers
+	// that contain them. It corresponds to the "report-all" mode of RFC
+	// 6243.
+	WithDefaultsReportAll
+	// WithDefaultsTrim does not report leaves that are set to their
+	// default value. It corresponds to the "trim" mode of RFC 6243.
+	WithDefaultsTrim
+)
+
+// String returns the name of the mode as used in the with-defaults
+// parameter of RFC 6243.
+func (m WithDefaultsMode) String() string {
+	switch m {
+	case WithDefaultsExplicit:
+		return "explicit"
+	case WithDefaultsReportAll:
+		return "report-all"
+	case WithDefaultsTrim:
+		return "trim"
+	}
+	return fmt.Sprintf("WithDefaultsMode(%d)", int(m))
+}
+
+// WithDefaults is a DiffOpt that specifies the with-defaults mode with
+// which the original and modified GoStructs are compared by Diff, such that,
+// for example, an unset leaf and the leaf set to its default value are equal
+// in the WithDefaultsReportAll and WithDefaultsTrim modes.
+type WithDefaults struct {
+	// Mode is the with-defaults mode.
+	Mode WithDefaultsMode
+}
+
+// IsDiffOpt marks WithDefaults as a diff option.
+func (*WithDefaults) IsDiffOpt() {}
+
+// hasWithDefaults returns the mode of the first WithDefaults from an opts
+// slice, or WithDefaultsExplicit if there isn't one.
+func hasWithDefaults(opts []DiffOpt) WithDefaultsMode {
+	for _, o := range opts {
+		if v, ok := o.(*WithDefaults); ok {
+			return v.Mode
+		}
+	}
+	return WithDefaultsExplicit
+}
+
+// defaultPopulator is the interface implemented by GoStructs that have a
+// generated PopulateDefaults method.
+type defaultPopulator interface {
+	PopulateDefaults()
+}
+
+// withDefaults returns s as it is to be reported in the with-defaults mode
+// mode. In WithDefaultsExplicit mode, s is returned unmodified, and
+// otherwise a copy of s is returned in which unset leaves are set to their
+// default value, or leaves set to their default value are unset,
+// respectively.
+func withDefaults(s GoStruct, mode WithDefaultsMode) (GoStruct, error) {
+	switch mode {
+	case WithDefaultsExplicit:
+		return s, nil
+	case WithDefaultsReportAll, WithDefaultsTrim:
+	default:
+		return nil, fmt.Errorf("invalid with-defaults mode %v", mode)
+	}
+	if util.IsValueNil(s) {
+		return s, nil
+	}
+
+	c, err := DeepCopy(s)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := applyWithDefaults(reflect.ValueOf(c), mode); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// applyWithDefaults modifies the struct pointed to by v, along with the
+// structs within it, as per the with-defaults mode, which is
+// WithDefaultsReportAll or WithDefaultsTrim. It returns true if any field of
+// the struct is set once it has been modified.
+func applyWithDefaults(v reflect.Value, mode WithDefaultsMode) (bool, error) {
+	sv := v.Elem()
+	st := sv.Type()
+
+	// The default values of the leaves of the struct are those of a new
+	// struct whose defaults are populated.
+	var defaults reflect.Value
+	if _, ok := v.Interface().(defaultPopulator); ok {
+		d := reflect.New(st)
+		d.Interface().(defaultPopulator).PopulateDefaults()
+		defaults = d.Elem()
+	}
+
+	var populated bool
+	for i := 0; i < sv.NumField(); i++ {
+		f, ft := sv.Field(i), st.Field(i)
+		if util.IsYgotAnnotation(ft) {
+			populated = populated || !f.IsZero()
+			continue
+		}
+
+		switch {
+		case util.IsTypeStructPtr(ft.Type):
+			if om, ok := f.Interface().(GoOrderedMap); ok {
+				if f.IsNil() {
+					continue
+				}
+				var err error
+				if rerr := yreflect.RangeOrderedMap(om, func(_ reflect.Value, e reflect.Value) bool {
+					_, err = applyWithDefaults(e, mode)
+					return err == nil
+				}); rerr != nil {
+					return false, rerr
+				}
+				if err != nil {
+					return false, err
+				}
+				populated = true
+				continue
+			}
+			if !f.IsNil() {
+				if _, err := applyWithDefaults(f, mode); err != nil {
+					return false, err
+				}
+				populated = true
+				continue
+			}
+			// Only non-presence containers are reported in order to
+			// report the default values of their leaves.
+			if mode != WithDefaultsReportAll || util.IsYangPresence(ft) {
+				continue
+			}
+			c := reflect.New(ft.Type.Elem())
+			set, err := applyWithDefaults(c, mode)
+			if err != nil {
+				return false, err
+			}
+			if set {
+				f.Set(c)
+				populated = true
+			}
+		case ft.Type.Kind() == reflect.Map:
+			for _, k := range f.MapKeys() {
+				if _, err := applyWithDefaults(f.MapIndex(k), mode); err != nil {
+					return false, err
+				}
+			}
+			populated = populated || f.Len() != 0
+		case util.IsTypeSlice(ft.Type) && util.IsTypeStructPtr(ft.Type.Elem()):
+			for j := 0; j < f.Len(); j++ {
+				if _, err := applyWithDefaults(f.Index(j), mode); err != nil {
+					return false, err
+				}
+			}
+			populated = populated || f.Len() != 0
+		default:
+			if defaults.IsValid() && !defaults.Field(i).IsZero() {
+				d := defaults.Field(i)
+				switch {
+				case mode == WithDefaultsTrim && reflect.DeepEqual(f.Interface(), d.Interface()):
+					f.Set(reflect.Zero(ft.Type))
+				case mode == WithDefaultsReportAll && f.IsZero():
+					f.Set(d)
+				}
+			}
+			populated = populated || !f.IsZero()
+		}
+	}
+	return populated, nil
+}
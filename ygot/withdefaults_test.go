@@ -0,0 +1,309 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// wdRoot and the following structs are used to test the with-defaults modes,
+// their PopulateDefaults methods are as generated.
+type wdRoot struct {
+	Interface map[string]*wdInterface `path:"interfaces/interface"`
+	System    *wdSystem               `path:"system"`
+	Bgp       *wdSystem               `path:"bgp" yangPresence:"true"`
+}
+
+func (*wdRoot) IsYANGGoStruct() {}
+
+func (t *wdRoot) PopulateDefaults() {
+	if t == nil {
+		return
+	}
+	for _, e := range t.Interface {
+		e.PopulateDefaults()
+	}
+	t.System.PopulateDefaults()
+	t.Bgp.PopulateDefaults()
+}
+
+type wdInterface struct {
+	Name        *string `path:"name"`
+	Mtu         *uint16 `path:"mtu"`
+	Description *string `path:"description"`
+}
+
+func (*wdInterface) IsYANGGoStruct() {}
+
+func (t *wdInterface) PopulateDefaults() {
+	if t == nil {
+		return
+	}
+	if t.Mtu == nil {
+		var v uint16 = 1500
+		t.Mtu = &v
+	}
+}
+
+type wdSystem struct {
+	Hostname *string `path:"hostname"`
+	Timezone *string `path:"timezone"`
+}
+
+func (*wdSystem) IsYANGGoStruct() {}
+
+func (t *wdSystem) PopulateDefaults() {
+	if t == nil {
+		return
+	}
+	if t.Timezone == nil {
+		var v string = "UTC"
+		t.Timezone = &v
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      GoStruct
+		inMode  WithDefaultsMode
+		want    GoStruct
+		wantErr string
+	}{{
+		desc: "explicit",
+		in: &wdRoot{Interface: map[string]*wdInterface{
+			"eth0": {Name: String("eth0")},
+			"eth1": {Name: String("eth1"), Mtu: Uint16(1500)},
+		}},
+		inMode: WithDefaultsExplicit,
+		want: &wdRoot{Interface: map[string]*wdInterface{
+			"eth0": {Name: String("eth0")},
+			"eth1": {Name: String("eth1"), Mtu: Uint16(1500)},
+		}},
+	}, {
+		desc: "report-all",
+		in: &wdRoot{Interface: map[string]*wdInterface{
+			"eth0": {Name: String("eth0")},
+			"eth1": {Name: String("eth1"), Mtu: Uint16(9000)},
+		}},
+		inMode: WithDefaultsReportAll,
+		want: &wdRoot{
+			Interface: map[string]*wdInterface{
+				"eth0": {Name: String("eth0"), Mtu: Uint16(1500)},
+				"eth1": {Name: String("eth1"), Mtu: Uint16(9000)},
+			},
+			System: &wdSystem{Timezone: String("UTC")},
+		},
+	}, {
+		desc:   "report-all with set presence container",
+		in:     &wdRoot{Bgp: &wdSystem{}},
+		inMode: WithDefaultsReportAll,
+		want: &wdRoot{
+			System: &wdSystem{Timezone: String("UTC")},
+			Bgp:    &wdSystem{Timezone: String("UTC")},
+		},
+	}, {
+		desc: "trim",
+		in: &wdRoot{
+			Interface: map[string]*wdInterface{
+				"eth0": {Name: String("eth0"), Mtu: Uint16(1500)},
+				"eth1": {Name: String("eth1"), Mtu: Uint16(9000)},
+			},
+			System: &wdSystem{Hostname: String("dev"), Timezone: String("UTC")},
+		},
+		inMode: WithDefaultsTrim,
+		want: &wdRoot{
+			Interface: map[string]*wdInterface{
+				"eth0": {Name: String("eth0")},
+				"eth1": {Name: String("eth1"), Mtu: Uint16(9000)},
+			},
+			System: &wdSystem{Hostname: String("dev")},
+		},
+	}, {
+		desc:   "struct without defaults",
+		in:     &renderExample{Str: String("s")},
+		inMode: WithDefaultsReportAll,
+		want:   &renderExample{Str: String("s")},
+	}, {
+		desc:    "invalid mode",
+		in:      &wdRoot{},
+		inMode:  WithDefaultsMode(42),
+		wantErr: "invalid with-defaults mode WithDefaultsMode(42)",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			orig, err := DeepCopy(tt.in)
+			if err != nil {
+				t.Fatalf("DeepCopy: %v", err)
+			}
+			got, err := withDefaults(tt.in, tt.inMode)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("withDefaults: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("withDefaults (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(orig, tt.in); diff != "" {
+				t.Errorf("withDefaults modified input (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConstructIETFJSONWithDefaults(t *testing.T) {
+	in := &wdRoot{Interface: map[string]*wdInterface{
+		"eth0": {Name: String("eth0"), Mtu: Uint16(1500)},
+	}}
+
+	tests := []struct {
+		desc   string
+		inMode WithDefaultsMode
+		want   map[string]any
+	}{{
+		desc:   "explicit",
+		inMode: WithDefaultsExplicit,
+		want: map[string]any{
+			"interfaces": map[string]any{
+				"interface": []any{map[string]any{"name": "eth0", "mtu": float64(1500)}},
+			},
+		},
+	}, {
+		desc:   "report-all",
+		inMode: WithDefaultsReportAll,
+		want: map[string]any{
+			"interfaces": map[string]any{
+				"interface": []any{map[string]any{"name": "eth0", "mtu": float64(1500)}},
+			},
+			"system": map[string]any{"timezone": "UTC"},
+		},
+	}, {
+		desc:   "trim",
+		inMode: WithDefaultsTrim,
+		want: map[string]any{
+			"interfaces": map[string]any{
+				"interface": []any{map[string]any{"name": "eth0"}},
+			},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ConstructIETFJSON(in, &RFC7951JSONConfig{WithDefaults: tt.inMode})
+			if err != nil {
+				t.Fatalf("ConstructIETFJSON: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ConstructIETFJSON (-want, +got):\n%s", diff)
+			}
+
+			b, err := Marshal7951(in, &RFC7951JSONConfig{WithDefaults: tt.inMode})
+			if err != nil {
+				t.Fatalf("Marshal7951: %v", err)
+			}
+			var gotJSON map[string]any
+			if err := json.Unmarshal(b, &gotJSON); err != nil {
+				t.Fatalf("json.Unmarshal: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, gotJSON); diff != "" {
+				t.Errorf("Marshal7951 (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTogNMINotificationsWithDefaults(t *testing.T) {
+	in := &wdRoot{System: &wdSystem{Hostname: String("dev"), Timezone: String("UTC")}}
+
+	tests := []struct {
+		desc   string
+		inMode WithDefaultsMode
+		want   []*gnmipb.Update
+	}{{
+		desc:   "explicit",
+		inMode: WithDefaultsExplicit,
+		want: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "system"}, {Name: "hostname"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "dev"}},
+		}, {
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "system"}, {Name: "timezone"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "UTC"}},
+		}},
+	}, {
+		desc:   "trim",
+		inMode: WithDefaultsTrim,
+		want: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "system"}, {Name: "hostname"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "dev"}},
+		}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := TogNMINotifications(in, 42, GNMINotificationsConfig{UsePathElem: true, WithDefaults: tt.inMode})
+			if err != nil {
+				t.Fatalf("TogNMINotifications: %v", err)
+			}
+			want := []*gnmipb.Notification{{Timestamp: 42, Update: tt.want}}
+			if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+				t.Errorf("TogNMINotifications (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffWithDefaults(t *testing.T) {
+	unset := &wdRoot{System: &wdSystem{Hostname: String("dev")}}
+	def := &wdRoot{System: &wdSystem{Hostname: String("dev"), Timezone: String("UTC")}}
+
+	tests := []struct {
+		desc   string
+		inOpts []DiffOpt
+		want   *gnmipb.Notification
+	}{{
+		desc: "explicit",
+		want: &gnmipb.Notification{Update: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "system"}, {Name: "timezone"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "UTC"}},
+		}}},
+	}, {
+		desc:   "report-all",
+		inOpts: []DiffOpt{&WithDefaults{Mode: WithDefaultsReportAll}},
+		want:   &gnmipb.Notification{},
+	}, {
+		desc:   "trim",
+		inOpts: []DiffOpt{&WithDefaults{Mode: WithDefaultsTrim}},
+		want:   &gnmipb.Notification{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Diff(unset, def, tt.inOpts...)
+			if err != nil {
+				t.Fatalf("Diff: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -26,12 +26,18 @@ import (
 // Refer to: https://tools.ietf.org/html/rfc6020#section-9.8.
 
 // ValidateBinaryRestrictions checks that the given binary string matches the
-// schema's length restrictions (if any). It returns an error if the validation
-// fails.
+// schema's length restrictions (if any). It returns a *ValidationError if the
+// validation fails, as per ValidateStringRestrictions.
 func ValidateBinaryRestrictions(schemaType *yang.YangType, binaryVal []byte) error {
+	return validateBinaryRestrictions(nil, schemaType, binaryVal)
+}
+
+// validateBinaryRestrictions implements ValidateBinaryRestrictions, using the
+// error statements of the type statement of schema where it is non-nil.
+func validateBinaryRestrictions(schema *yang.Entry, schemaType *yang.YangType, binaryVal []byte) error {
 	allowedRanges := schemaType.Length
 	if !lengthOk(allowedRanges, uint64(len(binaryVal))) {
-		return fmt.Errorf("length %d is outside range %v", len(binaryVal), allowedRanges)
+		return newRestrictionError(LengthConstraint, schema, schemaType, binaryVal, "", fmt.Errorf("length %d is outside range %v", len(binaryVal), allowedRanges))
 	}
 	return nil
 }
@@ -55,8 +61,8 @@ func validateBinary(schema *yang.Entry, value interface{}) error {
 	// Check that the length is within the allowed range.
 	binaryVal := reflect.ValueOf(value).Bytes()
 
-	if err := ValidateBinaryRestrictions(schema.Type, binaryVal); err != nil {
-		return fmt.Errorf("schema %q: %v", schema.Name, err)
+	if err := validateBinaryRestrictions(schema, schema.Type, binaryVal); err != nil {
+		return fmt.Errorf("schema %q: %w", schema.Name, err)
 	}
 	return nil
 }
@@ -0,0 +1,294 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/ycbor"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// CBOROptions is an UnmarshalOpt that specifies how the input of
+// UnmarshalCBOR is to be decoded.
+type CBOROptions struct {
+	// SIDs specifies that the input uses SID-based rather than name-based
+	// keys, which are resolved using the supplied SIDMap, such that it must
+	// contain the SID of each data node in the input, along with that of
+	// each identity that is the value of an identityref.
+	SIDs *ygot.SIDMap
+	// Path is the absolute schema node identifier of the node that is
+	// being unmarshalled into, relative to whose SID the SIDs of the keys
+	// of the input are, as per the field of the same name of
+	// ygot.CBORConfig. The empty string indicates the root of the schema
+	// tree.
+	Path string
+}
+
+// IsUnmarshalOpt marks CBOROptions as a valid UnmarshalOpt.
+func (*CBOROptions) IsUnmarshalOpt() {}
+
+// UnmarshalCBOR unmarshals the YANG-CBOR (RFC 9254) encoded data into
+// parent, using the given schema, with the same semantics as Unmarshal. data
+// is a CBOR map of the children of the node described by schema, as output
+// by ygot.MarshalCBOR, such that schema must be that of a container or list,
+// and parent the corresponding struct ptr. The keys of the map are the names
+// of the data nodes, unless SIDs are specified using CBOROptions, in which
+// case they are SIDs.
+//
+// The supported options are CBOROptions, IgnoreExtraFields and
+// PreferShadowPath.
+func UnmarshalCBOR(schema *yang.Entry, parent interface{}, data []byte, opts ...UnmarshalOpt) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema for parent type %T", parent)
+	}
+	if !schema.IsContainer() && !schema.IsList() {
+		return fmt.Errorf("cannot unmarshal CBOR into schema %s, expect container or list", schema.Name)
+	}
+
+	cfg := &CBOROptions{}
+	for _, o := range opts {
+		if v, ok := o.(*CBOROptions); ok {
+			cfg = v
+		}
+	}
+
+	v, err := ycbor.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	if _, ok := v.(map[any]any); !ok {
+		return fmt.Errorf("got CBOR value of type %T, expect map", v)
+	}
+
+	var parentSID uint64
+	if cfg.SIDs != nil && cfg.Path != "" {
+		var ok bool
+		if parentSID, ok = cfg.SIDs.SID(ygot.SIDNamespaceData, cfg.Path); !ok {
+			return fmt.Errorf("no SID found for data node %s", cfg.Path)
+		}
+	}
+	tree, err := cborToTree(v, parentSID, cfg.SIDs)
+	if err != nil {
+		return err
+	}
+
+	if schema.IsList() {
+		// The map is that of a single entry of the list.
+		newSchema := *schema
+		newSchema.ListAttr = nil
+		schema = &newSchema
+	}
+	return unmarshalGeneric(schema, parent, tree, CBOREncoding, opts...)
+}
+
+// cborSID is an unsigned integer in a YANG-CBOR data tree with SID-based
+// keys that is also the SID of an identity, and is hence either the value
+// of an identityref or an integer.
+type cborSID struct {
+	// value is the integer value.
+	value int64
+	// identity is the name of the identity, prefixed with its module.
+	identity string
+}
+
+// cborToTree returns the data tree that corresponds to the decoded CBOR
+// value v, which is the value unmarshalled with CBOREncoding. The tree has
+// the same structure as the RFC7951 JSON tree of the data, in which CBOR
+// maps are map[string]interface{} keyed by the names of the data nodes,
+// while the values of leaves are their CBOR values, other than null, which
+// is ycbor.Null. If sids is non-nil, the keys of v are SIDs relative to
+// parentSID, which is the SID of the data node represented by v, and
+// identityref values are resolved to the names of the identities.
+func cborToTree(v any, parentSID uint64, sids *ygot.SIDMap) (interface{}, error) {
+	switch v := v.(type) {
+	case map[any]any:
+		out := map[string]interface{}{}
+		for k, c := range v {
+			name, sid, err := cborKeyName(k, parentSID, sids)
+			if err != nil {
+				return nil, err
+			}
+			cv, err := cborToTree(c, sid, sids)
+			if err != nil {
+				return nil, err
+			}
+			out[name] = cv
+		}
+		return out, nil
+	case []any:
+		// The entries of lists and the values of leaf-lists have the SID
+		// of the list or leaf-list.
+		out := make([]interface{}, 0, len(v))
+		for _, c := range v {
+			cv, err := cborToTree(c, parentSID, sids)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, cv)
+		}
+		return out, nil
+	case nil:
+		return ycbor.Null{}, nil
+	case int64:
+		if sids == nil || v < 0 {
+			return v, nil
+		}
+		if i := sids.Item(uint64(v)); i != nil && i.Namespace == ygot.SIDNamespaceIdentity {
+			return cborSID{value: v, identity: i.Identifier}, nil
+		}
+	case ycbor.Tag:
+		if v.Number != ycbor.TagIdentityref || sids == nil {
+			return v, nil
+		}
+		sid, ok := v.Content.(int64)
+		if !ok {
+			return nil, fmt.Errorf("invalid identityref SID %v", v.Content)
+		}
+		i := sids.Item(uint64(sid))
+		if i == nil || i.Namespace != ygot.SIDNamespaceIdentity {
+			return nil, fmt.Errorf("no identity found for SID %d", sid)
+		}
+		return i.Identifier, nil
+	}
+	return v, nil
+}
+
+// cborKeyName returns the name of the data node that is the key k of a CBOR
+// map, along with its SID if sids is non-nil, in which case k is the delta
+// between its SID and parentSID, or an absolute SID tagged as such.
+func cborKeyName(k any, parentSID uint64, sids *ygot.SIDMap) (string, uint64, error) {
+	if sids == nil {
+		name, ok := k.(string)
+		if !ok {
+			return "", 0, fmt.Errorf("got CBOR key %v of type %T, expect name", k, k)
+		}
+		return name, 0, nil
+	}
+
+	var sid uint64
+	switch k := k.(type) {
+	case int64:
+		sid = parentSID + uint64(k)
+	case ycbor.Tag:
+		abs, ok := k.Content.(int64)
+		if k.Number != ycbor.TagAbsoluteSID || !ok {
+			return "", 0, fmt.Errorf("invalid CBOR key %v", k)
+		}
+		sid = uint64(abs)
+	default:
+		return "", 0, fmt.Errorf("got CBOR key %v of type %T, expect SID", k, k)
+	}
+
+	i := sids.Item(sid)
+	if i == nil || i.Namespace != ygot.SIDNamespaceData {
+		return "", 0, fmt.Errorf("no data node found for SID %d", sid)
+	}
+	// The name of the data node, along with its module where it differs
+	// from that of its parent, is the last element of its identifier.
+	return i.Identifier[strings.LastIndex(i.Identifier, "/")+1:], sid, nil
+}
+
+// cborUnionString returns the value of a union leaf in a YANG-CBOR data
+// tree as a string, and true, if it may be that of an enumeration or
+// identityref. Enumerations within unions are tagged, while identityrefs are
+// strings, or SIDs tagged as identityrefs that are resolved by cborToTree.
+func cborUnionString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case ycbor.Tag:
+		if s, ok := v.Content.(string); ok && v.Number == ycbor.TagEnumeration {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// cborToJSONValue returns the value of a leaf in a YANG-CBOR data tree as
+// the value that would be unmarshalled from its RFC7951 JSON representation,
+// for a leaf with the given schema.
+func cborToJSONValue(schema *yang.Entry, value interface{}) (interface{}, error) {
+	ykind := schema.Type.Kind
+	if v, ok := value.(cborSID); ok && ykind != yang.Yidentityref {
+		value = v.value
+	}
+
+	switch ykind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64, yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		var s string
+		switch v := value.(type) {
+		case int64:
+			s = strconv.FormatInt(v, 10)
+		case uint64:
+			s = strconv.FormatUint(v, 10)
+		default:
+			return nil, fmt.Errorf("got %T type, want CBOR integer", value)
+		}
+		if ykind == yang.Yint64 || ykind == yang.Yuint64 {
+			return s, nil
+		}
+		return strconv.ParseFloat(s, 64)
+	case yang.Ydecimal64:
+		t, ok := value.(ycbor.Tag)
+		f, isArr := t.Content.([]any)
+		if !ok || t.Number != ycbor.TagDecimalFraction || !isArr || len(f) != 2 {
+			return nil, fmt.Errorf("got %v, want CBOR decimal fraction", value)
+		}
+		exp, expOK := f[0].(int64)
+		mant, mantOK := f[1].(int64)
+		if !expOK || !mantOK {
+			return nil, fmt.Errorf("invalid CBOR decimal fraction %v", f)
+		}
+		return fmt.Sprintf("%de%d", mant, exp), nil
+	case yang.Ybinary:
+		b, ok := value.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("got %T type, want CBOR byte string", value)
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case yang.Yempty:
+		if _, ok := value.(ycbor.Null); !ok {
+			return nil, fmt.Errorf("got %v, empty leaves must be null", value)
+		}
+		return []interface{}{nil}, nil
+	case yang.Yenum:
+		switch v := value.(type) {
+		case int64:
+			if schema.Type.Enum == nil {
+				return nil, fmt.Errorf("no enumeration values in schema %s for value %d", schema.Name, v)
+			}
+			name, ok := schema.Type.Enum.ValueMap()[v]
+			if !ok {
+				return nil, fmt.Errorf("%d is not a valid value of enumeration %s", v, schema.Type.Name)
+			}
+			return name, nil
+		case ycbor.Tag:
+			if s, ok := v.Content.(string); ok && v.Number == ycbor.TagEnumeration {
+				return s, nil
+			}
+		}
+		return nil, fmt.Errorf("got %v, want CBOR enumeration", value)
+	case yang.Yidentityref:
+		if v, ok := value.(cborSID); ok {
+			return v.identity, nil
+		}
+	}
+	return value, nil
+}
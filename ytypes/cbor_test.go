@@ -0,0 +1,270 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/ycbor"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// cborTestStructSchema returns the schema for xmlTestStruct, in which the
+// values of the enumeration are populated.
+func cborTestStructSchema() *yang.Entry {
+	s := xmlTestStructSchema()
+	enum := yang.NewEnumType()
+	enum.Set("E_VALUE_FORTY_ONE", 40)
+	enum.Set("E_VALUE_FORTY_TWO", 41)
+	s.Dir["enum-leaf"].Type.Enum = enum
+	return s
+}
+
+// cborTestSIDs returns a SIDMap containing the SIDs of the leaves of
+// xmlTestStruct, which are allocated from 1000 in the order of the supplied
+// names.
+func cborTestSIDs(t *testing.T, names ...string) *ygot.SIDMap {
+	f := &ygot.SIDFile{ModuleName: "xt"}
+	for i, n := range names {
+		f.Items = append(f.Items, &ygot.SIDItem{Namespace: ygot.SIDNamespaceData, Identifier: "/xt:" + n, SID: uint64(1000 + i)})
+	}
+	m, err := ygot.NewSIDMap(f)
+	if err != nil {
+		t.Fatalf("cannot create SIDMap: %v", err)
+	}
+	return m
+}
+
+func TestUnmarshalCBOR(t *testing.T) {
+	sids := cborTestSIDs(t, "int8-leaf", "enum-leaf", "union-leaf")
+
+	tests := []struct {
+		desc     string
+		inSchema *yang.Entry
+		in       any
+		inOpts   []UnmarshalOpt
+		want     *xmlTestStruct
+		wantErr  string
+	}{{
+		desc: "scalar types",
+		in: map[string]any{
+			"xt:int8-leaf":    -8,
+			"xt:int64-leaf":   -64,
+			"xt:uint64-leaf":  uint64(18446744073709551615),
+			"xt:decimal-leaf": ycbor.Tag{Number: ycbor.TagDecimalFraction, Content: []any{-2, 4242}},
+			"xt:bool-leaf":    true,
+			"xt:string-leaf":  "a",
+			"xt:binary-leaf":  []byte{1, 2},
+			"xt:empty-leaf":   nil,
+		},
+		want: &xmlTestStruct{
+			Int8Leaf:    ygot.Int8(-8),
+			Int64Leaf:   ygot.Int64(-64),
+			Uint64Leaf:  ygot.Uint64(18446744073709551615),
+			DecimalLeaf: ygot.Float64(42.42),
+			BoolLeaf:    ygot.Bool(true),
+			StringLeaf:  ygot.String("a"),
+			BinaryLeaf:  Binary{1, 2},
+			EmptyLeaf:   true,
+		},
+	}, {
+		desc: "enumerations",
+		in: map[string]any{
+			"enum-leaf":      41,
+			"union-leaf":     ycbor.Tag{Number: ycbor.TagEnumeration, Content: "E_VALUE_FORTY_ONE"},
+			"union-leaflist": []any{1, "one"},
+		},
+		want: &xmlTestStruct{
+			EnumLeaf:      EnumType(42),
+			UnionLeaf:     &UnionLeafType_EnumType{41},
+			UnionLeafList: []UnionLeafType{&UnionLeafType_Uint32{1}, &UnionLeafType_String{"one"}},
+		},
+	}, {
+		desc: "SIDs",
+		in: map[int64]any{
+			1000: 1,
+			1001: 40,
+			1002: ycbor.Tag{Number: ycbor.TagEnumeration, Content: "E_VALUE_FORTY_TWO"},
+		},
+		inOpts: []UnmarshalOpt{&CBOROptions{SIDs: sids}},
+		want:   &xmlTestStruct{Int8Leaf: ygot.Int8(1), EnumLeaf: EnumType(41), UnionLeaf: &UnionLeafType_EnumType{42}},
+	}, {
+		desc:    "out of range integer",
+		in:      map[string]any{"int8-leaf": 128},
+		wantErr: "error parsing 128 for schema int8-leaf",
+	}, {
+		desc:    "wrong type",
+		in:      map[string]any{"string-leaf": 1},
+		wantErr: "got int64 type for field string-leaf, expect string",
+	}, {
+		desc:    "bad decimal",
+		in:      map[string]any{"decimal-leaf": 1.5},
+		wantErr: "want CBOR decimal fraction",
+	}, {
+		desc:    "bad empty leaf",
+		in:      map[string]any{"empty-leaf": false},
+		wantErr: "empty leaves must be null",
+	}, {
+		desc:    "bad enumeration",
+		in:      map[string]any{"enum-leaf": 42},
+		wantErr: "42 is not a valid value of enumeration",
+	}, {
+		desc:    "unknown SID",
+		in:      map[int64]any{999: 1},
+		inOpts:  []UnmarshalOpt{&CBOROptions{SIDs: sids}},
+		wantErr: "no data node found for SID 999",
+	}, {
+		desc:    "name key with SIDs",
+		in:      map[string]any{"int8-leaf": 1},
+		inOpts:  []UnmarshalOpt{&CBOROptions{SIDs: sids}},
+		wantErr: "expect SID",
+	}, {
+		desc:    "SID key without SIDs",
+		in:      map[int64]any{1000: 1},
+		wantErr: "expect name",
+	}, {
+		desc:    "not a map",
+		in:      []any{},
+		wantErr: "expect map",
+	}, {
+		desc:     "leaf schema",
+		inSchema: typeToLeafSchema("int8-leaf", yang.Yint8),
+		in:       map[string]any{},
+		wantErr:  "expect container or list",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in, err := ycbor.Marshal(cborTestValue(tt.in))
+			if err != nil {
+				t.Fatalf("cannot encode test input: %v", err)
+			}
+			schema := tt.inSchema
+			if schema == nil {
+				schema = cborTestStructSchema()
+			}
+			got := &xmlTestStruct{}
+			err = UnmarshalCBOR(schema, got, in, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalCBOR: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalCBOR (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// cborTestValue returns v, in which the values of type int are converted to
+// int64, such that it can be encoded by ycbor.Marshal.
+func cborTestValue(v any) any {
+	switch v := v.(type) {
+	case int:
+		return int64(v)
+	case []any:
+		out := []any{}
+		for _, e := range v {
+			out = append(out, cborTestValue(e))
+		}
+		return out
+	case map[string]any:
+		out := map[string]any{}
+		for k, e := range v {
+			out[k] = cborTestValue(e)
+		}
+		return out
+	case map[int64]any:
+		out := map[int64]any{}
+		for k, e := range v {
+			out[k] = cborTestValue(e)
+		}
+		return out
+	case ycbor.Tag:
+		return ycbor.Tag{Number: v.Number, Content: cborTestValue(v.Content)}
+	}
+	return v
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	allTypes := &xmlTestStruct{
+		Int8Leaf:      ygot.Int8(-128),
+		Int16Leaf:     ygot.Int16(32767),
+		Int32Leaf:     ygot.Int32(-2147483648),
+		Int64Leaf:     ygot.Int64(-9223372036854775808),
+		Uint8Leaf:     ygot.Uint8(255),
+		Uint16Leaf:    ygot.Uint16(65535),
+		Uint32Leaf:    ygot.Uint32(4294967295),
+		Uint64Leaf:    ygot.Uint64(18446744073709551615),
+		DecimalLeaf:   ygot.Float64(-0.125),
+		BoolLeaf:      ygot.Bool(false),
+		StringLeaf:    ygot.String("string"),
+		BinaryLeaf:    Binary("binary"),
+		EmptyLeaf:     true,
+		EnumLeaf:      EnumType(41),
+		Int8LeafList:  []int8{3, 1, 2},
+		UnionLeaf:     &UnionLeafType_EnumType{42},
+		UnionLeafList: []UnionLeafType{&UnionLeafType_Uint32{1}, &UnionLeafType_String{"one"}},
+	}
+	var names []string
+	for n := range cborTestStructSchema().Dir {
+		names = append(names, n)
+	}
+
+	tests := []struct {
+		desc     string
+		inSchema *yang.Entry
+		in       ygot.GoStruct
+		inSIDs   *ygot.SIDMap
+		new      func() ygot.GoStruct
+	}{{
+		desc:     "data tree",
+		inSchema: xpathTestSchema(nil),
+		in:       xpathTestData(),
+		new:      func() ygot.GoStruct { return &xpathTestDevice{} },
+	}, {
+		desc:     "all types",
+		inSchema: cborTestStructSchema(),
+		in:       allTypes,
+		new:      func() ygot.GoStruct { return &xmlTestStruct{} },
+	}, {
+		desc:     "all types with SIDs",
+		inSchema: cborTestStructSchema(),
+		in:       allTypes,
+		inSIDs:   cborTestSIDs(t, names...),
+		new:      func() ygot.GoStruct { return &xmlTestStruct{} },
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := ygot.MarshalCBOR(tt.in, &ygot.CBORConfig{SIDs: tt.inSIDs})
+			if err != nil {
+				t.Fatalf("MarshalCBOR: %v", err)
+			}
+			got := tt.new()
+			if err := UnmarshalCBOR(tt.inSchema, got, b, &CBOROptions{SIDs: tt.inSIDs}); err != nil {
+				t.Fatalf("UnmarshalCBOR(%x): %v", b, err)
+			}
+			if diff := cmp.Diff(tt.in, got); diff != "" {
+				t.Errorf("round trip of %x (-want, +got):\n%s", b, diff)
+			}
+		})
+	}
+}
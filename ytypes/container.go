@@ -23,13 +23,15 @@ import (
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 // Refer to: https://tools.ietf.org/html/rfc6020#section-7.5.
 
 // validateContainer validates each of the values in the map, keyed by the list
 // Key value, against the given list schema.
-func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
+func validateContainer(schema *yang.Entry, value ygot.GoStruct, st *validateState) util.Errors {
 	var errors []error
 	if util.IsValueNil(value) {
 		return nil
@@ -52,25 +54,41 @@ func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
 		structElems := reflect.ValueOf(value).Elem()
 		structTypes := structElems.Type()
 
+		// The errors for each field are stored such that they are returned
+		// in the order of the fields where children are validated
+		// concurrently.
+		fieldErrs := make([]util.Errors, structElems.NumField())
+		var concurrent []func()
 		for i := 0; i < structElems.NumField(); i++ {
 			fieldType := structElems.Type().Field(i)
 			fieldName := fieldType.Name
 			fieldValue := structElems.Field(i).Interface()
 
-			// Skip annotation fields when validating the schema.
+			// Annotation fields do not have a schema, hence only their
+			// metadata annotations are validated.
 			if util.IsYgotAnnotation(fieldType) {
+				fieldErrs[i] = validateMetadata(schema, fieldValue)
 				continue
 			}
 
 			cschema, err := util.ChildSchema(schema, structTypes.Field(i))
 			switch {
 			case err != nil:
-				errors = util.AppendErr(errors, fmt.Errorf("%s: %v", fieldName, err))
+				fieldErrs[i] = util.NewErrs(fmt.Errorf("%s: %v", fieldName, err))
 				continue
 			case cschema != nil:
 				// Regular named child.
-				if errs := Validate(cschema, fieldValue); errs != nil {
-					errors = util.AppendErrs(errors, util.PrefixErrors(errs, cschema.Path()))
+				elems := fieldPathElems(fieldType, cschema)
+				cst := st.child(value, append(elems, &gpb.PathElem{Name: cschema.Name})...)
+				validateField := func() {
+					if errs := validateNode(cschema, fieldValue, cst); errs != nil {
+						fieldErrs[i] = prefixValidationErrors(errs, cschema.Path(), elems...)
+					}
+				}
+				if st.concurrent(cschema) {
+					concurrent = append(concurrent, validateField)
+				} else {
+					validateField()
 				}
 			case !util.IsValueNilOrDefault(structElems.Field(i).Interface()):
 				// Either an element in choice schema subtree, or bad field.
@@ -78,11 +96,15 @@ func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
 				extraFields[fieldName] = nil
 			}
 		}
+		st.each(len(concurrent), func(i int) { concurrent[i]() })
+		for _, errs := range fieldErrs {
+			errors = util.AppendErrs(errors, errs)
+		}
 
 		// Field names in the data tree belonging to Choice have the schema of
 		// the elements of that choice. Hence, choice schemas must be checked
 		// separately.
-		for _, choiceSchema := range schema.Dir {
+		for _, choiceSchema := range sortedChildren(schema) {
 			if choiceSchema.IsChoice() {
 				selected, errs := validateChoice(choiceSchema, value)
 				for _, s := range selected {
@@ -125,6 +147,10 @@ func unmarshalContainer(schema *yang.Entry, parent interface{}, jsonTree interfa
 
 	util.DbgPrint("unmarshalContainer jsonTree %v, type %T, into parent type %T, schema name %s", util.ValueStrDebug(jsonTree), jsonTree, parent, schema.Name)
 
+	if enc == XMLEncoding {
+		jsonTree = xmlContainerValue(jsonTree)
+	}
+
 	// Since this is a container, the JSON data tree is a map.
 	jt, ok := jsonTree.(map[string]interface{})
 	if !ok {
@@ -156,19 +182,24 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 		f := destv.Field(i)
 		ft := destv.Type().Field(i)
 
-		// Skip annotation fields since they do not have a schema.
-		// TODO(robjs): Implement unmarshalling annotations.
+		// Annotation fields do not have a schema, but are populated with
+		// the RFC 7952 metadata annotations defined by the schema tree.
 		if util.IsYgotAnnotation(ft) {
 			// We need to find the paths that we should have unmarshalled here to avoid
-			// throwing errors to users whilst there is a TODO above.
+			// throwing errors to users where the annotations are not
+			// unmarshalled.
 			paths, err := pathTagFromField(ft)
 			if err != nil {
 				return fmt.Errorf("cannot find JSON field names for annotation field %s, %v", ft.Name, err)
 			}
+			if enc == JSONEncoding {
+				if err := unmarshalMetadata(schema, f, ft, jsonTree, hasIgnoreExtraFields(opts)); err != nil {
+					return err
+				}
+			}
 
 			for _, s := range strings.Split(paths, "|") {
-				pp := strings.Split(s, "/")
-				allSchemaPaths = append(allSchemaPaths, []string{pp[len(pp)-1]})
+				allSchemaPaths = append(allSchemaPaths, strings.Split(s, "/"))
 			}
 			continue
 		}
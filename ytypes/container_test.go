@@ -266,10 +266,10 @@ func TestValidateContainer(t *testing.T) {
 	}
 
 	// Additional tests through private API.
-	if err := validateContainer(nil, nil); err != nil {
+	if err := validateContainer(nil, nil, nil); err != nil {
 		t.Errorf("nil value: got error: %v, want error: nil", err)
 	}
-	if err := validateContainer(nil, &ContainerStruct{}); err == nil {
+	if err := validateContainer(nil, &ContainerStruct{}, nil); err == nil {
 		t.Errorf("nil schema: got error: nil, want nil schema error")
 	}
 }
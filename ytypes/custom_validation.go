@@ -0,0 +1,110 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// CustomValidateFunc is a custom validation function that is invoked by
+// Validate for a node of the data tree. value is the value of the node, which
+// is the Go value of a leaf, the slice of values of a leaf-list, or the
+// GoStruct of a container or list entry. parent is the GoStruct that contains
+// the node, which is nil for the node at which validation was requested. path
+// is the path of the node relative to the node at which validation was
+// requested, including the keys of list entries. The function returns an
+// error if the node is not valid.
+type CustomValidateFunc func(value interface{}, parent ygot.GoStruct, path *gpb.Path) error
+
+// CustomValidatorRegistry is a set of CustomValidateFuncs, each of which is
+// registered for a schema path. It is supplied to Validate using
+// CustomValidationOptions, such that the functions are invoked whilst the data
+// tree is traversed, rather than requiring a further traversal.
+type CustomValidatorRegistry struct {
+	validators map[string][]CustomValidateFunc
+}
+
+// NewCustomValidatorRegistry returns an empty CustomValidatorRegistry.
+func NewCustomValidatorRegistry() *CustomValidatorRegistry {
+	return &CustomValidatorRegistry{validators: map[string][]CustomValidateFunc{}}
+}
+
+// Register registers fn to be invoked for each node of the data tree whose
+// schema path is path, for example "/interfaces/interface/config/mtu". The
+// path is absolute, excludes choice and case nodes, and any module prefixes
+// within it are ignored. Where path is that of a list, fn is invoked for each
+// entry of the list. Multiple functions may be registered for the same path,
+// and are invoked in the order in which they were registered.
+func (r *CustomValidatorRegistry) Register(path string, fn CustomValidateFunc) {
+	p := strings.TrimSuffix(util.StripModulePrefixesStr(path), "/")
+	r.validators[p] = append(r.validators[p], fn)
+}
+
+// runValidators invokes the custom validators registered for the schema path
+// of schema with the supplied value, and returns a ValidationError for each
+// error that they return.
+func (st *validateState) runValidators(schema *yang.Entry, value interface{}) util.Errors {
+	if st == nil || st.validators == nil || len(st.validators.validators) == 0 {
+		return nil
+	}
+	fns := st.validators.validators[util.SchemaTreePathNoModule(schema)]
+	if len(fns) == 0 {
+		return nil
+	}
+	if rv := reflect.ValueOf(value); schema.IsLeaf() && rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		value = rv.Elem().Interface()
+	}
+
+	var errs util.Errors
+	for _, fn := range fns {
+		// Each function receives its own copy of the path, such that it
+		// may retain or modify it.
+		p := &gpb.Path{}
+		for _, e := range st.path {
+			p.Elem = append(p.Elem, &gpb.PathElem{Name: e.Name, Key: copyKeys(e.Key)})
+		}
+		err := fn(value, st.parent, p)
+		if err == nil {
+			continue
+		}
+		fnErrs, ok := err.(util.Errors)
+		if !ok {
+			fnErrs = util.NewErrs(err)
+		}
+		for _, e := range fnErrs {
+			errs = util.AppendErr(errs, newValidationError(CustomConstraint, schema, value, e))
+		}
+	}
+	return errs
+}
+
+// copyKeys returns a copy of the keys of a gNMI PathElem.
+func copyKeys(k map[string]string) map[string]string {
+	if k == nil {
+		return nil
+	}
+	c := make(map[string]string, len(k))
+	for n, v := range k {
+		c[n] = v
+	}
+	return c
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// customValidatorCall records the arguments of a call to a
+// CustomValidateFunc.
+type customValidatorCall struct {
+	Path   string
+	Value  interface{}
+	Parent string
+}
+
+func TestCustomValidatorRegistry(t *testing.T) {
+	tests := []struct {
+		desc       string
+		inPath     string
+		inFail     func(value interface{}) bool
+		inSchema   []string
+		inData     func(*xpathTestDevice) interface{}
+		wantCalls  []customValidatorCall
+		wantErrors []string
+	}{{
+		desc:   "leaf",
+		inPath: "/interfaces/interface/config/mtu",
+		wantCalls: []customValidatorCall{{
+			Path:   "/interfaces/interface[name=eth0]/config/mtu",
+			Value:  uint16(1500),
+			Parent: "*ytypes.xpathTestInterface",
+		}},
+	}, {
+		desc:   "leaf with module prefixes",
+		inPath: "/oc-if:interfaces/oc-if:interface/oc-if:config/oc-if:mtu",
+		wantCalls: []customValidatorCall{{
+			Path:   "/interfaces/interface[name=eth0]/config/mtu",
+			Value:  uint16(1500),
+			Parent: "*ytypes.xpathTestInterface",
+		}},
+	}, {
+		desc:   "failing leaf",
+		inPath: "/interfaces/interface/config/mtu",
+		inFail: func(v interface{}) bool { return v.(uint16) > 1400 },
+		wantCalls: []customValidatorCall{{
+			Path:   "/interfaces/interface[name=eth0]/config/mtu",
+			Value:  uint16(1500),
+			Parent: "*ytypes.xpathTestInterface",
+		}},
+		wantErrors: []string{
+			"/device/interfaces/interface: custom validation failed for /interfaces/interface[name=eth0]/config/mtu",
+		},
+	}, {
+		desc:   "list entries",
+		inPath: "/interfaces/interface/subinterfaces/subinterface",
+		wantCalls: []customValidatorCall{{
+			Path:   "/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]",
+			Value:  "*ytypes.xpathTestSubinterface",
+			Parent: "*ytypes.xpathTestInterface",
+		}, {
+			Path:   "/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=1]",
+			Value:  "*ytypes.xpathTestSubinterface",
+			Parent: "*ytypes.xpathTestInterface",
+		}},
+	}, {
+		desc:   "leaf-list",
+		inPath: "/interfaces/interface/subinterfaces/subinterface/config/address",
+		wantCalls: []customValidatorCall{{
+			Path:   "/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]/config/address",
+			Value:  []string{"192.0.2.1", "192.0.2.2"},
+			Parent: "*ytypes.xpathTestSubinterface",
+		}},
+	}, {
+		desc:     "validation of list entry",
+		inPath:   "/interfaces/interface",
+		inSchema: []string{"interfaces", "interface"},
+		inData: func(d *xpathTestDevice) interface{} {
+			return d.Interface["lo0"]
+		},
+		wantCalls: []customValidatorCall{{
+			Path:   "/interface[name=lo0]",
+			Value:  "*ytypes.xpathTestInterface",
+			Parent: "<nil>",
+		}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var gotCalls []customValidatorCall
+			r := NewCustomValidatorRegistry()
+			r.Register(tt.inPath, func(value interface{}, parent ygot.GoStruct, path *gpb.Path) error {
+				p, err := ygot.PathToString(path)
+				if err != nil {
+					return err
+				}
+				call := customValidatorCall{Path: p, Value: value, Parent: fmt.Sprintf("%T", parent)}
+				if _, ok := value.(ygot.GoStruct); ok {
+					call.Value = fmt.Sprintf("%T", value)
+				}
+				if parent == nil {
+					call.Parent = "<nil>"
+				}
+				gotCalls = append(gotCalls, call)
+				if tt.inFail != nil && tt.inFail(value) {
+					return fmt.Errorf("custom validation failed for %s", p)
+				}
+				return nil
+			})
+
+			schema := xpathTestSchema(nil)
+			d := xpathTestData()
+			var data interface{} = d
+			if tt.inData != nil {
+				data = tt.inData(d)
+			}
+			for _, n := range tt.inSchema {
+				schema = schema.Dir[n]
+			}
+
+			errs := Validate(schema, data, &CustomValidationOptions{Validators: r})
+			var gotErrors []string
+			for _, err := range errs {
+				gotErrors = append(gotErrors, err.Error())
+			}
+			if diff := cmp.Diff(tt.wantErrors, gotErrors); diff != "" {
+				t.Errorf("Validate(): did not get expected errors, (-want, +got):\n%s", diff)
+			}
+			for _, ve := range ValidationErrors(errs) {
+				if ve.Kind != CustomConstraint {
+					t.Errorf("Validate(): got error %v with kind %v, want %v", ve, ve.Kind, CustomConstraint)
+				}
+			}
+
+			sort.Slice(gotCalls, func(i, j int) bool { return gotCalls[i].Path < gotCalls[j].Path })
+			if diff := cmp.Diff(tt.wantCalls, gotCalls); diff != "" {
+				t.Errorf("Validate(): did not get expected validator calls, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
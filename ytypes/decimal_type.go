@@ -23,11 +23,17 @@ import (
 // Refer to: https://tools.ietf.org/html/rfc6020#section-9.3.
 
 // ValidateDecimalRestrictions checks that the given decimal matches the
-// schema's range restrictions (if any). It returns an error if the validation
-// fails.
+// schema's range restrictions (if any). It returns a *ValidationError if the
+// validation fails, as per ValidateStringRestrictions.
 func ValidateDecimalRestrictions(schemaType *yang.YangType, floatVal float64) error {
+	return validateDecimalRestrictions(nil, schemaType, floatVal)
+}
+
+// validateDecimalRestrictions implements ValidateDecimalRestrictions, using
+// the error statements of the type statement of schema where it is non-nil.
+func validateDecimalRestrictions(schema *yang.Entry, schemaType *yang.YangType, floatVal float64) error {
 	if !isInRanges(schemaType.Range, yang.FromFloat(floatVal)) {
-		return fmt.Errorf("decimal value %v is outside specified ranges", floatVal)
+		return newRestrictionError(RangeConstraint, schema, schemaType, floatVal, "", fmt.Errorf("decimal value %v is outside specified ranges", floatVal))
 	}
 	return nil
 }
@@ -46,8 +52,8 @@ func validateDecimal(schema *yang.Entry, value interface{}) error {
 		return fmt.Errorf("non float64 type %T with value %v for schema %s", value, value, schema.Name)
 	}
 
-	if err := ValidateDecimalRestrictions(schema.Type, f); err != nil {
-		return fmt.Errorf("schema %q: %v", schema.Name, err)
+	if err := validateDecimalRestrictions(schema, schema.Type, f); err != nil {
+		return fmt.Errorf("schema %q: %w", schema.Name, err)
 	}
 
 	return nil
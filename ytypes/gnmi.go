@@ -17,6 +17,7 @@ package ytypes
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
@@ -34,25 +35,45 @@ import (
 // to calling this function.
 //
 // If an error occurs during unmarshalling, schema.Root may already be
-// modified. A rollback is not performed.
+// modified. A rollback is not performed, unless the Transactional option is
+// specified, in which case the Notifications are applied as a single
+// transaction.
 func UnmarshalNotifications(schema *Schema, ns []*gpb.Notification, opts ...UnmarshalOpt) error {
+	if hasTransactional(opts) != nil {
+		return transact(schema, opts, func(s *Schema, opts []UnmarshalOpt) error {
+			return unmarshalNotifications(s, ns, opts, true)
+		})
+	}
+	return unmarshalNotifications(schema, ns, opts, false)
+}
+
+// unmarshalNotifications applies ns to schema.Root as per
+// UnmarshalNotifications. Where opErrs is set, the error of each operation
+// that cannot be applied is a *SetRequestError.
+func unmarshalNotifications(schema *Schema, ns []*gpb.Notification, opts []UnmarshalOpt, opErrs bool) error {
 	for _, n := range ns {
-		deletePaths := n.Delete
-		if n.Atomic {
-			deletePaths = append(deletePaths, &gpb.Path{})
-		}
-		err := UnmarshalSetRequest(schema, &gpb.SetRequest{
-			Prefix: n.Prefix,
-			Delete: deletePaths,
-			Update: n.Update,
-		}, opts...)
-		if err != nil {
+		if err := unmarshalSetRequest(schema, notificationSetRequest(n), opts, opErrs); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// notificationSetRequest returns the SetRequest that is equivalent to n,
+// which deletes the prefix of n before applying its updates where n is
+// atomic.
+func notificationSetRequest(n *gpb.Notification) *gpb.SetRequest {
+	deletePaths := n.GetDelete()
+	if n.GetAtomic() {
+		deletePaths = append(deletePaths, &gpb.Path{})
+	}
+	return &gpb.SetRequest{
+		Prefix: n.GetPrefix(),
+		Delete: deletePaths,
+		Update: n.GetUpdate(),
+	}
+}
+
 // UnmarshalSetRequest applies a SetRequest on the root GoStruct specified by
 // "schema". It *does not* perform validation after unmarshalling is complete.
 //
@@ -60,36 +81,117 @@ func UnmarshalNotifications(schema *Schema, ns []*gpb.Notification, opts ...Unma
 // using ygot.DeepCopy() if you wish to retain the value at schema.Root prior
 // to calling this function.
 //
+// The operations are processed in the order deletes, replaces,
+// union_replaces, then updates. The values of the union_replace operations
+// at the same path are merged, after the data at that path is deleted.
+//
+// Where the Origins option is specified, only the operations whose paths are
+// of the OpenConfig origin are applied to schema.Root, and those of other
+// origins are applied as specified by the option, in the lexical order of
+// their origin. Otherwise, the origins of the paths are ignored, and all of
+// the operations are applied to schema.Root.
+//
 // If an error occurs during unmarshalling, schema.Root may already be
-// modified. A rollback is not performed.
+// modified. A rollback is not performed, unless the Transactional option is
+// specified, in which case the error of each operation of the SetRequest
+// that cannot be applied is a *SetRequestError.
 func UnmarshalSetRequest(schema *Schema, req *gpb.SetRequest, opts ...UnmarshalOpt) error {
-	preferShadowPath := hasPreferShadowPath(opts)
-	ignoreExtraFields := hasIgnoreExtraFields(opts)
-	bestEffortUnmarshal := hasBestEffortUnmarshal(opts)
+	if hasTransactional(opts) != nil {
+		return transact(schema, opts, func(s *Schema, opts []UnmarshalOpt) error {
+			return unmarshalSetRequest(s, req, opts, true)
+		})
+	}
+	return unmarshalSetRequest(schema, req, opts, false)
+}
+
+// unmarshalSetRequest applies req to schema.Root as per UnmarshalSetRequest.
+// Where opErrs is set, the error of each operation that cannot be applied is
+// a *SetRequestError.
+func unmarshalSetRequest(schema *Schema, req *gpb.SetRequest, opts []UnmarshalOpt, opErrs bool) error {
 	if req == nil {
 		return nil
 	}
+	if hasOrigins(opts) == nil {
+		return applySetRequest(schema, req, opts, opErrs)
+	}
+	reqs, err := splitSetRequestByOrigin(req, opErrs)
+	if err != nil {
+		return err
+	}
+
+	bestEffortUnmarshal := hasBestEffortUnmarshal(opts)
+	var complianceErrs *ComplianceErrors
+	for _, r := range reqs {
+		err := unmarshalOriginSetRequest(schema, r.origin, r.req, opts, opErrs)
+		if err == nil {
+			continue
+		}
+		r.remapErrors(err)
+		if ce, ok := err.(*ComplianceErrors); ok && bestEffortUnmarshal {
+			complianceErrs = complianceErrs.append(ce.Errors...)
+			continue
+		}
+		return err
+	}
+
+	if bestEffortUnmarshal && complianceErrs != nil {
+		return complianceErrs
+	}
+	return nil
+}
+
+// unmarshalOriginSetRequest applies req, whose paths are of the supplied
+// origin, to schema.Root where origin is the OpenConfig origin, and otherwise
+// as specified by the Origins option, which must be present within opts.
+func unmarshalOriginSetRequest(schema *Schema, origin string, req *gpb.SetRequest, opts []UnmarshalOpt, opErrs bool) error {
+	if isOpenConfigOrigin(origin) {
+		return applySetRequest(schema, req, opts, opErrs)
+	}
+	o := hasOrigins(opts)
+	switch {
+	case o.Schemas[origin] != nil:
+		return applySetRequest(o.Schemas[origin], req, opts, opErrs)
+	case o.Opaque != nil:
+		return o.Opaque.apply(origin, req, opErrs)
+	}
+	return fmt.Errorf("no schema for origin %q", origin)
+}
+
+// applySetRequest applies req to schema.Root, ignoring the origins of its
+// paths. Where opErrs is set, the error of each operation that cannot be
+// applied is a *SetRequestError.
+func applySetRequest(schema *Schema, req *gpb.SetRequest, opts []UnmarshalOpt, opErrs bool) error {
+	preferShadowPath := hasPreferShadowPath(opts)
+	ignoreExtraFields := hasIgnoreExtraFields(opts)
+	bestEffortUnmarshal := hasBestEffortUnmarshal(opts)
 	root := schema.Root
 	rootName := reflect.TypeOf(root).Elem().Name()
 
 	var complianceErrs *ComplianceErrors
 
-	// Process deletes, then replace, then updates.
-	if err := deletePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Delete, preferShadowPath, bestEffortUnmarshal); err != nil {
+	// Process deletes, then replace, then union_replace, then updates.
+	if err := deletePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Delete, preferShadowPath, bestEffortUnmarshal, opErrs); err != nil {
+		if bestEffortUnmarshal {
+			complianceErrs = complianceErrs.append(err.(*ComplianceErrors).Errors...)
+		} else {
+			return err
+		}
+	}
+	if err := replacePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Replace, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal, opErrs); err != nil {
 		if bestEffortUnmarshal {
 			complianceErrs = complianceErrs.append(err.(*ComplianceErrors).Errors...)
 		} else {
 			return err
 		}
 	}
-	if err := replacePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Replace, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal); err != nil {
+	if err := unionReplacePaths(schema.SchemaTree[rootName], root, req.Prefix, req.UnionReplace, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal, opErrs); err != nil {
 		if bestEffortUnmarshal {
 			complianceErrs = complianceErrs.append(err.(*ComplianceErrors).Errors...)
 		} else {
 			return err
 		}
 	}
-	if err := updatePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal); err != nil {
+	if err := updatePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal, opErrs); err != nil {
 		if bestEffortUnmarshal {
 			complianceErrs = complianceErrs.append(err.(*ComplianceErrors).Errors...)
 		} else {
@@ -104,21 +206,22 @@ func UnmarshalSetRequest(schema *Schema, req *gpb.SetRequest, opts ...UnmarshalO
 }
 
 // deletePaths deletes a slice of paths from the given GoStruct.
-func deletePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, paths []*gpb.Path, preferShadowPath, bestEffortUnmarshal bool) error {
+func deletePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, paths []*gpb.Path, preferShadowPath, bestEffortUnmarshal, opErrs bool) error {
 	var dopts []DelNodeOpt
 	var ce *ComplianceErrors
 	if preferShadowPath {
 		dopts = append(dopts, &PreferShadowPath{})
 	}
 
-	for _, path := range paths {
+	for i, path := range paths {
 		if prefix != nil {
 			var err error
 			if path, err = util.JoinPaths(prefix, path); err != nil {
-				return fmt.Errorf("cannot join prefix with deletion path: %v", err)
+				return setRequestError(opErrs, gpb.UpdateResult_DELETE, i, paths[i], fmt.Errorf("cannot join prefix with deletion path: %v", err))
 			}
 		}
 		if err := DeleteNode(schema, goStruct, path, dopts...); err != nil {
+			err = setRequestError(opErrs, gpb.UpdateResult_DELETE, i, path, err)
 			if bestEffortUnmarshal {
 				ce = ce.append(err)
 				continue
@@ -157,26 +260,79 @@ func joinPrefixToUpdate(prefix *gpb.Path, update *gpb.Update) (*gpb.Update, erro
 // replacePaths unmarshals a slice of updates into the given GoStruct. It
 // deletes the values at these paths before unmarshalling them. These updates
 // can either by JSON-encoded or gNMI-encoded values (scalars).
-func replacePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, updates []*gpb.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal bool) error {
+func replacePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, updates []*gpb.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal, opErrs bool) error {
 	var dopts []DelNodeOpt
 	var ce *ComplianceErrors
 	if preferShadowPath {
 		dopts = append(dopts, &PreferShadowPath{})
 	}
 
-	for _, update := range updates {
+	for i, update := range updates {
 		var err error
 		if update, err = joinPrefixToUpdate(prefix, update); err != nil {
-			return err
+			return setRequestError(opErrs, gpb.UpdateResult_REPLACE, i, updates[i].GetPath(), err)
 		}
 		if err := DeleteNode(schema, goStruct, update.Path, dopts...); err != nil {
+			err = setRequestError(opErrs, gpb.UpdateResult_REPLACE, i, update.Path, err)
+			if bestEffortUnmarshal {
+				ce = ce.append(err)
+				continue
+			}
+			return err
+		}
+		if err := setNode(schema, goStruct, update, preferShadowPath, ignoreExtraFields); err != nil {
+			err = setRequestError(opErrs, gpb.UpdateResult_REPLACE, i, update.Path, err)
+			if bestEffortUnmarshal {
+				ce = ce.append(err)
+				continue
+			}
+			return err
+		}
+	}
+	if bestEffortUnmarshal && ce != nil {
+		return ce
+	}
+	return nil
+}
+
+// unionReplacePaths unmarshals a slice of union_replace updates into the given
+// GoStruct. It deletes the values at each of the distinct paths of the
+// updates before unmarshalling any of them, such that the values of the
+// updates at the same path, or at paths within one another, are merged.
+func unionReplacePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, updates []*gpb.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal, opErrs bool) error {
+	var dopts []DelNodeOpt
+	var ce *ComplianceErrors
+	if preferShadowPath {
+		dopts = append(dopts, &PreferShadowPath{})
+	}
+
+	joined := make([]*gpb.Update, len(updates))
+	deleted := map[string]bool{}
+	for i, update := range updates {
+		var err error
+		if joined[i], err = joinPrefixToUpdate(prefix, update); err != nil {
+			return setRequestError(opErrs, gpb.UpdateResult_UNION_REPLACE, i, update.GetPath(), err)
+		}
+		p, err := ygot.PathToString(joined[i].Path)
+		if err != nil {
+			return setRequestError(opErrs, gpb.UpdateResult_UNION_REPLACE, i, joined[i].Path, err)
+		}
+		if deleted[p] {
+			continue
+		}
+		deleted[p] = true
+		if err := DeleteNode(schema, goStruct, joined[i].Path, dopts...); err != nil {
+			err = setRequestError(opErrs, gpb.UpdateResult_UNION_REPLACE, i, joined[i].Path, err)
 			if bestEffortUnmarshal {
 				ce = ce.append(err)
 				continue
 			}
 			return err
 		}
+	}
+	for i, update := range joined {
 		if err := setNode(schema, goStruct, update, preferShadowPath, ignoreExtraFields); err != nil {
+			err = setRequestError(opErrs, gpb.UpdateResult_UNION_REPLACE, i, update.Path, err)
 			if bestEffortUnmarshal {
 				ce = ce.append(err)
 				continue
@@ -192,15 +348,16 @@ func replacePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path,
 
 // updatePaths unmarshals a slice of updates into the given GoStruct. These
 // updates can either by JSON-encoded or gNMI-encoded values (scalars).
-func updatePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, updates []*gpb.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal bool) error {
+func updatePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, updates []*gpb.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal, opErrs bool) error {
 	var ce *ComplianceErrors
 
-	for _, update := range updates {
+	for i, update := range updates {
 		var err error
 		if update, err = joinPrefixToUpdate(prefix, update); err != nil {
-			return err
+			return setRequestError(opErrs, gpb.UpdateResult_UPDATE, i, updates[i].GetPath(), err)
 		}
 		if err := setNode(schema, goStruct, update, preferShadowPath, ignoreExtraFields); err != nil {
+			err = setRequestError(opErrs, gpb.UpdateResult_UPDATE, i, update.Path, err)
 			if bestEffortUnmarshal {
 				ce = ce.append(err)
 				continue
@@ -216,7 +373,10 @@ func updatePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, u
 }
 
 // setNode unmarshals either a JSON-encoded value or a gNMI-encoded (scalar)
-// value into the given GoStruct.
+// value into the given GoStruct. Where the JSON value of an entry of an
+// `ordered-by user` list has the yang:insert and yang:key annotations of RFC
+// 7950, the entry is moved to the position that they specify, rather than
+// being appended if it is created.
 func setNode(schema *yang.Entry, goStruct ygot.GoStruct, update *gpb.Update, preferShadowPath, ignoreExtraFields bool) error {
 	sopts := []SetNodeOpt{&InitMissingElements{}}
 	if preferShadowPath {
@@ -226,8 +386,188 @@ func setNode(schema *yang.Entry, goStruct ygot.GoStruct, update *gpb.Update, pre
 		sopts = append(sopts, &IgnoreExtraFields{})
 	}
 
-	if err := SetNode(schema, goStruct, update.Path, update.Val, sopts...); err != nil {
+	val, ins, err := orderedMapInsertionOf(update.Val)
+	if err != nil {
+		return fmt.Errorf("setNode: %v", err)
+	}
+	if err := SetNode(schema, goStruct, update.Path, val, sopts...); err != nil {
 		return fmt.Errorf("setNode: %v", err)
 	}
+	if ins == nil {
+		return nil
+	}
+	if _, err := retrieveNode(schema, goStruct, update.Path, nil, retrieveNodeArgs{
+		insert:           ins,
+		preferShadowPath: preferShadowPath,
+	}); err != nil {
+		return fmt.Errorf("setNode: %v", err)
+	}
+	if !ins.done {
+		return fmt.Errorf("setNode: %s annotation of %v, which is not an entry of an ordered-by user list", ygot.YANGInsertAnnotation, update.Path)
+	}
+	return nil
+}
+
+// Transactional is an unmarshal option that specifies that UnmarshalSetRequest
+// and UnmarshalNotifications apply their input as a single transaction, such
+// that either all of it is applied to schema.Root, or, if any error occurs,
+// schema.Root is not modified. The input is applied to a copy of schema.Root,
+// which is then copied into schema.Root, such that schema.Root remains the
+// same pointer, but GoStructs within it may be replaced. The roots of the
+// schemas, and the opaque data, specified by the Origins option are part of
+// the same transaction.
+//
+// The error of each operation that cannot be applied is a *SetRequestError,
+// which identifies the operation within its SetRequest. Where
+// BestEffortUnmarshal is also specified, all of the errors are returned, and
+// schema.Root is not modified if there are any.
+type Transactional struct {
+	// Validate specifies that the result of the transaction is validated
+	// prior to it being committed, such that schema.Root is not modified
+	// if it is not valid. The error returned by validation is returned,
+	// whose ValidationErrors can be retrieved using ValidationErrors.
+	Validate bool
+	// ValidationOpts are the options used to validate the result of the
+	// transaction.
+	ValidationOpts []ygot.ValidationOption
+}
+
+// IsUnmarshalOpt marks Transactional as a valid UnmarshalOpt.
+func (*Transactional) IsUnmarshalOpt() {}
+
+// hasTransactional returns the first Transactional option from an opts
+// slice, or nil if there isn't one.
+func hasTransactional(opts []UnmarshalOpt) *Transactional {
+	for _, o := range opts {
+		if t, ok := o.(*Transactional); ok {
+			return t
+		}
+	}
+	return nil
+}
+
+// transact calls apply with copies of schema, and of the schemas and opaque
+// data specified by the Origins option within opts, whose roots are copies of
+// the originals, along with opts that specify the copies rather than the
+// originals. It copies the results into the originals if apply succeeds and,
+// where the Transactional option specifies it, each of the roots is valid.
+func transact(schema *Schema, opts []UnmarshalOpt, apply func(*Schema, []UnmarshalOpt) error) error {
+	t := hasTransactional(opts)
+	txn, err := copySchema(schema)
+	if err != nil {
+		return err
+	}
+	type txnSchema struct{ orig, txn *Schema }
+	schemas := []txnSchema{{schema, txn}}
+
+	var origins, txnOrigins *Origins
+	var txnOpts []UnmarshalOpt
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *Transactional:
+			continue
+		case *Origins:
+			if origins != nil {
+				break
+			}
+			origins = v
+			txnOrigins = &Origins{Schemas: map[string]*Schema{}, Opaque: v.Opaque.copy()}
+			for origin, s := range v.Schemas {
+				ts, err := copySchema(s)
+				if err != nil {
+					return fmt.Errorf("origin %q: %v", origin, err)
+				}
+				txnOrigins.Schemas[origin] = ts
+				schemas = append(schemas, txnSchema{s, ts})
+			}
+			o = txnOrigins
+		}
+		txnOpts = append(txnOpts, o)
+	}
+
+	if err := apply(txn, txnOpts); err != nil {
+		return err
+	}
+	if t.Validate {
+		for _, s := range schemas {
+			if err := ygot.ValidateGoStruct(s.txn.Root, t.ValidationOpts...); err != nil {
+				return err
+			}
+		}
+	}
+	for _, s := range schemas {
+		reflect.ValueOf(s.orig.Root).Elem().Set(reflect.ValueOf(s.txn.Root).Elem())
+	}
+	if origins != nil && origins.Opaque != nil {
+		for o := range origins.Opaque {
+			delete(origins.Opaque, o)
+		}
+		for o, vs := range txnOrigins.Opaque {
+			origins.Opaque[o] = vs
+		}
+	}
 	return nil
 }
+
+// copySchema returns a copy of schema whose root is a copy of schema.Root.
+func copySchema(schema *Schema) (*Schema, error) {
+	if schema == nil || schema.Root == nil {
+		return nil, fmt.Errorf("cannot copy nil root")
+	}
+	root, err := ygot.DeepCopy(schema.Root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy root: %v", err)
+	}
+	txn := *schema
+	txn.Root = root
+	return &txn, nil
+}
+
+// SetRequestError is the error of an operation of a SetRequest that cannot be
+// applied by UnmarshalSetRequest or UnmarshalNotifications where the
+// Transactional option is specified.
+type SetRequestError struct {
+	// Op is the kind of operation, which is DELETE for the paths of the
+	// delete field of the SetRequest, REPLACE for the replace field,
+	// UNION_REPLACE for the union_replace field, and UPDATE for the update
+	// field.
+	Op gpb.UpdateResult_Operation
+	// Index is the index of the operation within its field of the
+	// SetRequest.
+	Index int
+	// Path is the path of the operation, joined with the prefix of the
+	// SetRequest where possible.
+	Path *gpb.Path
+	// Err is the underlying error.
+	Err error
+}
+
+// newSetRequestError returns a SetRequestError for the operation with the
+// supplied kind, index and path, and the underlying error err.
+func newSetRequestError(op gpb.UpdateResult_Operation, i int, path *gpb.Path, err error) *SetRequestError {
+	return &SetRequestError{Op: op, Index: i, Path: path, Err: err}
+}
+
+// setRequestError returns a SetRequestError for the operation with the
+// supplied kind, index and path, and the underlying error err, where opErrs is
+// set. Otherwise, err is returned unmodified.
+func setRequestError(opErrs bool, op gpb.UpdateResult_Operation, i int, path *gpb.Path, err error) error {
+	if !opErrs {
+		return err
+	}
+	return newSetRequestError(op, i, path, err)
+}
+
+// Error implements the error interface.
+func (e *SetRequestError) Error() string {
+	p, err := ygot.PathToString(e.Path)
+	if err != nil {
+		p = e.Path.String()
+	}
+	return fmt.Sprintf("%s %d of %s: %v", strings.ToLower(e.Op.String()), e.Index, p, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *SetRequestError) Unwrap() error {
+	return e.Err
+}
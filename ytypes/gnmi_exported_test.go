@@ -15,15 +15,19 @@
 package ytypes_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
 	"github.com/openconfig/ygot/integration_tests/schemaops/utestschema"
 	"github.com/openconfig/ygot/internal/ytestutil"
 	"github.com/openconfig/ygot/ygot"
 	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestUnmarshalNotificationsOrderedMap(t *testing.T) {
@@ -149,3 +153,633 @@ func TestUnmarshalNotificationsOrderedMap(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalSetRequestOrderedMapInsert(t *testing.T) {
+	jsonVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(s)}}
+	}
+	orderedMap := func(keys ...string) *ctestschema.OrderedList_OrderedMap {
+		om := &ctestschema.OrderedList_OrderedMap{}
+		for _, k := range keys {
+			v, err := om.AppendNew(k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.Value = ygot.String(k + "-val")
+		}
+		return om
+	}
+	device := func(keys ...string) *ctestschema.Device {
+		return &ctestschema.Device{
+			OrderedList:           orderedMap(keys...),
+			OrderedMultikeyedList: ctestschema.GetOrderedMapMultikeyed(t),
+		}
+	}
+
+	tests := []struct {
+		desc       string
+		inRequest  *gpb.SetRequest
+		want       ygot.GoStruct
+		wantErrSub string
+	}{{
+		desc: "insert first",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=d]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "first"}, "ctestschema:config": {"key": "d", "value": "d-val"}}`),
+			}},
+		},
+		want: device("d", "a", "b", "c"),
+	}, {
+		desc: "insert before entry",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=d]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "before", "yang:key": "[ctestschema:key=\"b\"]"}, "ctestschema:config": {"key": "d", "value": "d-val"}}`),
+			}},
+		},
+		want: device("a", "d", "b", "c"),
+	}, {
+		desc: "insert after last entry",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=d]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "after", "yang:key": "[key='c']"}, "ctestschema:config": {"key": "d", "value": "d-val"}}`),
+			}},
+		},
+		want: device("a", "b", "c", "d"),
+	}, {
+		desc: "move last entry first, and first entry last",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=c]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "first"}}`),
+			}, {
+				Path: mustPath(`/ordered-lists/ordered-list[key=a]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "last"}}`),
+			}},
+		},
+		want: device("c", "b", "a"),
+	}, {
+		desc: "replace entry and move it after another",
+		inRequest: &gpb.SetRequest{
+			Replace: []*gpb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=a]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "after", "yang:key": "[key='b']"}, "ctestschema:config": {"key": "a", "value": "new"}}`),
+			}},
+		},
+		want: func() *ctestschema.Device {
+			d := device("b", "a", "c")
+			d.OrderedList.Get("a").Value = ygot.String("new")
+			return d
+		}(),
+	}, {
+		desc: "move entry of multi-keyed ordered map",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath(`/ordered-multikeyed-lists/ordered-multikeyed-list[key1=foo][key2=42]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "after", "yang:key": "[key2='84'][key1='baz']"}}`),
+			}},
+		},
+		want: func() *ctestschema.Device {
+			d := device("a", "b", "c")
+			k := ctestschema.OrderedMultikeyedList_Key{Key1: "foo", Key2: 42}
+			foo := d.OrderedMultikeyedList.Get(k)
+			d.OrderedMultikeyedList.Delete(k)
+			if err := d.OrderedMultikeyedList.Append(foo); err != nil {
+				t.Fatal(err)
+			}
+			return d
+		}(),
+	}, {
+		desc: "point does not exist",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=a]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "before", "yang:key": "[key='x']"}}`),
+			}},
+		},
+		wantErrSub: "which does not exist",
+	}, {
+		desc: "invalid position",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=a]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "middle"}}`),
+			}},
+		},
+		wantErrSub: "invalid yang:insert annotation",
+	}, {
+		desc: "missing point",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=a]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "after"}}`),
+			}},
+		},
+		wantErrSub: "requires a yang:key annotation",
+	}, {
+		desc: "invalid point",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath(`/ordered-lists/ordered-list[key=a]`),
+				Val:  jsonVal(`{"@": {"yang:insert": "after", "yang:key": "key=b"}}`),
+			}},
+		},
+		wantErrSub: "invalid yang:key annotation",
+	}, {
+		desc: "not an entry of an ordered map",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath(`/other-data`),
+				Val:  jsonVal(`{"@": {"yang:insert": "first"}}`),
+			}},
+		},
+		wantErrSub: "which is not an entry of an ordered-by user list",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := &ytypes.Schema{Root: device("a", "b", "c"), SchemaTree: ctestschema.SchemaTree}
+			err := ytypes.UnmarshalSetRequest(schema, tt.inRequest)
+			if diff := errdiff.Substring(err, tt.wantErrSub); diff != "" {
+				t.Fatalf("UnmarshalSetRequest: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, schema.Root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Errorf("UnmarshalSetRequest (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalSetRequestTransactional(t *testing.T) {
+	strVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+	}
+	motdUpdate := &gpb.Update{Path: mustPath("other-data/config/motd"), Val: strVal("hello")}
+	badUpdate := func(name string) *gpb.Update {
+		return &gpb.Update{Path: mustPath("other-data/config/" + name), Val: strVal("bad")}
+	}
+	// orderedListUpdates returns the updates that append n entries to the
+	// ordered list, which has a max-elements of 5.
+	orderedListUpdates := func(n int) []*gpb.Update {
+		var us []*gpb.Update
+		for i := 0; i < n; i++ {
+			k := fmt.Sprintf("key%d", i)
+			us = append(us, &gpb.Update{
+				Path: mustPath(fmt.Sprintf("ordered-lists/ordered-list[key=%s]/config/key", k)),
+				Val:  strVal(k),
+			}, &gpb.Update{
+				Path: mustPath(fmt.Sprintf("ordered-lists/ordered-list[key=%s]/key", k)),
+				Val:  strVal(k),
+			})
+		}
+		return us
+	}
+	orig := func() *ctestschema.Device {
+		return &ctestschema.Device{
+			OrderedList: ctestschema.GetOrderedMap(t),
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": {Key: ygot.String("foo"), Value: ygot.String("foo-val")},
+			},
+		}
+	}
+
+	tests := []struct {
+		desc            string
+		inRequest       *gpb.SetRequest
+		inUnmarshalOpts []ytypes.UnmarshalOpt
+		want            *ctestschema.Device
+		// wantErr specifies that an error is returned, which is not a
+		// SetRequestError.
+		wantErr bool
+		// wantErrs are the operations of the SetRequestErrors returned,
+		// in the form "<op> <index>".
+		wantErrs []string
+		// wantValidationErr specifies that validation fails.
+		wantValidationErr bool
+	}{{
+		desc: "transaction applied",
+		inRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{mustPath("unordered-lists/unordered-list[key=foo]")},
+			Update: []*gpb.Update{motdUpdate},
+		},
+		inUnmarshalOpts: []ytypes.UnmarshalOpt{&ytypes.Transactional{Validate: true}},
+		want: func() *ctestschema.Device {
+			d := orig()
+			d.UnorderedList = nil
+			d.OtherData = &ctestschema.OtherData{Motd: ygot.String("hello")}
+			return d
+		}(),
+	}, {
+		desc: "non-transactional request partially applied",
+		inRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{mustPath("unordered-lists/unordered-list[key=foo]")},
+			Update: []*gpb.Update{motdUpdate, badUpdate("foo")},
+		},
+		want: func() *ctestschema.Device {
+			d := orig()
+			d.UnorderedList = nil
+			d.OtherData = &ctestschema.OtherData{Motd: ygot.String("hello")}
+			return d
+		}(),
+		wantErr: true,
+	}, {
+		desc: "transaction rolled back",
+		inRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{mustPath("unordered-lists/unordered-list[key=foo]")},
+			Update: []*gpb.Update{motdUpdate, motdUpdate, badUpdate("foo")},
+		},
+		inUnmarshalOpts: []ytypes.UnmarshalOpt{&ytypes.Transactional{}},
+		want:            orig(),
+		wantErrs:        []string{"UPDATE 2"},
+	}, {
+		desc: "transaction rolled back on failed replace",
+		inRequest: &gpb.SetRequest{
+			Replace: []*gpb.Update{motdUpdate, badUpdate("foo")},
+		},
+		inUnmarshalOpts: []ytypes.UnmarshalOpt{&ytypes.Transactional{}},
+		want:            orig(),
+		wantErrs:        []string{"REPLACE 1"},
+	}, {
+		desc: "best effort transaction rolled back",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{badUpdate("foo"), motdUpdate, badUpdate("bar")},
+		},
+		inUnmarshalOpts: []ytypes.UnmarshalOpt{&ytypes.Transactional{}, &ytypes.BestEffortUnmarshal{}},
+		want:            orig(),
+		wantErrs:        []string{"UPDATE 0", "UPDATE 2"},
+	}, {
+		desc: "transaction valid",
+		inRequest: &gpb.SetRequest{
+			Update: orderedListUpdates(3),
+		},
+		inUnmarshalOpts: []ytypes.UnmarshalOpt{&ytypes.Transactional{Validate: true}},
+		want: func() *ctestschema.Device {
+			d := orig()
+			for i := 0; i < 3; i++ {
+				if _, err := d.OrderedList.AppendNew(fmt.Sprintf("key%d", i)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return d
+		}(),
+	}, {
+		desc: "transaction invalid",
+		inRequest: &gpb.SetRequest{
+			Update: orderedListUpdates(4),
+		},
+		inUnmarshalOpts:   []ytypes.UnmarshalOpt{&ytypes.Transactional{Validate: true}},
+		want:              orig(),
+		wantValidationErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := orig()
+			schema := &ytypes.Schema{Root: root, SchemaTree: ctestschema.SchemaTree}
+			err := ytypes.UnmarshalSetRequest(schema, tt.inRequest, tt.inUnmarshalOpts...)
+			if gotErr, wantErr := err != nil, tt.wantErr || len(tt.wantErrs) != 0 || tt.wantValidationErr; gotErr != wantErr {
+				t.Fatalf("got error: %v, want: %v", err, wantErr)
+			}
+
+			var errs []error
+			var ce *ytypes.ComplianceErrors
+			if errors.As(err, &ce) {
+				errs = ce.Errors
+			} else if err != nil {
+				errs = []error{err}
+			}
+			var gotErrs []string
+			for _, e := range errs {
+				var se *ytypes.SetRequestError
+				if errors.As(e, &se) {
+					gotErrs = append(gotErrs, fmt.Sprintf("%v %d", se.Op, se.Index))
+				}
+			}
+			if diff := cmp.Diff(tt.wantErrs, gotErrs); diff != "" {
+				t.Errorf("SetRequestErrors (-want, +got):\n%s", diff)
+			}
+			if got := len(ytypes.ValidationErrors(err)) != 0; got != tt.wantValidationErr {
+				t.Errorf("got validation error: %v, want: %v", err, tt.wantValidationErr)
+			}
+
+			if schema.Root != root {
+				t.Errorf("root was replaced")
+			}
+			if diff := cmp.Diff(tt.want, root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Errorf("(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalNotificationsTransactional(t *testing.T) {
+	root := &ctestschema.Device{OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")}}
+	schema := &ytypes.Schema{Root: root, SchemaTree: ctestschema.SchemaTree}
+	err := ytypes.UnmarshalNotifications(schema, []*gpb.Notification{{
+		Update: []*gpb.Update{{
+			Path: mustPath("other-data/config/motd"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "world"}},
+		}},
+	}, {
+		Delete: []*gpb.Path{mustPath("other-data/config/foo")},
+	}}, &ytypes.Transactional{})
+	var se *ytypes.SetRequestError
+	if !errors.As(err, &se) || se.Op != gpb.UpdateResult_DELETE {
+		t.Fatalf("UnmarshalNotifications: got error %v, want delete SetRequestError", err)
+	}
+	if got, want := root.GetOtherData().GetMotd(), "hello"; got != want {
+		t.Errorf("UnmarshalNotifications: got motd %q after rollback, want %q", got, want)
+	}
+}
+
+func TestUnmarshalSetRequestOrigins(t *testing.T) {
+	strVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+	}
+	asciiVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: s}}
+	}
+	jsonVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(s)}}
+	}
+	withOrigin := func(origin, p string) *gpb.Path {
+		gp := mustPath(p)
+		gp.Origin = origin
+		return gp
+	}
+	orig := func() *ctestschema.Device {
+		return &ctestschema.Device{
+			OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")},
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": {Key: ygot.String("foo"), Value: ygot.String("foo-val")},
+			},
+		}
+	}
+	entity := func(name string) *utestschema.Device {
+		d := &utestschema.Device{}
+		d.GetOrCreateTarget().GetOrCreateEntity(name)
+		return d
+	}
+
+	tests := []struct {
+		desc            string
+		inRequest       *gpb.SetRequest
+		inUnmarshalOpts []ytypes.UnmarshalOpt
+		// inOpaque is the opaque data of the Origins option, if any.
+		inOpaque ytypes.OpaqueData
+		// inUTS specifies that the Origins option specifies a schema
+		// for the "uts" origin.
+		inUTS      bool
+		want       *ctestschema.Device
+		wantUTS    *utestschema.Device
+		wantOpaque ytypes.OpaqueData
+		wantErr    bool
+	}{{
+		desc: "union_replace merges values at the same path",
+		inRequest: &gpb.SetRequest{
+			UnionReplace: []*gpb.Update{{
+				Path: mustPath("unordered-lists/unordered-list[key=foo]"),
+				Val:  jsonVal(`{"config":{"value":"a"}}`),
+			}, {
+				Path: mustPath("other-data/config/motd"),
+				Val:  strVal("world"),
+			}, {
+				Path: mustPath("unordered-lists/unordered-list[key=foo]"),
+				Val:  jsonVal(`{"key":"foo","config":{"key":"foo"}}`),
+			}},
+		},
+		want: &ctestschema.Device{
+			OtherData: &ctestschema.OtherData{Motd: ygot.String("world")},
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": {Key: ygot.String("foo"), Value: ygot.String("a")},
+			},
+		},
+	}, {
+		desc: "union_replace merges values within one another",
+		inRequest: &gpb.SetRequest{
+			UnionReplace: []*gpb.Update{{
+				Path: mustPath("unordered-lists/unordered-list[key=foo]/config/value"),
+				Val:  strVal("bar"),
+			}, {
+				Path: mustPath("unordered-lists/unordered-list[key=foo]"),
+				Val:  jsonVal(`{"key":"foo","config":{"key":"foo"}}`),
+			}},
+		},
+		want: &ctestschema.Device{
+			OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")},
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": {Key: ygot.String("foo"), Value: ygot.String("bar")},
+			},
+		},
+	}, {
+		desc: "mixed origins with opaque cli",
+		inRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{withOrigin("cli", "/")},
+			UnionReplace: []*gpb.Update{{
+				Path: withOrigin("openconfig", "other-data/config/motd"),
+				Val:  strVal("world"),
+			}, {
+				Path: withOrigin("cli", "/"),
+				Val:  asciiVal("hostname foo"),
+			}},
+		},
+		inOpaque: ytypes.OpaqueData{"cli": {"/": asciiVal("hostname bar"), "/interfaces": asciiVal("mtu 1500")}},
+		want: &ctestschema.Device{
+			OtherData: &ctestschema.OtherData{Motd: ygot.String("world")},
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": {Key: ygot.String("foo"), Value: ygot.String("foo-val")},
+			},
+		},
+		wantOpaque: ytypes.OpaqueData{"cli": {"/": asciiVal("hostname foo")}},
+	}, {
+		desc: "origin in prefix",
+		inRequest: &gpb.SetRequest{
+			Prefix: &gpb.Path{Origin: "cli"},
+			Update: []*gpb.Update{{
+				Path: mustPath("interfaces"),
+				Val:  asciiVal("mtu 9000"),
+			}},
+		},
+		inOpaque:   ytypes.OpaqueData{},
+		want:       orig(),
+		wantOpaque: ytypes.OpaqueData{"cli": {"/interfaces": asciiVal("mtu 9000")}},
+	}, {
+		desc: "origin routed to schema",
+		inRequest: &gpb.SetRequest{
+			Replace: []*gpb.Update{{
+				Path: withOrigin("uts", "target/entity[name=x]/name"),
+				Val:  strVal("x"),
+			}, {
+				Path: mustPath("other-data/config/motd"),
+				Val:  strVal("world"),
+			}},
+		},
+		inUTS: true,
+		want: &ctestschema.Device{
+			OtherData: &ctestschema.OtherData{Motd: ygot.String("world")},
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": {Key: ygot.String("foo"), Value: ygot.String("foo-val")},
+			},
+		},
+		wantUTS: entity("x"),
+	}, {
+		desc: "transaction rolled back across origins",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: withOrigin("cli", "/"),
+				Val:  asciiVal("hostname foo"),
+			}, {
+				Path: withOrigin("uts", "target/entity[name=x]/name"),
+				Val:  strVal("x"),
+			}, {
+				Path: mustPath("other-data/config/foo"),
+				Val:  strVal("bad"),
+			}},
+		},
+		inUnmarshalOpts: []ytypes.UnmarshalOpt{&ytypes.Transactional{}},
+		inOpaque:        ytypes.OpaqueData{},
+		inUTS:           true,
+		want:            orig(),
+		wantUTS:         &utestschema.Device{},
+		wantOpaque:      ytypes.OpaqueData{},
+		wantErr:         true,
+	}, {
+		desc: "opaque values cannot be merged",
+		inRequest: &gpb.SetRequest{
+			UnionReplace: []*gpb.Update{{
+				Path: withOrigin("cli", "/"),
+				Val:  asciiVal("hostname foo"),
+			}, {
+				Path: withOrigin("cli", "/"),
+				Val:  asciiVal("hostname bar"),
+			}},
+		},
+		inOpaque: ytypes.OpaqueData{},
+		wantErr:  true,
+	}, {
+		desc: "origin without schema",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: withOrigin("cli", "/"),
+				Val:  asciiVal("hostname foo"),
+			}},
+		},
+		inUTS:   true,
+		wantErr: true,
+	}, {
+		desc: "origins ignored without Origins option",
+		inRequest: &gpb.SetRequest{
+			Prefix: &gpb.Path{Origin: "custom"},
+			Update: []*gpb.Update{{
+				Path: mustPath("other-data/config/motd"),
+				Val:  strVal("world"),
+			}},
+		},
+		want: &ctestschema.Device{
+			OtherData: &ctestschema.OtherData{Motd: ygot.String("world")},
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": {Key: ygot.String("foo"), Value: ygot.String("foo-val")},
+			},
+		},
+	}, {
+		desc: "conflicting origins",
+		inRequest: &gpb.SetRequest{
+			Prefix: &gpb.Path{Origin: "cli"},
+			Update: []*gpb.Update{{
+				Path: withOrigin("openconfig", "other-data/config/motd"),
+				Val:  strVal("world"),
+			}},
+		},
+		inOpaque: ytypes.OpaqueData{},
+		wantErr:  true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := &ytypes.Schema{Root: orig(), SchemaTree: ctestschema.SchemaTree}
+			uts := &ytypes.Schema{Root: &utestschema.Device{}, SchemaTree: utestschema.SchemaTree}
+			opts := tt.inUnmarshalOpts
+			if tt.inOpaque != nil || tt.inUTS {
+				o := &ytypes.Origins{Opaque: tt.inOpaque}
+				if tt.inUTS {
+					o.Schemas = map[string]*ytypes.Schema{"uts": uts}
+				}
+				opts = append(opts, o)
+			}
+
+			err := ytypes.UnmarshalSetRequest(schema, tt.inRequest, opts...)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error: %v, want: %v", err, tt.wantErr)
+			}
+			if tt.want != nil {
+				if diff := cmp.Diff(tt.want, schema.Root); diff != "" {
+					t.Errorf("root (-want, +got):\n%s", diff)
+				}
+			}
+			if tt.wantUTS != nil {
+				if diff := cmp.Diff(tt.wantUTS, uts.Root); diff != "" {
+					t.Errorf("uts root (-want, +got):\n%s", diff)
+				}
+			}
+			if tt.wantOpaque != nil {
+				if diff := cmp.Diff(tt.wantOpaque, tt.inOpaque, protocmp.Transform()); diff != "" {
+					t.Errorf("opaque data (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshalNotificationsOrigins(t *testing.T) {
+	schema := &ytypes.Schema{Root: &ctestschema.Device{}, SchemaTree: ctestschema.SchemaTree}
+	opaque := ytypes.OpaqueData{}
+	err := ytypes.UnmarshalNotifications(schema, []*gpb.Notification{{
+		Prefix: &gpb.Path{Origin: "cli"},
+		Update: []*gpb.Update{{
+			Path: mustPath("interfaces"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "mtu 9000"}},
+		}},
+	}, {
+		Update: []*gpb.Update{{
+			Path: mustPath("other-data/config/motd"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "hello"}},
+		}},
+	}}, &ytypes.Origins{Opaque: opaque})
+	if err != nil {
+		t.Fatalf("UnmarshalNotifications: unexpected error: %v", err)
+	}
+
+	wantRoot := &ctestschema.Device{OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")}}
+	if diff := cmp.Diff(wantRoot, schema.Root); diff != "" {
+		t.Errorf("root (-want, +got):\n%s", diff)
+	}
+	wantOpaque := ytypes.OpaqueData{"cli": {"/interfaces": &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "mtu 9000"}}}}
+	if diff := cmp.Diff(wantOpaque, opaque, protocmp.Transform()); diff != "" {
+		t.Errorf("opaque data (-want, +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshalSetRequestErrorIndex(t *testing.T) {
+	schema := &ytypes.Schema{Root: &ctestschema.Device{}, SchemaTree: ctestschema.SchemaTree}
+	err := ytypes.UnmarshalSetRequest(schema, &gpb.SetRequest{
+		Update: []*gpb.Update{{
+			Path: &gpb.Path{Origin: "cli"},
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "hostname foo"}},
+		}, {
+			Path: mustPath("other-data/config/foo"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "bad"}},
+		}},
+	}, &ytypes.Origins{Opaque: ytypes.OpaqueData{}}, &ytypes.Transactional{})
+	var se *ytypes.SetRequestError
+	if !errors.As(err, &se) {
+		t.Fatalf("UnmarshalSetRequest: got error %v, want SetRequestError", err)
+	}
+	if se.Op != gpb.UpdateResult_UPDATE || se.Index != 1 {
+		t.Errorf("UnmarshalSetRequest: got error for %v %d, want UPDATE 1", se.Op, se.Index)
+	}
+}
@@ -0,0 +1,350 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// ValidateIncremental validates the modified data tree, whose root schema is
+// supplied, where it is known that the original data tree was valid. Only the
+// subtrees that differ between original and modified, as determined by
+// ygot.Diff, are validated, along with the leafrefs, must and when statements
+// elsewhere in the data tree that may refer to them. The errors returned are
+// those that Validate returns for modified, provided that original is valid
+// with the same options; errors within parts of the data tree that are not
+// affected by the changes are not reported. The schema must be that of the
+// fakeroot.
+func ValidateIncremental(schema *yang.Entry, original, modified ygot.GoStruct, opts ...ygot.ValidationOption) util.Errors {
+	n, err := ygot.Diff(original, modified)
+	if err != nil {
+		return util.NewErrs(fmt.Errorf("cannot compute changes between data trees: %v", err))
+	}
+	return ValidateNotification(schema, modified, n, opts...)
+}
+
+// ValidateNotification validates the data tree value, whose root schema is
+// supplied, where it results from applying the changes described by the gNMI
+// Notification n, such as that returned by ygot.Diff, to a valid data tree.
+// Only the nodes that are affected by the updates and deletes within n are
+// validated, as described for ValidateIncremental. The schema must be that of
+// the fakeroot.
+func ValidateNotification(schema *yang.Entry, value ygot.GoStruct, n *gpb.Notification, opts ...ygot.ValidationOption) util.Errors {
+	if schema == nil || !util.IsFakeRoot(schema) {
+		return util.NewErrs(fmt.Errorf("incremental validation requires the schema of the fakeroot, got %v", schema))
+	}
+	return validate(schema, value, newChangeFilter(n), opts...)
+}
+
+// changeStatus describes the relationship between a node of the data tree and
+// the changes described by a changeFilter.
+type changeStatus int
+
+const (
+	// changeUnrelated indicates that the node is neither within, nor an
+	// ancestor of, a subtree that contains changes.
+	changeUnrelated changeStatus = iota
+	// changeAncestor indicates that the node is an ancestor of a subtree
+	// that contains changes.
+	changeAncestor
+	// changeInside indicates that the node is within a subtree that
+	// contains changes, such that it is validated in full.
+	changeInside
+)
+
+// changeFilter describes the set of changes made to a data tree, and is used
+// to determine which parts of the data tree are to be validated.
+type changeFilter struct {
+	// roots are the paths of the subtrees that contain changes, which are
+	// the parents of the nodes that are updated or deleted.
+	roots [][]*gpb.PathElem
+	// names is the set of names of the nodes along the changed paths.
+	names map[string]bool
+	// xpathDeps and leafrefDeps memoise whether an XPath expression or
+	// the path of a leafref refers to any of names.
+	xpathDeps   map[string]bool
+	leafrefDeps map[string]bool
+	// xpathSubtree and leafrefSubtree memoise whether a schema entry or
+	// any of its descendants has a must or when statement, or a leafref,
+	// that refers to any of names.
+	xpathSubtree   map[*yang.Entry]bool
+	leafrefSubtree map[*yang.Entry]bool
+}
+
+// newChangeFilter returns the changeFilter that describes the updates and
+// deletes within the notification n.
+func newChangeFilter(n *gpb.Notification) *changeFilter {
+	f := &changeFilter{
+		names:          map[string]bool{},
+		xpathDeps:      map[string]bool{},
+		leafrefDeps:    map[string]bool{},
+		xpathSubtree:   map[*yang.Entry]bool{},
+		leafrefSubtree: map[*yang.Entry]bool{},
+	}
+	var paths []*gpb.Path
+	for _, u := range n.GetUpdate() {
+		paths = append(paths, u.GetPath())
+	}
+	paths = append(paths, n.GetDelete()...)
+
+	for _, p := range paths {
+		var elems []*gpb.PathElem
+		for _, e := range append(append([]*gpb.PathElem{}, n.GetPrefix().GetElem()...), p.GetElem()...) {
+			name := util.StripModulePrefix(e.GetName())
+			f.names[name] = true
+			elems = append(elems, &gpb.PathElem{Name: name, Key: e.GetKey()})
+		}
+		if len(elems) > 0 {
+			elems = elems[:len(elems)-1]
+		}
+		f.roots = append(f.roots, elems)
+	}
+	return f
+}
+
+// status returns the relationship between the node with the supplied path and
+// the changes described by f.
+func (f *changeFilter) status(path []*gpb.PathElem) changeStatus {
+	s := changeUnrelated
+	for _, r := range f.roots {
+		if !changePathsMatch(path, r) {
+			continue
+		}
+		if len(path) >= len(r) {
+			return changeInside
+		}
+		s = changeAncestor
+	}
+	return s
+}
+
+// changePathsMatch reports whether the elements that a and b have in common
+// may refer to the same nodes. Elements match where their names are equal and
+// their keys are equal, or either element does not specify keys, such that
+// the path of a list is considered to be an ancestor of the path of each of
+// its entries.
+func changePathsMatch(a, b []*gpb.PathElem) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i].Name != b[i].Name && a[i].Name != "*" && b[i].Name != "*" {
+			return false
+		}
+		if len(a[i].Key) == 0 || len(b[i].Key) == 0 {
+			continue
+		}
+		for k, v := range a[i].Key {
+			if bv, ok := b[i].Key[k]; !ok || (v != bv && v != "*" && bv != "*") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// xpathStatusFunc returns a function that returns the relationship between
+// a node of a data tree used to evaluate XPath expressions and the changes
+// described by f. The status of each node is determined from that of its
+// parent where possible. Where f is nil, every node is considered to be
+// within a changed subtree.
+func (f *changeFilter) xpathStatusFunc() func(*xpathNode) changeStatus {
+	if f == nil {
+		return func(*xpathNode) changeStatus { return changeInside }
+	}
+	memo := map[*xpathNode]changeStatus{}
+	var statusOf func(n *xpathNode) changeStatus
+	statusOf = func(n *xpathNode) changeStatus {
+		if s, ok := memo[n]; ok {
+			return s
+		}
+		var s changeStatus
+		switch {
+		case n.parent != nil && statusOf(n.parent) != changeAncestor:
+			s = statusOf(n.parent)
+		default:
+			s = f.status(n.gnmiPath().GetElem())
+		}
+		memo[n] = s
+		return s
+	}
+	return statusOf
+}
+
+// evaluate reports whether the XPath expression expr of a must or when
+// statement that applies to a node whose status is s is to be evaluated. This
+// is the case where the node is within a changed subtree, or expr may refer
+// to a node that is changed.
+func (f *changeFilter) evaluate(s changeStatus, expr string) bool {
+	return f == nil || s == changeInside || f.xpathDependsOnChange(expr)
+}
+
+// skipXPathSubtree reports whether the must and when statements of the node
+// n, whose status is s, and of its descendants need not be evaluated.
+func (f *changeFilter) skipXPathSubtree(n *xpathNode, s changeStatus) bool {
+	if f == nil || s != changeUnrelated {
+		return false
+	}
+	// The when statements of the choice and case statements that the
+	// node is within apply to it.
+	for e := n.schema.Parent; e != nil && util.IsChoiceOrCase(e); e = e.Parent {
+		ws, err := whenStatements(e)
+		if err != nil {
+			// The statements are evaluated such that the
+			// error is reported.
+			return false
+		}
+		for _, w := range ws {
+			if f.xpathDependsOnChange(w.expr) {
+				return false
+			}
+		}
+	}
+	return !f.xpathSubtreeDependsOnChange(n.schema)
+}
+
+// xpathSubtreeDependsOnChange reports whether any must or when statement of
+// the schema entry e or its descendants may refer to a changed node.
+func (f *changeFilter) xpathSubtreeDependsOnChange(e *yang.Entry) bool {
+	if d, ok := f.xpathSubtree[e]; ok {
+		return d
+	}
+	f.xpathSubtree[e] = false
+	// Where the when statements cannot be determined, they are evaluated
+	// such that the error is reported.
+	ws, err := whenStatements(e)
+	d := err != nil
+	for _, s := range append(mustStatements(e), ws...) {
+		if f.xpathDependsOnChange(s.expr) {
+			d = true
+			break
+		}
+	}
+	for _, c := range e.Dir {
+		if d {
+			break
+		}
+		d = f.xpathSubtreeDependsOnChange(c)
+	}
+	f.xpathSubtree[e] = d
+	return d
+}
+
+// xpathDependsOnChange reports whether the XPath expression expr may refer
+// to a changed node, which is the case where a name test within it is the
+// name of a changed node, or it selects nodes irrespective of their names.
+// Expressions that cannot be parsed are considered to refer to changed nodes
+// such that the errors returned for them are the same as for Validate.
+func (f *changeFilter) xpathDependsOnChange(expr string) bool {
+	if d, ok := f.xpathDeps[expr]; ok {
+		return d
+	}
+	x, err := parseXPath(expr)
+	d := err != nil || f.xpathExprDependsOnChange(x)
+	f.xpathDeps[expr] = d
+	return d
+}
+
+// xpathExprDependsOnChange reports whether the parsed XPath expression x may
+// refer to a changed node.
+func (f *changeFilter) xpathExprDependsOnChange(x xpathExpr) bool {
+	anyOf := func(xs ...xpathExpr) bool {
+		for _, x := range xs {
+			if x != nil && f.xpathExprDependsOnChange(x) {
+				return true
+			}
+		}
+		return false
+	}
+	switch x := x.(type) {
+	case *xpathBinaryExpr:
+		return anyOf(x.lhs, x.rhs)
+	case *xpathNegateExpr:
+		return anyOf(x.expr)
+	case *xpathFuncExpr:
+		// deref() follows a leafref, whose path is not part of the
+		// expression.
+		return x.name == "deref" || anyOf(x.args...)
+	case *xpathFilterExpr:
+		return anyOf(append([]xpathExpr{x.expr}, x.preds...)...)
+	case *xpathPathExpr:
+		if anyOf(x.filter) {
+			return true
+		}
+		for _, s := range x.steps {
+			switch {
+			case s.name == "*" || f.names[s.name]:
+				return true
+			case s.nodeType != "" && s.axis != "self" && s.axis != "parent":
+				return true
+			case anyOf(s.preds...):
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// leafrefPathNameRE matches the names of the nodes within the path of a
+// leafref, including any module prefix.
+var leafrefPathNameRE = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_.\-]*(:[A-Za-z_][A-Za-z0-9_.\-]*)?`)
+
+// leafrefDependsOnChange reports whether the path of the leafref with the
+// schema e may refer to a changed node, which is the case where any of the
+// names within it, including those within its predicates, is the name of a
+// changed node.
+func (f *changeFilter) leafrefDependsOnChange(e *yang.Entry) bool {
+	if e == nil || !util.IsLeafRef(e) {
+		return false
+	}
+	p := e.Type.Path
+	if d, ok := f.leafrefDeps[p]; ok {
+		return d
+	}
+	d := false
+	for _, n := range leafrefPathNameRE.FindAllString(p, -1) {
+		if f.names[util.StripModulePrefix(n)] {
+			d = true
+			break
+		}
+	}
+	f.leafrefDeps[p] = d
+	return d
+}
+
+// leafrefSubtreeDependsOnChange reports whether the schema entry e or any of
+// its descendants is a leafref whose path may refer to a changed node.
+func (f *changeFilter) leafrefSubtreeDependsOnChange(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	if d, ok := f.leafrefSubtree[e]; ok {
+		return d
+	}
+	f.leafrefSubtree[e] = false
+	d := f.leafrefDependsOnChange(e)
+	for _, c := range e.Dir {
+		if d {
+			break
+		}
+		d = f.leafrefSubtreeDependsOnChange(c)
+	}
+	f.leafrefSubtree[e] = d
+	return d
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// sortedErrStrings returns the messages of the supplied errors, sorted.
+func sortedErrStrings(errs util.Errors) []string {
+	var out []string
+	for _, err := range errs {
+		out = append(out, err.Error())
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestValidateIncremental(t *testing.T) {
+	mtuRange := func(root *yang.Entry) {
+		root.Dir["interfaces"].Dir["interface"].Dir["config"].Dir["mtu"].Type.Range = yang.YangRange{{Min: yang.FromInt(68), Max: yang.FromInt(9000)}}
+	}
+
+	tests := []struct {
+		desc       string
+		inModify   func(*yang.Entry)
+		inOriginal func(*xpathTestDevice)
+		inModified func(*xpathTestDevice)
+		inOpts     []ygot.ValidationOption
+		// wantErrors, if set, are the errors expected, otherwise the
+		// errors returned by Validate for the modified data tree are
+		// expected.
+		wantErrors []string
+	}{{
+		desc: "no changes",
+	}, {
+		desc:     "changed leaf out of range",
+		inModify: mtuRange,
+		inModified: func(d *xpathTestDevice) {
+			d.Interface["eth0"].Mtu = ygot.Uint16(9216)
+		},
+	}, {
+		desc: "deleted list entry referenced by leafref",
+		inModified: func(d *xpathTestDevice) {
+			delete(d.Interface, "eth0")
+		},
+	}, {
+		desc: "changed leafref",
+		inModified: func(d *xpathTestDevice) {
+			d.Bgp.Neighbor["192.0.2.254"].Interface = ygot.String("eth1")
+		},
+	}, {
+		desc: "must statement referencing changed node",
+		inModify: func(root *yang.Entry) {
+			root.Dir["bgp"].Dir["neighbors"].Dir["neighbor"].Extra = map[string][]interface{}{"must": {&yang.Must{
+				Name: "config/peer-as != /bgp/global/config/as",
+			}}}
+		},
+		inModified: func(d *xpathTestDevice) {
+			d.Bgp.As = ygot.Uint32(64513)
+		},
+		inOpts: []ygot.ValidationOption{&MustOptions{}},
+	}, {
+		desc: "min-elements of list with deleted entries",
+		inModify: func(root *yang.Entry) {
+			root.Dir["interfaces"].Dir["interface"].Dir["subinterfaces"].Dir["subinterface"].ListAttr.MinElements = 1
+		},
+		inOriginal: func(d *xpathTestDevice) {
+			d.Interface["lo0"].Subinterface = map[uint32]*xpathTestSubinterface{5: {Index: ygot.Uint32(5)}}
+		},
+		inModified: func(d *xpathTestDevice) {
+			d.Interface["lo0"].Subinterface = map[uint32]*xpathTestSubinterface{5: {Index: ygot.Uint32(5)}}
+			d.Interface["eth0"].Subinterface = map[uint32]*xpathTestSubinterface{}
+		},
+	}, {
+		desc: "mandatory leaf within added list entry",
+		inModify: func(root *yang.Entry) {
+			root.Dir["interfaces"].Dir["interface"].Dir["config"].Dir["mtu"].Mandatory = yang.TSTrue
+		},
+		inOriginal: func(d *xpathTestDevice) {
+			d.Interface["lo0"].Mtu = ygot.Uint16(1500)
+		},
+		inModified: func(d *xpathTestDevice) {
+			d.Interface["lo0"].Mtu = ygot.Uint16(1500)
+			d.Interface["eth1"] = &xpathTestInterface{Name: ygot.String("eth1"), Type: xpathTestEthernet}
+		},
+		inOpts: []ygot.ValidationOption{&MandatoryOptions{}},
+	}, {
+		desc:     "errors in unchanged subtrees are not reported",
+		inModify: mtuRange,
+		inOriginal: func(d *xpathTestDevice) {
+			d.Interface["eth0"].Mtu = ygot.Uint16(9216)
+		},
+		inModified: func(d *xpathTestDevice) {
+			d.Interface["eth0"].Mtu = ygot.Uint16(9216)
+			d.Interface["lo0"].Description = ygot.String("loopback zero")
+		},
+		wantErrors: []string{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := xpathTestSchema(tt.inModify)
+			original, modified := xpathTestData(), xpathTestData()
+			if tt.inOriginal != nil {
+				tt.inOriginal(original)
+			}
+			if tt.inModified != nil {
+				tt.inModified(modified)
+			}
+			if errs := Validate(schema, original, tt.inOpts...); errs != nil && tt.wantErrors == nil {
+				t.Fatalf("Validate(original): got unexpected errors: %v", errs)
+			}
+
+			want := tt.wantErrors
+			if want == nil {
+				want = sortedErrStrings(Validate(schema, modified, tt.inOpts...))
+				if tt.inModified != nil && len(want) == 0 {
+					t.Fatalf("Validate(modified): got no errors, test case should produce errors")
+				}
+			}
+			got := sortedErrStrings(ValidateIncremental(schema, original, modified, tt.inOpts...))
+			if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ValidateIncremental(): did not get expected errors, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateIncrementalSkipsUnchanged(t *testing.T) {
+	var got []string
+	r := NewCustomValidatorRegistry()
+	r.Register("/interfaces/interface/config/mtu", func(_ interface{}, _ ygot.GoStruct, path *gpb.Path) error {
+		p, err := ygot.PathToString(path)
+		if err != nil {
+			return err
+		}
+		got = append(got, p)
+		return nil
+	})
+
+	original, modified := xpathTestData(), xpathTestData()
+	modified.Interface["lo0"].Mtu = ygot.Uint16(9000)
+	if errs := ValidateIncremental(xpathTestSchema(nil), original, modified, &CustomValidationOptions{Validators: r}); errs != nil {
+		t.Fatalf("ValidateIncremental(): got unexpected errors: %v", errs)
+	}
+	if diff := cmp.Diff([]string{"/interfaces/interface[name=lo0]/config/mtu"}, got); diff != "" {
+		t.Errorf("ValidateIncremental(): did not validate expected leaves, (-want, +got):\n%s", diff)
+	}
+}
+
+func TestValidateNotificationNotFakeRoot(t *testing.T) {
+	schema := xpathTestSchema(nil).Dir["bgp"]
+	if errs := ValidateNotification(schema, xpathTestData().Bgp, &gpb.Notification{}); errs == nil {
+		t.Errorf("ValidateNotification(): got no error for schema that is not the fakeroot")
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// This file implements the positioning of the entries of `ordered-by user`
+// lists that are set by the updates of a SetRequest or Notification, as
+// specified by the RFC 7950 insert and key annotations of their JSON values.
+
+// orderedMapInsertion is the position of an entry of an ordered map, as
+// specified by the insert and key annotations of the JSON value of an update
+// of the entry.
+type orderedMapInsertion struct {
+	// where is the position of the entry.
+	where ygot.YANGPatchWhere
+	// point is the key of the entry before or after which the entry is
+	// placed, keyed by the name of each of its key leaves.
+	point map[string]string
+	// done is set once the entry has been placed.
+	done bool
+}
+
+// orderedMapInsertionOf returns the value v without the insert and key
+// annotations of its JSON_IETF or JSON value, along with the position that
+// they specify. Where v does not have an insert annotation, it is returned
+// unmodified, along with a nil position.
+func orderedMapInsertionOf(v *gpb.TypedValue) (*gpb.TypedValue, *orderedMapInsertion, error) {
+	var js []byte
+	switch {
+	case v.GetJsonIetfVal() != nil:
+		js = v.GetJsonIetfVal()
+	case v.GetJsonVal() != nil:
+		js = v.GetJsonVal()
+	default:
+		return v, nil, nil
+	}
+	if !bytes.Contains(js, []byte(ygot.YANGInsertAnnotation)) {
+		return v, nil, nil
+	}
+
+	// Numbers are decoded as json.Numbers such that they are re-encoded
+	// unmodified.
+	d := json.NewDecoder(bytes.NewReader(js))
+	d.UseNumber()
+	var j map[string]interface{}
+	if err := d.Decode(&j); err != nil {
+		// Invalid JSON is reported when the value is unmarshalled.
+		return v, nil, nil
+	}
+	md, ok := j["@"].(map[string]interface{})
+	if !ok {
+		return v, nil, nil
+	}
+	where, ok := md[ygot.YANGInsertAnnotation]
+	if !ok {
+		return v, nil, nil
+	}
+
+	ws, _ := where.(string)
+	ins := &orderedMapInsertion{where: ygot.YANGPatchWhere(ws)}
+	switch ins.where {
+	case ygot.YANGPatchFirst, ygot.YANGPatchLast:
+	case ygot.YANGPatchBefore, ygot.YANGPatchAfter:
+		k, ok := md[ygot.YANGKeyAnnotation].(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("%s annotation %q requires a %s annotation", ygot.YANGInsertAnnotation, ws, ygot.YANGKeyAnnotation)
+		}
+		var err error
+		if ins.point, err = keyPredicates(k); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("invalid %s annotation: %v", ygot.YANGInsertAnnotation, where)
+	}
+
+	delete(md, ygot.YANGInsertAnnotation)
+	delete(md, ygot.YANGKeyAnnotation)
+	if len(md) == 0 {
+		delete(j, "@")
+	}
+	js, err := json.Marshal(j)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot encode JSON value without its %s annotation: %v", ygot.YANGInsertAnnotation, err)
+	}
+	if v.GetJsonIetfVal() != nil {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: js}}, ins, nil
+	}
+	return &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{JsonVal: js}}, ins, nil
+}
+
+// keyPredicates returns the key values of the RFC 7950 key predicates s, such
+// as "[name='a'][id='1']", keyed by the name of each key leaf without its
+// module prefix.
+func keyPredicates(s string) (map[string]string, error) {
+	invalid := fmt.Errorf("invalid %s annotation: %q", ygot.YANGKeyAnnotation, s)
+	keys := map[string]string{}
+	for p := strings.TrimSpace(s); p != ""; p = strings.TrimSpace(p) {
+		if p[0] != '[' {
+			return nil, invalid
+		}
+		eq := strings.IndexByte(p, '=')
+		if eq < 0 {
+			return nil, invalid
+		}
+		name := util.StripModulePrefix(strings.TrimSpace(p[1:eq]))
+		p = strings.TrimSpace(p[eq+1:])
+		if name == "" || p == "" || p[0] != '\'' && p[0] != '"' {
+			return nil, invalid
+		}
+		end := strings.IndexByte(p[1:], p[0])
+		if end < 0 {
+			return nil, invalid
+		}
+		keys[name] = p[1 : end+1]
+		p = strings.TrimSpace(p[end+2:])
+		if p == "" || p[0] != ']' {
+			return nil, invalid
+		}
+		p = p[1:]
+	}
+	if len(keys) == 0 {
+		return nil, invalid
+	}
+	return keys, nil
+}
+
+// insertOrderedMapEntry moves the entry with key k of the ordered map om,
+// which is described by schema, to the position specified by ins. The entries
+// from the first whose position changes are deleted and re-appended in their
+// new order.
+func insertOrderedMapEntry(schema *yang.Entry, om ygot.GoOrderedMap, k reflect.Value, ins *orderedMapInsertion) error {
+	keys, err := yreflect.OrderedMapKeys(om)
+	if err != nil {
+		return err
+	}
+	var order []reflect.Value
+	for _, ok := range keys {
+		if ok.Interface() != k.Interface() {
+			order = append(order, ok)
+		}
+	}
+
+	at := len(order)
+	switch ins.where {
+	case ygot.YANGPatchFirst:
+		at = 0
+	case ygot.YANGPatchBefore, ygot.YANGPatchAfter:
+		at = -1
+		for i, pk := range order {
+			pv, _, err := yreflect.GetOrderedMapElement(om, pk)
+			if err != nil {
+				return err
+			}
+			km, err := getKeyFields(pk, pv, schema.Key)
+			if err != nil {
+				return err
+			}
+			if maps.Equal(km, ins.point) {
+				at = i
+				break
+			}
+		}
+		if at < 0 {
+			return status.Errorf(codes.NotFound, "cannot insert entry %v of list %s %s entry %v, which does not exist", k.Interface(), schema.Path(), ins.where, ins.point)
+		}
+		if ins.where == ygot.YANGPatchAfter {
+			at++
+		}
+	}
+	order = slices.Insert(order, at, k)
+
+	from := 0
+	for from < len(keys) && order[from].Interface() == keys[from].Interface() {
+		from++
+	}
+	var vals []reflect.Value
+	for _, rk := range order[from:] {
+		v, _, err := yreflect.GetOrderedMapElement(om, rk)
+		if err != nil {
+			return err
+		}
+		vals = append(vals, v)
+		if _, err := yreflect.DeleteFromOrderedMap(om, rk); err != nil {
+			return err
+		}
+	}
+	for _, v := range vals {
+		if err := yreflect.AppendIntoOrderedMap(om, v.Interface()); err != nil {
+			return err
+		}
+	}
+	ins.done = true
+	return nil
+}
@@ -57,21 +57,33 @@ var (
 )
 
 // ValidateIntRestrictions checks that the given signed int matches the
-// schema's range restrictions (if any). It returns an error if the validation
-// fails.
+// schema's range restrictions (if any). It returns a *ValidationError if the
+// validation fails, as per ValidateStringRestrictions.
 func ValidateIntRestrictions(schemaType *yang.YangType, intVal int64) error {
+	return validateIntRestrictions(nil, schemaType, intVal)
+}
+
+// validateIntRestrictions implements ValidateIntRestrictions, using the
+// error statements of the type statement of schema where it is non-nil.
+func validateIntRestrictions(schema *yang.Entry, schemaType *yang.YangType, intVal int64) error {
 	if !isInRanges(schemaType.Range, yang.FromInt(intVal)) {
-		return fmt.Errorf("signed integer value %v is outside specified ranges", intVal)
+		return newRestrictionError(RangeConstraint, schema, schemaType, intVal, "", fmt.Errorf("signed integer value %v is outside specified ranges", intVal))
 	}
 	return nil
 }
 
 // ValidateUintRestrictions checks that the given unsigned int matches the
-// schema's range restrictions (if any). It returns an error if the validation
-// fails.
+// schema's range restrictions (if any). It returns a *ValidationError if the
+// validation fails, as per ValidateStringRestrictions.
 func ValidateUintRestrictions(schemaType *yang.YangType, uintVal uint64) error {
+	return validateUintRestrictions(nil, schemaType, uintVal)
+}
+
+// validateUintRestrictions implements ValidateUintRestrictions, using the
+// error statements of the type statement of schema where it is non-nil.
+func validateUintRestrictions(schema *yang.Entry, schemaType *yang.YangType, uintVal uint64) error {
 	if !isInRanges(schemaType.Range, yang.FromUint(uintVal)) {
-		return fmt.Errorf("unsigned integer value %v is outside specified ranges", uintVal)
+		return newRestrictionError(RangeConstraint, schema, schemaType, uintVal, "", fmt.Errorf("unsigned integer value %v is outside specified ranges", uintVal))
 	}
 	return nil
 }
@@ -95,12 +107,12 @@ func validateInt(schema *yang.Entry, value interface{}) error {
 
 	// Check that the value satisfies any range restrictions.
 	if isSigned(kind) {
-		if err := ValidateIntRestrictions(schema.Type, reflect.ValueOf(value).Int()); err != nil {
-			return fmt.Errorf("schema %q: %v", schema.Name, err)
+		if err := validateIntRestrictions(schema, schema.Type, reflect.ValueOf(value).Int()); err != nil {
+			return fmt.Errorf("schema %q: %w", schema.Name, err)
 		}
 	} else {
-		if err := ValidateUintRestrictions(schema.Type, reflect.ValueOf(value).Uint()); err != nil {
-			return fmt.Errorf("schema %q: %v", schema.Name, err)
+		if err := validateUintRestrictions(schema, schema.Type, reflect.ValueOf(value).Uint()); err != nil {
+			return fmt.Errorf("schema %q: %w", schema.Name, err)
 		}
 	}
 
@@ -0,0 +1,296 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// InverseSetRequest returns the inverse of req with respect to schema.Root,
+// which is a SetRequest that, when applied by UnmarshalSetRequest after req
+// has been applied to schema.Root, restores schema.Root to its current value.
+// The subtrees deleted by req are restored with their values, those added by
+// it are deleted, and `ordered-by user` lists are restored in their order.
+// Containers that do not contain any leaves are not restored, since they
+// cannot be represented in a SetRequest.
+//
+// schema.Root is not modified. req is applied with the supplied opts to a
+// copy of only the data at the paths that it touches, and it is an error if
+// it cannot be applied. The Transactional option is ignored, such that the
+// result is not validated.
+//
+// The origins of the paths of req are handled as by UnmarshalSetRequest.
+// Where the Origins option is specified, the operations of each origin are
+// inverted with respect to the schema or opaque data of the origin, and the
+// paths of the inverse are of the same origin. Otherwise, the origins are
+// ignored.
+func InverseSetRequest(schema *Schema, req *gpb.SetRequest, opts ...UnmarshalOpt) (*gpb.SetRequest, error) {
+	var reqs []*gpb.SetRequest
+	if req != nil {
+		reqs = append(reqs, req)
+	}
+	return inverse(schema, reqs, opts)
+}
+
+// InverseNotifications returns the inverse of ns with respect to schema.Root,
+// as per InverseSetRequest, as Notifications that, when applied by
+// UnmarshalNotifications after ns has been applied to schema.Root, restore
+// schema.Root to its current value. The timestamp of the returned
+// Notifications is not set.
+func InverseNotifications(schema *Schema, ns []*gpb.Notification, opts ...UnmarshalOpt) ([]*gpb.Notification, error) {
+	var reqs []*gpb.SetRequest
+	for _, n := range ns {
+		reqs = append(reqs, notificationSetRequest(n))
+	}
+	req, err := inverse(schema, reqs, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetDelete()) == 0 && len(req.GetUpdate()) == 0 {
+		return nil, nil
+	}
+	return []*gpb.Notification{{
+		Delete: req.GetDelete(),
+		Update: req.GetUpdate(),
+	}}, nil
+}
+
+// inverse returns the SetRequest that restores the data specified by schema
+// and opts after reqs are applied to it in order. The operations of each
+// origin are inverted separately where the Origins option is specified.
+func inverse(schema *Schema, reqs []*gpb.SetRequest, opts []UnmarshalOpt) (*gpb.SetRequest, error) {
+	var applyOpts []UnmarshalOpt
+	for _, o := range opts {
+		switch o.(type) {
+		case *Origins, *Transactional:
+			continue
+		}
+		applyOpts = append(applyOpts, o)
+	}
+	o := hasOrigins(opts)
+	if o == nil {
+		return inverseSchema(schema, reqs, applyOpts)
+	}
+
+	byOrigin := map[string][]*gpb.SetRequest{}
+	for _, req := range reqs {
+		rs, err := splitSetRequestByOrigin(req, false)
+		if err != nil {
+			return nil, fmt.Errorf("cannot apply change: %w", err)
+		}
+		for _, r := range rs {
+			byOrigin[r.origin] = append(byOrigin[r.origin], r.req)
+		}
+	}
+	var origins []string
+	for origin := range byOrigin {
+		origins = append(origins, origin)
+	}
+	sort.Strings(origins)
+
+	out := &gpb.SetRequest{}
+	for _, origin := range origins {
+		var inv *gpb.SetRequest
+		var err error
+		switch rs := byOrigin[origin]; {
+		case isOpenConfigOrigin(origin):
+			inv, err = inverseSchema(schema, rs, applyOpts)
+		case o.Schemas[origin] != nil:
+			inv, err = inverseSchema(o.Schemas[origin], rs, applyOpts)
+		case o.Opaque != nil:
+			inv, err = o.Opaque.inverse(origin, rs)
+		default:
+			err = fmt.Errorf("cannot apply change: no schema for origin %q", origin)
+		}
+		if err != nil {
+			if isOpenConfigOrigin(origin) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("origin %q: %w", origin, err)
+		}
+		if !isOpenConfigOrigin(origin) {
+			for _, p := range inv.Delete {
+				p.Origin = origin
+			}
+			for _, u := range inv.Update {
+				u.Path.Origin = origin
+			}
+		}
+		out.Delete = append(out.Delete, inv.Delete...)
+		out.Update = append(out.Update, inv.Update...)
+	}
+	return out, nil
+}
+
+// inverseSchema applies reqs to a copy of the data of schema.Root at the
+// paths that they touch, ignoring the origins of the paths, and returns the
+// SetRequest that restores the copy to schema.Root.
+func inverseSchema(schema *Schema, reqs []*gpb.SetRequest, opts []UnmarshalOpt) (*gpb.SetRequest, error) {
+	if schema == nil || schema.Root == nil {
+		return nil, fmt.Errorf("cannot copy nil root")
+	}
+	var paths []*gpb.Path
+	for _, req := range reqs {
+		for _, p := range req.GetDelete() {
+			paths = append(paths, scopePath(req.GetPrefix(), p))
+		}
+		for _, us := range [][]*gpb.Update{req.GetReplace(), req.GetUnionReplace(), req.GetUpdate()} {
+			for _, u := range us {
+				paths = append(paths, scopePath(req.GetPrefix(), u.GetPath()))
+			}
+		}
+	}
+
+	original := *schema
+	original.Root = scopeStruct(reflect.ValueOf(schema.Root), paths).Interface().(ygot.GoStruct)
+	modified, err := copySchema(&original)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range reqs {
+		if err := applySetRequest(modified, req, opts, false); err != nil {
+			return nil, fmt.Errorf("cannot apply change: %w", err)
+		}
+	}
+	req, err := ygot.DiffSetRequest(modified.Root, original.Root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compute inverse: %v", err)
+	}
+	return req, nil
+}
+
+// scopePath returns the path p joined with prefix, without their origins. It
+// returns the root path where they cannot be joined, such that the error is
+// reported when the operation at p is applied.
+func scopePath(prefix, p *gpb.Path) *gpb.Path {
+	jp, err := util.JoinPaths(stripOrigin(prefix), stripOrigin(p))
+	if err != nil {
+		return &gpb.Path{}
+	}
+	return jp
+}
+
+// scopeStruct returns a copy of the GoStruct pointer v that contains only the
+// data of v that is at or below the supplied paths, which are relative to v,
+// along with the leaves of each GoStruct above them, such that the keys of
+// the list entries above them are populated. The subtrees at the paths are
+// shared with v rather than copied. The data of an `ordered-by user` list is
+// copied in its entirety where any of the paths is within it, since its
+// entries are diffed as a whole.
+func scopeStruct(v reflect.Value, paths []*gpb.Path) reflect.Value {
+	for _, p := range paths {
+		if len(p.GetElem()) == 0 {
+			return v
+		}
+	}
+
+	out := reflect.New(v.Elem().Type())
+	for i := 0; i < v.Elem().NumField(); i++ {
+		fv, ft := v.Elem().Field(i), v.Elem().Type().Field(i)
+		if fv.IsZero() {
+			continue
+		}
+		_, isOrderedMap := fv.Interface().(ygot.GoOrderedMap)
+		isStruct, isMap := util.IsValueStructPtr(fv), util.IsValueMap(fv)
+		if util.IsYgotAnnotation(ft) || !isOrderedMap && !isStruct && !isMap {
+			out.Elem().Field(i).Set(fv)
+			continue
+		}
+
+		fieldPaths, err := util.SchemaPaths(ft)
+		if err != nil {
+			// The paths of the field are unknown, such that it
+			// is kept in its entirety.
+			out.Elem().Field(i).Set(fv)
+			continue
+		}
+		fieldPaths = append(fieldPaths, util.ShadowSchemaPaths(ft)...)
+
+		var share bool
+		var sub []*gpb.Path
+		for _, p := range paths {
+			for _, fp := range fieldPaths {
+				switch {
+				case !util.PathPartiallyMatchesPrefix(p, fp):
+				case isOrderedMap || len(p.GetElem()) < len(fp):
+					share = true
+				case isMap && len(p.GetElem()[len(fp)-1].GetKey()) == 0:
+					// The path is of the list rather than of
+					// one of its entries.
+					share = true
+				case isMap:
+					sub = append(sub, &gpb.Path{Elem: p.GetElem()[len(fp)-1:]})
+				default:
+					sub = append(sub, &gpb.Path{Elem: p.GetElem()[len(fp):]})
+				}
+			}
+		}
+		switch {
+		case share:
+			out.Elem().Field(i).Set(fv)
+		case len(sub) == 0:
+		case isMap:
+			out.Elem().Field(i).Set(scopeMap(fv, sub))
+		default:
+			out.Elem().Field(i).Set(scopeStruct(fv, sub))
+		}
+	}
+	return out
+}
+
+// scopeMap returns a copy of the map of list entries v that contains only the
+// entries that the supplied paths, whose first element is that of the list,
+// are within, each of which is scoped to the paths by scopeStruct. Where the
+// entries cannot be matched to the keys of the paths, v is returned.
+func scopeMap(v reflect.Value, paths []*gpb.Path) reflect.Value {
+	out := reflect.MakeMap(v.Type())
+	for _, k := range v.MapKeys() {
+		e := v.MapIndex(k)
+		keys, err := ygot.PathKeyFromStruct(e)
+		if err != nil {
+			return v
+		}
+		var sub []*gpb.Path
+		for _, p := range paths {
+			pk := p.GetElem()[0].GetKey()
+			if len(pk) != len(keys) {
+				return v
+			}
+			match := true
+			for name, val := range pk {
+				if val == "*" {
+					return v
+				}
+				if keys[name] != val {
+					match = false
+				}
+			}
+			if match {
+				sub = append(sub, &gpb.Path{Elem: p.GetElem()[1:]})
+			}
+		}
+		if len(sub) != 0 {
+			out.SetMapIndex(k, scopeStruct(e, sub))
+		}
+	}
+	return out
+}
@@ -0,0 +1,413 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/integration_tests/schemaops/utestschema"
+	"github.com/openconfig/ygot/internal/ytestutil"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// inverseTestRoot returns the root used by the tests of the inverses of
+// SetRequests and Notifications.
+func inverseTestRoot(t *testing.T) *ctestschema.Device {
+	return &ctestschema.Device{
+		OrderedList: ctestschema.GetOrderedMap(t),
+		OtherData:   &ctestschema.OtherData{Motd: ygot.String("hello")},
+		UnorderedList: map[string]*ctestschema.UnorderedList{
+			"foo": {Key: ygot.String("foo"), Value: ygot.String("foo-val")},
+		},
+	}
+}
+
+func TestInverseSetRequest(t *testing.T) {
+	strVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+	}
+	jsonVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(s)}}
+	}
+
+	tests := []struct {
+		desc      string
+		inRequest *gpb.SetRequest
+		wantErr   string
+	}{{
+		desc: "update leaf and add list entry",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath("other-data/config/motd"),
+				Val:  strVal("world"),
+			}, {
+				Path: mustPath("unordered-lists/unordered-list[key=bar]/config/key"),
+				Val:  strVal("bar"),
+			}, {
+				Path: mustPath("unordered-lists/unordered-list[key=bar]/key"),
+				Val:  strVal("bar"),
+			}},
+		},
+	}, {
+		desc: "delete subtrees",
+		inRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{
+				mustPath("other-data"),
+				mustPath("unordered-lists/unordered-list[key=foo]"),
+			},
+		},
+	}, {
+		desc: "delete root",
+		inRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{{}},
+		},
+	}, {
+		desc: "replace list entry with JSON_IETF",
+		inRequest: &gpb.SetRequest{
+			Replace: []*gpb.Update{{
+				Path: mustPath("unordered-lists/unordered-list[key=foo]"),
+				Val:  jsonVal(`{"ctestschema:config":{"key":"foo"},"ctestschema:key":"foo"}`),
+			}},
+		},
+	}, {
+		desc: "reorder ordered list",
+		inRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{mustPath("ordered-lists")},
+			Update: []*gpb.Update{{
+				Path: mustPath("ordered-lists/ordered-list[key=bar]/config/key"),
+				Val:  strVal("bar"),
+			}, {
+				Path: mustPath("ordered-lists/ordered-list[key=bar]/key"),
+				Val:  strVal("bar"),
+			}, {
+				Path: mustPath("ordered-lists/ordered-list[key=foo]/config/key"),
+				Val:  strVal("foo"),
+			}, {
+				Path: mustPath("ordered-lists/ordered-list[key=foo]/key"),
+				Val:  strVal("foo"),
+			}},
+		},
+	}, {
+		desc: "request with prefix",
+		inRequest: &gpb.SetRequest{
+			Prefix: mustPath("other-data"),
+			Update: []*gpb.Update{{
+				Path: mustPath("config/motd"),
+				Val:  strVal("world"),
+			}},
+		},
+	}, {
+		desc:      "empty request",
+		inRequest: &gpb.SetRequest{},
+	}, {
+		desc: "request cannot be applied",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath("other-data/config/foo"),
+				Val:  strVal("world"),
+			}},
+		},
+		wantErr: "cannot apply change",
+	}, {
+		desc: "origins ignored without Origins option",
+		inRequest: &gpb.SetRequest{
+			Prefix: &gpb.Path{Origin: "custom"},
+			Update: []*gpb.Update{{
+				Path: mustPath("other-data/config/motd"),
+				Val:  strVal("world"),
+			}},
+		},
+	}, {
+		desc: "update within list entry",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath("unordered-lists/unordered-list[key=foo]/config/value"),
+				Val:  strVal("bar-val"),
+			}},
+		},
+	}, {
+		desc: "delete ordered list entry",
+		inRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{mustPath("ordered-lists/ordered-list[key=foo]")},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema, err := ctestschema.Schema()
+			if err != nil {
+				t.Fatalf("cannot get schema: %v", err)
+			}
+			schema.Root = inverseTestRoot(t)
+
+			inv, err := ytypes.InverseSetRequest(schema, tt.inRequest)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("InverseSetRequest: %s", diff)
+			}
+			if diff := cmp.Diff(inverseTestRoot(t), schema.Root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Fatalf("InverseSetRequest modified root (-want, +got):\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if err := ytypes.UnmarshalSetRequest(schema, tt.inRequest); err != nil {
+				t.Fatalf("UnmarshalSetRequest of request: %v", err)
+			}
+			if err := ytypes.UnmarshalSetRequest(schema, inv); err != nil {
+				t.Fatalf("UnmarshalSetRequest of inverse: %v", err)
+			}
+			if diff := cmp.Diff(inverseTestRoot(t), schema.Root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Errorf("applying request then inverse did not restore root (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInverseNotifications(t *testing.T) {
+	strVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+	}
+
+	tests := []struct {
+		desc     string
+		inNotifs []*gpb.Notification
+		wantNil  bool
+		wantErr  string
+	}{{
+		desc: "updates and deletes",
+		inNotifs: []*gpb.Notification{{
+			Delete: []*gpb.Path{mustPath("unordered-lists/unordered-list[key=foo]")},
+			Update: []*gpb.Update{{
+				Path: mustPath("other-data/config/motd"),
+				Val:  strVal("world"),
+			}},
+		}, {
+			Prefix: mustPath("unordered-lists/unordered-list[key=bar]"),
+			Update: []*gpb.Update{{
+				Path: mustPath("config/key"),
+				Val:  strVal("bar"),
+			}, {
+				Path: mustPath("key"),
+				Val:  strVal("bar"),
+			}},
+		}},
+	}, {
+		desc: "atomic ordered list",
+		inNotifs: []*gpb.Notification{{
+			Prefix: mustPath("ordered-lists"),
+			Atomic: true,
+			Update: []*gpb.Update{{
+				Path: mustPath("ordered-list[key=baz]/config/key"),
+				Val:  strVal("baz"),
+			}, {
+				Path: mustPath("ordered-list[key=baz]/key"),
+				Val:  strVal("baz"),
+			}, {
+				Path: mustPath("ordered-list[key=foo]/config/key"),
+				Val:  strVal("foo"),
+			}, {
+				Path: mustPath("ordered-list[key=foo]/key"),
+				Val:  strVal("foo"),
+			}},
+		}},
+	}, {
+		desc: "no change",
+		inNotifs: []*gpb.Notification{{
+			Update: []*gpb.Update{{
+				Path: mustPath("other-data/config/motd"),
+				Val:  strVal("hello"),
+			}},
+		}},
+		wantNil: true,
+	}, {
+		desc: "origins ignored without Origins option",
+		inNotifs: []*gpb.Notification{{
+			Prefix: &gpb.Path{Origin: "custom"},
+			Update: []*gpb.Update{{
+				Path: mustPath("other-data/config/motd"),
+				Val:  strVal("world"),
+			}},
+		}},
+	}, {
+		desc: "notifications cannot be applied",
+		inNotifs: []*gpb.Notification{{
+			Update: []*gpb.Update{{
+				Path: mustPath("other-data/config/foo"),
+				Val:  strVal("world"),
+			}},
+		}},
+		wantErr: "cannot apply change",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema, err := ctestschema.Schema()
+			if err != nil {
+				t.Fatalf("cannot get schema: %v", err)
+			}
+			schema.Root = inverseTestRoot(t)
+
+			inv, err := ytypes.InverseNotifications(schema, tt.inNotifs)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("InverseNotifications: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if gotNil := inv == nil; gotNil != tt.wantNil {
+				t.Errorf("InverseNotifications: got nil inverse %v, want %v", gotNil, tt.wantNil)
+			}
+
+			if err := ytypes.UnmarshalNotifications(schema, tt.inNotifs); err != nil {
+				t.Fatalf("UnmarshalNotifications of notifications: %v", err)
+			}
+			if err := ytypes.UnmarshalNotifications(schema, inv); err != nil {
+				t.Fatalf("UnmarshalNotifications of inverse: %v", err)
+			}
+			if diff := cmp.Diff(inverseTestRoot(t), schema.Root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Errorf("applying notifications then inverse did not restore root (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInverseOrigins(t *testing.T) {
+	strVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+	}
+	asciiVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: s}}
+	}
+	withOrigin := func(origin, p string) *gpb.Path {
+		gp := mustPath(p)
+		gp.Origin = origin
+		return gp
+	}
+	origUTS := func() *utestschema.Device {
+		d := &utestschema.Device{}
+		d.GetOrCreateTarget().GetOrCreateEntity("x")
+		return d
+	}
+	origOpaque := func() ytypes.OpaqueData {
+		return ytypes.OpaqueData{"cli": {
+			"/":           asciiVal("hostname foo"),
+			"/interfaces": asciiVal("mtu 9000"),
+		}}
+	}
+
+	tests := []struct {
+		desc      string
+		inRequest *gpb.SetRequest
+		// inNoOpaque specifies that the Origins option does not
+		// specify opaque data.
+		inNoOpaque bool
+		wantErr    string
+	}{{
+		desc: "operations of each origin",
+		inRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{
+				withOrigin("uts", "target/entity[name=x]"),
+				withOrigin("cli", "/interfaces"),
+			},
+			Replace: []*gpb.Update{{
+				Path: withOrigin("cli", "/"),
+				Val:  asciiVal("hostname bar"),
+			}},
+			Update: []*gpb.Update{{
+				Path: withOrigin("uts", "target/entity[name=y]/name"),
+				Val:  strVal("y"),
+			}, {
+				Path: withOrigin("openconfig", "other-data/config/motd"),
+				Val:  strVal("world"),
+			}, {
+				Path: withOrigin("cli", "/system"),
+				Val:  asciiVal("ntp server 192.0.2.1"),
+			}},
+		},
+	}, {
+		desc: "origin in prefix",
+		inRequest: &gpb.SetRequest{
+			Prefix: &gpb.Path{Origin: "cli"},
+			Delete: []*gpb.Path{{}},
+		},
+	}, {
+		desc: "origin without schema",
+		inRequest: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: withOrigin("cli", "/"),
+				Val:  asciiVal("hostname bar"),
+			}},
+		},
+		inNoOpaque: true,
+		wantErr:    `origin "cli": cannot apply change: no schema for origin "cli"`,
+	}, {
+		desc: "conflicting origins",
+		inRequest: &gpb.SetRequest{
+			Prefix: &gpb.Path{Origin: "cli"},
+			Update: []*gpb.Update{{
+				Path: withOrigin("uts", "target/entity[name=y]/name"),
+				Val:  strVal("y"),
+			}},
+		},
+		wantErr: "cannot apply change",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := &ytypes.Schema{Root: inverseTestRoot(t), SchemaTree: ctestschema.SchemaTree}
+			uts := &ytypes.Schema{Root: origUTS(), SchemaTree: utestschema.SchemaTree}
+			o := &ytypes.Origins{Schemas: map[string]*ytypes.Schema{"uts": uts}}
+			if !tt.inNoOpaque {
+				o.Opaque = origOpaque()
+			}
+
+			inv, err := ytypes.InverseSetRequest(schema, tt.inRequest, o)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("InverseSetRequest: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			for _, p := range inv.GetDelete() {
+				if p.GetOrigin() == "openconfig" {
+					t.Errorf("InverseSetRequest: got delete of %v with origin openconfig, want no origin", p)
+				}
+			}
+
+			for _, req := range []*gpb.SetRequest{tt.inRequest, inv} {
+				if err := ytypes.UnmarshalSetRequest(schema, req, o); err != nil {
+					t.Fatalf("UnmarshalSetRequest(%v): %v", req, err)
+				}
+			}
+			if diff := cmp.Diff(inverseTestRoot(t), schema.Root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Errorf("applying request then inverse did not restore root (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(origUTS(), uts.Root); diff != "" {
+				t.Errorf("applying request then inverse did not restore uts root (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(origOpaque(), o.Opaque, protocmp.Transform()); diff != "" {
+				t.Errorf("applying request then inverse did not restore opaque data (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
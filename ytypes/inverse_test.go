@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestScopeStruct(t *testing.T) {
+	tests := []struct {
+		desc    string
+		inPaths []*gpb.Path
+		want    *xpathTestDevice
+	}{{
+		desc: "no paths",
+		want: &xpathTestDevice{},
+	}, {
+		desc:    "root",
+		inPaths: []*gpb.Path{{}},
+		want:    xpathTestData(),
+	}, {
+		desc:    "leaf of list entry",
+		inPaths: []*gpb.Path{mustPath("/interfaces/interface[name=lo0]/config/mtu")},
+		want: &xpathTestDevice{
+			Interface: map[string]*xpathTestInterface{
+				"lo0": xpathTestData().Interface["lo0"],
+			},
+		},
+	}, {
+		desc:    "nested list entry",
+		inPaths: []*gpb.Path{mustPath("/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=1]")},
+		want: &xpathTestDevice{
+			Interface: map[string]*xpathTestInterface{
+				"eth0": {
+					Name: ygot.String("eth0"),
+					Mtu:  ygot.Uint16(1500),
+					Type: xpathTestEthernet,
+					Subinterface: map[uint32]*xpathTestSubinterface{
+						1: xpathTestData().Interface["eth0"].Subinterface[1],
+					},
+				},
+			},
+		},
+	}, {
+		desc:    "absent list entry",
+		inPaths: []*gpb.Path{mustPath("/interfaces/interface[name=eth1]/config/mtu")},
+		want: &xpathTestDevice{
+			Interface: map[string]*xpathTestInterface{},
+		},
+	}, {
+		desc:    "list",
+		inPaths: []*gpb.Path{mustPath("/interfaces/interface")},
+		want: &xpathTestDevice{
+			Interface: xpathTestData().Interface,
+		},
+	}, {
+		desc:    "compressed container",
+		inPaths: []*gpb.Path{mustPath("/bgp/neighbors")},
+		want: &xpathTestDevice{
+			Bgp: &xpathTestBgp{
+				As:       ygot.Uint32(64512),
+				Neighbor: xpathTestData().Bgp.Neighbor,
+			},
+		},
+	}, {
+		desc:    "wildcard key",
+		inPaths: []*gpb.Path{mustPath("/interfaces/interface[name=*]/config/mtu")},
+		want: &xpathTestDevice{
+			Interface: xpathTestData().Interface,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := scopeStruct(reflect.ValueOf(xpathTestData()), tt.inPaths).Interface()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("scopeStruct(): (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,639 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// UnmarshalJSONReader unmarshals the RFC7951 JSON document read from r into
+// parent, using the given schema, with the same semantics as Unmarshal. Rather
+// than decoding the whole document into a map[string]interface{} before
+// walking it, the document is decoded token by token alongside the schema and
+// the values are written directly into parent, such that only the value of a
+// single leaf or leaf-list is held in memory at a time. Entries of keyed lists
+// that already have entries in parent are the exception, since each entry is
+// buffered such that it can be merged into the existing entry with the same
+// key. Unlike Unmarshal, entries of ordered maps are merged in the same way,
+// rather than an entry with the key of an existing entry being an error.
+//
+// The supported options are IgnoreExtraFields, PreferShadowPath and
+// BestEffortUnmarshal. Where BestEffortUnmarshal is supplied, unmarshalling
+// continues following any error other than malformed JSON, skipping the value
+// that caused it, and a *ComplianceErrors containing each of the errors is
+// returned. Where unmarshalling fails, parent may have been partially
+// modified.
+func UnmarshalJSONReader(schema *yang.Entry, parent interface{}, r io.Reader, opts ...UnmarshalOpt) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema for parent type %T", parent)
+	}
+	d := &jsonStreamDecoder{
+		dec: json.NewDecoder(r),
+		jsonStreamConfig: &jsonStreamConfig{
+			ignoreExtraFields: hasIgnoreExtraFields(opts),
+			preferShadowPath:  hasPreferShadowPath(opts),
+			bestEffort:        hasBestEffortUnmarshal(opts),
+			fields:            map[jsonStreamFieldsKey]*jsonStreamNode{},
+		},
+	}
+	// The options are passed on to unmarshalGeneric for leaves, which does
+	// not accept BestEffortUnmarshal.
+	for _, o := range opts {
+		if _, ok := o.(*BestEffortUnmarshal); !ok {
+			d.opts = append(d.opts, o)
+		}
+	}
+
+	var err error
+	switch {
+	case schema.IsLeaf(), schema.IsLeafList():
+		err = d.leaf(schema, parent, nil)
+	case schema.IsList() && !util.IsTypeStructPtr(reflect.TypeOf(parent)):
+		var start json.Token
+		if start, err = d.dec.Token(); err == nil {
+			err = d.list(schema, parent, start)
+		}
+	case schema.IsList(), schema.IsContainer():
+		// A list schema with a struct ptr parent is used to unmarshal
+		// a single entry of the list.
+		if !util.IsValueStructPtr(reflect.ValueOf(parent)) {
+			return fmt.Errorf("UnmarshalJSONReader got parent type %T for schema %s, expect struct ptr", parent, schema.Name)
+		}
+		err = d.objectAt(schema, parent)
+	case schema.IsChoice():
+		return fmt.Errorf("cannot pass choice schema %s to UnmarshalJSONReader", schema.Name)
+	default:
+		return fmt.Errorf("unknown schema type for schema %s", schema.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := d.dec.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data following JSON value for schema %s", schema.Name)
+	}
+	if len(d.errs) != 0 {
+		return &ComplianceErrors{Errors: d.errs}
+	}
+	return nil
+}
+
+// jsonStreamConfig is the configuration of a jsonStreamDecoder, which is
+// shared with the decoders used for buffered values.
+type jsonStreamConfig struct {
+	// opts are the options passed to unmarshalGeneric.
+	opts []UnmarshalOpt
+	// ignoreExtraFields, preferShadowPath and bestEffort indicate that the
+	// corresponding UnmarshalOpts were supplied.
+	ignoreExtraFields bool
+	preferShadowPath  bool
+	bestEffort        bool
+	// errs are the errors encountered where bestEffort is set.
+	errs []error
+	// fields stores the tree of JSON member names for each combination of
+	// schema and struct type that is unmarshalled. It is populated as the
+	// document is read, and discarded along with the decoder, since the
+	// generated schemas are rebuilt by each call to their Schema function.
+	fields map[jsonStreamFieldsKey]*jsonStreamNode
+}
+
+// jsonStreamDecoder unmarshals a JSON document into a GoStruct as it is read.
+type jsonStreamDecoder struct {
+	dec *json.Decoder
+	*jsonStreamConfig
+}
+
+// fail returns err, unless best effort unmarshalling was requested, in which
+// case err is recorded and nil is returned such that unmarshalling continues.
+// It must only be used where the value that caused err has been consumed.
+func (d *jsonStreamDecoder) fail(err error) error {
+	if err == nil || !d.bestEffort {
+		return err
+	}
+	d.errs = append(d.errs, err)
+	return nil
+}
+
+// delim checks that the token t, which is the first token of a JSON value,
+// is the delimiter want or null. It returns false where the token is null.
+func (d *jsonStreamDecoder) delim(t json.Token, want json.Delim, schema *yang.Entry) (bool, error) {
+	switch t {
+	case want:
+		return true, nil
+	case nil:
+		return false, nil
+	}
+	if dl, ok := t.(json.Delim); ok {
+		// Consume the remainder of the value, such that decoding can
+		// continue in best effort mode.
+		if err := d.skipRest(dl); err != nil {
+			return false, err
+		}
+	}
+	return false, d.fail(fmt.Errorf("schema %s: got JSON value %v, expect %v", schema.Name, t, want))
+}
+
+// skip consumes the next JSON value.
+func (d *jsonStreamDecoder) skip() error {
+	t, err := d.dec.Token()
+	if err != nil {
+		return err
+	}
+	if dl, ok := t.(json.Delim); ok {
+		return d.skipRest(dl)
+	}
+	return nil
+}
+
+// skipRest consumes the remainder of the object or array that was opened by
+// the delimiter dl.
+func (d *jsonStreamDecoder) skipRest(dl json.Delim) error {
+	if dl != '{' && dl != '[' {
+		return nil
+	}
+	for depth := 1; depth > 0; {
+		t, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	return nil
+}
+
+// object unmarshals a JSON object whose first token is start into parent,
+// which must be a struct ptr whose schema is supplied.
+func (d *jsonStreamDecoder) object(schema *yang.Entry, parent interface{}, start json.Token) error {
+	ok, err := d.delim(start, '{', schema)
+	if !ok || err != nil {
+		return err
+	}
+	fields, err := d.fieldsFor(schema, reflect.TypeOf(parent))
+	if err != nil {
+		return err
+	}
+	return d.members(schema, parent, fields, map[int]jsonStreamValue{})
+}
+
+// objectAt unmarshals the next JSON value, which must be an object, into
+// parent, which must be a struct ptr whose schema is supplied.
+func (d *jsonStreamDecoder) objectAt(schema *yang.Entry, parent interface{}) error {
+	start, err := d.dec.Token()
+	if err != nil {
+		return err
+	}
+	return d.object(schema, parent, start)
+}
+
+// jsonStreamValue is a value that was unmarshalled into a field, along with
+// the path at which it was found.
+type jsonStreamValue struct {
+	path  []string
+	value interface{}
+}
+
+// members unmarshals the members of the JSON object whose opening delimiter
+// has been read into parent, where n describes the fields of parent that are
+// found within the object. Values that were unmarshalled into fields of parent
+// that have multiple paths are stored in seen, such that values for the same
+// field are checked to be equal.
+func (d *jsonStreamDecoder) members(schema *yang.Entry, parent interface{}, n *jsonStreamNode, seen map[int]jsonStreamValue) error {
+	for d.dec.More() {
+		t, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		name, ok := t.(string)
+		if !ok {
+			return fmt.Errorf("schema %s: got JSON object key %v, expect string", schema.Name, t)
+		}
+
+		c := n.children[util.StripModulePrefix(name)]
+		switch {
+		case c == nil:
+			if err := d.skip(); err != nil {
+				return err
+			}
+			if !d.ignoreExtraFields {
+				if err := d.fail(fmt.Errorf("parent container %s (type %T): JSON contains unexpected field %s", schema.Name, parent, util.StripModulePrefix(name))); err != nil {
+					return err
+				}
+			}
+		case c.ignore:
+			if err := d.skip(); err != nil {
+				return err
+			}
+		case c.field == nil:
+			t, err := d.dec.Token()
+			if err != nil {
+				return err
+			}
+			if t != json.Delim('{') {
+				if dl, ok := t.(json.Delim); ok {
+					if err := d.skipRest(dl); err != nil {
+						return err
+					}
+				}
+				if err := d.fail(fmt.Errorf("parent container %s (type %T): JSON contains unexpected leaf field(s) [%s] at non-leaf node", schema.Name, parent, util.StripModulePrefix(name))); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.members(schema, parent, c, seen); err != nil {
+				return err
+			}
+		default:
+			if err := d.field(parent, c.field, seen); err != nil {
+				return err
+			}
+		}
+	}
+	// Consume the closing delimiter.
+	_, err := d.dec.Token()
+	return err
+}
+
+// field unmarshals the next JSON value into the field f of parent.
+func (d *jsonStreamDecoder) field(parent interface{}, f *jsonStreamField, seen map[int]jsonStreamValue) error {
+	cschema := f.schema
+	if cschema.IsLeaf() || cschema.IsLeafList() {
+		return d.leaf(cschema, parent, func(v interface{}) error {
+			if !f.multiplePaths {
+				return nil
+			}
+			if s, ok := seen[f.index]; ok && !reflect.DeepEqual(s.value, v) {
+				return fmt.Errorf("values at paths %v and %v are different: %v != %v", s.path, f.path, s.value, v)
+			}
+			seen[f.index] = jsonStreamValue{path: f.path, value: v}
+			return nil
+		})
+	}
+
+	start, err := d.dec.Token()
+	if err != nil || start == nil {
+		return err
+	}
+	destv := reflect.ValueOf(parent).Elem()
+	fv := destv.Field(f.index)
+	// Only create a new field if it is nil, otherwise update just the
+	// fields that are in the data tree, and preserve all other existing
+	// values.
+	if util.IsNilOrInvalidValue(fv) {
+		makeField(destv, destv.Type().Field(f.index))
+	}
+	switch {
+	case util.IsUnkeyedList(cschema):
+		return d.list(cschema, fv.Addr().Interface(), start)
+	case cschema.IsList():
+		return d.list(cschema, fv.Interface(), start)
+	default:
+		return d.object(cschema, fv.Interface(), start)
+	}
+}
+
+// leaf unmarshals the next JSON value into the leaf or leaf-list with the
+// supplied schema within parent. check, if non-nil, is called with the value
+// before it is unmarshalled.
+func (d *jsonStreamDecoder) leaf(schema *yang.Entry, parent interface{}, check func(interface{}) error) error {
+	var v interface{}
+	if err := d.dec.Decode(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+	if check != nil {
+		if err := check(v); err != nil {
+			return d.fail(err)
+		}
+	}
+	return d.fail(unmarshalGeneric(schema, parent, v, JSONEncoding, d.opts...))
+}
+
+// list unmarshals a JSON array whose first token is start into parent, which
+// is the map, ordered map or slice ptr that stores the list with the supplied
+// schema.
+func (d *jsonStreamDecoder) list(schema *yang.Entry, parent interface{}, start json.Token) error {
+	if err := validateListSchema(schema); err != nil {
+		return err
+	}
+	t := reflect.TypeOf(parent)
+	orderedMap, isOrderedMap := parent.(ygot.GoOrderedMap)
+
+	var elemType reflect.Type
+	switch {
+	case isOrderedMap:
+		var err error
+		if elemType, err = yreflect.OrderedMapElementType(orderedMap); err != nil {
+			return err
+		}
+	case util.IsTypeMap(t):
+		elemType = t.Elem()
+	case util.IsTypeSlicePtr(t):
+		elemType = t.Elem().Elem()
+	default:
+		return fmt.Errorf("list %s got parent type %T, expect map, ordered map or slice ptr", schema.Name, parent)
+	}
+	if !util.IsTypeStructPtr(elemType) {
+		return fmt.Errorf("list %s parent type %T has bad element type %v", schema.Name, parent, elemType)
+	}
+
+	ok, err := d.delim(start, '[', schema)
+	if !ok || err != nil {
+		return err
+	}
+	// Where the map or ordered map already has entries, each entry in the
+	// JSON is merged into any existing entry with the same key, which
+	// requires the entry to be buffered such that its key can be read
+	// before it is unmarshalled.
+	merge := isOrderedMap && orderedMap.Len() > 0 || util.IsTypeMap(t) && reflect.ValueOf(parent).Len() > 0
+	for d.dec.More() {
+		if err := d.listEntry(schema, parent, elemType, isOrderedMap, merge); err != nil {
+			return err
+		}
+	}
+	// Consume the closing delimiter.
+	_, err = d.dec.Token()
+	return err
+}
+
+// listEntry unmarshals the next JSON value, which is an entry of the list
+// with the supplied schema, and inserts it into parent. Where merge is set,
+// the entry is instead unmarshalled into any existing entry of parent with the
+// same key, such that update rather than replace semantics are used.
+func (d *jsonStreamDecoder) listEntry(schema *yang.Entry, parent interface{}, elemType reflect.Type, isOrderedMap, merge bool) error {
+	src := d
+	if merge {
+		var raw json.RawMessage
+		if err := d.dec.Decode(&raw); err != nil {
+			return err
+		}
+		src = &jsonStreamDecoder{dec: json.NewDecoder(bytes.NewReader(raw)), jsonStreamConfig: d.jsonStreamConfig}
+		v, err := d.existingListEntry(schema, parent, elemType, isOrderedMap, raw)
+		if err != nil {
+			return err
+		}
+		if v.IsValid() {
+			return src.objectAt(schema, v.Interface())
+		}
+	}
+
+	newVal := reflect.New(elemType.Elem())
+	if err := src.objectAt(schema, newVal.Interface()); err != nil {
+		return err
+	}
+
+	var err error
+	switch {
+	case isOrderedMap:
+		err = yreflect.AppendIntoOrderedMap(parent.(ygot.GoOrderedMap), newVal.Interface())
+	case util.IsTypeMap(reflect.TypeOf(parent)):
+		var key reflect.Value
+		if key, err = makeKeyForInsert(schema, parent, newVal); err != nil {
+			break
+		}
+		err = util.InsertIntoMap(parent, key.Interface(), newVal.Interface())
+	default:
+		err = util.InsertIntoSlice(parent, newVal.Interface())
+	}
+	return d.fail(err)
+}
+
+// existingListEntry returns the entry of parent, which is the map or ordered
+// map that stores the list with the supplied schema, whose key is that of the
+// JSON list entry raw. Only the key leaves of raw are unmarshalled to find the
+// key. Where raw does not have a valid key, or parent does not have an entry
+// with its key, the zero Value is returned, such that the entry is
+// unmarshalled as a new entry, which reports any error within it.
+func (d *jsonStreamDecoder) existingListEntry(schema *yang.Entry, parent interface{}, elemType reflect.Type, isOrderedMap bool, raw json.RawMessage) (reflect.Value, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &members); err != nil {
+		return reflect.Value{}, nil
+	}
+	fields, err := d.fieldsFor(schema, elemType)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	// The key leaves are unmarshalled without best effort, such that their
+	// errors are reported once, when the entry itself is unmarshalled.
+	keyVal := reflect.New(elemType.Elem())
+	kc := &jsonStreamConfig{opts: d.opts, fields: d.fields}
+	seen := map[int]jsonStreamValue{}
+	for _, k := range strings.Fields(schema.Key) {
+		c := fields.children[k]
+		if c == nil || c.field == nil {
+			return reflect.Value{}, nil
+		}
+		var found bool
+		for name, m := range members {
+			if util.StripModulePrefix(name) != k {
+				continue
+			}
+			kd := &jsonStreamDecoder{dec: json.NewDecoder(bytes.NewReader(m)), jsonStreamConfig: kc}
+			if err := kd.field(keyVal.Interface(), c.field, seen); err != nil {
+				return reflect.Value{}, nil
+			}
+			found = true
+		}
+		if !found {
+			return reflect.Value{}, nil
+		}
+	}
+
+	if isOrderedMap {
+		om := parent.(ygot.GoOrderedMap)
+		keyType, err := yreflect.OrderedMapKeyType(om)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		key, err := makeKeyOfType(schema, keyType, keyVal)
+		if err != nil {
+			return reflect.Value{}, nil
+		}
+		v, ok, err := yreflect.GetOrderedMapElement(om, key)
+		if err != nil || !ok {
+			return reflect.Value{}, err
+		}
+		return v, nil
+	}
+	key, err := makeKeyForInsert(schema, parent, keyVal)
+	if err != nil {
+		return reflect.Value{}, nil
+	}
+	if v := reflect.ValueOf(parent).MapIndex(key); v.IsValid() && !v.IsZero() {
+		return v, nil
+	}
+	return reflect.Value{}, nil
+}
+
+// jsonStreamNode is a node of the tree of JSON member names that are found
+// within the JSON object corresponding to a GoStruct.
+type jsonStreamNode struct {
+	// children are the members of the object, keyed by their names without
+	// module prefixes.
+	children map[string]*jsonStreamNode
+	// field is the field that the value of the member is unmarshalled into,
+	// or nil if the member is an object that contains the values of fields.
+	field *jsonStreamField
+	// ignore indicates that the value of the member is ignored.
+	ignore bool
+}
+
+// jsonStreamField is a field of a GoStruct that is unmarshalled from JSON.
+type jsonStreamField struct {
+	// index is the index of the field within the struct.
+	index int
+	// schema is the schema of the field.
+	schema *yang.Entry
+	// path is the path of the member within the JSON object.
+	path []string
+	// multiplePaths indicates that the field has more than one path.
+	multiplePaths bool
+}
+
+// jsonStreamFieldsKey is the key of the fields of a jsonStreamConfig.
+type jsonStreamFieldsKey struct {
+	schema *yang.Entry
+	t      reflect.Type
+}
+
+// fieldsFor returns the tree of JSON member names that are found within the
+// JSON object corresponding to the struct ptr type t, whose schema is
+// supplied, caching it for the remainder of the document.
+func (c *jsonStreamConfig) fieldsFor(schema *yang.Entry, t reflect.Type) (*jsonStreamNode, error) {
+	k := jsonStreamFieldsKey{schema: schema, t: t}
+	if n, ok := c.fields[k]; ok {
+		return n, nil
+	}
+	n, err := jsonStreamFieldsFor(schema, t, c.preferShadowPath)
+	if err != nil {
+		return nil, err
+	}
+	c.fields[k] = n
+	return n, nil
+}
+
+// jsonStreamFieldsFor returns the tree of JSON member names that are found
+// within the JSON object corresponding to the struct ptr type t, whose schema
+// is supplied. The paths of each field are determined as for unmarshalStruct.
+func jsonStreamFieldsFor(schema *yang.Entry, t reflect.Type, preferShadowPath bool) (*jsonStreamNode, error) {
+
+	root := &jsonStreamNode{children: map[string]*jsonStreamNode{}}
+	add := func(path []string, f *jsonStreamField) error {
+		n := root
+		for i, p := range path {
+			p = util.StripModulePrefix(p)
+			c, ok := n.children[p]
+			if !ok {
+				c = &jsonStreamNode{children: map[string]*jsonStreamNode{}}
+				n.children[p] = c
+			}
+			if i < len(path)-1 {
+				if c.field != nil {
+					return fmt.Errorf("struct %s: path %v is within the path of field %s", t.Elem(), path, t.Elem().Field(c.field.index).Name)
+				}
+				n = c
+				continue
+			}
+			switch {
+			case f == nil:
+				if c.field == nil && len(c.children) == 0 {
+					c.ignore = true
+				}
+			case len(c.children) > 0 || (c.field != nil && c.field.index != f.index):
+				return fmt.Errorf("struct %s: path %v is shared by multiple fields", t.Elem(), path)
+			default:
+				c.field = f
+				c.ignore = false
+			}
+		}
+		return nil
+	}
+
+	st := t.Elem()
+	childSchemaFn := util.ChildSchema
+	if preferShadowPath {
+		childSchemaFn = util.ChildSchemaPreferShadow
+	}
+	// The paths of each field that are ignored are added once the paths
+	// of all fields that are unmarshalled are known.
+	var ignored [][]string
+	for i := 0; i < st.NumField(); i++ {
+		ft := st.Field(i)
+		if util.IsYgotAnnotation(ft) {
+			paths, err := pathTagFromField(ft)
+			if err != nil {
+				return nil, fmt.Errorf("cannot find JSON field names for annotation field %s, %v", ft.Name, err)
+			}
+			for _, s := range strings.Split(paths, "|") {
+				ignored = append(ignored, strings.Split(s, "/"))
+			}
+			continue
+		}
+
+		cschema, err := childSchemaFn(schema, ft)
+		if err != nil {
+			return nil, err
+		}
+		if cschema == nil {
+			return nil, fmt.Errorf("could not find schema for type %v, field name %s", t, ft.Name)
+		}
+		dps, err := dataTreePaths(schema, cschema, ft)
+		if err != nil {
+			return nil, err
+		}
+		sps, err := shadowDataTreePaths(schema, cschema, ft)
+		if err != nil {
+			return nil, err
+		}
+		// The values at shadow paths are unmarshalled in preference to
+		// those at paths where requested, and the others are ignored.
+		active := dps
+		if preferShadowPath && len(sps) > 0 {
+			active, sps = sps, dps
+		}
+		ignored = append(ignored, sps...)
+		for _, p := range active {
+			if len(p) == 0 {
+				return nil, fmt.Errorf("struct %s: field %s has an empty path", st, ft.Name)
+			}
+			if err := add(p, &jsonStreamField{index: i, schema: cschema, path: p, multiplePaths: len(active) > 1}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, p := range ignored {
+		if err := add(p, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return root, nil
+}
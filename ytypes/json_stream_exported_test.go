@@ -0,0 +1,102 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/internal/ytestutil"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+func TestUnmarshalJSONReaderOrderedMap(t *testing.T) {
+	// orderedMap returns an ordered map with an entry for each key, in
+	// order, with the value in vals at the same index.
+	orderedMap := func(keys []string, vals []string) *ctestschema.OrderedList_OrderedMap {
+		om := &ctestschema.OrderedList_OrderedMap{}
+		for i, k := range keys {
+			v, err := om.AppendNew(k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.Value = ygot.String(vals[i])
+		}
+		return om
+	}
+
+	tests := []struct {
+		desc     string
+		inParent *ctestschema.Device
+		inJSON   string
+		inOpts   []ytypes.UnmarshalOpt
+		want     *ctestschema.Device
+		wantErr  string
+		wantErrs int
+	}{{
+		desc:   "new ordered map",
+		inJSON: `{"ordered-lists": {"ordered-list": [{"key": "foo", "config": {"key": "foo", "value": "foo-val"}}, {"key": "bar", "config": {"key": "bar", "value": "bar-val"}}]}}`,
+		want:   &ctestschema.Device{OrderedList: ctestschema.GetOrderedMap(t)},
+	}, {
+		desc:     "merge into existing entry",
+		inParent: &ctestschema.Device{OrderedList: ctestschema.GetOrderedMap(t)},
+		inJSON:   `{"ordered-lists": {"ordered-list": [{"key": "bar", "config": {"value": "bar-val2"}}, {"key": "baz", "config": {"key": "baz", "value": "baz-val"}}]}}`,
+		want: &ctestschema.Device{
+			OrderedList: orderedMap([]string{"foo", "bar", "baz"}, []string{"foo-val", "bar-val2", "baz-val"}),
+		},
+	}, {
+		desc:     "merge into existing entry with prefixed key",
+		inParent: &ctestschema.Device{OrderedList: ctestschema.GetOrderedMap(t)},
+		inJSON:   `{"ctestschema:ordered-lists": {"ordered-list": [{"ctestschema:key": "foo", "config": {"value": "foo-val2"}}]}}`,
+		want: &ctestschema.Device{
+			OrderedList: orderedMap([]string{"foo", "bar"}, []string{"foo-val2", "bar-val"}),
+		},
+	}, {
+		desc:     "best effort merge into existing entry",
+		inParent: &ctestschema.Device{OrderedList: ctestschema.GetOrderedMap(t)},
+		inJSON:   `{"ordered-lists": {"ordered-list": [{"key": "foo", "config": {"value": 42}}]}}`,
+		inOpts:   []ytypes.UnmarshalOpt{&ytypes.BestEffortUnmarshal{}},
+		want:     &ctestschema.Device{OrderedList: ctestschema.GetOrderedMap(t)},
+		wantErr:  "got float64 type for field value, expect string",
+		wantErrs: 1,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := tt.inParent
+			if got == nil {
+				got = &ctestschema.Device{}
+			}
+			err := ytypes.UnmarshalJSONReader(ctestschema.SchemaTree["Device"], got, strings.NewReader(tt.inJSON), tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalJSONReader(): did not get expected error, %s", diff)
+			}
+			if tt.wantErrs != 0 {
+				var ce *ytypes.ComplianceErrors
+				if !errors.As(err, &ce) || len(ce.Errors) != tt.wantErrs {
+					t.Fatalf("UnmarshalJSONReader(): got error %v, want %d compliance errors", err, tt.wantErrs)
+				}
+			}
+			if diff := cmp.Diff(tt.want, got, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Errorf("UnmarshalJSONReader(): did not get expected data tree, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// xpathTestJSON is the RFC7951 JSON representation of xpathTestData.
+const xpathTestJSON = `{
+  "openconfig-interfaces:interfaces": {
+    "interface": [{
+      "name": "eth0",
+      "config": {"name": "eth0", "mtu": 1500, "type": "iana-if-type:ethernetCsmacd"},
+      "subinterfaces": {
+        "subinterface": [
+          {"index": 0, "config": {"index": 0, "address": ["192.0.2.1", "192.0.2.2"]}},
+          {"index": 1, "config": {"index": 1, "description": "sub one"}}
+        ]
+      }
+    }, {
+      "name": "lo0",
+      "config": {"name": "lo0", "type": "iana-if-type:softwareLoopback", "enabled": false, "description": "loopback"}
+    }]
+  },
+  "bgp": {
+    "global": {"config": {"as": 64512}},
+    "neighbors": {
+      "neighbor": [{
+        "neighbor-address": "192.0.2.254",
+        "config": {"neighbor-address": "192.0.2.254", "peer-as": 64513, "interface": "eth0"}
+      }]
+    }
+  }
+}`
+
+func TestUnmarshalJSONReader(t *testing.T) {
+	tests := []struct {
+		desc        string
+		inParent    func() *xpathTestDevice
+		inJSON      string
+		inOpts      []UnmarshalOpt
+		want        *xpathTestDevice
+		wantErr     string
+		wantErrs    int
+		wantNoMatch bool
+	}{{
+		desc:   "data tree",
+		inJSON: xpathTestJSON,
+		want:   xpathTestData(),
+	}, {
+		desc:   "empty object",
+		inJSON: `{}`,
+		want:   &xpathTestDevice{},
+	}, {
+		desc:   "null values",
+		inJSON: `{"bgp": {"global": {"config": {"as": null}}, "neighbors": {"neighbor": null}}}`,
+		want:   &xpathTestDevice{Bgp: &xpathTestBgp{}},
+	}, {
+		desc:    "null in place of a non-leaf node",
+		inJSON:  `{"interfaces": null}`,
+		wantErr: "JSON contains unexpected leaf field(s) [interfaces] at non-leaf node",
+	}, {
+		desc: "merge into existing list entries",
+		inParent: func() *xpathTestDevice {
+			d := xpathTestData()
+			d.Bgp = nil
+			return d
+		},
+		inJSON: `{"interfaces": {"interface": [
+		  {"name": "eth0", "config": {"name": "eth0", "mtu": 9000}},
+		  {"name": "eth1", "config": {"name": "eth1"}}
+		]}}`,
+		want: func() *xpathTestDevice {
+			d := xpathTestData()
+			d.Bgp = nil
+			d.Interface["eth0"].Mtu = ygot.Uint16(9000)
+			d.Interface["eth1"] = &xpathTestInterface{Name: ygot.String("eth1")}
+			return d
+		}(),
+	}, {
+		desc:    "unexpected field",
+		inJSON:  `{"bgp": {"global": {"config": {"as": 1, "router-id": "192.0.2.1"}}}}`,
+		wantErr: "parent container bgp (type *ytypes.xpathTestBgp): JSON contains unexpected field router-id",
+	}, {
+		desc:   "unexpected field with IgnoreExtraFields",
+		inJSON: `{"bgp": {"global": {"config": {"as": 1, "router-id": {"a": [1, 2]}}}}}`,
+		inOpts: []UnmarshalOpt{&IgnoreExtraFields{}},
+		want:   &xpathTestDevice{Bgp: &xpathTestBgp{As: ygot.Uint32(1)}},
+	}, {
+		desc:    "different values at the paths of a field",
+		inJSON:  `{"interfaces": {"interface": [{"name": "eth0", "config": {"name": "eth1"}}]}}`,
+		wantErr: "values at paths [name] and [config name] are different: eth0 != eth1",
+	}, {
+		desc:    "bad leaf value",
+		inJSON:  `{"bgp": {"global": {"config": {"as": "one"}}}}`,
+		wantErr: "got string type for field as, expect float64",
+	}, {
+		desc: "best effort",
+		inJSON: `{
+		  "interfaces": {"interface": [{"name": "eth0", "config": {"name": "eth0", "mtu": "big"}}]},
+		  "bgp": {"global": {"config": {"as": 64512, "router-id": "192.0.2.1"}}}
+		}`,
+		inOpts:   []UnmarshalOpt{&BestEffortUnmarshal{}},
+		wantErr:  "JSON contains unexpected field router-id",
+		wantErrs: 2,
+		want: &xpathTestDevice{
+			Interface: map[string]*xpathTestInterface{"eth0": {Name: ygot.String("eth0")}},
+			Bgp:       &xpathTestBgp{As: ygot.Uint32(64512)},
+		},
+		// Unmarshal does not support best effort unmarshalling of a
+		// data tree.
+		wantNoMatch: true,
+	}, {
+		desc: "best effort merge into existing list entry",
+		inParent: func() *xpathTestDevice {
+			d := xpathTestData()
+			d.Bgp = nil
+			return d
+		},
+		inJSON: `{"interfaces": {"interface": [
+		  {"name": "eth0", "config": {"name": "eth0", "mtu": "big"}}
+		]}}`,
+		inOpts:   []UnmarshalOpt{&BestEffortUnmarshal{}},
+		wantErr:  "got string type for field mtu, expect float64",
+		wantErrs: 1,
+		want: func() *xpathTestDevice {
+			d := xpathTestData()
+			d.Bgp = nil
+			return d
+		}(),
+		wantNoMatch: true,
+	}, {
+		desc:    "trailing data",
+		inJSON:  `{"bgp": {}} {}`,
+		wantErr: "unexpected data following JSON value for schema device",
+		// Unmarshal does not read JSON.
+		wantNoMatch: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := xpathTestSchema(nil)
+			newParent := func() *xpathTestDevice {
+				if tt.inParent != nil {
+					return tt.inParent()
+				}
+				return &xpathTestDevice{}
+			}
+
+			got := newParent()
+			err := UnmarshalJSONReader(schema, got, strings.NewReader(tt.inJSON), tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalJSONReader(): did not get expected error, %s", diff)
+			}
+			if tt.wantErrs != 0 {
+				var ce *ComplianceErrors
+				if !errors.As(err, &ce) || len(ce.Errors) != tt.wantErrs {
+					t.Fatalf("UnmarshalJSONReader(): got error %v, want %d compliance errors", err, tt.wantErrs)
+				}
+			}
+			if tt.want != nil {
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("UnmarshalJSONReader(): did not get expected data tree, (-want, +got):\n%s", diff)
+				}
+			}
+			if tt.wantNoMatch {
+				return
+			}
+
+			// The result must be the same as that of Unmarshal.
+			var jv interface{}
+			if err := json.Unmarshal([]byte(tt.inJSON), &jv); err != nil {
+				t.Fatalf("json.Unmarshal(): got unexpected error: %v", err)
+			}
+			want := newParent()
+			wantErr := Unmarshal(schema, want, jv, tt.inOpts...)
+			if (err == nil) != (wantErr == nil) {
+				t.Fatalf("UnmarshalJSONReader(): got error %v, Unmarshal got error %v", err, wantErr)
+			}
+			if err == nil {
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("UnmarshalJSONReader(): did not get same data tree as Unmarshal, (-Unmarshal, +UnmarshalJSONReader):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONReaderSchemas(t *testing.T) {
+	schema := xpathTestSchema(nil)
+	intfSchema := schema.Dir["interfaces"].Dir["interface"]
+
+	t.Run("list", func(t *testing.T) {
+		got := map[string]*xpathTestInterface{}
+		if err := UnmarshalJSONReader(intfSchema, got, strings.NewReader(`[{"name": "eth0"}, {"name": "lo0"}]`)); err != nil {
+			t.Fatalf("UnmarshalJSONReader(): got unexpected error: %v", err)
+		}
+		want := map[string]*xpathTestInterface{
+			"eth0": {Name: ygot.String("eth0")},
+			"lo0":  {Name: ygot.String("lo0")},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("UnmarshalJSONReader(): did not get expected list, (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("list entry", func(t *testing.T) {
+		got := &xpathTestInterface{}
+		if err := UnmarshalJSONReader(intfSchema, got, strings.NewReader(`{"config": {"mtu": 1500}}`)); err != nil {
+			t.Fatalf("UnmarshalJSONReader(): got unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(&xpathTestInterface{Mtu: ygot.Uint16(1500)}, got); diff != "" {
+			t.Errorf("UnmarshalJSONReader(): did not get expected list entry, (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("leaf", func(t *testing.T) {
+		got := &xpathTestInterface{}
+		if err := UnmarshalJSONReader(intfSchema.Dir["config"].Dir["mtu"], got, strings.NewReader(`1500`)); err != nil {
+			t.Fatalf("UnmarshalJSONReader(): got unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(&xpathTestInterface{Mtu: ygot.Uint16(1500)}, got); diff != "" {
+			t.Errorf("UnmarshalJSONReader(): did not get expected leaf, (-want, +got):\n%s", diff)
+		}
+	})
+}
+
+// jsonStreamShadowTest is a struct whose field has a shadow path, which is
+// used to test the PreferShadowPath option of UnmarshalJSONReader.
+type jsonStreamShadowTest struct {
+	Mtu *uint16 `path:"state/mtu" shadow-path:"config/mtu"`
+}
+
+func (*jsonStreamShadowTest) IsYANGGoStruct() {}
+
+func TestUnmarshalJSONReaderPreferShadowPath(t *testing.T) {
+	mtu := func() *yang.Entry {
+		return &yang.Entry{Name: "mtu", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yuint16}}
+	}
+	schema := &yang.Entry{
+		Name: "interface",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"config": {Name: "config", Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{"mtu": mtu()}},
+			"state":  {Name: "state", Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{"mtu": mtu()}},
+		},
+	}
+	populateParentField(nil, schema)
+	const in = `{"config": {"mtu": 1500}, "state": {"mtu": 9000}}`
+
+	tests := []struct {
+		desc   string
+		inOpts []UnmarshalOpt
+		want   *jsonStreamShadowTest
+	}{{
+		desc: "path",
+		want: &jsonStreamShadowTest{Mtu: ygot.Uint16(9000)},
+	}, {
+		desc:   "shadow path",
+		inOpts: []UnmarshalOpt{&PreferShadowPath{}},
+		want:   &jsonStreamShadowTest{Mtu: ygot.Uint16(1500)},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &jsonStreamShadowTest{}
+			if err := UnmarshalJSONReader(schema, got, strings.NewReader(in), tt.inOpts...); err != nil {
+				t.Fatalf("UnmarshalJSONReader(): got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalJSONReader(): did not get expected struct, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -515,8 +515,10 @@ func unmarshalUnion(schema *yang.Entry, parent interface{}, fieldName string, va
 		if sv, ok = value.(*gpb.TypedValue).GetValue().(*gpb.TypedValue_StringVal); ok {
 			valueStr = sv.StringVal
 		}
-	case JSONEncoding:
+	case JSONEncoding, XMLEncoding:
 		valueStr, ok = value.(string)
+	case CBOREncoding:
+		valueStr, ok = cborUnionString(value)
 	default:
 		return fmt.Errorf("unknown encoding %v", enc)
 	}
@@ -687,6 +689,18 @@ func unmarshalScalar(parent interface{}, schema *yang.Entry, fieldName string, v
 	switch enc {
 	case JSONEncoding:
 		return sanitizeJSON(parent, schema, fieldName, value)
+	case XMLEncoding:
+		v, err := xmlToJSONValue(schema.Type.Kind, value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %v for schema %s: %v", value, schema.Name, err)
+		}
+		return sanitizeJSON(parent, schema, fieldName, v)
+	case CBOREncoding:
+		v, err := cborToJSONValue(schema, value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %v for schema %s: %v", value, schema.Name, err)
+		}
+		return sanitizeJSON(parent, schema, fieldName, v)
 	case GNMIEncoding, gNMIEncodingWithJSONTolerance:
 		tv, ok := value.(*gpb.TypedValue)
 		if !ok {
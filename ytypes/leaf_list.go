@@ -50,9 +50,9 @@ func validateLeafList(schema *yang.Entry, value interface{}) util.Errors {
 			// Handle the case that this is a leaf-list of enumerated values, where we expect that the
 			// input to validateLeaf is a scalar value, rather than a pointer.
 			if _, ok := cv.(ygot.GoEnum); ok {
-				errors = util.AppendErrs(errors, validateLeaf(schema, cv))
+				errors = util.AppendErrs(errors, leafValidationErrors(schema, cv, validateLeaf(schema, cv)))
 			} else {
-				errors = util.AppendErrs(errors, validateLeaf(schema, &cv))
+				errors = util.AppendErrs(errors, leafValidationErrors(schema, cv, validateLeaf(schema, &cv)))
 			}
 
 		}
@@ -89,6 +89,8 @@ func validateLeafListSchema(schema *yang.Entry) error {
 // - enc is the encoding type used to encode the value
 // - value is a JSON array if enc is JSONEncoding, represented as Go slice
 // - value is a gNMI TypedValue if enc is GNMIEncoding, represented as TypedValue_LeafListVal
+// - value is the value of one or more XML elements if enc is XMLEncoding
+// - value is a CBOR array if enc is CBOREncoding, represented as Go slice
 func unmarshalLeafList(schema *yang.Entry, parent interface{}, value interface{}, enc Encoding, opts ...UnmarshalOpt) error {
 	if util.IsValueNil(value) {
 		if enc == JSONEncoding {
@@ -132,7 +134,10 @@ func unmarshalLeafList(schema *yang.Entry, parent interface{}, value interface{}
 				return err
 			}
 		}
-	case JSONEncoding:
+	case JSONEncoding, XMLEncoding, CBOREncoding:
+		if enc == XMLEncoding {
+			value = xmlListValue(value)
+		}
 		leafList, ok := value.([]interface{})
 		if !ok {
 			return fmt.Errorf("unmarshalLeafList for schema %s: value %v: got type %T, expect []interface{}", schema.Name, util.ValueStr(value), value)
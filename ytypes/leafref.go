@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	log "github.com/golang/glog"
@@ -47,9 +48,15 @@ func ValidateLeafRefData(schema *yang.Entry, value interface{}, opt *LeafrefOpti
 		return nil
 	}
 
-	// validateLeafRefDataIterFunc is called on every node in the tree through
-	// ForEachField below.
-	validateLeafRefDataIterFunc := func(ni *util.NodeInfo, in, out interface{}) util.Errors {
+	pathQueryRootNode := &util.PathQueryNodeMemo{Memo: util.PathQueryMemo{}}
+	return util.ForEachField(schema, value, pathQueryRootNode, nil, validateLeafRefDataIterFunc(value, opt))
+}
+
+// validateLeafRefDataIterFunc returns the function that is called on every
+// node in the data tree with root value to validate the leafrefs within it. The
+// function requires the *util.PathQueryNodeMemo of the node as its input.
+func validateLeafRefDataIterFunc(value interface{}, opt *LeafrefOptions) util.FieldIteratorFunc {
+	return func(ni *util.NodeInfo, in, out interface{}) util.Errors {
 		if util.IsValueNil(ni) || util.IsNilOrInvalidValue(ni.FieldValue) {
 			return nil
 		}
@@ -79,20 +86,128 @@ func ValidateLeafRefData(schema *yang.Entry, value interface{}, opt *LeafrefOpti
 
 		match, err := matchesNodes(ni, matchNodes)
 		if err != nil {
-			return leafrefErrOrLog(util.NewErrs(err), opt)
+			return leafrefErrOrLog(util.NewErrs(leafrefValidationError(ni, err)), opt)
 		}
 		if !match {
 			e := fmt.Errorf("field name %s value %s schema path %s has leafref path %s not equal to any target nodes",
 				ni.StructField.Name, util.ValueStr(ni.FieldValue.Interface()), ni.Schema.Path(), pathStr)
 			util.DbgPrint("ERR: %s", e)
-			return leafrefErrOrLog(util.NewErrs(e), opt)
+			return leafrefErrOrLog(util.NewErrs(leafrefValidationError(ni, e)), opt)
 		}
 
 		return nil
 	}
+}
 
-	pathQueryRootNode := &util.PathQueryNodeMemo{Memo: util.PathQueryMemo{}}
-	return util.ForEachField(schema, value, pathQueryRootNode, nil, validateLeafRefDataIterFunc)
+// leafrefVisitor is a util.Visitor that validates the leafrefs within a data
+// tree. It maintains a util.PathQueryNodeMemo for each node in the same manner
+// as util.ForEachField, such that leafrefs are resolved as per
+// ValidateLeafRefData. Where a changeFilter is supplied, only the leafrefs that
+// are affected by the changes that it describes are validated, and where tasks
+// is non-nil, the leafrefs are collected rather than validated.
+type leafrefVisitor struct {
+	// filter describes the changes, it is nil within a changed subtree, or
+	// where all leafrefs are to be validated.
+	filter *changeFilter
+	// unrelated indicates that the node being visited is not affected by
+	// the changes, such that only leafrefs that may refer to changed nodes
+	// are validated.
+	unrelated bool
+	// parent is the memo of the parent of the node being visited.
+	parent *util.PathQueryNodeMemo
+	// iterFunction validates a single leafref.
+	iterFunction util.FieldIteratorFunc
+	// tasks, if non-nil, collects the leafrefs that are to be validated.
+	tasks *[]leafrefTask
+	// errs collects the errors that are found.
+	errs *util.DefaultWalkErrors
+}
+
+// Visit implements the util.Visitor interface.
+func (v leafrefVisitor) Visit(node util.WalkNode) util.Visitor {
+	if node == nil {
+		return nil
+	}
+	ni := node.NodeInfo()
+	if util.IsValueNil(ni) || util.IsNilOrInvalidValue(ni.FieldValue) {
+		// There is no data, and hence no leafrefs, within the node.
+		return nil
+	}
+	if v.filter != nil && !v.unrelated && ni.Parent != nil {
+		switch v.filter.status(nodeInfoPath(ni).GetElem()) {
+		case changeInside:
+			v.filter = nil
+		case changeUnrelated:
+			v.unrelated = true
+		}
+	}
+	if v.unrelated && !v.filter.leafrefSubtreeDependsOnChange(ni.Schema) {
+		return nil
+	}
+
+	in := &util.PathQueryNodeMemo{
+		Parent: v.parent,
+		Memo:   util.PathQueryMemo{},
+	}
+	switch {
+	case v.unrelated && !v.filter.leafrefDependsOnChange(ni.Schema):
+	case v.tasks != nil:
+		if ni.Schema != nil && util.IsLeafRef(ni.Schema) && !ni.Schema.IsLeafList() {
+			path, err := ygot.PathToString(nodeInfoPath(ni))
+			if err != nil {
+				path = ni.Schema.Path()
+			}
+			*v.tasks = append(*v.tasks, leafrefTask{ni: copyNodeInfo(ni), memo: in, path: path})
+		}
+	default:
+		if err := v.iterFunction(ni, in, nil); err != nil {
+			v.errs.Collect(err)
+		}
+	}
+	// Since a value receiver is used, v is a copy and it is safe to
+	// modify it.
+	v.parent = in
+	return v
+}
+
+// validateLeafRefs validates the leafrefs within the data tree with root
+// value, whose schema is supplied, as per ValidateLeafRefData. Where f is
+// non-nil, only the leafrefs within the subtrees that are changed, or that may
+// refer to nodes that are changed, are validated. Where p is non-nil, the
+// leafrefs are validated concurrently, and the memo of each node is shared
+// between the goroutines.
+func validateLeafRefs(schema *yang.Entry, value interface{}, opt *LeafrefOptions, f *changeFilter, p *validatePool) util.Errors {
+	if opt != nil && opt.IgnoreMissingData {
+		return nil
+	}
+	if util.IsValueNil(value) {
+		return nil
+	}
+	errs := new(util.DefaultWalkErrors)
+	v := leafrefVisitor{
+		filter:       f,
+		iterFunction: validateLeafRefDataIterFunc(value, opt),
+		errs:         errs,
+	}
+	var tasks []leafrefTask
+	if p != nil {
+		v.tasks = &tasks
+	}
+	util.Walk(v, util.WalkNodeFromGoStruct(value), util.DefaultWalkOptions().WithWalkErrors(errs).WithSchema(schema))
+
+	// The leafrefs are validated in the order of their paths, such that
+	// the errors are returned in a deterministic order.
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].path < tasks[j].path })
+	results := make([]util.Errors, len(tasks))
+	p.each(len(tasks), func(i int) {
+		results[i] = v.iterFunction(tasks[i].ni, tasks[i].memo, nil)
+	})
+	for _, r := range results {
+		if r != nil {
+			errs.Collect(r)
+		}
+	}
+	return errs.Errors
 }
 
 // leafrefErrOrLog returns an error if the global ValidationOptions specifies
@@ -111,6 +226,20 @@ func leafrefErrOrLog(e util.Errors, opt *LeafrefOptions) util.Errors {
 	return nil
 }
 
+// leafrefValidationError returns a ValidationError for the leafref described
+// by ni, whose value does not match any of the nodes that its path refers to.
+func leafrefValidationError(ni *util.NodeInfo, err error) *ValidationError {
+	var value interface{}
+	if v := ni.FieldValue; v.Kind() == reflect.Ptr && !v.IsNil() {
+		value = v.Elem().Interface()
+	} else {
+		value = v.Interface()
+	}
+	ve := newValidationError(LeafrefConstraint, ni.Schema, value, err)
+	ve.Path = nodeInfoPath(ni)
+	return ve
+}
+
 // leafRefToGNMIPath takes a leafref path string and transforms any leafref
 // path references of the form a[k1 = ../path/to/val and k2 = ...] to a GNMI
 // path where the key values are the values being referenced i.e.
@@ -216,29 +345,37 @@ func dataNodesAtPath(ni *util.NodeInfo, path *gpb.Path, pathQueryNode *util.Path
 
 	util.DbgPrint("root element type %s with remaining path %s", root.FieldValue.Type(), path)
 
-	// Check whether we have already done a lookup for the path specified by 'path' from this node before
-	// -- if so, return it from the cache rather than walking the tree again
-
-	// Get the query path for this node.
+	// Get the query path for this node, under which its lookup is
+	// memoized rather than walking the tree again.
 	strPath, err := ygot.PathToString(path)
 	if err != nil {
 		return nil, err
 	}
 
-	// Now, check for a previous identical query in the memo map.
-	qVal, ok := pathQueryRoot.Memo[strPath]
-	if ok {
-		return qVal.Nodes, qVal.Err
-	}
-	// Get all non-nil values
-	var nodes []any
-	treeNodes, err := GetNode(root.Schema, root.FieldValue.Interface(), path, &GetPartialKeyMatch{}, &GetHandleWildcards{}, &GetTolerateNil{})
-	for _, treeNode := range treeNodes {
-		if !util.IsValueNil(treeNode.Data) {
-			nodes = append(nodes, treeNode.Data)
+	return memoizedPathQuery(pathQueryRoot, strPath, func() ([]interface{}, error) {
+		// Get all non-nil values
+		var nodes []any
+		treeNodes, err := GetNode(root.Schema, root.FieldValue.Interface(), path, &GetPartialKeyMatch{}, &GetHandleWildcards{}, &GetTolerateNil{})
+		for _, treeNode := range treeNodes {
+			if !util.IsValueNil(treeNode.Data) {
+				nodes = append(nodes, treeNode.Data)
+			}
 		}
+		return nodes, err
+	})
+}
+
+// memoizedPathQuery returns the nodes selected by path from the node whose
+// memo is memo, which are returned by query. Where the same path has
+// previously been queried from the node, the memoized result is returned
+// rather than calling query. It is used to resolve both leafref paths and the
+// location paths of XPath expressions.
+func memoizedPathQuery(memo *util.PathQueryNodeMemo, path string, query func() ([]interface{}, error)) ([]interface{}, error) {
+	if qVal, ok := memo.Get(path); ok {
+		return qVal.Nodes, qVal.Err
 	}
-	pathQueryRoot.Memo[strPath] = util.PathQueryResult{Nodes: nodes, Err: err}
+	nodes, err := query()
+	memo.Set(path, util.PathQueryResult{Nodes: nodes, Err: err})
 	return nodes, err
 }
 
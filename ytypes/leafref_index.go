@@ -0,0 +1,371 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// LeafrefReference is a reference from a leafref within a data tree to a node
+// that it refers to.
+type LeafrefReference struct {
+	// Path is the path of the leafref leaf or leaf-list.
+	Path *gpb.Path
+	// Value is the value of the leafref, or of the member of the leaf-list
+	// that refers to the target.
+	Value interface{}
+	// Target is the path of the node that the leafref refers to.
+	Target *gpb.Path
+}
+
+// String returns a human-readable representation of the reference.
+func (r *LeafrefReference) String() string {
+	pathStr := func(p *gpb.Path) string {
+		s, err := ygot.PathToString(p)
+		if err != nil {
+			return p.String()
+		}
+		return s
+	}
+	return fmt.Sprintf("%s (%v) -> %s", pathStr(r.Path), r.Value, pathStr(r.Target))
+}
+
+// LeafrefIndex is an index of the leafrefs within a data tree, which allows
+// the leafrefs that refer to a node, such as an entry of a list, to be found.
+// The index reflects the data tree at the time that it was built, and must be
+// rebuilt once the data tree is modified.
+type LeafrefIndex struct {
+	// leafrefs are the leafrefs within the data tree, in document order.
+	leafrefs []*indexedLeafref
+	// strip indicates that the data tree is not that of a fake root, such
+	// that the first element of the path of each XPath node, which is the
+	// name of the top node of the data tree, is not part of the paths
+	// within the index.
+	strip bool
+}
+
+// indexedLeafref is a leafref leaf, or member of a leafref leaf-list, within
+// a LeafrefIndex.
+type indexedLeafref struct {
+	// node is the node of the leafref within the XPath data tree that the
+	// index was built from.
+	node *xpathNode
+	// path and value are the path and value of the leafref.
+	path  *gpb.Path
+	value interface{}
+	// targets are the paths of the nodes that the leafref refers to.
+	targets []*gpb.Path
+}
+
+// key returns a string that identifies the leafref within any LeafrefIndex
+// of the same data tree.
+func (l *indexedLeafref) key() string {
+	p, err := ygot.PathToString(l.path)
+	if err != nil {
+		p = l.path.String()
+	}
+	return fmt.Sprintf("%s=%v", p, l.value)
+}
+
+// NewLeafrefIndex returns the LeafrefIndex of the data tree root, whose
+// schema is supplied. Leafref paths are resolved in the same way as for must
+// and when statements, and hence absolute paths can only be resolved where the
+// schema is that of the fakeroot. The paths within the index are relative to
+// root. An error is returned if the path of a leafref cannot be resolved.
+func NewLeafrefIndex(schema *yang.Entry, root interface{}) (*LeafrefIndex, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("nil schema for data tree")
+	}
+	if util.IsValueNil(root) {
+		return nil, fmt.Errorf("nil data tree")
+	}
+	xroot, top := newXPathTree(schema, root)
+	x := &LeafrefIndex{strip: xroot != top}
+	var errs util.Errors
+	walkXPathTree(top, func(n *xpathNode) bool {
+		if !n.isLeaf() || !util.IsLeafRef(n.schema) {
+			return true
+		}
+		ts, err := derefXPathNode([]*xpathNode{n})
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("%s: cannot resolve leafref path %q: %v", n.pathString(), n.schema.Type.Path, err))
+			return true
+		}
+		l := &indexedLeafref{node: n, path: x.relPath(n), value: n.leafValue().Interface()}
+		for _, t := range ts {
+			l.targets = append(l.targets, x.relPath(t))
+		}
+		x.leafrefs = append(x.leafrefs, l)
+		return true
+	})
+	if errs != nil {
+		return nil, errs
+	}
+	return x, nil
+}
+
+// relPath returns the path of the XPath node n relative to the root of the
+// data tree.
+func (x *LeafrefIndex) relPath(n *xpathNode) *gpb.Path {
+	p := n.gnmiPath()
+	if x.strip && len(p.Elem) > 0 {
+		p.Elem = p.Elem[1:]
+	}
+	return p
+}
+
+// Referrers returns the references from leafrefs to the node with the
+// supplied path, or to any of its descendants, in the order in which the
+// leafrefs appear in the data tree. Leafrefs that are themselves within the
+// node are not included. Where the keys of a list are omitted from the path,
+// it refers to every entry of the list.
+func (x *LeafrefIndex) Referrers(path *gpb.Path) []*LeafrefReference {
+	var out []*LeafrefReference
+	q := [][]*gpb.PathElem{normalisedPathElems(path)}
+	for _, l := range x.leafrefs {
+		out = append(out, l.references(q)...)
+	}
+	return out
+}
+
+// references returns the references from the leafref l to the nodes within
+// any of the subtrees with the supplied paths, or nil if l is itself within
+// one of the subtrees.
+func (l *indexedLeafref) references(subtrees [][]*gpb.PathElem) []*LeafrefReference {
+	if pathWithinAny(l.path.GetElem(), subtrees) {
+		return nil
+	}
+	var out []*LeafrefReference
+	for _, t := range l.targets {
+		if pathWithinAny(t.GetElem(), subtrees) {
+			out = append(out, &LeafrefReference{Path: l.path, Value: l.value, Target: t})
+		}
+	}
+	return out
+}
+
+// dangling returns the leafrefs within the index that would no longer refer
+// to any node if the subtrees with the supplied paths were deleted, along
+// with the references that they have to nodes within the subtrees.
+func (x *LeafrefIndex) dangling(subtrees [][]*gpb.PathElem) ([]*indexedLeafref, []*LeafrefReference) {
+	var ls []*indexedLeafref
+	var refs []*LeafrefReference
+	for _, l := range x.leafrefs {
+		r := l.references(subtrees)
+		if len(r) == 0 || len(r) != len(l.targets) {
+			continue
+		}
+		ls = append(ls, l)
+		refs = append(refs, r...)
+	}
+	return ls, refs
+}
+
+// normalisedPathElems returns the elements of path with any module prefixes
+// removed from their names.
+func normalisedPathElems(path *gpb.Path) []*gpb.PathElem {
+	var out []*gpb.PathElem
+	for _, e := range path.GetElem() {
+		out = append(out, &gpb.PathElem{Name: util.StripModulePrefix(e.GetName()), Key: e.GetKey()})
+	}
+	return out
+}
+
+// pathWithinAny reports whether the path p is within any of the subtrees with
+// the supplied paths.
+func pathWithinAny(p []*gpb.PathElem, subtrees [][]*gpb.PathElem) bool {
+	for _, s := range subtrees {
+		if len(p) >= len(s) && changePathsMatch(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// LeafrefDeleteMode specifies the behaviour of DeleteNode where the node that
+// is deleted is referred to by leafrefs elsewhere in the data tree.
+type LeafrefDeleteMode int
+
+const (
+	// RefuseLeafrefDelete indicates that the node is not deleted, and a
+	// *ReferencedNodeError is returned.
+	RefuseLeafrefDelete LeafrefDeleteMode = iota
+	// ReportLeafrefDelete indicates that the node is deleted, and the
+	// leafrefs that no longer refer to any node are reported.
+	ReportLeafrefDelete
+	// CascadeLeafrefDelete indicates that the node is deleted, along with
+	// the leafrefs that no longer refer to any node. Where such a leafref
+	// is a key of a list, the list entry is deleted. The leafrefs that
+	// refer to the nodes that are deleted as a result are also deleted,
+	// and so on.
+	CascadeLeafrefDelete
+)
+
+// CheckLeafrefs specifies that DeleteNode checks the referential integrity of
+// the data tree, such that a leafref that refers to the node that is deleted,
+// or to one of its descendants, and which does not refer to any other node, is
+// handled as specified by Mode. The schema supplied to DeleteNode must be that
+// of the fakeroot for absolute leafref paths to be resolved.
+type CheckLeafrefs struct {
+	// Mode specifies how leafrefs that refer to the node are handled.
+	Mode LeafrefDeleteMode
+	// Referrers is populated by DeleteNode with the references from the
+	// leafrefs that no longer refer to any node as a result of the delete,
+	// or would no longer do so where the delete is refused. Where leafrefs
+	// are deleted by CascadeLeafrefDelete, the references of each leafref
+	// that is deleted are included.
+	Referrers []*LeafrefReference
+}
+
+// IsDelNodeOpt implements the DelNodeOpt interface.
+func (*CheckLeafrefs) IsDelNodeOpt() {}
+
+// delNodeCheckLeafrefs returns the first instance of CheckLeafrefs within the
+// supplied DelNodeOpt slice, or nil if there is none.
+func delNodeCheckLeafrefs(opts []DelNodeOpt) *CheckLeafrefs {
+	for _, o := range opts {
+		if c, ok := o.(*CheckLeafrefs); ok {
+			return c
+		}
+	}
+	return nil
+}
+
+// ReferencedNodeError is returned by DeleteNode where the node that is to be
+// deleted is referred to by leafrefs, and RefuseLeafrefDelete is specified.
+type ReferencedNodeError struct {
+	// Path is the path of the node that was to be deleted.
+	Path *gpb.Path
+	// Referrers are the references to the node and its descendants from
+	// leafrefs that would no longer refer to any node.
+	Referrers []*LeafrefReference
+}
+
+// Error implements the error interface.
+func (e *ReferencedNodeError) Error() string {
+	var refs []string
+	for _, r := range e.Referrers {
+		refs = append(refs, r.String())
+	}
+	p, err := ygot.PathToString(e.Path)
+	if err != nil {
+		p = e.Path.String()
+	}
+	return fmt.Sprintf("cannot delete %s, it is referred to by leafrefs: %s", p, strings.Join(refs, ", "))
+}
+
+// deleteNodeCheckingLeafrefs deletes the node at path from the data tree root
+// using args, handling the leafrefs that refer to it as specified by opt.
+func deleteNodeCheckingLeafrefs(schema *yang.Entry, root interface{}, path *gpb.Path, args retrieveNodeArgs, opt *CheckLeafrefs) error {
+	opt.Referrers = nil
+	x, err := NewLeafrefIndex(schema, root)
+	if err != nil {
+		return err
+	}
+	dangling, refs := x.dangling([][]*gpb.PathElem{normalisedPathElems(path)})
+	opt.Referrers = refs
+	if len(dangling) > 0 && opt.Mode == RefuseLeafrefDelete {
+		return &ReferencedNodeError{Path: path, Referrers: refs}
+	}
+
+	if _, err := retrieveNode(schema, root, path, nil, args); err != nil {
+		return err
+	}
+	if opt.Mode != CascadeLeafrefDelete {
+		return nil
+	}
+
+	// The data tree is indexed again following each round of deletes,
+	// and the leafrefs that were found to be dangling are deleted, which
+	// may in turn cause further leafrefs to be dangling.
+	for len(dangling) > 0 {
+		pending := map[string]bool{}
+		for _, l := range dangling {
+			pending[l.key()] = true
+		}
+		if x, err = NewLeafrefIndex(schema, root); err != nil {
+			return err
+		}
+
+		var remove []*xpathNode
+		var removed [][]*gpb.PathElem
+		seen := map[*xpathNode]bool{}
+		for _, l := range x.leafrefs {
+			if !pending[l.key()] || len(l.targets) != 0 {
+				continue
+			}
+			n := cascadeNode(l.node)
+			if seen[n] {
+				continue
+			}
+			seen[n] = true
+			remove = append(remove, n)
+			removed = append(removed, x.relPath(n).GetElem())
+		}
+
+		var newRefs []*LeafrefReference
+		dangling, newRefs = x.dangling(removed)
+		opt.Referrers = append(opt.Referrers, newRefs...)
+
+		// Nodes are removed in the reverse order to which they were
+		// visited, such that removing a member of a slice does not
+		// change the index of any other node that is to be removed.
+		for i := len(remove) - 1; i >= 0; i-- {
+			n := remove[i]
+			if _, err := n.remove(); err != nil {
+				return fmt.Errorf("%s: cannot remove node: %v", n.pathString(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// cascadeNode returns the node that is deleted where the leafref n is deleted
+// by CascadeLeafrefDelete. This is the list entry that n is within where n is
+// stored in the same field as a key of the entry, since the entry cannot exist
+// without its key, and otherwise n itself.
+func cascadeNode(n *xpathNode) *xpathNode {
+	e := n.parent
+	for e != nil && e.schema != nil && !e.schema.IsList() {
+		e = e.parent
+	}
+	if e == nil || e.schema == nil || !n.field.CanAddr() {
+		return n
+	}
+	for _, c := range e.childNodes() {
+		if c.isLeaf() && isListKeyName(e.schema, c.schema.Name) && c.field.CanAddr() && c.field.UnsafeAddr() == n.field.UnsafeAddr() {
+			return e
+		}
+	}
+	return n
+}
+
+// isListKeyName reports whether name is the name of a key leaf of the list
+// with the supplied schema.
+func isListKeyName(list *yang.Entry, name string) bool {
+	for _, k := range strings.Fields(list.Key) {
+		if k == name {
+			return true
+		}
+	}
+	return false
+}
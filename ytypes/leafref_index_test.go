@@ -0,0 +1,195 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// neighborAddressLeafref modifies the schema returned by xpathTestSchema such
+// that the address of each BGP neighbor refers to an address of a
+// subinterface.
+func neighborAddressLeafref(root *yang.Entry) {
+	root.Dir["bgp"].Dir["neighbors"].Dir["neighbor"].Dir["config"].Dir["neighbor-address"].Type = &yang.YangType{
+		Kind: yang.Yleafref,
+		Path: "/interfaces/interface/subinterfaces/subinterface/config/address",
+	}
+}
+
+func TestLeafrefIndexReferrers(t *testing.T) {
+	x, err := NewLeafrefIndex(xpathTestSchema(nil), xpathTestData())
+	if err != nil {
+		t.Fatalf("NewLeafrefIndex(): got unexpected error: %v", err)
+	}
+	neighborRef := &LeafrefReference{
+		Path:   mustPath("/bgp/neighbors/neighbor[neighbor-address=192.0.2.254]/config/interface"),
+		Value:  "eth0",
+		Target: mustPath("/interfaces/interface[name=eth0]/name"),
+	}
+
+	tests := []struct {
+		desc   string
+		inPath string
+		want   []*LeafrefReference
+	}{{
+		desc:   "list entry",
+		inPath: "/interfaces/interface[name=eth0]",
+		want:   []*LeafrefReference{neighborRef},
+	}, {
+		desc:   "leaf",
+		inPath: "/interfaces/interface[name=eth0]/name",
+		want:   []*LeafrefReference{neighborRef},
+	}, {
+		desc:   "list without keys",
+		inPath: "/interfaces/interface",
+		want:   []*LeafrefReference{neighborRef},
+	}, {
+		desc:   "module prefixes",
+		inPath: "/oc-if:interfaces/oc-if:interface[name=eth0]",
+		want:   []*LeafrefReference{neighborRef},
+	}, {
+		desc:   "unreferenced list entry",
+		inPath: "/interfaces/interface[name=lo0]",
+	}, {
+		desc:   "leafrefs within the node are not included",
+		inPath: "/bgp",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := x.Referrers(mustPath(tt.inPath))
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Referrers(%s): did not get expected references, (-want, +got):\n%s", tt.inPath, diff)
+			}
+		})
+	}
+}
+
+func TestDeleteNodeCheckLeafrefs(t *testing.T) {
+	tests := []struct {
+		desc          string
+		inModify      func(*yang.Entry)
+		inData        func(*xpathTestDevice)
+		inPath        string
+		inMode        LeafrefDeleteMode
+		wantRefused   bool
+		wantReferrers []string
+		wantData      func(*xpathTestDevice)
+	}{{
+		desc:          "refuse delete of referenced list entry",
+		inPath:        "/interfaces/interface[name=eth0]",
+		inMode:        RefuseLeafrefDelete,
+		wantRefused:   true,
+		wantReferrers: []string{"/bgp/neighbors/neighbor[neighbor-address=192.0.2.254]/config/interface (eth0) -> /interfaces/interface[name=eth0]/name"},
+	}, {
+		desc:   "refuse mode with unreferenced list entry",
+		inPath: "/interfaces/interface[name=lo0]",
+		inMode: RefuseLeafrefDelete,
+		wantData: func(d *xpathTestDevice) {
+			delete(d.Interface, "lo0")
+		},
+	}, {
+		desc:   "refuse mode with leafref that refers to another node",
+		inPath: "/interfaces/interface[name=eth0]",
+		inMode: RefuseLeafrefDelete,
+		inData: func(d *xpathTestDevice) {
+			d.Bgp.Neighbor["192.0.2.254"].Interface = ygot.String("lo0")
+		},
+		wantData: func(d *xpathTestDevice) {
+			d.Bgp.Neighbor["192.0.2.254"].Interface = ygot.String("lo0")
+			delete(d.Interface, "eth0")
+		},
+	}, {
+		desc:          "report dangling leafref",
+		inPath:        "/interfaces/interface[name=eth0]",
+		inMode:        ReportLeafrefDelete,
+		wantReferrers: []string{"/bgp/neighbors/neighbor[neighbor-address=192.0.2.254]/config/interface (eth0) -> /interfaces/interface[name=eth0]/name"},
+		wantData: func(d *xpathTestDevice) {
+			delete(d.Interface, "eth0")
+		},
+	}, {
+		desc:          "cascade delete of leaf",
+		inPath:        "/interfaces/interface[name=eth0]",
+		inMode:        CascadeLeafrefDelete,
+		wantReferrers: []string{"/bgp/neighbors/neighbor[neighbor-address=192.0.2.254]/config/interface (eth0) -> /interfaces/interface[name=eth0]/name"},
+		wantData: func(d *xpathTestDevice) {
+			delete(d.Interface, "eth0")
+			d.Bgp.Neighbor["192.0.2.254"].Interface = nil
+		},
+	}, {
+		desc:     "cascade delete of list entry whose key is a leafref",
+		inModify: neighborAddressLeafref,
+		inData: func(d *xpathTestDevice) {
+			d.Bgp.Neighbor = map[string]*xpathTestNeighbor{
+				"192.0.2.1": {NeighborAddress: ygot.String("192.0.2.1"), Interface: ygot.String("lo0")},
+				"192.0.2.2": {NeighborAddress: ygot.String("192.0.2.2")},
+			}
+		},
+		inPath: "/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]",
+		inMode: CascadeLeafrefDelete,
+		wantReferrers: []string{
+			"/bgp/neighbors/neighbor[neighbor-address=192.0.2.1]/config/neighbor-address (192.0.2.1) -> /interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]/config/address",
+			"/bgp/neighbors/neighbor[neighbor-address=192.0.2.2]/config/neighbor-address (192.0.2.2) -> /interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]/config/address",
+		},
+		wantData: func(d *xpathTestDevice) {
+			delete(d.Interface["eth0"].Subinterface, 0)
+			d.Bgp.Neighbor = map[string]*xpathTestNeighbor{}
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := xpathTestSchema(tt.inModify)
+			got, want := xpathTestData(), xpathTestData()
+			if tt.inData != nil {
+				tt.inData(got)
+				tt.inData(want)
+			}
+			if tt.wantData != nil {
+				tt.wantData(want)
+			}
+
+			opt := &CheckLeafrefs{Mode: tt.inMode}
+			err := DeleteNode(schema, got, mustPath(tt.inPath), opt)
+			var re *ReferencedNodeError
+			if gotRefused := errors.As(err, &re); gotRefused != tt.wantRefused {
+				t.Fatalf("DeleteNode(%s): got error %v, want refused %v", tt.inPath, err, tt.wantRefused)
+			}
+			if err != nil && !tt.wantRefused {
+				t.Fatalf("DeleteNode(%s): got unexpected error: %v", tt.inPath, err)
+			}
+			if re != nil && len(re.Referrers) != len(opt.Referrers) {
+				t.Errorf("DeleteNode(%s): got error with %d referrers, want %d", tt.inPath, len(re.Referrers), len(opt.Referrers))
+			}
+
+			var gotReferrers []string
+			for _, r := range opt.Referrers {
+				gotReferrers = append(gotReferrers, r.String())
+			}
+			if diff := cmp.Diff(tt.wantReferrers, gotReferrers); diff != "" {
+				t.Errorf("DeleteNode(%s): did not get expected referrers, (-want, +got):\n%s", tt.inPath, diff)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("DeleteNode(%s): did not get expected data tree, (-want, +got):\n%s", tt.inPath, diff)
+			}
+		})
+	}
+}
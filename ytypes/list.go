@@ -17,6 +17,7 @@ package ytypes
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/kylelemons/godebug/pretty"
@@ -24,13 +25,15 @@ import (
 	"github.com/openconfig/ygot/internal/yreflect"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 // Refer to: https://tools.ietf.org/html/rfc6020#section-7.8.
 
 // validateList validates each of the values in the map, keyed by the list Key
 // value, against the given list schema.
-func validateList(schema *yang.Entry, value interface{}) util.Errors {
+func validateList(schema *yang.Entry, value interface{}, st *validateState) util.Errors {
 	var errors []error
 	if util.IsValueNil(value) {
 		return nil
@@ -49,41 +52,85 @@ func validateList(schema *yang.Entry, value interface{}) util.Errors {
 		// Check list attributes: size constraints etc.
 		// Skip this check if not a list type - in this case value may be a list
 		// element which shares the list schema (excluding ListAttr).
-		errors = util.AppendErrs(errors, validateListAttr(schema, value))
+		errs := validateListAttr(schema, value)
+		// Check that the entries satisfy the list's unique statements.
+		errs = util.AppendErrs(errs, validateUnique(schema, value))
+		errors = util.AppendErrs(errors, prefixValidationErrors(errs, "", &gpb.PathElem{Name: schema.Name}))
+	}
+
+	// validateElement validates the element val, whose path element is pe,
+	// including invoking any custom validators registered for the list.
+	validateElement := func(val reflect.Value, pe *gpb.PathElem) util.Errors {
+		est := st.listEntry(pe)
+		if est.skipped() {
+			return nil
+		}
+		errs := est.runValidators(schema, val.Interface())
+		return util.AppendErrs(errs, validateStructElems(schema, val.Interface(), est))
 	}
 
-	checkMapElement := func(key, val reflect.Value) {
+	checkMapElement := func(key, val reflect.Value) util.Errors {
 		structElems := val.Elem()
 		// Check that keys are present and have correct values.
-		errors = util.AppendErrs(errors, checkKeys(schema, structElems, key))
+		errs := checkKeys(schema, structElems, key)
 
 		// Verify each elements's fields.
-		errors = util.AppendErrs(errors, validateStructElems(schema, val.Interface()))
+		pe := listEntryPathElem(schema, val)
+		errs = util.AppendErrs(errs, validateElement(val, pe))
+		return prefixValidationErrors(errs, "", pe)
+	}
+
+	// checkMapElements validates the entries of a keyed list, which may be
+	// done concurrently, and returns the errors in the order of keys.
+	checkMapElements := func(keys, vals []reflect.Value) {
+		entryErrs := make([]util.Errors, len(keys))
+		st.each(len(keys), func(i int) {
+			entryErrs[i] = checkMapElement(keys[i], vals[i])
+		})
+		for _, errs := range entryErrs {
+			errors = util.AppendErrs(errors, errs)
+		}
 	}
 
 	switch {
 	case isOrderedMap:
-		errors = util.AppendErr(errors, yreflect.RangeOrderedMap(orderedMap, func(k, v reflect.Value) bool {
-			checkMapElement(k, v)
+		var keys, vals []reflect.Value
+		err := yreflect.RangeOrderedMap(orderedMap, func(k, v reflect.Value) bool {
+			keys, vals = append(keys, k), append(vals, v)
 			return true
-		}))
+		})
+		checkMapElements(keys, vals)
+		errors = util.AppendErr(errors, err)
 	case kind == reflect.Slice:
 		// List without key is a slice in the data tree.
 		sv := reflect.ValueOf(value)
-		for i := 0; i < sv.Len(); i++ {
-			errors = util.AppendErrs(errors, validateStructElems(schema, sv.Index(i).Interface()))
+		entryErrs := make([]util.Errors, sv.Len())
+		st.each(sv.Len(), func(i int) {
+			pe := &gpb.PathElem{Name: schema.Name}
+			entryErrs[i] = prefixValidationErrors(validateElement(sv.Index(i), pe), "", pe)
+		})
+		for _, errs := range entryErrs {
+			errors = util.AppendErrs(errors, errs)
 		}
 	case kind == reflect.Map:
 		// List with key is a map in the data tree, with the key being the value
 		// of the key field(s) in the elements.
-		for _, key := range reflect.ValueOf(value).MapKeys() {
-			checkMapElement(key, reflect.ValueOf(value).MapIndex(key))
+		mv := reflect.ValueOf(value)
+		keys := mv.MapKeys()
+		if st.ordered() {
+			keys = sortedMapKeys(mv)
 		}
+		vals := make([]reflect.Value, len(keys))
+		for i, key := range keys {
+			vals[i] = mv.MapIndex(key)
+		}
+		checkMapElements(keys, vals)
 	case kind == reflect.Ptr:
 		// Validate was called on a list element rather than the whole list, or
 		// on a completely bogus struct. In either case, evaluate just the
 		// element against the list schema without considering list attributes.
-		errors = util.AppendErrs(errors, validateStructElems(schema, value))
+		pe := listEntryPathElem(schema, reflect.ValueOf(value))
+		errors = util.AppendErrs(errors, prefixValidationErrors(validateElement(reflect.ValueOf(value), pe), "", pe))
 
 	default:
 		errors = util.AppendErr(errors, fmt.Errorf("validateList expected map/slice/GoOrderedMap type for %s, got %T", schema.Name, value))
@@ -92,6 +139,78 @@ func validateList(schema *yang.Entry, value interface{}) util.Errors {
 	return errors
 }
 
+// validateUnique checks that the entries of the list value satisfy each of the
+// unique statements of the list schema, such that no two entries in which all
+// of the leaves referenced by a statement exist have the same combined values
+// for those leaves. Leaves whose default value is in use are considered to
+// exist, as per RFC7950 Section 7.8.3.
+func validateUnique(schema *yang.Entry, value interface{}) util.Errors {
+	us := uniqueStatements(schema)
+	if len(us) == 0 {
+		return nil
+	}
+
+	var errors []error
+	entries := newXPathChildNodes(nil, schema, reflect.ValueOf(value))
+	for _, u := range us {
+		seen := map[string]*xpathNode{}
+		for _, n := range entries {
+			combined, ok, err := uniqueValues(n, u)
+			if err != nil {
+				return util.AppendErr(errors, fmt.Errorf("list %s: invalid unique statement %q: %v", schema.Name, strings.Join(u, " "), err))
+			}
+			if !ok {
+				continue
+			}
+			if prev, ok := seen[combined]; ok {
+				errors = util.AppendErr(errors, newValidationError(UniqueConstraint, schema, combined, fmt.Errorf("list %s: entries %s and %s have the same values (%s) for unique statement %q",
+					schema.Name, listEntryString(prev), listEntryString(n), combined, strings.Join(u, " "))))
+				continue
+			}
+			seen[combined] = n
+		}
+	}
+	return errors
+}
+
+// uniqueValues returns the combined values of the leaves of the list entry n
+// that are referenced by the descendant schema node identifiers ids of a
+// unique statement. It returns false if any of the leaves do not exist.
+func uniqueValues(n *xpathNode, ids []string) (string, bool, error) {
+	vals := make([]string, 0, len(ids))
+	for _, id := range ids {
+		ns, err := evalXPathNodes(id, n)
+		if err != nil {
+			return "", false, err
+		}
+		if len(ns) == 0 {
+			return "", false, nil
+		}
+		vals = append(vals, fmt.Sprintf("%s=%q", id, ns[0].stringValue()))
+	}
+	return strings.Join(vals, ", "), true, nil
+}
+
+// listEntryString returns a string identifying the list entry n, which is its
+// keys for a keyed list, or its index for a list without keys.
+func listEntryString(n *xpathNode) string {
+	if !n.key.IsValid() {
+		return fmt.Sprintf("at index %d", n.index)
+	}
+	elems := n.gnmiPath().GetElem()
+	keys := elems[len(elems)-1].GetKey()
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, k := range names {
+		fmt.Fprintf(&b, "[%s=%s]", k, keys[k])
+	}
+	return b.String()
+}
+
 // checkKeys checks that the map key value for the list equals the value of the
 // key field(s) in the elements for the map value.
 //
@@ -107,11 +226,17 @@ func validateList(schema *yang.Entry, value interface{}) util.Errors {
 //     value of the map key of the containing map in the data tree.
 func checkKeys(schema *yang.Entry, structElems reflect.Value, keyValue reflect.Value) util.Errors {
 	keys := strings.Fields(schema.Key)
+	var errs util.Errors
 	if len(keys) == 1 {
-		return checkBasicKeyValue(structElems, schema.Key, keyValue)
+		errs = checkBasicKeyValue(structElems, schema.Key, keyValue)
+	} else {
+		errs = checkStructKeyValues(structElems, keyValue)
 	}
 
-	return checkStructKeyValues(structElems, keyValue)
+	for i, err := range errs {
+		errs[i] = newValidationError(KeyConstraint, schema, keyValue.Interface(), err)
+	}
+	return errs
 }
 
 // checkBasicKeyValue checks if keyValue, which is the value of the map key,
@@ -179,7 +304,7 @@ func checkStructKeyValues(structElems reflect.Value, keyStruct reflect.Value) ut
 // validateStructElems validates each of the struct fields against the schema.
 // TODO(mostrowski): choice directly under list is not handled here.
 // Also, there's code duplication with a very similar operation in container.
-func validateStructElems(schema *yang.Entry, value interface{}) util.Errors {
+func validateStructElems(schema *yang.Entry, value interface{}, st *validateState) util.Errors {
 	var errors []error
 	structElems := reflect.ValueOf(value).Elem()
 	structTypes := structElems.Type()
@@ -187,13 +312,21 @@ func validateStructElems(schema *yang.Entry, value interface{}) util.Errors {
 	if structElems.Kind() != reflect.Struct {
 		return util.NewErrs(fmt.Errorf("expected a struct type for %s: got %s", schema.Name, util.ValueStr(value)))
 	}
-	// Verify each elements's fields.
+	// parent is nil where the element is not a GoStruct, in which case
+	// custom validators are invoked without it.
+	parent, _ := value.(ygot.GoStruct)
+	// Verify each elements's fields, storing the errors for each field such
+	// that they are returned in the order of the fields where children are
+	// validated concurrently.
+	fieldErrs := make([]util.Errors, structElems.NumField())
+	var concurrent []func()
 	for i := 0; i < structElems.NumField(); i++ {
 		ft := structElems.Type().Field(i)
 
-		// If this is an annotation field, then skip it since it does not have
-		// a schema.
+		// If this is an annotation field, then only its metadata
+		// annotations are validated since it does not have a schema.
 		if util.IsYgotAnnotation(ft) {
+			fieldErrs[i] = validateMetadata(schema, structElems.Field(i).Interface())
 			continue
 		}
 
@@ -202,15 +335,28 @@ func validateStructElems(schema *yang.Entry, value interface{}) util.Errors {
 
 		cschema, err := util.ChildSchema(schema, structTypes.Field(i))
 		if err != nil {
-			errors = util.AppendErr(errors, err)
+			fieldErrs[i] = util.NewErrs(err)
 			continue
 		}
 		if cschema == nil {
-			errors = util.AppendErr(errors, fmt.Errorf("child schema not found for struct %s field %s", schema.Name, fieldName))
+			fieldErrs[i] = util.NewErrs(fmt.Errorf("child schema not found for struct %s field %s", schema.Name, fieldName))
+			continue
+		}
+		elems := fieldPathElems(ft, cschema)
+		cst := st.child(parent, append(elems, &gpb.PathElem{Name: cschema.Name})...)
+		validateField := func() {
+			fieldErrs[i] = prefixValidationErrors(validateNode(cschema, fieldValue, cst), "", elems...)
+		}
+		if st.concurrent(cschema) {
+			concurrent = append(concurrent, validateField)
 		} else {
-			errors = util.AppendErrs(errors, Validate(cschema, fieldValue))
+			validateField()
 		}
 	}
+	st.each(len(concurrent), func(i int) { concurrent[i]() })
+	for _, errs := range fieldErrs {
+		errors = util.AppendErrs(errors, errs)
+	}
 
 	return errors
 }
@@ -311,7 +457,7 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 	case !isOrderedMap && util.IsTypeStructPtr(t):
 		// May be trying to unmarshal a single list element rather than the
 		// whole list.
-		return unmarshalContainerWithListSchema(schema, parent, jsonList, opts...)
+		return unmarshalContainerWithListSchema(schema, parent, jsonList, enc, opts...)
 	case !isOrderedMap:
 		if !(util.IsTypeMap(t) || util.IsTypeSlicePtr(t)) {
 			return fmt.Errorf("unmarshalList for %s got parent type %s, expect map, slice ptr or struct ptr", schema.Name, t.Kind())
@@ -331,6 +477,14 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 		}
 	}
 
+	if enc == XMLEncoding {
+		var l []interface{}
+		for _, v := range xmlListValue(jsonList) {
+			l = append(l, xmlContainerValue(v))
+		}
+		jsonList = l
+	}
+
 	// jsonList represents a JSON array, which is a Go slice.
 	jl, ok := jsonList.([]interface{})
 	if !ok {
@@ -491,7 +645,12 @@ func makeValForInsert(schema *yang.Entry, parent interface{}, keys map[string]st
 // which must be a map.
 func makeKeyForInsert(schema *yang.Entry, parentMap interface{}, newVal reflect.Value) (reflect.Value, error) {
 	// Key is always a value type, never a ptr.
-	listKeyType := reflect.TypeOf(parentMap).Key()
+	return makeKeyOfType(schema, reflect.TypeOf(parentMap).Key(), newVal)
+}
+
+// makeKeyOfType returns the key of type listKeyType, which is the key type of
+// a map or ordered map, of the struct newVal.
+func makeKeyOfType(schema *yang.Entry, listKeyType reflect.Type, newVal reflect.Value) (reflect.Value, error) {
 	newKey := reflect.New(listKeyType).Elem()
 
 	if util.IsTypeStruct(listKeyType) {
@@ -575,7 +734,7 @@ func insertAndGetKey(schema *yang.Entry, root interface{}, keys map[string]strin
 // the whole list, the supplied schema is the same - the only difference is
 // that in the latter case the target is a struct ptr. The supplied opts control
 // the behaviour of the unmarshal function.
-func unmarshalContainerWithListSchema(schema *yang.Entry, parent interface{}, value interface{}, opts ...UnmarshalOpt) error {
+func unmarshalContainerWithListSchema(schema *yang.Entry, parent interface{}, value interface{}, enc Encoding, opts ...UnmarshalOpt) error {
 
 	if !util.IsTypeStructPtr(reflect.TypeOf(parent)) {
 		return fmt.Errorf("unmarshalContainerWithListSchema value %v, type %T, into parent type %T, schema name %s: parent must be a struct ptr",
@@ -585,7 +744,7 @@ func unmarshalContainerWithListSchema(schema *yang.Entry, parent interface{}, va
 	// with ListAttrs unset.
 	newSchema := *schema
 	newSchema.ListAttr = nil
-	return Unmarshal(&newSchema, parent, value, opts...)
+	return unmarshalGeneric(&newSchema, parent, value, enc, opts...)
 }
 
 // getKeyValue returns the value from the structVal field whose last path
@@ -123,19 +123,19 @@ func TestValidateListSchema(t *testing.T) {
 
 func TestValidateList(t *testing.T) {
 	// nil value
-	if got := validateList(nil, nil); got != nil {
+	if got := validateList(nil, nil, nil); got != nil {
 		t.Errorf("nil value: Unmarshal got error: %v, want error: nil", got)
 	}
 
 	// nil schema
-	err := util.Errors(validateList(nil, &struct{}{})).Error()
+	err := util.Errors(validateList(nil, &struct{}{}, nil)).Error()
 	wantErr := `list schema is nil`
 	if got, want := err, wantErr; got != want {
 		t.Errorf("nil schema: Unmarshal got error: %v, want error: %v", got, want)
 	}
 
 	// bad value type
-	err = util.Errors(validateList(validListSchema, struct{}{})).Error()
+	err = util.Errors(validateList(validListSchema, struct{}{}, nil)).Error()
 	wantErr = `validateList expected map/slice/GoOrderedMap type for valid-list-schema, got struct {}`
 	if got, want := err, wantErr; got != want {
 		t.Errorf("nil schema: Unmarshal got error: %v, want error: %v", got, want)
@@ -415,7 +415,7 @@ func TestUnmarshalList(t *testing.T) {
 
 	// bad parent type for unmarshalContainerWithListSchema
 	wantErr = `unmarshalContainerWithListSchema value [], type []interface {}, into parent type struct {}, schema name valid-list-schema: parent must be a struct ptr`
-	if got, want := errToString(unmarshalContainerWithListSchema(validListSchema, struct{}{}, []interface{}{})), wantErr; got != want {
+	if got, want := errToString(unmarshalContainerWithListSchema(validListSchema, struct{}{}, []interface{}{}, JSONEncoding)), wantErr; got != want {
 		t.Errorf("nil schema: Unmarshal got error: %v, want error: %v", got, want)
 	}
 }
@@ -2155,3 +2155,85 @@ func TestUnmarshalUnionKeyedList(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateListUnique(t *testing.T) {
+	unkeyedSchema := &yang.Entry{
+		Name:     "list-schema",
+		Kind:     yang.DirectoryEntry,
+		ListAttr: yang.NewDefaultListAttr(),
+		Extra:    map[string][]interface{}{"unique": {&yang.Value{Name: "a b"}}},
+		Dir: map[string]*yang.Entry{
+			"a": {Kind: yang.LeafEntry, Name: "a", Type: &yang.YangType{Kind: yang.Ystring}},
+			"b": {Kind: yang.LeafEntry, Name: "b", Type: &yang.YangType{Kind: yang.Ystring}},
+		},
+	}
+	populateParentField(nil, unkeyedSchema)
+
+	type UnkeyedElemStruct struct {
+		A *string `path:"a"`
+		B *string `path:"b"`
+	}
+
+	// keyedSchema is a schema in which the unique statement references a
+	// leaf within a container that is compressed out of the generated code.
+	keyedSchema := xpathTestSchema(func(root *yang.Entry) {
+		l := root.Dir["interfaces"].Dir["interface"].Dir["subinterfaces"].Dir["subinterface"]
+		l.Extra = map[string][]interface{}{"unique": {map[string]interface{}{"Name": "config/description"}}}
+	})
+	keyedListSchema := keyedSchema.Dir["interfaces"].Dir["interface"].Dir["subinterfaces"].Dir["subinterface"]
+
+	tests := []struct {
+		desc    string
+		schema  *yang.Entry
+		val     interface{}
+		wantErr string
+	}{{
+		desc:   "unkeyed list with unique values",
+		schema: unkeyedSchema,
+		val: []*UnkeyedElemStruct{
+			{A: ygot.String("a1"), B: ygot.String("b1")},
+			{A: ygot.String("a1"), B: ygot.String("b2")},
+		},
+	}, {
+		desc:   "unkeyed list with entry missing a unique leaf",
+		schema: unkeyedSchema,
+		val: []*UnkeyedElemStruct{
+			{A: ygot.String("a1")},
+			{A: ygot.String("a1")},
+		},
+	}, {
+		desc:   "unkeyed list with duplicate values",
+		schema: unkeyedSchema,
+		val: []*UnkeyedElemStruct{
+			{A: ygot.String("a1"), B: ygot.String("b1")},
+			{A: ygot.String("a2"), B: ygot.String("b2")},
+			{A: ygot.String("a1"), B: ygot.String("b1")},
+		},
+		wantErr: `list list-schema: entries at index 0 and at index 2 have the same values (a="a1", b="b1") for unique statement "a b"`,
+	}, {
+		desc:   "keyed list with unique values",
+		schema: keyedListSchema,
+		val: map[uint32]*xpathTestSubinterface{
+			0: {Index: ygot.Uint32(0), Description: ygot.String("zero")},
+			1: {Index: ygot.Uint32(1), Description: ygot.String("one")},
+		},
+	}, {
+		desc:   "keyed list with duplicate values",
+		schema: keyedListSchema,
+		val: map[uint32]*xpathTestSubinterface{
+			0:  {Index: ygot.Uint32(0), Description: ygot.String("dup")},
+			1:  {Index: ygot.Uint32(1), Description: ygot.String("one")},
+			10: {Index: ygot.Uint32(10), Description: ygot.String("dup")},
+		},
+		wantErr: `list subinterface: entries [index=0] and [index=10] have the same values (config/description="dup") for unique statement "config/description"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := Validate(tt.schema, tt.val)
+			if got, want := errs.String(), tt.wantErr; got != want {
+				t.Errorf("Validate got error: %v, want error: %v", got, want)
+			}
+		})
+	}
+}
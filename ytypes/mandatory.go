@@ -0,0 +1,209 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Refer to: https://tools.ietf.org/html/rfc7950#section-3 and
+// https://tools.ietf.org/html/rfc7950#section-7.6.5.
+
+// MandatoryOptions enables the validation of leaves and choices that are
+// marked mandatory in the schema. Where it is supplied, Validate returns an
+// error for each mandatory leaf that is not populated, and each mandatory
+// choice for which no case is selected, within the data tree being validated.
+// Mandatory nodes that have a when statement that is false, or that are
+// within such a node, are exempt. Since partial fragments of a data tree are
+// commonly validated, mandatory nodes are not checked unless this option is
+// supplied.
+type MandatoryOptions struct {
+	// SkipPaths specifies the subtrees of the data tree within which
+	// mandatory leaves and choices are not checked. The paths are matched
+	// against the path of each node, as reported in validation errors, and
+	// may contain wildcard names and keys.
+	SkipPaths []*gpb.Path
+}
+
+// IsValidationOption ensures that MandatoryOptions implements the
+// ValidationOption interface.
+func (*MandatoryOptions) IsValidationOption() {}
+
+// validateMandatory checks that the mandatory leaves and choices within the
+// data tree rooted at value, whose schema is supplied, are populated. The
+// descendants of nodes that exist in the data tree are checked, including
+// those of non-presence containers, which exist whenever their parent does.
+// Nodes within config false subtrees, within cases that are not selected, and
+// within nodes whose when statement is false, are not checked. Where the
+// change filter f is non-nil, only the nodes within the changed subtrees are
+// checked.
+func validateMandatory(schema *yang.Entry, value interface{}, opt *MandatoryOptions, f *changeFilter) util.Errors {
+	_, top := newXPathTree(schema, value)
+	status := f.xpathStatusFunc()
+	var errs util.Errors
+	var check func(n *xpathNode)
+	check = func(n *xpathNode) {
+		if n.isLeaf() || n.schema.ReadOnly() || mandatorySkipped(n, opt) || whenFalse(n) {
+			return
+		}
+		switch status(n) {
+		case changeUnrelated:
+			return
+		case changeInside:
+			errs = util.AppendErrs(errs, missingMandatory(n, n.schema))
+		}
+		for _, c := range n.childNodes() {
+			check(c)
+		}
+	}
+	check(top)
+	return errs
+}
+
+// mandatorySkipped reports whether the node n is within one of the subtrees
+// of the data tree that are excluded from mandatory checks by opt.
+func mandatorySkipped(n *xpathNode, opt *MandatoryOptions) bool {
+	if opt == nil || len(opt.SkipPaths) == 0 {
+		return false
+	}
+	p := n.gnmiPath()
+	for _, s := range opt.SkipPaths {
+		if util.PathMatchesQuery(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// whenFalse reports whether a when statement that applies to the node n, or
+// to a choice or case that it is within, is false. Statements that cannot be
+// evaluated are considered to be true, since they are reported where
+// WhenOptions is supplied. The statements of the top-level node are not
+// evaluated, since their context node may be outside of the data tree.
+func whenFalse(n *xpathNode) bool {
+	if n.parent == nil || n.parent.schema == nil {
+		return false
+	}
+	w, err := evalWhen(n, &WhenOptions{}, nil, changeInside)
+	return err == nil && w != nil
+}
+
+// absentNode returns a node with the schema e as a child of the node n, which
+// does not exist in the data tree, such that the when statements of a leaf or
+// choice that is not populated can be evaluated with it as the context node.
+func absentNode(n *xpathNode, e *yang.Entry) *xpathNode {
+	return &xpathNode{schema: e, parent: n, virtual: true, expanded: true}
+}
+
+// missingMandatory returns an error for each mandatory leaf and choice that
+// is a child of the schema entry e, which is either the schema of the data
+// node n or a case within it, and that is not populated in n. Leaves and
+// choices whose when statement is false are not required.
+func missingMandatory(n *xpathNode, e *yang.Entry) util.Errors {
+	var errs util.Errors
+	for _, c := range sortedChildren(e) {
+		switch {
+		case c.ReadOnly():
+			continue
+		case c.IsChoice():
+			sel := selectedCase(n, c)
+			switch {
+			case sel == nil && c.Mandatory == yang.TSTrue && !whenFalse(absentNode(n, c)):
+				ve := newValidationError(MandatoryConstraint, c, nil, fmt.Errorf("%s: no case is selected for mandatory choice %s", n.pathString(), c.Name))
+				ve.Path = n.gnmiPath()
+				errs = util.AppendErr(errs, ve)
+			case sel != nil:
+				errs = util.AppendErrs(errs, missingMandatory(n, sel))
+			}
+		case c.IsLeaf() && c.Mandatory == yang.TSTrue:
+			if !hasDataChild(n, c) && !whenFalse(absentNode(n, c)) {
+				ve := newValidationError(MandatoryConstraint, c, nil, fmt.Errorf("%s: mandatory leaf is not populated", childPathString(n, c)))
+				ve.Path = childPath(n, c)
+				errs = util.AppendErr(errs, ve)
+			}
+		}
+	}
+	return errs
+}
+
+// selectedCase returns the case of the choice schema choice that has data
+// within the node n, or nil if no case is selected. Unlike IsCaseSelected,
+// which considers the fields of a single GoStruct, the children of n within
+// the data tree are considered, such that cases within containers that are
+// compressed out of the generated code are handled. Where a case is specified
+// using the shorthand syntax, the data node that it consists of is returned.
+func selectedCase(n *xpathNode, choice *yang.Entry) *yang.Entry {
+	for _, c := range n.childNodes() {
+		if c.virtual || c.isDefault {
+			continue
+		}
+		for e := c.schema; e != nil && e != n.schema; e = e.Parent {
+			if e.Parent == choice {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// hasDataChild reports whether n has a child in the data tree with the
+// schema e that is populated.
+func hasDataChild(n *xpathNode, e *yang.Entry) bool {
+	for _, c := range n.childNodes() {
+		if c.schema == e && !c.virtual && !c.isDefault {
+			return true
+		}
+	}
+	return false
+}
+
+// childPath returns the path of the child of the node n whose schema is e.
+func childPath(n *xpathNode, e *yang.Entry) *gpb.Path {
+	p := n.gnmiPath()
+	p.Elem = append(p.Elem, &gpb.PathElem{Name: e.Name})
+	return p
+}
+
+// childPathString returns the path of the child of the node n whose schema is
+// e as a human-readable string.
+func childPathString(n *xpathNode, e *yang.Entry) string {
+	s, err := ygot.PathToString(childPath(n, e))
+	if err != nil {
+		return e.Path()
+	}
+	return s
+}
+
+// sortedChildren returns the children of the schema entry e sorted by name,
+// such that errors are reported in a deterministic order.
+func sortedChildren(e *yang.Entry) []*yang.Entry {
+	names := make([]string, 0, len(e.Dir))
+	for k := range e.Dir {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	out := make([]*yang.Entry, 0, len(names))
+	for _, k := range names {
+		out = append(out, e.Dir[k])
+	}
+	return out
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestValidateMandatory(t *testing.T) {
+	// modify returns a function that calls fn with the schema entry at the
+	// path p, relative to the root.
+	modify := func(p []string, fn func(*yang.Entry)) func(*yang.Entry) {
+		return func(root *yang.Entry) {
+			e := root
+			for _, n := range p {
+				e = e.Dir[n]
+			}
+			fn(e)
+		}
+	}
+	mandatory := func(e *yang.Entry) { e.Mandatory = yang.TSTrue }
+	// when returns a function that adds the supplied when statement to a
+	// schema entry as the entry's own statement.
+	when := func(expr string) func(*yang.Entry) {
+		return func(e *yang.Entry) {
+			e.Extra = map[string][]interface{}{"when": {&yang.Value{Name: expr}}}
+			e.Annotation = map[string]interface{}{util.WhenXPathAnnotation: expr}
+		}
+	}
+	all := func(fns ...func(*yang.Entry)) func(*yang.Entry) {
+		return func(root *yang.Entry) {
+			for _, fn := range fns {
+				fn(root)
+			}
+		}
+	}
+	mandatoryMtu := modify([]string{"interfaces", "interface", "config", "mtu"}, mandatory)
+	mandatoryAs := modify([]string{"bgp", "global", "config", "as"}, mandatory)
+	neighborPath := []string{"bgp", "neighbors", "neighbor"}
+
+	withKeyChain := func(keyID bool) *xpathTestDevice {
+		d := xpathTestData()
+		n := d.Bgp.Neighbor["192.0.2.254"]
+		n.KeyChain = ygot.String("chain")
+		if keyID {
+			n.KeyId = ygot.Uint32(1)
+		}
+		return d
+	}
+
+	enabled := []ygot.ValidationOption{&MandatoryOptions{}}
+
+	tests := []struct {
+		desc       string
+		inModify   func(*yang.Entry)
+		inData     *xpathTestDevice
+		inOpts     []ygot.ValidationOption
+		wantErrors []string
+	}{{
+		desc:   "no mandatory nodes",
+		inData: xpathTestData(),
+		inOpts: enabled,
+	}, {
+		desc:     "mandatory checks not enabled",
+		inModify: mandatoryMtu,
+		inData:   xpathTestData(),
+	}, {
+		desc:     "mandatory leaf missing from list entry",
+		inModify: mandatoryMtu,
+		inData:   xpathTestData(),
+		inOpts:   enabled,
+		wantErrors: []string{
+			"/interfaces/interface[name=lo0]/config/mtu: mandatory leaf is not populated",
+		},
+	}, {
+		desc:     "mandatory checks skipped for path",
+		inModify: mandatoryMtu,
+		inData:   xpathTestData(),
+		inOpts: []ygot.ValidationOption{&MandatoryOptions{SkipPaths: []*gpb.Path{{
+			Elem: []*gpb.PathElem{{Name: "interfaces"}, {Name: "interface", Key: map[string]string{"name": "lo0"}}},
+		}}}},
+	}, {
+		desc:     "mandatory checks skipped for wildcard path",
+		inModify: mandatoryMtu,
+		inData:   xpathTestData(),
+		inOpts: []ygot.ValidationOption{&MandatoryOptions{SkipPaths: []*gpb.Path{{
+			Elem: []*gpb.PathElem{{Name: "interfaces"}, {Name: "interface", Key: map[string]string{"name": "*"}}, {Name: "config"}},
+		}}}},
+	}, {
+		desc: "mandatory leaf within config false subtree",
+		inModify: all(mandatoryMtu, modify([]string{"interfaces", "interface", "config"}, func(e *yang.Entry) {
+			e.Config = yang.TSFalse
+		})),
+		inData: xpathTestData(),
+		inOpts: enabled,
+	}, {
+		desc:     "mandatory leaf within non-presence containers",
+		inModify: mandatoryAs,
+		inData:   &xpathTestDevice{},
+		inOpts:   enabled,
+		wantErrors: []string{
+			"/bgp/global/config/as: mandatory leaf is not populated",
+		},
+	}, {
+		desc: "mandatory leaf within absent presence container",
+		inModify: all(mandatoryAs, modify([]string{"bgp"}, func(e *yang.Entry) {
+			e.Extra = map[string][]interface{}{"presence": {&yang.Value{Name: "bgp is enabled"}}}
+		})),
+		inData: &xpathTestDevice{},
+		inOpts: enabled,
+	}, {
+		desc: "mandatory leaf within existing presence container",
+		inModify: all(mandatoryAs, modify([]string{"bgp"}, func(e *yang.Entry) {
+			e.Extra = map[string][]interface{}{"presence": {&yang.Value{Name: "bgp is enabled"}}}
+		})),
+		inData: &xpathTestDevice{Bgp: &xpathTestBgp{}},
+		inOpts: enabled,
+		wantErrors: []string{
+			"/bgp/global/config/as: mandatory leaf is not populated",
+		},
+	}, {
+		desc:     "mandatory choice without selected case",
+		inModify: modify(append(neighborPath, "auth"), mandatory),
+		inData:   xpathTestData(),
+		inOpts:   enabled,
+		wantErrors: []string{
+			"/bgp/neighbors/neighbor[neighbor-address=192.0.2.254]: no case is selected for mandatory choice auth",
+		},
+	}, {
+		desc:     "mandatory choice with selected case",
+		inModify: modify(append(neighborPath, "auth"), mandatory),
+		inData:   withKeyChain(true),
+		inOpts:   enabled,
+	}, {
+		desc:     "mandatory leaf within unselected case",
+		inModify: modify(append(neighborPath, "auth", "keychain", "key-id"), mandatory),
+		inData:   xpathTestData(),
+		inOpts:   enabled,
+	}, {
+		desc:     "mandatory leaf within selected case",
+		inModify: modify(append(neighborPath, "auth", "keychain", "key-id"), mandatory),
+		inData:   withKeyChain(false),
+		inOpts:   enabled,
+		wantErrors: []string{
+			"/bgp/neighbors/neighbor[neighbor-address=192.0.2.254]/key-id: mandatory leaf is not populated",
+		},
+	}, {
+		desc:     "mandatory leaf with false when statement",
+		inModify: all(mandatoryMtu, modify([]string{"interfaces", "interface", "config", "mtu"}, when("../type = 'ianaift:ethernetCsmacd'"))),
+		inData:   xpathTestData(),
+		inOpts:   enabled,
+	}, {
+		desc:     "mandatory leaf with true when statement",
+		inModify: all(mandatoryMtu, modify([]string{"interfaces", "interface", "config", "mtu"}, when("../type = 'ianaift:softwareLoopback'"))),
+		inData:   xpathTestData(),
+		inOpts:   enabled,
+		wantErrors: []string{
+			"/interfaces/interface[name=lo0]/config/mtu: mandatory leaf is not populated",
+		},
+	}, {
+		desc:     "mandatory leaf within non-presence container with false when statement",
+		inModify: all(mandatoryAs, modify([]string{"bgp", "global"}, when("../neighbors/neighbor"))),
+		inData:   &xpathTestDevice{},
+		inOpts:   enabled,
+	}, {
+		desc:     "mandatory leaf within non-presence container with true when statement",
+		inModify: all(mandatoryAs, modify([]string{"bgp", "global"}, when("not(../neighbors/neighbor)"))),
+		inData:   &xpathTestDevice{},
+		inOpts:   enabled,
+		wantErrors: []string{
+			"/bgp/global/config/as: mandatory leaf is not populated",
+		},
+	}, {
+		desc: "mandatory choice with false when statement",
+		inModify: all(
+			modify(append(neighborPath, "auth"), mandatory),
+			modify(append(neighborPath, "auth"), when("neighbor-address = '192.0.2.1'")),
+		),
+		inData: xpathTestData(),
+		inOpts: enabled,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := xpathTestSchema(tt.inModify)
+			errs := Validate(schema, tt.inData, tt.inOpts...)
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tt.wantErrors, got); diff != "" {
+				t.Errorf("Validate(): did not get expected errors, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// This file implements the unmarshalling and validation of RFC 7952 metadata
+// annotations, which are stored as ygot.MetadataAnnotations within the
+// annotation fields of GoStructs.
+
+var metadataAnnotationType = reflect.TypeOf(&ygot.MetadataAnnotation{})
+
+// unmarshalMetadata unmarshals the RFC 7952 metadata annotations that are
+// members of jsonTree, which is the JSON tree of a struct described by
+// schema, into the annotation field f of the struct, whose type is ft. The
+// annotations are the members of the paths of the field, whose last element
+// is "@" or "@leaf", optionally with the module of the leaf prepended to its
+// name. Unknown annotations are an error unless ignoreUnknown is set. It does
+// nothing if the field cannot store MetadataAnnotations, or the schema tree
+// does not define any metadata annotations, in which case the members are
+// ignored. Annotations of the values of leaf-lists are not supported, and are
+// also ignored.
+func unmarshalMetadata(schema *yang.Entry, f reflect.Value, ft reflect.StructField, jsonTree map[string]interface{}, ignoreUnknown bool) error {
+	if ft.Type.Kind() != reflect.Slice || !metadataAnnotationType.AssignableTo(ft.Type.Elem()) {
+		return nil
+	}
+
+	var members []map[string]interface{}
+	for _, p := range strings.Split(ft.Tag.Get("path"), "|") {
+		v, _ := getJSONTreeValForPath(jsonTree, strings.Split(p, "/"))
+		if m, ok := v.(map[string]interface{}); ok {
+			members = append(members, m)
+		}
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	defs, err := util.MetadataAnnotations(schema)
+	if err != nil {
+		return err
+	}
+	if defs == nil {
+		return nil
+	}
+
+	parsed := map[string]*ygot.MetadataAnnotation{}
+	for _, m := range members {
+		for name, v := range m {
+			t, ok := defs[name]
+			switch {
+			case !ok && ignoreUnknown:
+				continue
+			case !ok:
+				return fmt.Errorf("unknown metadata annotation %s for field %s", name, ft.Name)
+			}
+			gv, err := metadataValue(name, t, v)
+			if err != nil {
+				return err
+			}
+			parsed[name] = &ygot.MetadataAnnotation{Name: name, Value: gv}
+		}
+	}
+
+	// Existing annotations are retained, other than those that are
+	// replaced by the unmarshalled annotations.
+	out := reflect.MakeSlice(ft.Type, 0, f.Len()+len(parsed))
+	for i := 0; i < f.Len(); i++ {
+		if m, ok := f.Index(i).Interface().(*ygot.MetadataAnnotation); ok && m != nil && parsed[m.Name] != nil {
+			continue
+		}
+		out = reflect.Append(out, f.Index(i))
+	}
+	var names []string
+	for n := range parsed {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		out = reflect.Append(out, reflect.ValueOf(parsed[n]))
+	}
+	f.Set(out)
+	return nil
+}
+
+// metadataValue returns the value of the metadata annotation name, of type
+// t, whose RFC 7951 JSON value is v, as stored in a ygot.MetadataAnnotation.
+// It returns an error if v is not a valid value of t.
+func metadataValue(name string, t *yang.YangType, v interface{}) (interface{}, error) {
+	var gv interface{}
+	switch t.Kind {
+	case yang.Yunion:
+		// The value is that of the first type of the union that it is a
+		// valid value of.
+		for _, ut := range t.Type {
+			if uv, err := metadataValue(name, ut, v); err == nil {
+				return uv, nil
+			}
+		}
+		return nil, fmt.Errorf("%v is not a valid value of metadata annotation %s", v, name)
+	case yang.Yenum, yang.Yidentityref, yang.Ybits:
+		gv = v
+	case yang.Yempty:
+		if a, ok := v.([]interface{}); !ok || len(a) != 1 || a[0] != nil {
+			return nil, fmt.Errorf("got %v for metadata annotation %s, empty values must be [null]", v, name)
+		}
+	case yang.Yleafref:
+		return nil, fmt.Errorf("unsupported type %v of metadata annotation %s", t.Kind, name)
+	default:
+		var err error
+		if gv, err = sanitizeJSON(nil, &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: t}, "", v); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateMetadataValue(name, t, gv); err != nil {
+		return nil, err
+	}
+	return gv, nil
+}
+
+// validateMetadataValue validates the value v of the metadata annotation
+// name, as stored in a ygot.MetadataAnnotation, against its type t.
+func validateMetadataValue(name string, t *yang.YangType, v interface{}) error {
+	schema := &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: t}
+	wrongType := func() error {
+		return fmt.Errorf("got %T type for metadata annotation %s, expect %v", v, name, t.Kind)
+	}
+
+	var err error
+	switch t.Kind {
+	case yang.Yunion:
+		for _, ut := range t.Type {
+			if validateMetadataValue(name, ut, v) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%v is not a valid value of metadata annotation %s", v, name)
+	case yang.Ystring, yang.Ybits:
+		s, ok := v.(string)
+		if !ok {
+			return wrongType()
+		}
+		if t.Kind == yang.Ystring {
+			err = validateStringRestrictions(schema, t, s)
+		}
+	case yang.Ybinary:
+		b, ok := v.([]byte)
+		if !ok {
+			return wrongType()
+		}
+		err = validateBinaryRestrictions(schema, t, b)
+	case yang.Ydecimal64:
+		f, ok := v.(float64)
+		if !ok {
+			return wrongType()
+		}
+		err = validateDecimalRestrictions(schema, t, f)
+	case yang.Ybool:
+		if _, ok := v.(bool); !ok {
+			return wrongType()
+		}
+	case yang.Yempty:
+		if v != nil {
+			return wrongType()
+		}
+	case yang.Yenum:
+		s, ok := v.(string)
+		if !ok {
+			return wrongType()
+		}
+		if t.Enum == nil || !t.Enum.IsDefined(s) {
+			return fmt.Errorf("%s is not a valid value of enumeration metadata annotation %s", s, name)
+		}
+	case yang.Yidentityref:
+		s, ok := v.(string)
+		if !ok {
+			return wrongType()
+		}
+		if !isIdentityOf(t.IdentityBase, util.StripModulePrefix(s)) {
+			return fmt.Errorf("%s is not a valid value of identityref metadata annotation %s", s, name)
+		}
+	default:
+		if !isIntegerType(t.Kind) {
+			return fmt.Errorf("unsupported type %v of metadata annotation %s", t.Kind, name)
+		}
+		rv := reflect.ValueOf(v)
+		if !rv.IsValid() || typeKindFromKind[rv.Kind()] != t.Kind {
+			return wrongType()
+		}
+		if isSigned(t.Kind) {
+			err = validateIntRestrictions(schema, t, rv.Int())
+		} else {
+			err = validateUintRestrictions(schema, t, rv.Uint())
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("metadata annotation %s: %w", name, err)
+	}
+	return nil
+}
+
+// isIdentityOf reports whether the identity with the given name is derived
+// from base.
+func isIdentityOf(base *yang.Identity, name string) bool {
+	if base == nil {
+		return false
+	}
+	for _, i := range base.Values {
+		if i.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateMetadata validates the ygot.MetadataAnnotations within the value
+// of an annotation field of a struct described by schema against the
+// definitions of the annotations in the schema tree. Annotations of other
+// types are not validated.
+func validateMetadata(schema *yang.Entry, value interface{}) util.Errors {
+	annos, ok := value.([]ygot.Annotation)
+	if !ok {
+		return nil
+	}
+	var ms []*ygot.MetadataAnnotation
+	for _, a := range annos {
+		if m, ok := a.(*ygot.MetadataAnnotation); ok && m != nil {
+			ms = append(ms, m)
+		}
+	}
+	if len(ms) == 0 {
+		return nil
+	}
+
+	defs, err := util.MetadataAnnotations(schema)
+	if err != nil {
+		return util.NewErrs(err)
+	}
+	var errs util.Errors
+	for _, m := range ms {
+		t, ok := defs[m.Name]
+		if !ok {
+			errs = util.AppendErr(errs, fmt.Errorf("unknown metadata annotation %s", m.Name))
+			continue
+		}
+		errs = util.AppendErr(errs, validateMetadataValue(m.Name, t, m.Value))
+	}
+	return errs
+}
@@ -0,0 +1,295 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+type metadataTestStruct struct {
+	ΛMetadata []ygot.Annotation `path:"@" ygotAnnotation:"true"`
+	Leaf      *string           `path:"leaf" module:"mt"`
+	ΛLeaf     []ygot.Annotation `path:"@leaf" ygotAnnotation:"true"`
+	Other     *string           `path:"config/other" module:"mt/mt"`
+	ΛOther    []ygot.Annotation `path:"config/@other" ygotAnnotation:"true"`
+}
+
+func (*metadataTestStruct) IsYANGGoStruct()                          {}
+func (*metadataTestStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*metadataTestStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*metadataTestStruct) ΛBelongingModule() string                 { return "mt" }
+
+// metadataTestSchema returns the schema of metadataTestStruct, which is the
+// root of a schema tree that defines the metadata annotations md.
+func metadataTestSchema(md map[string]*yang.YangType) *yang.Entry {
+	s := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"leaf": {Name: "leaf", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+			"config": {
+				Name: "config",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"other": {Name: "other", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+				},
+			},
+		},
+		Annotation: map[string]interface{}{"isFakeRoot": true},
+	}
+	if md != nil {
+		s.Annotation[util.MetadataAnnotationsAnnotation] = md
+	}
+	populateParentField(nil, s)
+	return s
+}
+
+// metadataTestTypes returns the types of the metadata annotations of the
+// tests.
+func metadataTestTypes(t *testing.T) map[string]*yang.YangType {
+	r, err := yang.ParseRangesInt("0..10")
+	if err != nil {
+		t.Fatalf("cannot parse range: %v", err)
+	}
+	enum := yang.NewEnumType()
+	enum.Set("a", 0)
+	enum.Set("b", 1)
+	return map[string]*yang.YangType{
+		"mt:origin": {
+			Kind:         yang.Yidentityref,
+			IdentityBase: &yang.Identity{Name: "origin", Values: []*yang.Identity{{Name: "intended"}, {Name: "learned"}}},
+		},
+		"mt:count": {Kind: yang.Yuint32, Range: r},
+		"mt:mode":  {Kind: yang.Yenum, Enum: enum},
+		"mt:big":   {Kind: yang.Yint64, Range: yang.Int64Range},
+		"mt:id": {Kind: yang.Yunion, Type: []*yang.YangType{
+			{Kind: yang.Yuint8, Range: yang.Uint8Range},
+			{Kind: yang.Ystring},
+		}},
+		"mt:flag": {Kind: yang.Yempty},
+		"mt:data": {Kind: yang.Ybinary},
+	}
+}
+
+func TestUnmarshalMetadata(t *testing.T) {
+	types := metadataTestTypes(t)
+
+	tests := []struct {
+		desc     string
+		inSchema *yang.Entry
+		inParent *metadataTestStruct
+		in       string
+		inOpts   []UnmarshalOpt
+		want     *metadataTestStruct
+		wantErr  string
+	}{{
+		desc:     "annotations of struct and leaves",
+		inSchema: metadataTestSchema(types),
+		in: `{
+			"@": {"mt:origin": "ietf-origin:learned", "mt:count": 10, "mt:big": "-9000000000"},
+			"leaf": "l",
+			"@leaf": {"mt:mode": "b", "mt:flag": [null], "mt:data": "AQI="},
+			"config": {"other": "o", "@mt:other": {"mt:id": 200}}
+		}`,
+		want: &metadataTestStruct{
+			ΛMetadata: []ygot.Annotation{
+				&ygot.MetadataAnnotation{Name: "mt:big", Value: int64(-9000000000)},
+				&ygot.MetadataAnnotation{Name: "mt:count", Value: uint32(10)},
+				&ygot.MetadataAnnotation{Name: "mt:origin", Value: "ietf-origin:learned"},
+			},
+			Leaf: ygot.String("l"),
+			ΛLeaf: []ygot.Annotation{
+				&ygot.MetadataAnnotation{Name: "mt:data", Value: []byte{1, 2}},
+				&ygot.MetadataAnnotation{Name: "mt:flag"},
+				&ygot.MetadataAnnotation{Name: "mt:mode", Value: "b"},
+			},
+			Other:  ygot.String("o"),
+			ΛOther: []ygot.Annotation{&ygot.MetadataAnnotation{Name: "mt:id", Value: uint8(200)}},
+		},
+	}, {
+		desc:     "union value of second type",
+		inSchema: metadataTestSchema(types),
+		in:       `{"@": {"mt:id": 256}, "@leaf": {"mt:id": "x"}}`,
+		wantErr:  "256 is not a valid value of metadata annotation mt:id",
+	}, {
+		desc:     "existing annotations retained",
+		inSchema: metadataTestSchema(types),
+		inParent: &metadataTestStruct{ΛMetadata: []ygot.Annotation{
+			&ygot.MetadataAnnotation{Name: "mt:mode", Value: "a"},
+			&ygot.MetadataAnnotation{Name: "mt:count", Value: uint32(1)},
+		}},
+		in: `{"@": {"mt:count": 2}}`,
+		want: &metadataTestStruct{ΛMetadata: []ygot.Annotation{
+			&ygot.MetadataAnnotation{Name: "mt:mode", Value: "a"},
+			&ygot.MetadataAnnotation{Name: "mt:count", Value: uint32(2)},
+		}},
+	}, {
+		desc:     "no definitions",
+		inSchema: metadataTestSchema(nil),
+		in:       `{"@": {"mt:count": 2}, "leaf": "l"}`,
+		want:     &metadataTestStruct{Leaf: ygot.String("l")},
+	}, {
+		desc:     "unknown annotation",
+		inSchema: metadataTestSchema(types),
+		in:       `{"@": {"mt:unknown": 2}}`,
+		wantErr:  "unknown metadata annotation mt:unknown for field ΛMetadata",
+	}, {
+		desc:     "unknown annotation ignored",
+		inSchema: metadataTestSchema(types),
+		in:       `{"@": {"mt:unknown": 2, "mt:count": 1}}`,
+		inOpts:   []UnmarshalOpt{&IgnoreExtraFields{}},
+		want:     &metadataTestStruct{ΛMetadata: []ygot.Annotation{&ygot.MetadataAnnotation{Name: "mt:count", Value: uint32(1)}}},
+	}, {
+		desc:     "value out of range",
+		inSchema: metadataTestSchema(types),
+		in:       `{"@": {"mt:count": 11}}`,
+		wantErr:  "metadata annotation mt:count: unsigned integer value 11 is outside specified ranges",
+	}, {
+		desc:     "invalid identity",
+		inSchema: metadataTestSchema(types),
+		in:       `{"@leaf": {"mt:origin": "ietf-origin:unknown"}}`,
+		wantErr:  "ietf-origin:unknown is not a valid value of identityref metadata annotation mt:origin",
+	}, {
+		desc:     "invalid enumeration",
+		inSchema: metadataTestSchema(types),
+		in:       `{"@": {"mt:mode": "c"}}`,
+		wantErr:  "c is not a valid value of enumeration metadata annotation mt:mode",
+	}, {
+		desc:     "wrong type",
+		inSchema: metadataTestSchema(types),
+		in:       `{"@": {"mt:big": 1}}`,
+		wantErr:  "got float64 type for field mt:big, expect string",
+	}, {
+		desc:     "invalid empty value",
+		inSchema: metadataTestSchema(types),
+		in:       `{"@": {"mt:flag": true}}`,
+		wantErr:  "empty values must be [null]",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var in interface{}
+			if err := json.Unmarshal([]byte(tt.in), &in); err != nil {
+				t.Fatalf("cannot unmarshal test input: %v", err)
+			}
+			got := tt.inParent
+			if got == nil {
+				got = &metadataTestStruct{}
+			}
+			err := Unmarshal(tt.inSchema, got, in, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("Unmarshal: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Unmarshal (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateMetadata(t *testing.T) {
+	types := metadataTestTypes(t)
+
+	tests := []struct {
+		desc     string
+		inSchema *yang.Entry
+		in       *metadataTestStruct
+		wantErr  string
+	}{{
+		desc:     "valid annotations",
+		inSchema: metadataTestSchema(types),
+		in: &metadataTestStruct{
+			ΛMetadata: []ygot.Annotation{&ygot.MetadataAnnotation{Name: "mt:origin", Value: "ietf-origin:intended"}},
+			ΛLeaf:     []ygot.Annotation{&ygot.MetadataAnnotation{Name: "mt:id", Value: "id"}},
+		},
+	}, {
+		desc:     "unknown annotation",
+		inSchema: metadataTestSchema(types),
+		in:       &metadataTestStruct{ΛMetadata: []ygot.Annotation{&ygot.MetadataAnnotation{Name: "mt:unknown"}}},
+		wantErr:  "unknown metadata annotation mt:unknown",
+	}, {
+		desc:     "invalid value",
+		inSchema: metadataTestSchema(types),
+		in:       &metadataTestStruct{ΛOther: []ygot.Annotation{&ygot.MetadataAnnotation{Name: "mt:count", Value: uint32(11)}}},
+		wantErr:  "metadata annotation mt:count: unsigned integer value 11 is outside specified ranges",
+	}, {
+		desc:     "wrong type",
+		inSchema: metadataTestSchema(types),
+		in:       &metadataTestStruct{ΛOther: []ygot.Annotation{&ygot.MetadataAnnotation{Name: "mt:count", Value: int32(1)}}},
+		wantErr:  "got int32 type for metadata annotation mt:count, expect uint32",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var err error
+			if errs := Validate(tt.inSchema, tt.in); errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Errorf("Validate: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMetadataRoundTrip(t *testing.T) {
+	schema := metadataTestSchema(metadataTestTypes(t))
+	in := &metadataTestStruct{
+		ΛMetadata: []ygot.Annotation{
+			&ygot.MetadataAnnotation{Name: "mt:big", Value: int64(-1)},
+			&ygot.MetadataAnnotation{Name: "mt:origin", Value: "ietf-origin:intended"},
+		},
+		Leaf: ygot.String("l"),
+		ΛLeaf: []ygot.Annotation{
+			&ygot.MetadataAnnotation{Name: "mt:data", Value: []byte{1, 2}},
+			&ygot.MetadataAnnotation{Name: "mt:flag"},
+		},
+		Other:  ygot.String("o"),
+		ΛOther: []ygot.Annotation{&ygot.MetadataAnnotation{Name: "mt:id", Value: uint8(1)}},
+	}
+
+	j, err := ygot.ConstructIETFJSON(in, &ygot.RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		t.Fatalf("ConstructIETFJSON: %v", err)
+	}
+	// The JSON is unmarshalled from its serialisation.
+	b, err := json.Marshal(j)
+	if err != nil {
+		t.Fatalf("cannot marshal JSON: %v", err)
+	}
+	var tree interface{}
+	if err := json.Unmarshal(b, &tree); err != nil {
+		t.Fatalf("cannot unmarshal JSON: %v", err)
+	}
+
+	got := &metadataTestStruct{}
+	if err := Unmarshal(schema, got, tree); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", b, err)
+	}
+	if diff := cmp.Diff(in, got); diff != "" {
+		t.Errorf("round trip of %s (-want, +got):\n%s", b, diff)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// Refer to: https://tools.ietf.org/html/rfc7950#section-7.5.3.
+
+// MustOptions enables the evaluation of the YANG must statements in the
+// schema during validation. Since must statements may reference any node in
+// the data tree, they should be evaluated when validating the root of the
+// data tree, such that absolute paths can be resolved.
+type MustOptions struct {
+	// IgnoreUnsupported specifies that must statements whose XPath
+	// expression cannot be parsed, or that use XPath features that are not
+	// supported by the evaluator, are skipped rather than resulting in a
+	// validation error.
+	IgnoreUnsupported bool
+}
+
+// IsValidationOption ensures that MustOptions implements the ValidationOption
+// interface.
+func (*MustOptions) IsValidationOption() {}
+
+// validateMust evaluates the must statements of each node in the data tree
+// rooted at value, whose schema is supplied, and returns an error for each
+// statement that is not satisfied. Where the change filter f is non-nil, only
+// the statements that may be affected by the changes are evaluated.
+func validateMust(schema *yang.Entry, value interface{}, opt *MustOptions, f *changeFilter) util.Errors {
+	_, top := newXPathTree(schema, value)
+	status := f.xpathStatusFunc()
+	var errs util.Errors
+	walkXPathTree(top, func(n *xpathNode) bool {
+		s := status(n)
+		if f.skipXPathSubtree(n, s) {
+			return false
+		}
+		for _, m := range mustStatements(n.schema) {
+			if f.evaluate(s, m.expr) {
+				errs = util.AppendErr(errs, evalMust(n, m, opt))
+			}
+		}
+		return true
+	})
+	return errs
+}
+
+// evalMust evaluates the must statement m with n as the context node,
+// returning an error if it is not satisfied.
+func evalMust(n *xpathNode, m *xpathStatement, opt *MustOptions) error {
+	ok, err := evalXPathBool(m.expr, n)
+	switch {
+	case err != nil && opt.IgnoreUnsupported:
+		util.DbgPrint("skipping must statement %q at %s: %v", m.expr, n.pathString(), err)
+		return nil
+	case err != nil:
+		return xpathValidationError(MustConstraint, n, fmt.Errorf("%s: cannot evaluate must statement %q: %v", n.pathString(), m.expr, err))
+	case ok:
+		return nil
+	}
+
+	ve := xpathValidationError(MustConstraint, n, fmt.Errorf("%s: must statement %q is not satisfied", n.pathString(), m.expr))
+	ve.setErrorStatements(m.errorMessage, m.errorAppTag)
+	return ve
+}
+
+// walkXPathTree calls fn for n and each of its descendants that store data
+// in depth-first order. Non-presence containers without data and leaves whose
+// default value is in use are not visited. If fn returns false, the
+// descendants of the node are not visited.
+func walkXPathTree(n *xpathNode, fn func(*xpathNode) bool) {
+	if n.virtual || n.isDefault {
+		return
+	}
+	if !fn(n) {
+		return
+	}
+	for _, c := range n.childNodes() {
+		walkXPathTree(c, fn)
+	}
+}
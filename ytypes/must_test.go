@@ -0,0 +1,127 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestValidateMust(t *testing.T) {
+	// addMust returns a function that adds the supplied must statement to
+	// the neighbor list within the schema.
+	addMust := func(m interface{}) func(*yang.Entry) {
+		return func(root *yang.Entry) {
+			n := root.Dir["bgp"].Dir["neighbors"].Dir["neighbor"]
+			n.Extra = map[string][]interface{}{"must": {m}}
+		}
+	}
+	peerAsMust := &yang.Must{
+		Name:         "config/peer-as != /bgp/global/config/as",
+		ErrorMessage: &yang.Value{Name: "iBGP peers are not supported"},
+		ErrorAppTag:  &yang.Value{Name: "ibgp-unsupported"},
+	}
+	// jsonMust is the representation of peerAsMust after the schema has
+	// been serialised to, and unmarshalled from, JSON.
+	jsonMust := map[string]interface{}{
+		"Name":         "config/peer-as != /bgp/global/config/as",
+		"ErrorMessage": map[string]interface{}{"Name": "iBGP peers are not supported"},
+	}
+
+	ibgp := func() *xpathTestDevice {
+		d := xpathTestData()
+		d.Bgp.Neighbor["192.0.2.254"].PeerAs = ygot.Uint32(64512)
+		return d
+	}
+
+	tests := []struct {
+		desc       string
+		inModify   func(*yang.Entry)
+		inData     *xpathTestDevice
+		inOpts     []ygot.ValidationOption
+		wantErrors []string
+	}{{
+		desc:     "must statement satisfied",
+		inModify: addMust(peerAsMust),
+		inData:   xpathTestData(),
+		inOpts:   []ygot.ValidationOption{&MustOptions{}},
+	}, {
+		desc:     "must statement not satisfied",
+		inModify: addMust(peerAsMust),
+		inData:   ibgp(),
+		inOpts:   []ygot.ValidationOption{&MustOptions{}},
+		wantErrors: []string{
+			`/bgp/neighbors/neighbor[neighbor-address=192.0.2.254]: must statement "config/peer-as != /bgp/global/config/as" is not satisfied: iBGP peers are not supported (error-app-tag ibgp-unsupported)`,
+		},
+	}, {
+		desc:     "must statement from JSON schema not satisfied",
+		inModify: addMust(jsonMust),
+		inData:   ibgp(),
+		inOpts:   []ygot.ValidationOption{&MustOptions{}},
+		wantErrors: []string{
+			`/bgp/neighbors/neighbor[neighbor-address=192.0.2.254]: must statement "config/peer-as != /bgp/global/config/as" is not satisfied: iBGP peers are not supported`,
+		},
+	}, {
+		desc:     "must statements not evaluated without option",
+		inModify: addMust(peerAsMust),
+		inData:   ibgp(),
+	}, {
+		desc: "must statement on leaf using default value",
+		inModify: func(root *yang.Entry) {
+			e := root.Dir["interfaces"].Dir["interface"].Dir["config"].Dir["description"]
+			e.Extra = map[string][]interface{}{"must": {&yang.Must{Name: "../enabled = 'true'"}}}
+		},
+		inData: xpathTestData(),
+		inOpts: []ygot.ValidationOption{&MustOptions{}},
+		wantErrors: []string{
+			`/interfaces/interface[name=lo0]/config/description: must statement "../enabled = 'true'" is not satisfied`,
+		},
+	}, {
+		desc:     "unsupported expression",
+		inModify: addMust(&yang.Must{Name: "unknown-function(.)"}),
+		inData:   xpathTestData(),
+		inOpts:   []ygot.ValidationOption{&MustOptions{}},
+		wantErrors: []string{
+			`/bgp/neighbors/neighbor[neighbor-address=192.0.2.254]: cannot evaluate must statement "unknown-function(.)": unsupported XPath function unknown-function()`,
+		},
+	}, {
+		desc:     "unsupported expression ignored",
+		inModify: addMust(&yang.Must{Name: "unknown-function(.)"}),
+		inData:   xpathTestData(),
+		inOpts:   []ygot.ValidationOption{&MustOptions{IgnoreUnsupported: true}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := xpathTestSchema(tt.inModify)
+			errs := Validate(schema, tt.inData, tt.inOpts...)
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if len(got) != len(tt.wantErrors) {
+				t.Fatalf("Validate(): got errors %v, want %v", util.Errors(errs), tt.wantErrors)
+			}
+			for i := range got {
+				if got[i] != tt.wantErrors[i] {
+					t.Errorf("Validate(): got error %d %q, want %q", i, got[i], tt.wantErrors[i])
+				}
+			}
+		})
+	}
+}
@@ -70,6 +70,10 @@ type retrieveNodeArgs struct {
 	// ignoreExtraFields avoids generating an error when the input path
 	// refers to a field that does not exist in the GoStruct.
 	ignoreExtraFields bool
+	// If insert is set to a non-nil value, the entry of an ordered map
+	// corresponding to the given path is moved to the position that it
+	// specifies.
+	insert *orderedMapInsertion
 }
 
 // retrieveNode is an internal function that retrieves the node specified by
@@ -444,6 +448,7 @@ func retrieveNodeOrderedList(schema *yang.Entry, root ygot.GoOrderedMap, path, t
 	}
 
 	var outerErr error
+	var insertKey reflect.Value
 	if err := yreflect.RangeOrderedMap(root, func(k reflect.Value, v reflect.Value) bool {
 		keyMap, err := getKeyFields(k, v, schema.Key)
 		if err != nil {
@@ -503,6 +508,12 @@ func retrieveNodeOrderedList(schema *yang.Entry, root ygot.GoOrderedMap, path, t
 			if nodes != nil {
 				matches = append(matches, nodes...)
 			}
+			if args.insert != nil && len(remainingPath.GetElem()) == 0 {
+				// The entry is moved once the ordered map is no
+				// longer being ranged over.
+				insertKey = k
+				return false
+			}
 		}
 
 		return true
@@ -512,6 +523,11 @@ func retrieveNodeOrderedList(schema *yang.Entry, root ygot.GoOrderedMap, path, t
 	if outerErr != nil {
 		return nil, outerErr
 	}
+	if insertKey.IsValid() {
+		if err := insertOrderedMapEntry(schema, root, insertKey, args.insert); err != nil {
+			return nil, err
+		}
+	}
 
 	if len(matches) == 0 && args.modifyRoot {
 		if keyN != len(newKeyVals) {
@@ -1026,11 +1042,18 @@ func hasDelNodePreferShadowPath(opts []DelNodeOpt) bool {
 // Regardless of whether the deletion operation is executed, any intermediate
 // non-leaf nodes traversed by the path that is equal to the empty struct or
 // map will be set to nil, similar to the behaviour of ygot.PruneEmptyBranches.
+//
+// Where CheckLeafrefs is supplied, leafrefs that refer to the node, or to any
+// of its descendants, are handled as it specifies.
 func DeleteNode(schema *yang.Entry, root interface{}, path *gpb.Path, opts ...DelNodeOpt) error {
-	_, err := retrieveNode(schema, root, path, nil, retrieveNodeArgs{
+	args := retrieveNodeArgs{
 		delete:           true,
 		preferShadowPath: hasDelNodePreferShadowPath(opts),
-	})
+	}
+	if c := delNodeCheckLeafrefs(opts); c != nil {
+		return deleteNodeCheckingLeafrefs(schema, root, path, args, c)
+	}
 
+	_, err := retrieveNode(schema, root, path, nil, args)
 	return err
 }
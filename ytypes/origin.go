@@ -0,0 +1,341 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Origins is an unmarshal option that specifies how UnmarshalSetRequest
+// applies the operations whose paths have an origin other than the OpenConfig
+// origin, which is specified by an empty origin or "openconfig", and whose
+// operations are applied to the supplied schema. UnmarshalNotifications
+// applies each Notification as a SetRequest, such that its updates and
+// deletes are routed by the origin of their prefix and paths in the same way.
+//
+// Without this option, the origins of the paths are ignored, and all of the
+// operations are applied to the supplied schema.
+type Origins struct {
+	// Schemas are the schemas to which the operations are applied, keyed
+	// by origin.
+	Schemas map[string]*Schema
+	// Opaque, if non-nil, stores the values of the operations whose
+	// origin does not have a schema within Schemas, such as the "cli"
+	// origin.
+	Opaque OpaqueData
+}
+
+// IsUnmarshalOpt marks Origins as a valid UnmarshalOpt.
+func (*Origins) IsUnmarshalOpt() {}
+
+// hasOrigins returns the first Origins option from an opts slice, or nil if
+// there isn't one.
+func hasOrigins(opts []UnmarshalOpt) *Origins {
+	for _, o := range opts {
+		if v, ok := o.(*Origins); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// OpaqueData stores the values of an origin that is not modelled by a schema,
+// such as the configuration of the "cli" origin, as opaque values that are
+// not interpreted. It is keyed by origin, and then by the path of each value
+// as per ygot.PathToString.
+//
+// Where a SetRequest is applied to OpaqueData, deleting or replacing a path
+// removes the values at and below it, and updating a path sets its value.
+type OpaqueData map[string]map[string]*gpb.TypedValue
+
+// copy returns a copy of d, whose values are shared with d.
+func (d OpaqueData) copy() OpaqueData {
+	if d == nil {
+		return nil
+	}
+	out := OpaqueData{}
+	for o, vs := range d {
+		out[o] = map[string]*gpb.TypedValue{}
+		for p, v := range vs {
+			out[o][p] = v
+		}
+	}
+	return out
+}
+
+// deleteSubtree removes the values at and below the path p of origin.
+func (d OpaqueData) deleteSubtree(origin, p string) {
+	for k := range d[origin] {
+		if p == "/" || k == p || strings.HasPrefix(k, p+"/") {
+			delete(d[origin], k)
+		}
+	}
+	if len(d[origin]) == 0 {
+		delete(d, origin)
+	}
+}
+
+// set sets the value at the path p of origin to v.
+func (d OpaqueData) set(origin, p string, v *gpb.TypedValue) {
+	if d[origin] == nil {
+		d[origin] = map[string]*gpb.TypedValue{}
+	}
+	d[origin][p] = v
+}
+
+// apply applies req, whose paths are of the supplied origin, to d. The
+// union_replace operations are treated as replaces, where no more than one of
+// them may be at each path, since opaque values cannot be merged. Where opErrs
+// is set, the error of each operation that cannot be applied is a
+// *SetRequestError.
+func (d OpaqueData) apply(origin string, req *gpb.SetRequest, opErrs bool) error {
+	pathStr := func(op gpb.UpdateResult_Operation, i int, p *gpb.Path) (string, error) {
+		jp, err := util.JoinPaths(req.GetPrefix(), p)
+		if err != nil {
+			return "", setRequestError(opErrs, op, i, p, err)
+		}
+		s, err := ygot.PathToString(jp)
+		if err != nil {
+			return "", setRequestError(opErrs, op, i, jp, err)
+		}
+		return s, nil
+	}
+
+	for i, p := range req.GetDelete() {
+		s, err := pathStr(gpb.UpdateResult_DELETE, i, p)
+		if err != nil {
+			return err
+		}
+		d.deleteSubtree(origin, s)
+	}
+	for i, u := range req.GetReplace() {
+		s, err := pathStr(gpb.UpdateResult_REPLACE, i, u.GetPath())
+		if err != nil {
+			return err
+		}
+		d.deleteSubtree(origin, s)
+		d.set(origin, s, u.GetVal())
+	}
+	seen := map[string]bool{}
+	for i, u := range req.GetUnionReplace() {
+		s, err := pathStr(gpb.UpdateResult_UNION_REPLACE, i, u.GetPath())
+		if err != nil {
+			return err
+		}
+		if seen[s] {
+			return setRequestError(opErrs, gpb.UpdateResult_UNION_REPLACE, i, u.GetPath(), fmt.Errorf("cannot union opaque values of origin %q", origin))
+		}
+		seen[s] = true
+		d.deleteSubtree(origin, s)
+		d.set(origin, s, u.GetVal())
+	}
+	for i, u := range req.GetUpdate() {
+		s, err := pathStr(gpb.UpdateResult_UPDATE, i, u.GetPath())
+		if err != nil {
+			return err
+		}
+		d.set(origin, s, u.GetVal())
+	}
+	return nil
+}
+
+// inverse applies reqs, whose paths are of the supplied origin, to a copy of
+// the values of origin within d in order, and returns the SetRequest that
+// restores the copy to the values within d. The paths of the returned
+// SetRequest do not specify an origin.
+func (d OpaqueData) inverse(origin string, reqs []*gpb.SetRequest) (*gpb.SetRequest, error) {
+	modified := OpaqueData{}
+	for p, v := range d[origin] {
+		modified.set(origin, p, v)
+	}
+	for _, req := range reqs {
+		if err := modified.apply(origin, req, false); err != nil {
+			return nil, fmt.Errorf("cannot apply change: %w", err)
+		}
+	}
+
+	var deletes, updates []string
+	for p := range modified[origin] {
+		if _, ok := d[origin][p]; !ok {
+			deletes = append(deletes, p)
+		}
+	}
+	for p, v := range d[origin] {
+		if !proto.Equal(modified[origin][p], v) {
+			updates = append(updates, p)
+		}
+	}
+	sort.Strings(deletes)
+	sort.Strings(updates)
+
+	out := &gpb.SetRequest{}
+	for _, p := range deletes {
+		sp, err := ygot.StringToStructuredPath(p)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compute inverse: %v", err)
+		}
+		out.Delete = append(out.Delete, sp)
+	}
+	for _, p := range updates {
+		sp, err := ygot.StringToStructuredPath(p)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compute inverse: %v", err)
+		}
+		out.Update = append(out.Update, &gpb.Update{Path: sp, Val: d[origin][p]})
+	}
+	return out, nil
+}
+
+// isOpenConfigOrigin reports whether origin is the OpenConfig origin.
+func isOpenConfigOrigin(origin string) bool {
+	return origin == "" || origin == "openconfig"
+}
+
+// originSetRequest is the part of a SetRequest whose paths are of a single
+// origin.
+type originSetRequest struct {
+	// origin is the origin of the paths, which is empty for the
+	// OpenConfig origin.
+	origin string
+	// req contains the operations of the origin, whose paths and prefix
+	// do not specify an origin where origin is not the OpenConfig origin.
+	req *gpb.SetRequest
+	// indices are the indices of each of the operations of req within the
+	// corresponding field of the original SetRequest, keyed by the kind
+	// of operation.
+	indices map[gpb.UpdateResult_Operation][]int
+}
+
+// remapErrors replaces the index of each SetRequestError within err, which
+// was returned when applying o.req, with the index of the operation within
+// the original SetRequest.
+func (o *originSetRequest) remapErrors(err error) {
+	errs := []error{err}
+	var ce *ComplianceErrors
+	if errors.As(err, &ce) {
+		errs = ce.Errors
+	}
+	for _, e := range errs {
+		var se *SetRequestError
+		if errors.As(e, &se) {
+			if is := o.indices[se.Op]; se.Index < len(is) {
+				se.Index = is[se.Index]
+			}
+		}
+	}
+}
+
+// splitSetRequestByOrigin returns the parts of req for each of the origins of
+// its paths, with the OpenConfig origin first, followed by the other origins
+// in lexical order. Where all of the paths of req are of the OpenConfig
+// origin, req is returned unmodified. Where opErrs is set, the error of an
+// operation whose origin conflicts with that of the prefix is a
+// *SetRequestError.
+func splitSetRequestByOrigin(req *gpb.SetRequest, opErrs bool) ([]*originSetRequest, error) {
+	pfxOrigin := req.GetPrefix().GetOrigin()
+	originOf := func(op gpb.UpdateResult_Operation, i int, p *gpb.Path) (string, error) {
+		o := p.GetOrigin()
+		switch {
+		case pfxOrigin != "" && o != "" && o != pfxOrigin:
+			return "", setRequestError(opErrs, op, i, p, fmt.Errorf("prefix and path have different origins: %s != %s", pfxOrigin, o))
+		case pfxOrigin != "":
+			o = pfxOrigin
+		}
+		if isOpenConfigOrigin(o) {
+			return "", nil
+		}
+		return o, nil
+	}
+
+	byOrigin := map[string]*originSetRequest{}
+	get := func(o string) *originSetRequest {
+		if r, ok := byOrigin[o]; ok {
+			return r
+		}
+		r := &originSetRequest{
+			origin:  o,
+			req:     &gpb.SetRequest{Prefix: stripOrigin(req.GetPrefix())},
+			indices: map[gpb.UpdateResult_Operation][]int{},
+		}
+		byOrigin[o] = r
+		return r
+	}
+
+	for i, p := range req.GetDelete() {
+		o, err := originOf(gpb.UpdateResult_DELETE, i, p)
+		if err != nil {
+			return nil, err
+		}
+		r := get(o)
+		r.req.Delete = append(r.req.Delete, stripOrigin(p))
+		r.indices[gpb.UpdateResult_DELETE] = append(r.indices[gpb.UpdateResult_DELETE], i)
+	}
+	for _, f := range []struct {
+		op      gpb.UpdateResult_Operation
+		updates []*gpb.Update
+		field   func(*gpb.SetRequest) *[]*gpb.Update
+	}{
+		{gpb.UpdateResult_REPLACE, req.GetReplace(), func(r *gpb.SetRequest) *[]*gpb.Update { return &r.Replace }},
+		{gpb.UpdateResult_UNION_REPLACE, req.GetUnionReplace(), func(r *gpb.SetRequest) *[]*gpb.Update { return &r.UnionReplace }},
+		{gpb.UpdateResult_UPDATE, req.GetUpdate(), func(r *gpb.SetRequest) *[]*gpb.Update { return &r.Update }},
+	} {
+		for i, u := range f.updates {
+			o, err := originOf(f.op, i, u.GetPath())
+			if err != nil {
+				return nil, err
+			}
+			r := get(o)
+			us := f.field(r.req)
+			*us = append(*us, &gpb.Update{Path: stripOrigin(u.GetPath()), Val: u.GetVal(), Duplicates: u.GetDuplicates()})
+			r.indices[f.op] = append(r.indices[f.op], i)
+		}
+	}
+
+	if _, ok := byOrigin[""]; len(byOrigin) == 0 || ok && len(byOrigin) == 1 {
+		// All of the paths are of the OpenConfig origin, such that the
+		// indices of the operations are unchanged.
+		return []*originSetRequest{{req: req}}, nil
+	}
+
+	var origins []string
+	for o := range byOrigin {
+		origins = append(origins, o)
+	}
+	sort.Strings(origins)
+	var out []*originSetRequest
+	for _, o := range origins {
+		out = append(out, byOrigin[o])
+	}
+	return out, nil
+}
+
+// stripOrigin returns p without its origin, copying it if it has one.
+func stripOrigin(p *gpb.Path) *gpb.Path {
+	if p.GetOrigin() == "" {
+		return p
+	}
+	c := proto.Clone(p).(*gpb.Path)
+	c.Origin = ""
+	return c
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// ParallelOptions enables the concurrent validation of the data tree, such
+// that the entries of lists and the containers within the tree, along with
+// the leafrefs within it, are validated by multiple goroutines. Must, when and
+// mandatory statements are evaluated by a single goroutine. The errors that
+// are returned are ordered deterministically, with the entries of keyed lists
+// being validated in the order of their keys. Any custom validation functions
+// that are supplied may be invoked concurrently.
+type ParallelOptions struct {
+	// Workers is the maximum number of goroutines that validate the data
+	// tree, including the calling goroutine. Where it is less than 1, the
+	// value of runtime.GOMAXPROCS is used.
+	Workers int
+}
+
+// IsValidationOption ensures that ParallelOptions implements the
+// ValidationOption interface.
+func (*ParallelOptions) IsValidationOption() {}
+
+// validatePool bounds the number of goroutines that are used to validate a
+// data tree.
+type validatePool struct {
+	// sem holds a token for each goroutine that is running in addition to
+	// the goroutine that requested validation.
+	sem chan struct{}
+}
+
+// newValidatePool returns a pool that uses at most workers goroutines,
+// including the calling goroutine.
+func newValidatePool(workers int) *validatePool {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &validatePool{sem: make(chan struct{}, workers-1)}
+}
+
+// each calls fn for each integer in [0, n), and returns once all calls have
+// returned. Each call is made in a new goroutine where the pool has capacity
+// for it, and otherwise in the calling goroutine, such that nested calls to
+// each cannot deadlock.
+func (p *validatePool) each(n int, fn func(i int)) {
+	if p == nil || n < 2 || cap(p.sem) == 0 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case p.sem <- struct{}{}:
+			wg.Add(1)
+			go func(i int) {
+				defer func() {
+					<-p.sem
+					wg.Done()
+				}()
+				fn(i)
+			}(i)
+		default:
+			fn(i)
+		}
+	}
+	wg.Wait()
+}
+
+// concurrent reports whether the node with the supplied schema is validated
+// concurrently with its siblings, which is the case for nodes that may have
+// descendants, since validating a leaf is not worth the cost of a goroutine.
+func (st *validateState) concurrent(schema *yang.Entry) bool {
+	return st != nil && st.pool != nil && !schema.IsLeaf() && !schema.IsLeafList()
+}
+
+// each calls fn for each integer in [0, n), concurrently where st has a pool.
+func (st *validateState) each(n int, fn func(i int)) {
+	if st == nil {
+		(*validatePool)(nil).each(n, fn)
+		return
+	}
+	st.pool.each(n, fn)
+}
+
+// ordered reports whether the entries of lists are to be validated in a
+// deterministic order.
+func (st *validateState) ordered() bool {
+	return st != nil && st.pool != nil
+}
+
+// sortedMapKeys returns the keys of the map v sorted by their string
+// representations, such that the entries of a keyed list are visited in a
+// deterministic order.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	strs := make([]string, len(keys))
+	for i, k := range keys {
+		strs[i] = fmt.Sprint(k.Interface())
+	}
+	idx := make([]int, len(keys))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return strs[idx[i]] < strs[idx[j]] })
+	out := make([]reflect.Value, len(keys))
+	for i, j := range idx {
+		out[i] = keys[j]
+	}
+	return out
+}
+
+// leafrefTask is a leafref that is to be validated, along with the memo that
+// is used to resolve its path, and the path of the leafref within the data
+// tree.
+type leafrefTask struct {
+	ni   *util.NodeInfo
+	memo *util.PathQueryNodeMemo
+	path string
+}
+
+// copyNodeInfo returns a copy of ni and each of its ancestors. util.Walk
+// modifies the NodeInfo of a field that has multiple schema paths whilst
+// traversing each of them, hence a copy is retained where a node is to be
+// processed after the traversal.
+func copyNodeInfo(ni *util.NodeInfo) *util.NodeInfo {
+	if ni == nil {
+		return nil
+	}
+	c := *ni
+	c.Parent = copyNodeInfo(ni.Parent)
+	return &c
+}
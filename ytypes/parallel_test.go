@@ -0,0 +1,130 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// parallelTestData returns a data tree for the schema returned by
+// xpathTestSchema with many interfaces, subinterfaces and BGP neighbors, some
+// of which are invalid.
+func parallelTestData() *xpathTestDevice {
+	d := &xpathTestDevice{
+		Interface: map[string]*xpathTestInterface{},
+		Bgp: &xpathTestBgp{
+			As:       ygot.Uint32(64512),
+			Neighbor: map[string]*xpathTestNeighbor{},
+		},
+	}
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("eth%d", i)
+		intf := &xpathTestInterface{
+			Name:         ygot.String(name),
+			Mtu:          ygot.Uint16(uint16(1500 + i*200)),
+			Type:         xpathTestEthernet,
+			Subinterface: map[uint32]*xpathTestSubinterface{},
+		}
+		for j := uint32(0); j < 20; j++ {
+			intf.Subinterface[j] = &xpathTestSubinterface{Index: ygot.Uint32(j)}
+		}
+		d.Interface[name] = intf
+
+		addr := fmt.Sprintf("192.0.2.%d", i)
+		d.Bgp.Neighbor[addr] = &xpathTestNeighbor{
+			NeighborAddress: ygot.String(addr),
+			PeerAs:          ygot.Uint32(64513),
+			// Every fifth neighbor refers to an interface that does
+			// not exist.
+			Interface: ygot.String(fmt.Sprintf("eth%d", i+i%5*100)),
+		}
+	}
+	return d
+}
+
+func TestValidateParallel(t *testing.T) {
+	schema := xpathTestSchema(func(root *yang.Entry) {
+		root.Dir["interfaces"].Dir["interface"].Dir["config"].Dir["mtu"].Type.Range = yang.YangRange{{Min: yang.FromInt(68), Max: yang.FromInt(9000)}}
+	})
+	d := parallelTestData()
+
+	want := sortedErrStrings(Validate(schema, d))
+	if len(want) == 0 {
+		t.Fatalf("Validate(): got no errors, test data should produce errors")
+	}
+
+	var first []string
+	for _, workers := range []int{0, 1, 4, 16} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			var mu sync.Mutex
+			calls := 0
+			r := NewCustomValidatorRegistry()
+			r.Register("/interfaces/interface/subinterfaces/subinterface", func(interface{}, ygot.GoStruct, *gpb.Path) error {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+				return nil
+			})
+
+			errs := Validate(schema, d, &ParallelOptions{Workers: workers}, &CustomValidationOptions{Validators: r})
+			if diff := cmp.Diff(want, sortedErrStrings(errs)); diff != "" {
+				t.Errorf("Validate(): did not get expected errors, (-want, +got):\n%s", diff)
+			}
+			if calls != 1000 {
+				t.Errorf("Validate(): got %d custom validator calls, want 1000", calls)
+			}
+
+			// The errors must be returned in the same order irrespective
+			// of the number of workers.
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if first == nil {
+				first = got
+				return
+			}
+			if diff := cmp.Diff(first, got); diff != "" {
+				t.Errorf("Validate(): errors not returned in a deterministic order, (-first, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePoolEach(t *testing.T) {
+	p := newValidatePool(3)
+	var mu sync.Mutex
+	var got int
+	// Nested calls run in the calling goroutine where the pool is
+	// exhausted, rather than blocking.
+	p.each(10, func(int) {
+		p.each(10, func(int) {
+			mu.Lock()
+			defer mu.Unlock()
+			got++
+		})
+	})
+	if got != 100 {
+		t.Errorf("each(): got %d calls, want 100", got)
+	}
+}
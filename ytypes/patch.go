@@ -0,0 +1,830 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// UnmarshalJSONPatch applies a JSON Patch, as per RFC 6902, to the RFC 7951
+// JSON rendering of the root GoStruct specified by "schema", and unmarshals
+// the result into it using the supplied opts. It *does not* perform
+// validation after unmarshalling is complete.
+//
+// The patch is applied atomically: if any of its operations fails, including
+// a failed test operation, schema.Root is not modified.
+func UnmarshalJSONPatch(schema *Schema, patch ygot.JSONPatch, opts ...UnmarshalOpt) error {
+	return unmarshalPatch(schema, func(doc any) (any, error) {
+		for i, op := range patch {
+			var err error
+			if doc, err = applyJSONPatchOp(doc, op); err != nil {
+				return nil, fmt.Errorf("operation %d (%s %q): %v", i, op.Op, op.Path, err)
+			}
+		}
+		return doc, nil
+	}, opts)
+}
+
+// UnmarshalYANGPatch applies a YANG Patch, as per RFC 8072, to the root
+// GoStruct specified by "schema", using the supplied opts to unmarshal the
+// patched data. It *does not* perform validation after unmarshalling is
+// complete. The targets of the edits are relative to the root. The containers
+// and list entries that contain the target of a create, merge, replace or
+// insert edit are created where they do not exist, such that a list entry is
+// created with only its key leaves, whose values are those of the target.
+//
+// The patch is applied atomically: if any of its edits fails, schema.Root is
+// not modified.
+func UnmarshalYANGPatch(schema *Schema, patch *ygot.YANGPatch, opts ...UnmarshalOpt) error {
+	if patch == nil {
+		return fmt.Errorf("nil YANG Patch")
+	}
+	return unmarshalPatch(schema, func(doc any) (any, error) {
+		root, ok := doc.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid root %v", doc)
+		}
+		for _, e := range patch.Edits {
+			if err := applyYANGPatchEdit(schema.RootSchema(), root, e); err != nil {
+				return nil, fmt.Errorf("edit %q: %v", e.EditID, err)
+			}
+		}
+		return root, nil
+	}, opts)
+}
+
+// unmarshalPatch applies a patch to the RFC 7951 JSON rendering of
+// schema.Root using apply, and replaces schema.Root with the result of
+// unmarshalling the patched document.
+func unmarshalPatch(schema *Schema, apply func(any) (any, error), opts []UnmarshalOpt) error {
+	if schema == nil || !schema.IsValid() {
+		return fmt.Errorf("invalid schema")
+	}
+	j, err := ygot.ConstructIETFJSON(schema.Root, &ygot.RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return fmt.Errorf("cannot render root: %v", err)
+	}
+	doc, err := normalizeJSON(j)
+	if err != nil {
+		return fmt.Errorf("cannot render root: %v", err)
+	}
+	if doc, err = apply(doc); err != nil {
+		return err
+	}
+	js, err := json.Marshal(pruneEmptyJSON(schema.RootSchema(), doc))
+	if err != nil {
+		return fmt.Errorf("cannot marshal patched data: %v", err)
+	}
+	root := reflect.New(reflect.TypeOf(schema.Root).Elem()).Interface().(ygot.GoStruct)
+	if err := schema.Unmarshal(js, root, opts...); err != nil {
+		return fmt.Errorf("cannot unmarshal patched data: %v", err)
+	}
+	reflect.ValueOf(schema.Root).Elem().Set(reflect.ValueOf(root).Elem())
+	return nil
+}
+
+// normalizeJSON returns the value that results from marshalling v to JSON and
+// unmarshalling it, such that it is a deep copy of v that consists of the
+// types used by encoding/json.
+func normalizeJSON(v any) (any, error) {
+	js, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(js, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// pruneEmptyJSON removes the members of the objects within v, whose schema is
+// e, whose values are empty objects or arrays, after pruning those values, and
+// returns v. Empty objects are retained where they are the value of a presence
+// container, since their existence is meaningful.
+func pruneEmptyJSON(e *yang.Entry, v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, c := range t {
+			var ce *yang.Entry
+			if e != nil {
+				ce = util.FirstChild(e, []string{k})
+			}
+			switch pc := pruneEmptyJSON(ce, c).(type) {
+			case map[string]any:
+				if len(pc) == 0 && (ce == nil || !isPresenceContainer(ce)) {
+					delete(t, k)
+				}
+			case []any:
+				if len(pc) == 0 {
+					delete(t, k)
+				}
+			}
+		}
+	case []any:
+		for _, c := range t {
+			pruneEmptyJSON(e, c)
+		}
+	}
+	return v
+}
+
+// parseJSONPointer returns the reference tokens of the JSON Pointer ptr, as
+// per RFC 6901.
+func parseJSONPointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil, fmt.Errorf("invalid JSON Pointer %q", ptr)
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// jsonArrayIndex returns the index specified by the reference token tok
+// within an array of length n. The token "-" specifies n, which is only
+// allowed where end is true.
+func jsonArrayIndex(tok string, n int, end bool) (int, error) {
+	if tok == "-" && end {
+		return n, nil
+	}
+	i, err := strconv.Atoi(tok)
+	if err != nil || strconv.Itoa(i) != tok || i < 0 {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+	if i > n || (i == n && !end) {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+// jsonPointerGet returns the value within v referenced by tokens.
+func jsonPointerGet(v any, tokens []string) (any, error) {
+	for _, tok := range tokens {
+		switch t := v.(type) {
+		case map[string]any:
+			c, ok := t[tok]
+			if !ok {
+				return nil, fmt.Errorf("member %q does not exist", tok)
+			}
+			v = c
+		case []any:
+			i, err := jsonArrayIndex(tok, len(t), false)
+			if err != nil {
+				return nil, err
+			}
+			v = t[i]
+		default:
+			return nil, fmt.Errorf("cannot reference %q within a scalar value", tok)
+		}
+	}
+	return v, nil
+}
+
+// jsonPointerUpdate calls f with the value within v that contains the value
+// referenced by tokens, which must not be empty, and the last of the tokens,
+// and returns v with the contained value replaced by the result of f.
+func jsonPointerUpdate(v any, tokens []string, f func(parent any, tok string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return f(v, tokens[0])
+	}
+	c, err := jsonPointerGet(v, tokens[:1])
+	if err != nil {
+		return nil, err
+	}
+	nc, err := jsonPointerUpdate(c, tokens[1:], f)
+	if err != nil {
+		return nil, err
+	}
+	switch t := v.(type) {
+	case map[string]any:
+		t[tokens[0]] = nc
+	case []any:
+		i, _ := jsonArrayIndex(tokens[0], len(t), false)
+		t[i] = nc
+	}
+	return v, nil
+}
+
+// jsonPointerAdd adds value to v at the location referenced by tokens.
+func jsonPointerAdd(v any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return jsonPointerUpdate(v, tokens, func(parent any, tok string) (any, error) {
+		switch t := parent.(type) {
+		case map[string]any:
+			t[tok] = value
+			return t, nil
+		case []any:
+			i, err := jsonArrayIndex(tok, len(t), true)
+			if err != nil {
+				return nil, err
+			}
+			return append(t[:i], append([]any{value}, t[i:]...)...), nil
+		}
+		return nil, fmt.Errorf("cannot add %q to a scalar value", tok)
+	})
+}
+
+// jsonPointerRemove removes the value referenced by tokens from v.
+func jsonPointerRemove(v any, tokens []string) (any, error) {
+	if len(tokens) == 0 {
+		return map[string]any{}, nil
+	}
+	return jsonPointerUpdate(v, tokens, func(parent any, tok string) (any, error) {
+		switch t := parent.(type) {
+		case map[string]any:
+			if _, ok := t[tok]; !ok {
+				return nil, fmt.Errorf("member %q does not exist", tok)
+			}
+			delete(t, tok)
+			return t, nil
+		case []any:
+			i, err := jsonArrayIndex(tok, len(t), false)
+			if err != nil {
+				return nil, err
+			}
+			return append(t[:i], t[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("cannot remove %q from a scalar value", tok)
+	})
+}
+
+// applyJSONPatchOp applies the JSON Patch operation op to doc, and returns the
+// patched document.
+func applyJSONPatchOp(doc any, op *ygot.JSONPatchOperation) (any, error) {
+	path, err := parseJSONPointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	value, err := normalizeJSON(op.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %v", err)
+	}
+
+	switch op.Op {
+	case ygot.JSONPatchAdd:
+		return jsonPointerAdd(doc, path, value)
+	case ygot.JSONPatchRemove:
+		return jsonPointerRemove(doc, path)
+	case ygot.JSONPatchReplace:
+		if doc, err = jsonPointerRemove(doc, path); err != nil {
+			return nil, err
+		}
+		return jsonPointerAdd(doc, path, value)
+	case ygot.JSONPatchMove, ygot.JSONPatchCopy:
+		from, err := parseJSONPointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		v, err := jsonPointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == ygot.JSONPatchCopy {
+			if v, err = normalizeJSON(v); err != nil {
+				return nil, err
+			}
+			return jsonPointerAdd(doc, path, v)
+		}
+		if op.Path != op.From && strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("cannot move %q into one of its children", op.From)
+		}
+		if doc, err = jsonPointerRemove(doc, from); err != nil {
+			return nil, err
+		}
+		return jsonPointerAdd(doc, path, v)
+	case ygot.JSONPatchTest:
+		v, err := jsonPointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(v, value) {
+			return nil, fmt.Errorf("test failed, got %v, want %v", v, value)
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("unknown operation %q", op.Op)
+}
+
+// restconfSegment is a segment of an RFC 8040 data resource identifier.
+type restconfSegment struct {
+	// name is the name of the data node, which may be module-qualified.
+	name string
+	// keys are the values of the keys of a list entry, or the value of a
+	// leaf-list.
+	keys []string
+}
+
+// parseRESTCONFTarget parses the data resource identifier t.
+func parseRESTCONFTarget(t string) ([]*restconfSegment, error) {
+	if !strings.HasPrefix(t, "/") || t == "/" {
+		return nil, fmt.Errorf("invalid target %q", t)
+	}
+	var segs []*restconfSegment
+	for _, s := range strings.Split(t[1:], "/") {
+		name, keys, hasKeys := strings.Cut(s, "=")
+		if name == "" {
+			return nil, fmt.Errorf("invalid target %q", t)
+		}
+		seg := &restconfSegment{name: name}
+		if hasKeys {
+			for _, k := range strings.Split(keys, ",") {
+				uk, err := url.PathUnescape(k)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key %q within target %q: %v", k, t, err)
+				}
+				seg.keys = append(seg.keys, uk)
+			}
+		}
+		segs = append(segs, seg)
+	}
+	return segs, nil
+}
+
+// jsonMember returns the name of the member of obj for the data node name,
+// which may be module-qualified, and whether it exists. If it does not exist,
+// name is returned.
+func jsonMember(obj map[string]any, name string) (string, bool) {
+	if _, ok := obj[name]; ok {
+		return name, true
+	}
+	n := util.StripModulePrefix(name)
+	for k := range obj {
+		if util.StripModulePrefix(k) == n {
+			return k, true
+		}
+	}
+	return name, false
+}
+
+// jsonScalarString returns the string form of the JSON scalar v, as it is
+// written as a key value within a data resource identifier.
+func jsonScalarString(v any) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// jsonKeyEqual reports whether the JSON scalar v is equal to the key value k.
+func jsonKeyEqual(v any, k string) bool {
+	s := jsonScalarString(v)
+	return s == k || util.StripModulePrefix(s) == util.StripModulePrefix(k)
+}
+
+// jsonListIndex returns the index of the element of the JSON array arr, which
+// is the value of the list or leaf-list e, with the supplied keys, or -1 if
+// there is none.
+func jsonListIndex(e *yang.Entry, arr []any, keys []string) int {
+	for i, v := range arr {
+		if jsonEntryHasKeys(e, v, keys) {
+			return i
+		}
+	}
+	return -1
+}
+
+// jsonEntryHasKeys reports whether the element v of the value of the list or
+// leaf-list e has the supplied keys.
+func jsonEntryHasKeys(e *yang.Entry, v any, keys []string) bool {
+	if e.IsLeafList() {
+		return len(keys) == 1 && jsonKeyEqual(v, keys[0])
+	}
+	names := strings.Fields(e.Key)
+	obj, ok := v.(map[string]any)
+	if !ok || len(names) != len(keys) {
+		return false
+	}
+	for i, n := range names {
+		m, ok := jsonMember(obj, n)
+		if !ok || !jsonKeyEqual(obj[m], keys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonEntryKeys returns the values of the keys of the element v of the value
+// of the list e.
+func jsonEntryKeys(e *yang.Entry, v any) []string {
+	obj, _ := v.(map[string]any)
+	var keys []string
+	for _, n := range strings.Fields(e.Key) {
+		m, _ := jsonMember(obj, n)
+		keys = append(keys, jsonScalarString(obj[m]))
+	}
+	return keys
+}
+
+// jsonKeysEntry returns an element of the value of the list e that contains
+// only its key leaves, whose values are keys as written within a data resource
+// identifier.
+func jsonKeysEntry(e *yang.Entry, keys []string) (map[string]any, error) {
+	names := strings.Fields(e.Key)
+	if len(names) != len(keys) {
+		return nil, fmt.Errorf("got %d keys, want %d", len(keys), len(names))
+	}
+	v := map[string]any{}
+	for i, n := range names {
+		ke := e.Dir[n]
+		if ke == nil {
+			return nil, fmt.Errorf("key %q is not a child of the list", n)
+		}
+		v[n] = jsonKeyValue(ke, keys[i])
+	}
+	return v, nil
+}
+
+// jsonKeyValue returns the RFC 7951 JSON value of the key leaf e, whose value
+// is k as written within a data resource identifier. The values of integer
+// types of up to 32 bits and booleans are JSON numbers and literals, while
+// those of other types, including 64-bit integers and decimal64, are strings.
+func jsonKeyValue(e *yang.Entry, k string) any {
+	e, err := util.ResolveIfLeafRef(e)
+	if err != nil || e.Type == nil {
+		return k
+	}
+	for _, t := range util.FlattenedTypes([]*yang.YangType{e.Type}) {
+		switch t.Kind {
+		case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+			if f, err := strconv.ParseFloat(k, 64); err == nil {
+				return f
+			}
+		case yang.Ybool:
+			if k == "true" || k == "false" {
+				return k == "true"
+			}
+		}
+	}
+	return k
+}
+
+// yangPatchTarget is the location of the target of a YANG Patch edit within an
+// RFC 7951 JSON document.
+type yangPatchTarget struct {
+	// entry is the schema of the target.
+	entry *yang.Entry
+	// parent is the object that contains the target, which is nil if it
+	// does not exist.
+	parent map[string]any
+	// member is the name of the member of parent for the target.
+	member string
+	// keys are the keys of the target, which are set if it is a list entry
+	// or leaf-list value.
+	keys []string
+	// index is the index of the target within the array that is the value
+	// of member, or -1 if it does not exist.
+	index int
+}
+
+// isListElement reports whether the target is a list entry or leaf-list
+// value.
+func (t *yangPatchTarget) isListElement() bool {
+	return t.entry.IsList() || t.entry.IsLeafList()
+}
+
+// array returns the array that contains the target, if it is a list entry or
+// leaf-list value.
+func (t *yangPatchTarget) array() []any {
+	if t.parent == nil {
+		return nil
+	}
+	arr, _ := t.parent[t.member].([]any)
+	return arr
+}
+
+// exists reports whether the target exists.
+func (t *yangPatchTarget) exists() bool {
+	if t.parent == nil {
+		return false
+	}
+	if t.isListElement() {
+		return t.index >= 0
+	}
+	_, ok := t.parent[t.member]
+	return ok
+}
+
+// get returns the value of the target, which must exist.
+func (t *yangPatchTarget) get() any {
+	if t.isListElement() {
+		return t.array()[t.index]
+	}
+	return t.parent[t.member]
+}
+
+// set sets the value of the target to v, appending it to its array if it is
+// a list entry or leaf-list value that does not exist.
+func (t *yangPatchTarget) set(v any) {
+	if !t.isListElement() {
+		t.parent[t.member] = v
+		return
+	}
+	arr := t.array()
+	if t.index < 0 {
+		t.index = len(arr)
+		arr = append(arr, v)
+	} else {
+		arr[t.index] = v
+	}
+	t.parent[t.member] = arr
+}
+
+// remove removes the target, which must exist.
+func (t *yangPatchTarget) remove() {
+	if !t.isListElement() {
+		delete(t.parent, t.member)
+		return
+	}
+	arr := t.array()
+	t.parent[t.member] = append(arr[:t.index], arr[t.index+1:]...)
+	t.index = -1
+}
+
+// insert inserts v into the array of the target, which must be a list entry
+// or leaf-list value that does not exist, at index i.
+func (t *yangPatchTarget) insert(v any, i int) {
+	arr := t.array()
+	t.parent[t.member] = append(arr[:i], append([]any{v}, arr[i:]...)...)
+	t.index = i
+}
+
+// resolveYANGPatchTarget returns the location of the data resource identifier
+// target within the JSON object root, whose schema is schema. The containers
+// and list entries that contain the target are created where create is true
+// and they do not exist, such that a list entry is created with only its key
+// leaves, whose values are those within target.
+func resolveYANGPatchTarget(schema *yang.Entry, root map[string]any, target string, create bool) (*yangPatchTarget, error) {
+	segs, err := parseRESTCONFTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*yang.Entry
+	for i, seg := range segs {
+		e := util.FirstChild(schema, []string{seg.name})
+		switch {
+		case e == nil:
+			return nil, fmt.Errorf("%s: %q is not a child of %s", target, seg.name, schema.Name)
+		case (e.IsList() || e.IsLeafList()) != (seg.keys != nil):
+			return nil, fmt.Errorf("%s: keys are required for, and only for, list entries and leaf-list values: %q", target, seg.name)
+		case i < len(segs)-1 && (e.IsLeaf() || e.IsLeafList()):
+			return nil, fmt.Errorf("%s: %q is not a container or list", target, seg.name)
+		}
+		entries = append(entries, e)
+		schema = e
+	}
+
+	obj := root
+	for i, seg := range segs[:len(segs)-1] {
+		m, ok := jsonMember(obj, seg.name)
+		if entries[i].IsList() {
+			var arr []any
+			if ok {
+				arr, _ = obj[m].([]any)
+			}
+			idx := jsonListIndex(entries[i], arr, seg.keys)
+			if idx < 0 {
+				if !create {
+					return &yangPatchTarget{entry: entries[len(entries)-1], index: -1}, nil
+				}
+				v, err := jsonKeysEntry(entries[i], seg.keys)
+				if err != nil {
+					return nil, fmt.Errorf("%s: cannot create list entry %s: %v", target, seg.name, err)
+				}
+				idx = len(arr)
+				obj[m] = append(arr, v)
+				arr = obj[m].([]any)
+			}
+			if obj, ok = arr[idx].(map[string]any); !ok {
+				return nil, fmt.Errorf("%s: invalid list entry %v", target, arr[idx])
+			}
+			continue
+		}
+		if !ok {
+			if !create {
+				return &yangPatchTarget{entry: entries[len(entries)-1], index: -1}, nil
+			}
+			obj[m] = map[string]any{}
+		}
+		if obj, ok = obj[m].(map[string]any); !ok {
+			return nil, fmt.Errorf("%s: invalid container %v", target, obj[m])
+		}
+	}
+
+	seg := segs[len(segs)-1]
+	t := &yangPatchTarget{entry: entries[len(entries)-1], parent: obj, keys: seg.keys, index: -1}
+	var ok bool
+	t.member, ok = jsonMember(obj, seg.name)
+	if ok && t.isListElement() {
+		t.index = jsonListIndex(t.entry, t.array(), seg.keys)
+	}
+	return t, nil
+}
+
+// yangPatchValue returns the value of the target t within the value v of a
+// YANG Patch edit, which is an object whose only member is the target.
+func yangPatchValue(t *yangPatchTarget, target string, v any) (any, error) {
+	v, err := normalizeJSON(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid value: %v", target, err)
+	}
+	obj, ok := v.(map[string]any)
+	if !ok || len(obj) != 1 {
+		return nil, fmt.Errorf("%s: value must be an object with a single member, got %v", target, v)
+	}
+	m, ok := jsonMember(obj, t.entry.Name)
+	if !ok {
+		return nil, fmt.Errorf("%s: value does not contain %q", target, t.entry.Name)
+	}
+	val := obj[m]
+	if !t.isListElement() {
+		return val, nil
+	}
+	arr, ok := val.([]any)
+	if !ok || len(arr) != 1 {
+		return nil, fmt.Errorf("%s: value of list entry or leaf-list value must be an array with a single element, got %v", target, val)
+	}
+	if !jsonEntryHasKeys(t.entry, arr[0], t.keys) {
+		return nil, fmt.Errorf("%s: value %v does not match the keys of the target", target, arr[0])
+	}
+	return arr[0], nil
+}
+
+// yangPatchPosition returns the index at which the target t is inserted or
+// moved by the edit e.
+func yangPatchPosition(t *yangPatchTarget, e *ygot.YANGPatchEdit) (int, error) {
+	arr := t.array()
+	switch e.Where {
+	case "", ygot.YANGPatchLast:
+		return len(arr), nil
+	case ygot.YANGPatchFirst:
+		return 0, nil
+	case ygot.YANGPatchBefore, ygot.YANGPatchAfter:
+		segs, err := parseRESTCONFTarget(e.Point)
+		if err != nil {
+			return 0, fmt.Errorf("invalid point: %v", err)
+		}
+		seg := segs[len(segs)-1]
+		if util.StripModulePrefix(seg.name) != t.entry.Name {
+			return 0, fmt.Errorf("point %q is not within the list of the target", e.Point)
+		}
+		i := jsonListIndex(t.entry, arr, seg.keys)
+		if i < 0 {
+			return 0, fmt.Errorf("point %q does not exist", e.Point)
+		}
+		if e.Where == ygot.YANGPatchAfter {
+			i++
+		}
+		return i, nil
+	}
+	return 0, fmt.Errorf("invalid where %q", e.Where)
+}
+
+// applyYANGPatchEdit applies the YANG Patch edit e to the JSON object root,
+// whose schema is schema.
+func applyYANGPatchEdit(schema *yang.Entry, root map[string]any, e *ygot.YANGPatchEdit) error {
+	create := false
+	switch e.Operation {
+	case ygot.YANGPatchCreate, ygot.YANGPatchMerge, ygot.YANGPatchReplace, ygot.YANGPatchInsert:
+		create = true
+	}
+	t, err := resolveYANGPatchTarget(schema, root, e.Target, create)
+	if err != nil {
+		return err
+	}
+
+	switch e.Operation {
+	case ygot.YANGPatchDelete:
+		if !t.exists() {
+			return fmt.Errorf("%s: data does not exist", e.Target)
+		}
+		t.remove()
+		return nil
+	case ygot.YANGPatchRemove:
+		if t.exists() {
+			t.remove()
+		}
+		return nil
+	case ygot.YANGPatchMove:
+		if !t.isListElement() || !t.entry.ListAttr.OrderedByUser {
+			return fmt.Errorf("%s: cannot move data that is not within an ordered-by user list or leaf-list", e.Target)
+		}
+		if !t.exists() {
+			return fmt.Errorf("%s: data does not exist", e.Target)
+		}
+		v := t.get()
+		t.remove()
+		i, err := yangPatchPosition(t, e)
+		if err != nil {
+			return err
+		}
+		t.insert(v, i)
+		return nil
+	}
+
+	v, err := yangPatchValue(t, e.Target, e.Value)
+	if err != nil {
+		return err
+	}
+	switch e.Operation {
+	case ygot.YANGPatchCreate:
+		if t.exists() {
+			return fmt.Errorf("%s: data already exists", e.Target)
+		}
+		t.set(v)
+	case ygot.YANGPatchMerge:
+		if t.exists() {
+			v = mergeJSON(t.entry, t.get(), v)
+		}
+		t.set(v)
+	case ygot.YANGPatchReplace:
+		t.set(v)
+	case ygot.YANGPatchInsert:
+		if !t.isListElement() || !t.entry.ListAttr.OrderedByUser {
+			return fmt.Errorf("%s: cannot insert data that is not within an ordered-by user list or leaf-list", e.Target)
+		}
+		if t.exists() {
+			return fmt.Errorf("%s: data already exists", e.Target)
+		}
+		i, err := yangPatchPosition(t, e)
+		if err != nil {
+			return err
+		}
+		t.insert(v, i)
+	default:
+		return fmt.Errorf("%s: unknown operation %q", e.Target, e.Operation)
+	}
+	return nil
+}
+
+// mergeJSON merges the JSON value src into dst, whose schema is e, and
+// returns the merged value. The entries of lists are merged by their keys,
+// and the values of leaf-lists that are not within dst are appended to it.
+func mergeJSON(e *yang.Entry, dst, src any) any {
+	dm, ok := dst.(map[string]any)
+	sm, ok2 := src.(map[string]any)
+	if !ok || !ok2 {
+		return src
+	}
+	for k, sv := range sm {
+		dk, ok := jsonMember(dm, k)
+		if !ok {
+			dm[k] = sv
+			continue
+		}
+		var ce *yang.Entry
+		if e != nil {
+			ce = util.FirstChild(e, []string{k})
+		}
+		da, ok := dm[dk].([]any)
+		sa, ok2 := sv.([]any)
+		switch {
+		case ce != nil && ok && ok2 && ce.IsList():
+			for _, s := range sa {
+				if i := jsonListIndex(ce, da, jsonEntryKeys(ce, s)); i >= 0 {
+					da[i] = mergeJSON(ce, da[i], s)
+				} else {
+					da = append(da, s)
+				}
+			}
+			dm[dk] = da
+		case ce != nil && ok && ok2 && ce.IsLeafList():
+			for _, s := range sa {
+				if jsonListIndex(ce, da, []string{jsonScalarString(s)}) < 0 {
+					da = append(da, s)
+				}
+			}
+			dm[dk] = da
+		default:
+			dm[dk] = mergeJSON(ce, dm[dk], sv)
+		}
+	}
+	return dm
+}
@@ -0,0 +1,578 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/internal/ytestutil"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+// patchOrderedMap returns an ordered map with entries of the supplied keys,
+// whose values are the keys with a "-val" suffix.
+func patchOrderedMap(t *testing.T, keys ...string) *ctestschema.OrderedList_OrderedMap {
+	om := &ctestschema.OrderedList_OrderedMap{}
+	for _, k := range keys {
+		v, err := om.AppendNew(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v.Value = ygot.String(k + "-val")
+	}
+	return om
+}
+
+func TestUnmarshalYANGPatch(t *testing.T) {
+	with := func(f func(*ctestschema.Device)) *ctestschema.Device {
+		d := inverseTestRoot(t)
+		f(d)
+		return d
+	}
+	entryValue := func(member, k string) map[string]any {
+		return map[string]any{
+			member: []any{map[string]any{
+				"config": map[string]any{"key": k, "value": k + "-val"},
+				"key":    k,
+			}},
+		}
+	}
+
+	tests := []struct {
+		desc    string
+		inEdits []*ygot.YANGPatchEdit
+		want    *ctestschema.Device
+		wantErr string
+	}{{
+		desc: "merge leaf into container that does not exist",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchRemove,
+			Target:    "/ctestschema:other-data",
+		}, {
+			EditID:    "2",
+			Operation: ygot.YANGPatchMerge,
+			Target:    "/ctestschema:other-data/config/motd",
+			Value:     map[string]any{"ctestschema:motd": "world"},
+		}},
+		want: with(func(d *ctestschema.Device) { d.OtherData.Motd = ygot.String("world") }),
+	}, {
+		desc: "merge leaf into list entry that does not exist",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchMerge,
+			Target:    "/ctestschema:unordered-lists/unordered-list=bar/config/value",
+			Value:     map[string]any{"ctestschema:value": "new"},
+		}},
+		want: with(func(d *ctestschema.Device) {
+			d.UnorderedList["bar"] = &ctestschema.UnorderedList{Key: ygot.String("bar"), Value: ygot.String("new")}
+		}),
+	}, {
+		desc: "create leaf within multi-keyed list entry that does not exist",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchCreate,
+			Target:    "/ctestschema-rootmod:ordered-multikeyed-lists/ordered-multikeyed-list=foo,42/config/value",
+			Value:     map[string]any{"ctestschema:value": "new"},
+		}},
+		want: with(func(d *ctestschema.Device) {
+			d.OrderedMultikeyedList = &ctestschema.OrderedMultikeyedList_OrderedMap{}
+			v, err := d.OrderedMultikeyedList.AppendNew("foo", 42)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.Value = ygot.String("new")
+		}),
+	}, {
+		desc: "remove leaf within list entry that does not exist",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchRemove,
+			Target:    "/ctestschema:unordered-lists/unordered-list=bar/config/value",
+		}},
+		want: inverseTestRoot(t),
+	}, {
+		desc: "merge list entry into existing entry",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchMerge,
+			Target:    "/ctestschema:unordered-lists/unordered-list=foo",
+			Value: map[string]any{
+				"ctestschema:unordered-list": []any{map[string]any{
+					"config": map[string]any{"key": "foo", "value": "new"},
+					"key":    "foo",
+				}},
+			},
+		}, {
+			EditID:    "2",
+			Operation: ygot.YANGPatchMerge,
+			Target:    "/ctestschema:unordered-lists",
+			Value:     map[string]any{"ctestschema:unordered-lists": entryValue("unordered-list", "bar")},
+		}},
+		want: with(func(d *ctestschema.Device) {
+			d.UnorderedList["foo"].Value = ygot.String("new")
+			d.UnorderedList["bar"] = &ctestschema.UnorderedList{Key: ygot.String("bar"), Value: ygot.String("bar-val")}
+		}),
+	}, {
+		desc: "create, replace and delete",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchCreate,
+			Target:    "/ctestschema:unordered-lists/unordered-list=bar",
+			Value:     entryValue("ctestschema:unordered-list", "bar"),
+		}, {
+			EditID:    "2",
+			Operation: ygot.YANGPatchReplace,
+			Target:    "/ctestschema:unordered-lists/unordered-list=foo/config",
+			Value:     map[string]any{"ctestschema:config": map[string]any{"key": "foo"}},
+		}, {
+			EditID:    "3",
+			Operation: ygot.YANGPatchDelete,
+			Target:    "/ctestschema:other-data/config/motd",
+		}},
+		want: with(func(d *ctestschema.Device) {
+			d.OtherData = nil
+			d.UnorderedList["foo"].Value = nil
+			d.UnorderedList["bar"] = &ctestschema.UnorderedList{Key: ygot.String("bar"), Value: ygot.String("bar-val")}
+		}),
+	}, {
+		desc: "insert and move within ordered list",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchInsert,
+			Target:    "/ctestschema:ordered-lists/ordered-list=baz",
+			Where:     ygot.YANGPatchBefore,
+			Point:     "/ctestschema:ordered-lists/ordered-list=bar",
+			Value:     entryValue("ctestschema:ordered-list", "baz"),
+		}, {
+			EditID:    "2",
+			Operation: ygot.YANGPatchInsert,
+			Target:    "/ctestschema:ordered-lists/ordered-list=qux",
+			Value:     entryValue("ctestschema:ordered-list", "qux"),
+		}, {
+			EditID:    "3",
+			Operation: ygot.YANGPatchMove,
+			Target:    "/ctestschema:ordered-lists/ordered-list=foo",
+			Where:     ygot.YANGPatchAfter,
+			Point:     "/ctestschema:ordered-lists/ordered-list=bar",
+		}, {
+			EditID:    "4",
+			Operation: ygot.YANGPatchMove,
+			Target:    "/ctestschema:ordered-lists/ordered-list=qux",
+			Where:     ygot.YANGPatchFirst,
+		}},
+		want: with(func(d *ctestschema.Device) { d.OrderedList = patchOrderedMap(t, "qux", "baz", "bar", "foo") }),
+	}, {
+		desc: "create existing data",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchCreate,
+			Target:    "/ctestschema:other-data/config/motd",
+			Value:     map[string]any{"ctestschema:motd": "world"},
+		}},
+		wantErr: `edit "1": /ctestschema:other-data/config/motd: data already exists`,
+	}, {
+		desc: "delete data that does not exist",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchMerge,
+			Target:    "/ctestschema:other-data/config/motd",
+			Value:     map[string]any{"ctestschema:motd": "world"},
+		}, {
+			EditID:    "2",
+			Operation: ygot.YANGPatchDelete,
+			Target:    "/ctestschema:unordered-lists/unordered-list=bar/config/value",
+		}},
+		wantErr: `edit "2"`,
+	}, {
+		desc: "insert into unordered list",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchInsert,
+			Target:    "/ctestschema:unordered-lists/unordered-list=bar",
+			Value:     entryValue("ctestschema:unordered-list", "bar"),
+		}},
+		wantErr: "not within an ordered-by user list",
+	}, {
+		desc: "move relative to point that does not exist",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchMove,
+			Target:    "/ctestschema:ordered-lists/ordered-list=foo",
+			Where:     ygot.YANGPatchBefore,
+			Point:     "/ctestschema:ordered-lists/ordered-list=baz",
+		}},
+		wantErr: "does not exist",
+	}, {
+		desc: "value does not match target keys",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchMerge,
+			Target:    "/ctestschema:unordered-lists/unordered-list=bar",
+			Value:     entryValue("ctestschema:unordered-list", "baz"),
+		}},
+		wantErr: "does not match the keys of the target",
+	}, {
+		desc: "unknown node",
+		inEdits: []*ygot.YANGPatchEdit{{
+			EditID:    "1",
+			Operation: ygot.YANGPatchRemove,
+			Target:    "/ctestschema:other-data/config/foo",
+		}},
+		wantErr: `"foo" is not a child of config`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema, err := ctestschema.Schema()
+			if err != nil {
+				t.Fatalf("cannot get schema: %v", err)
+			}
+			schema.Root = inverseTestRoot(t)
+
+			err = ytypes.UnmarshalYANGPatch(schema, &ygot.YANGPatch{PatchID: "patch", Edits: tt.inEdits})
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalYANGPatch: %s", diff)
+			}
+			want := tt.want
+			if err != nil {
+				want = inverseTestRoot(t)
+			}
+			if diff := cmp.Diff(want, schema.Root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Errorf("UnmarshalYANGPatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONPatch(t *testing.T) {
+	with := func(f func(*ctestschema.Device)) *ctestschema.Device {
+		d := inverseTestRoot(t)
+		f(d)
+		return d
+	}
+
+	tests := []struct {
+		desc    string
+		inPatch ygot.JSONPatch
+		want    *ctestschema.Device
+		wantErr string
+	}{{
+		desc: "add, replace and remove",
+		inPatch: ygot.JSONPatch{{
+			Op:    ygot.JSONPatchTest,
+			Path:  "/ctestschema:other-data/config/motd",
+			Value: "hello",
+		}, {
+			Op:    ygot.JSONPatchReplace,
+			Path:  "/ctestschema:other-data/config/motd",
+			Value: "world",
+		}, {
+			Op:   ygot.JSONPatchAdd,
+			Path: "/ctestschema:unordered-lists/unordered-list/-",
+			Value: map[string]any{
+				"config": map[string]any{"key": "bar"},
+				"key":    "bar",
+			},
+		}, {
+			Op:   ygot.JSONPatchRemove,
+			Path: "/ctestschema:unordered-lists/unordered-list/0/config/value",
+		}},
+		want: with(func(d *ctestschema.Device) {
+			d.OtherData.Motd = ygot.String("world")
+			d.UnorderedList["foo"].Value = nil
+			d.UnorderedList["bar"] = &ctestschema.UnorderedList{Key: ygot.String("bar")}
+		}),
+	}, {
+		desc: "move and copy",
+		inPatch: ygot.JSONPatch{{
+			Op:   ygot.JSONPatchMove,
+			Path: "/ctestschema:ordered-lists/ordered-list/0",
+			From: "/ctestschema:ordered-lists/ordered-list/1",
+		}, {
+			Op:   ygot.JSONPatchCopy,
+			Path: "/ctestschema:ordered-lists/ordered-list/0/config/value",
+			From: "/ctestschema:other-data/config/motd",
+		}, {
+			Op:   ygot.JSONPatchRemove,
+			Path: "/ctestschema:other-data",
+		}},
+		want: with(func(d *ctestschema.Device) {
+			d.OrderedList = patchOrderedMap(t, "bar", "foo")
+			d.OrderedList.Get("bar").Value = ygot.String("hello")
+			d.OtherData = nil
+		}),
+	}, {
+		desc: "failed test",
+		inPatch: ygot.JSONPatch{{
+			Op:   ygot.JSONPatchRemove,
+			Path: "/ctestschema:other-data",
+		}, {
+			Op:    ygot.JSONPatchTest,
+			Path:  "/ctestschema:unordered-lists/unordered-list/0/key",
+			Value: "bar",
+		}},
+		wantErr: "test failed",
+	}, {
+		desc: "remove member that does not exist",
+		inPatch: ygot.JSONPatch{{
+			Op:   ygot.JSONPatchRemove,
+			Path: "/ctestschema:unordered-lists/unordered-list/1",
+		}},
+		wantErr: `operation 0 (remove "/ctestschema:unordered-lists/unordered-list/1"): array index 1 out of range`,
+	}, {
+		desc: "patched data cannot be unmarshalled",
+		inPatch: ygot.JSONPatch{{
+			Op:    ygot.JSONPatchAdd,
+			Path:  "/ctestschema:other-data/config/foo",
+			Value: "bar",
+		}},
+		wantErr: "cannot unmarshal patched data",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema, err := ctestschema.Schema()
+			if err != nil {
+				t.Fatalf("cannot get schema: %v", err)
+			}
+			schema.Root = inverseTestRoot(t)
+
+			err = ytypes.UnmarshalJSONPatch(schema, tt.inPatch)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalJSONPatch: %s", diff)
+			}
+			want := tt.want
+			if err != nil {
+				want = inverseTestRoot(t)
+			}
+			if diff := cmp.Diff(want, schema.Root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+				t.Errorf("UnmarshalJSONPatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestPatchRoundTrip tests that the YANG Patch and JSON Patch rendered by
+// ygot from the differences between two GoStructs transform the first into
+// the second when they are applied to it.
+func TestPatchRoundTrip(t *testing.T) {
+	multikeyed := func() *ctestschema.Device {
+		return &ctestschema.Device{OrderedMultikeyedList: ctestschema.GetOrderedMapMultikeyed(t)}
+	}
+
+	tests := []struct {
+		desc   string
+		inOrig *ctestschema.Device
+		inMod  *ctestschema.Device
+	}{{
+		desc:   "identical",
+		inOrig: inverseTestRoot(t),
+		inMod:  inverseTestRoot(t),
+	}, {
+		desc:   "from empty",
+		inOrig: &ctestschema.Device{},
+		inMod:  inverseTestRoot(t),
+	}, {
+		desc:   "to empty",
+		inOrig: inverseTestRoot(t),
+		inMod:  &ctestschema.Device{},
+	}, {
+		desc:   "modified leaves and list entries",
+		inOrig: inverseTestRoot(t),
+		inMod: &ctestschema.Device{
+			OrderedList: patchOrderedMap(t, "bar", "baz"),
+			UnorderedList: map[string]*ctestschema.UnorderedList{
+				"foo": {Key: ygot.String("foo"), Value: ygot.String("new")},
+				"a/b": {Key: ygot.String("a/b")},
+			},
+		},
+	}, {
+		desc: "replaced keyed list entry",
+		inOrig: &ctestschema.Device{UnorderedList: map[string]*ctestschema.UnorderedList{
+			"a": {Key: ygot.String("a"), Value: ygot.String("a-val")},
+			"c": {Key: ygot.String("c"), Value: ygot.String("c-val")},
+		}},
+		inMod: &ctestschema.Device{UnorderedList: map[string]*ctestschema.UnorderedList{
+			"b": {Key: ygot.String("b")},
+			"c": {Key: ygot.String("c"), Value: ygot.String("new")},
+		}},
+	}, {
+		desc:   "reordered ordered list",
+		inOrig: &ctestschema.Device{OrderedList: patchOrderedMap(t, "a", "b", "c", "d", "e")},
+		inMod:  &ctestschema.Device{OrderedList: patchOrderedMap(t, "e", "c", "x", "a", "d")},
+	}, {
+		desc:   "nested ordered list",
+		inOrig: &ctestschema.Device{OrderedList: ctestschema.GetOrderedMap(t)},
+		inMod:  &ctestschema.Device{OrderedList: ctestschema.GetNestedOrderedMap(t)},
+	}, {
+		desc:   "multi-keyed ordered list",
+		inOrig: multikeyed(),
+		inMod: func() *ctestschema.Device {
+			d := multikeyed()
+			d.OrderedMultikeyedList.Delete(ctestschema.OrderedMultikeyedList_Key{Key1: "foo", Key2: 42})
+			v, err := d.OrderedMultikeyedList.AppendNew("foo", 42)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.Value = ygot.String("new")
+			return d
+		}(),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Run("YANG Patch", func(t *testing.T) {
+				schema, err := ctestschema.Schema()
+				if err != nil {
+					t.Fatalf("cannot get schema: %v", err)
+				}
+				orig, err := ygot.DeepCopy(tt.inOrig)
+				if err != nil {
+					t.Fatalf("cannot copy original: %v", err)
+				}
+				schema.Root = orig
+				patch, err := ygot.DiffYANGPatch(tt.inOrig, tt.inMod, "patch")
+				if err != nil {
+					t.Fatalf("DiffYANGPatch: %v", err)
+				}
+				if err := ytypes.UnmarshalYANGPatch(schema, patch); err != nil {
+					t.Fatalf("UnmarshalYANGPatch: %v", err)
+				}
+				if diff := cmp.Diff(tt.inMod, schema.Root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+					t.Errorf("applying YANG Patch did not result in modified struct (-want, +got):\n%s", diff)
+				}
+			})
+			t.Run("JSON Patch", func(t *testing.T) {
+				schema, err := ctestschema.Schema()
+				if err != nil {
+					t.Fatalf("cannot get schema: %v", err)
+				}
+				orig, err := ygot.DeepCopy(tt.inOrig)
+				if err != nil {
+					t.Fatalf("cannot copy original: %v", err)
+				}
+				schema.Root = orig
+				patch, err := ygot.DiffJSONPatch(tt.inOrig, tt.inMod)
+				if err != nil {
+					t.Fatalf("DiffJSONPatch: %v", err)
+				}
+				if err := ytypes.UnmarshalJSONPatch(schema, patch); err != nil {
+					t.Fatalf("UnmarshalJSONPatch: %v", err)
+				}
+				if diff := cmp.Diff(tt.inMod, schema.Root, ytestutil.OrderedMapCmpOptions...); diff != "" {
+					t.Errorf("applying JSON Patch did not result in modified struct (-want, +got):\n%s", diff)
+				}
+			})
+		})
+	}
+}
+
+// PatchPresenceRoot is the root of the schema returned by patchPresenceSchema.
+type PatchPresenceRoot struct {
+	Leaf *string                 `path:"leaf" module:"m"`
+	P    *PatchPresenceContainer `path:"p" module:"m" yangPresence:"true"`
+}
+
+func (*PatchPresenceRoot) IsYANGGoStruct()          {}
+func (*PatchPresenceRoot) ΛBelongingModule() string { return "" }
+
+// PatchPresenceContainer is a presence container within PatchPresenceRoot.
+type PatchPresenceContainer struct {
+	Value *string `path:"value" module:"m"`
+}
+
+func (*PatchPresenceContainer) IsYANGGoStruct()          {}
+func (*PatchPresenceContainer) ΛBelongingModule() string { return "m" }
+
+// patchPresenceSchema returns a schema whose root has a leaf and a presence
+// container, and whose Root has the presence container set but empty.
+func patchPresenceSchema() *ytypes.Schema {
+	root := &yang.Entry{
+		Name:       "device",
+		Kind:       yang.DirectoryEntry,
+		Annotation: map[string]interface{}{"isFakeRoot": true},
+		Dir: map[string]*yang.Entry{
+			"leaf": {Name: "leaf", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+			"p": {
+				Name:  "p",
+				Kind:  yang.DirectoryEntry,
+				Extra: map[string][]interface{}{"presence": {&yang.Value{Name: "enabled"}}},
+				Dir: map[string]*yang.Entry{
+					"value": {Name: "value", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+				},
+			},
+		},
+	}
+	for _, c := range root.Dir {
+		c.Parent = root
+		for _, gc := range c.Dir {
+			gc.Parent = c
+		}
+	}
+	return &ytypes.Schema{
+		Root:       &PatchPresenceRoot{P: &PatchPresenceContainer{}},
+		SchemaTree: map[string]*yang.Entry{"PatchPresenceRoot": root},
+		Unmarshal: func(b []byte, d ygot.GoStruct, opts ...ytypes.UnmarshalOpt) error {
+			var v any
+			if err := json.Unmarshal(b, &v); err != nil {
+				return err
+			}
+			return ytypes.Unmarshal(root, d, v, opts...)
+		},
+	}
+}
+
+func TestPatchPresenceContainer(t *testing.T) {
+	want := &PatchPresenceRoot{Leaf: ygot.String("foo"), P: &PatchPresenceContainer{}}
+
+	t.Run("JSON Patch", func(t *testing.T) {
+		schema := patchPresenceSchema()
+		if err := ytypes.UnmarshalJSONPatch(schema, ygot.JSONPatch{{
+			Op:    ygot.JSONPatchAdd,
+			Path:  "/m:leaf",
+			Value: "foo",
+		}}); err != nil {
+			t.Fatalf("UnmarshalJSONPatch: %v", err)
+		}
+		if diff := cmp.Diff(want, schema.Root); diff != "" {
+			t.Errorf("UnmarshalJSONPatch (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("YANG Patch", func(t *testing.T) {
+		schema := patchPresenceSchema()
+		if err := ytypes.UnmarshalYANGPatch(schema, &ygot.YANGPatch{
+			PatchID: "p",
+			Edits: []*ygot.YANGPatchEdit{{
+				EditID:    "e",
+				Operation: ygot.YANGPatchCreate,
+				Target:    "/m:leaf",
+				Value:     map[string]any{"m:leaf": "foo"},
+			}},
+		}); err != nil {
+			t.Fatalf("UnmarshalYANGPatch: %v", err)
+		}
+		if diff := cmp.Diff(want, schema.Root); diff != "" {
+			t.Errorf("UnmarshalYANGPatch (-want, +got):\n%s", diff)
+		}
+	})
+}
@@ -97,25 +97,45 @@ func (c *regexpCache) compilePattern(pattern string, isPOSIX bool) (*regexp.Rege
 }
 
 // ValidateStringRestrictions checks that the given string matches the string
-// schema's length and pattern restrictions (if any). It returns an error if
-// the validation fails.
+// schema's length and pattern restrictions (if any). It returns a
+// *ValidationError if the validation fails, which carries the error-message
+// and error-app-tag of the restriction where these are specified by a typedef
+// that the type is derived from.
 func ValidateStringRestrictions(schemaType *yang.YangType, stringVal string) error {
+	return validateStringRestrictions(nil, schemaType, stringVal)
+}
+
+// validateStringRestrictions implements ValidateStringRestrictions. Where
+// schema is non-nil, it is the schema of the leaf whose type is schemaType,
+// and the error statements of its type statement are also used.
+func validateStringRestrictions(schema *yang.Entry, schemaType *yang.YangType, stringVal string) error {
 	// Check that the length is within the allowed range.
 	allowedRanges := schemaType.Length
 	strLen := uint64(utf8.RuneCountInString(stringVal))
 	if !lengthOk(allowedRanges, strLen) {
-		return fmt.Errorf("length %d is outside range %v", strLen, allowedRanges)
+		return newRestrictionError(LengthConstraint, schema, schemaType, stringVal, "", fmt.Errorf("length %d is outside range %v", strLen, allowedRanges))
 	}
 
 	// Check that the value satisfies any regex patterns.
 	patterns, isPOSIX := util.SanitizedPattern(schemaType)
-	for _, p := range patterns {
+	// The POSIX patterns are collected separately from the pattern
+	// statements, hence a POSIX pattern can only be attributed to the
+	// pattern statement at the same index where there are as many of each.
+	attributed := !isPOSIX || len(schemaType.POSIXPattern) == len(schemaType.Pattern)
+	for i, p := range patterns {
 		r, err := reCache.compilePattern(p, isPOSIX)
 		if err != nil {
 			return err
 		}
 		if !r.MatchString(stringVal) {
-			return fmt.Errorf("%q does not match regular expression pattern %q", stringVal, r)
+			// Where the failing pattern cannot be attributed to a
+			// pattern statement, the error is reported without the
+			// error statements of any restriction.
+			var pattern string
+			if attributed && i < len(schemaType.Pattern) {
+				pattern = schemaType.Pattern[i]
+			}
+			return newRestrictionError(PatternConstraint, schema, schemaType, stringVal, pattern, fmt.Errorf("%q does not match regular expression pattern %q", stringVal, r))
 		}
 	}
 	return nil
@@ -140,8 +160,8 @@ func validateString(schema *yang.Entry, value interface{}) error {
 	// sure it's the primitive string type.
 	stringVal := vv.Convert(reflect.TypeOf("")).Interface().(string)
 
-	if err := ValidateStringRestrictions(schema.Type, stringVal); err != nil {
-		return fmt.Errorf("schema %q: %v", schema.Name, err)
+	if err := validateStringRestrictions(schema, schema.Type, stringVal); err != nil {
+		return fmt.Errorf("schema %q: %w", schema.Name, err)
 	}
 	return nil
 }
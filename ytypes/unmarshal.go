@@ -112,6 +112,16 @@ const (
 	// This is made unexported because the feature is unstable and could
 	// change at any point.
 	gNMIEncodingWithJSONTolerance
+
+	// XMLEncoding indicates that provided value is a data tree decoded
+	// from XML, as returned by xmlToTree, in which the values of leaves
+	// are their character data.
+	XMLEncoding
+
+	// CBOREncoding indicates that provided value is a data tree decoded
+	// from YANG-CBOR, as returned by cborToTree, in which the values of
+	// leaves are their CBOR values.
+	CBOREncoding
 )
 
 // unmarshalGeneric unmarshals the provided value encoded with the given
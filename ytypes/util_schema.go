@@ -108,13 +108,13 @@ func validateListAttr(schema *yang.Entry, value interface{}) util.Errors {
 	// leaf-list. Check that the data tree falls within the required size
 	// bounds.
 	if size < schema.ListAttr.MinElements {
-		errors = util.AppendErr(errors, fmt.Errorf("list %s contains fewer than min required elements: %d < %d", schema.Name, size, schema.ListAttr.MinElements))
+		errors = util.AppendErr(errors, newValidationError(MinElementsConstraint, schema, size, fmt.Errorf("list %s contains fewer than min required elements: %d < %d", schema.Name, size, schema.ListAttr.MinElements)))
 	}
 	// 0 is an invalid value for MaxElements
 	// (https://tools.ietf.org/html/rfc7950#section-7.7.6).
 	// For useability it best represents the value "unbounded".
 	if schema.ListAttr.MaxElements != 0 && size > schema.ListAttr.MaxElements {
-		errors = util.AppendErr(errors, fmt.Errorf("list %s contains more than max allowed elements: %d > %d", schema.Name, size, schema.ListAttr.MaxElements))
+		errors = util.AppendErr(errors, newValidationError(MaxElementsConstraint, schema, size, fmt.Errorf("list %s contains more than max allowed elements: %d > %d", schema.Name, size, schema.ListAttr.MaxElements)))
 	}
 	return errors
 }
@@ -255,7 +255,7 @@ func checkDataTreeAgainstPaths(jsonTree map[string]interface{}, dataPaths [][]st
 	var checkTree func(map[string]interface{}, map[string]interface{})
 	checkTree = func(jsonTree map[string]interface{}, keyTree map[string]interface{}) {
 		for key := range jsonTree {
-			shortKey := util.StripModulePrefix(key)
+			shortKey := jsonMemberName(key)
 			if _, ok := keyTree[shortKey]; !ok {
 				missingKeys = append(missingKeys, shortKey)
 			}
@@ -370,3 +370,150 @@ func hasRelativePath(schema *yang.Entry, path []string) bool {
 
 	return len(p) == 0
 }
+
+// xpathStatement is a YANG statement whose argument is an XPath expression,
+// such as must or when, along with the error-message and error-app-tag
+// substatements that were specified for it, if any.
+type xpathStatement struct {
+	// expr is the XPath expression.
+	expr string
+	// errorMessage is the argument of the error-message substatement.
+	errorMessage string
+	// errorAppTag is the argument of the error-app-tag substatement.
+	errorAppTag string
+	// parentContext indicates that the context node of a when statement
+	// is the parent of the node that it applies to, rather than the node
+	// itself, which is the case for when statements that are inherited
+	// from augment and uses statements, or specified on a choice or case.
+	parentContext bool
+}
+
+// mustStatements returns the must statements of the supplied schema entry.
+// The statements are stored within the Extra field of the entry, such that
+// they are either *yang.Must values where the entry was produced by parsing
+// YANG, or maps where the entry was unmarshalled from a JSON schema, as is
+// the case for the schema stored within generated code.
+func mustStatements(e *yang.Entry) []*xpathStatement {
+	if e == nil {
+		return nil
+	}
+	var out []*xpathStatement
+	for _, m := range e.Extra["must"] {
+		switch m := m.(type) {
+		case *yang.Must:
+			s := &xpathStatement{expr: m.Name}
+			if m.ErrorMessage != nil {
+				s.errorMessage = m.ErrorMessage.Name
+			}
+			if m.ErrorAppTag != nil {
+				s.errorAppTag = m.ErrorAppTag.Name
+			}
+			out = append(out, s)
+		case map[string]interface{}:
+			expr, ok := m["Name"].(string)
+			if !ok {
+				continue
+			}
+			out = append(out, &xpathStatement{
+				expr:         expr,
+				errorMessage: jsonValueName(m["ErrorMessage"]),
+				errorAppTag:  jsonValueName(m["ErrorAppTag"]),
+			})
+		}
+	}
+	return out
+}
+
+// whenStatements returns the when statements of the supplied schema entry,
+// which are stored within the Extra field of the entry in the same manner as
+// must statements. Where the entry has when statements that are inherited from
+// augment and uses statements, its own when statement is identified using the
+// entry's Node, or, where the entry was unmarshalled from JSON, the annotation
+// added by ygen. An error is returned if neither is available, since the
+// context node of each statement cannot then be determined.
+func whenStatements(e *yang.Entry) ([]*xpathStatement, error) {
+	if e == nil || len(e.Extra["when"]) == 0 {
+		return nil, nil
+	}
+	var own string
+	var hasOwn bool
+	isChoiceOrCase := util.IsChoiceOrCase(e)
+	switch {
+	case isChoiceOrCase:
+		// The context node of all when statements of a choice or case
+		// is its parent, hence their origin need not be known.
+	case e.Node != nil:
+		own, hasOwn = e.GetWhenXPath()
+	default:
+		a, ok := e.Annotation[util.WhenXPathAnnotation]
+		if !ok {
+			return nil, fmt.Errorf("cannot distinguish the when statement of schema entry %s from those inherited from augment and uses statements, the generated code must be regenerated", e.Path())
+		}
+		own, _ = a.(string)
+		hasOwn = own != ""
+	}
+
+	var out []*xpathStatement
+	for _, w := range e.Extra["when"] {
+		var expr string
+		switch w := w.(type) {
+		case *yang.Value:
+			if w != nil {
+				expr = w.Name
+			}
+		case map[string]interface{}:
+			expr, _ = w["Name"].(string)
+		}
+		if expr == "" {
+			continue
+		}
+		s := &xpathStatement{expr: expr}
+		switch {
+		case isChoiceOrCase:
+			s.parentContext = true
+		case hasOwn && expr == own:
+			hasOwn = false
+		default:
+			s.parentContext = true
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// uniqueStatements returns the unique statements of the supplied list schema
+// entry, each as the descendant schema node identifiers that it specifies.
+// The statements are stored within the Extra field of the entry in the same
+// manner as must statements.
+func uniqueStatements(e *yang.Entry) [][]string {
+	if e == nil {
+		return nil
+	}
+	var out [][]string
+	for _, u := range e.Extra["unique"] {
+		var arg string
+		switch u := u.(type) {
+		case *yang.Value:
+			if u != nil {
+				arg = u.Name
+			}
+		case map[string]interface{}:
+			arg, _ = u["Name"].(string)
+		}
+		if ids := strings.Fields(arg); len(ids) != 0 {
+			out = append(out, ids)
+		}
+	}
+	return out
+}
+
+// jsonValueName returns the name of a *yang.Value that has been unmarshalled
+// from JSON into v, or the empty string if v is not such a value.
+func jsonValueName(v interface{}) string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	s, _ := m["Name"].(string)
+	return s
+}
@@ -20,6 +20,8 @@ import (
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 // LeafrefOptions controls the behaviour of validation functions for leaf-ref
@@ -48,6 +50,9 @@ func (*LeafrefOptions) IsValidationOption() {}
 type CustomValidationOptions struct {
 	// FakeRootCustomValidate specifies the user implemented method
 	FakeRootCustomValidate func(ygot.GoStruct) error
+	// Validators specifies the custom validate functions to be invoked
+	// for the nodes of the data tree with particular schema paths.
+	Validators *CustomValidatorRegistry
 }
 
 // IsValidationOption ensures that CustomValidationOptions implements the ValidationOption
@@ -57,6 +62,13 @@ func (*CustomValidationOptions) IsValidationOption() {}
 // Validate recursively validates the value of the given data tree struct
 // against the given schema.
 func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOption) util.Errors {
+	return validate(schema, value, nil, opts...)
+}
+
+// validate validates the value of the given data tree struct against the
+// given schema. Where the change filter f is non-nil, only the parts of the
+// data tree that are affected by the changes that it describes are validated.
+func validate(schema *yang.Entry, value interface{}, f *changeFilter, opts ...ygot.ValidationOption) util.Errors {
 	// Nil value means the field is unset.
 	if util.IsValueNil(value) {
 		return nil
@@ -72,12 +84,24 @@ func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOpti
 	// explicitly returning an error.
 	var leafrefOpt *LeafrefOptions
 	var customValidOpt *CustomValidationOptions
+	var mustOpt *MustOptions
+	var whenOpt *WhenOptions
+	var mandatoryOpt *MandatoryOptions
+	var pool *validatePool
 	for _, o := range opts {
 		switch v := o.(type) {
 		case *LeafrefOptions:
 			leafrefOpt = v
 		case *CustomValidationOptions:
 			customValidOpt = v
+		case *MustOptions:
+			mustOpt = v
+		case *WhenOptions:
+			whenOpt = v
+		case *MandatoryOptions:
+			mandatoryOpt = v
+		case *ParallelOptions:
+			pool = newValidatePool(v.Workers)
 		}
 	}
 
@@ -85,34 +109,175 @@ func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOpti
 	if util.IsFakeRoot(schema) {
 		// Leafref validation traverses entire tree from the root. Do this only
 		// once from the fakeroot.
-		errs = ValidateLeafRefData(schema, value, leafrefOpt)
+		if f == nil && pool == nil {
+			errs = ValidateLeafRefData(schema, value, leafrefOpt)
+		} else {
+			errs = validateLeafRefs(schema, value, leafrefOpt, f, pool)
+		}
 		// If CustomValidation is enabled, call the CustomValidateFunc
 		// and append the error, if any
 		gsv, ok := value.(ygot.GoStruct)
-		if ok && customValidOpt != nil {
+		if ok && customValidOpt != nil && customValidOpt.FakeRootCustomValidate != nil {
 			if err := customValidOpt.FakeRootCustomValidate(gsv); err != nil {
 				errs = util.AppendErr(errs, err)
 			}
 		}
 	}
 
+	var st *validateState
+	if (customValidOpt != nil && customValidOpt.Validators != nil) || f != nil || pool != nil {
+		st = &validateState{filter: f, pool: pool}
+		if customValidOpt != nil {
+			st.validators = customValidOpt.Validators
+		}
+		if !util.IsFakeRoot(schema) {
+			st.path = []*gpb.PathElem{{Name: schema.Name}}
+		}
+		st = st.applyFilter()
+	}
+
+	// Must and when statements may reference any node in the data tree, and
+	// hence are evaluated once from the node at which validation was
+	// requested. Recursive validation of the children of the node does not
+	// repeat this evaluation. When statements are evaluated first, such that
+	// nodes that they prune are not subject to the validation that follows.
+	if _, ok := value.(ygot.GoStruct); ok && (schema.IsContainer() || schema.IsList()) {
+		if whenOpt != nil {
+			errs = util.AppendErrs(errs, validateWhen(schema, value, whenOpt, f))
+		}
+		if mustOpt != nil {
+			errs = util.AppendErrs(errs, validateMust(schema, value, mustOpt, f))
+		}
+		// Mandatory nodes are similarly checked once for the entire
+		// data tree, where the check is enabled.
+		if mandatoryOpt != nil {
+			errs = util.AppendErrs(errs, validateMandatory(schema, value, mandatoryOpt, f))
+		}
+	}
+
+	return util.AppendErrs(errs, validateNode(schema, value, st))
+}
+
+// validateState is the state of the validation of a data tree that is
+// threaded through the recursive validation of its nodes. A nil
+// *validateState is valid, and is used where no state is required.
+type validateState struct {
+	// validators are the custom validators to invoke.
+	validators *CustomValidatorRegistry
+	// filter describes the changes to the data tree where only the nodes
+	// affected by them are to be validated. It is nil where the node being
+	// validated, and hence all of its descendants, are to be validated.
+	filter *changeFilter
+	// skip indicates that the node being validated is not affected by the
+	// changes described by filter, such that it is not validated.
+	skip bool
+	// pool is used to validate nodes concurrently, it is nil where nodes
+	// are validated sequentially.
+	pool *validatePool
+	// parent is the GoStruct that contains the node being validated.
+	parent ygot.GoStruct
+	// path is the path of the node being validated, relative to the node
+	// at which validation was requested.
+	path []*gpb.PathElem
+}
+
+// child returns the state for validating a node that is stored in the parent
+// GoStruct, whose path is that of st with the elements elems appended.
+func (st *validateState) child(parent ygot.GoStruct, elems ...*gpb.PathElem) *validateState {
+	if st == nil {
+		return nil
+	}
+	p := make([]*gpb.PathElem, 0, len(st.path)+len(elems))
+	p = append(append(p, st.path...), elems...)
+	return (&validateState{validators: st.validators, filter: st.filter, pool: st.pool, parent: parent, path: p}).applyFilter()
+}
+
+// listEntry returns the state for validating an entry of the list whose
+// state is st, where pe is the path element of the entry.
+func (st *validateState) listEntry(pe *gpb.PathElem) *validateState {
+	if st == nil {
+		return nil
+	}
+	p := append([]*gpb.PathElem{}, st.path...)
+	if len(p) > 0 && p[len(p)-1].Name == pe.Name {
+		p[len(p)-1] = pe
+	} else {
+		p = append(p, pe)
+	}
+	return (&validateState{validators: st.validators, filter: st.filter, pool: st.pool, parent: st.parent, path: p}).applyFilter()
+}
+
+// applyFilter determines whether the node whose state is st is affected by
+// the changes described by its filter. The filter is dropped for nodes that
+// are within a changed subtree, and nil is returned where no further state is
+// required to validate the node.
+func (st *validateState) applyFilter() *validateState {
+	if st.filter == nil {
+		return st
+	}
+	switch st.filter.status(st.path) {
+	case changeUnrelated:
+		st.skip = true
+		return st
+	case changeInside:
+		st.filter = nil
+		if st.validators == nil && st.pool == nil {
+			return nil
+		}
+	}
+	return st
+}
+
+// skipped reports whether the node whose state is st is not to be validated.
+func (st *validateState) skipped() bool {
+	return st != nil && st.skip
+}
+
+// validateNode recursively validates the value of the given data tree node
+// against the given schema. Unlike Validate, it does not perform the checks
+// that apply to the data tree as a whole, and hence is used to validate the
+// descendants of the node at which validation was requested. The state of the
+// validation, st, may be nil where no state is required.
+func validateNode(schema *yang.Entry, value interface{}, st *validateState) util.Errors {
+	if st.skipped() {
+		return nil
+	}
+	// Nil value means the field is unset.
+	if util.IsValueNil(value) {
+		return nil
+	}
+	if schema == nil {
+		return util.NewErrs(fmt.Errorf("nil schema for type %T, value %v", value, value))
+	}
+
 	util.DbgPrint("Validate with value %v, type %T, schema name %s", util.ValueStrDebug(value), value, schema.Name)
 
+	// The path element of the node is added to the path of each
+	// ValidationError returned, other than for lists, which add their own
+	// element since it includes the key of each entry.
+	elem := &gpb.PathElem{Name: schema.Name}
 	switch {
 	case schema.IsLeaf():
-		return util.AppendErrs(errs, validateLeaf(schema, value))
+		errs := leafValidationErrors(schema, value, validateLeaf(schema, value))
+		errs = util.AppendErrs(errs, st.runValidators(schema, value))
+		return prefixValidationErrors(errs, "", elem)
 	case schema.IsContainer():
 		gsv, ok := value.(ygot.GoStruct)
 		if !ok {
-			return util.AppendErr(errs, fmt.Errorf("type %T is not a GoStruct for schema %s", value, schema.Name))
+			return util.NewErrs(fmt.Errorf("type %T is not a GoStruct for schema %s", value, schema.Name))
+		}
+		errs := util.AppendErrs(st.runValidators(schema, value), validateContainer(schema, gsv, st))
+		if util.IsFakeRoot(schema) {
+			return errs
 		}
-		return util.AppendErrs(errs, validateContainer(schema, gsv))
+		return prefixValidationErrors(errs, "", elem)
 	case schema.IsLeafList():
-		return util.AppendErrs(errs, validateLeafList(schema, value))
+		errs := util.AppendErrs(validateLeafList(schema, value), st.runValidators(schema, value))
+		return prefixValidationErrors(errs, "", elem)
 	case schema.IsList():
-		return util.AppendErrs(errs, validateList(schema, value))
+		return validateList(schema, value, st)
 	case schema.IsChoice():
-		return util.AppendErrs(errs, util.NewErrs(fmt.Errorf("cannot pass choice schema %s to Validate", schema.Name)))
+		return util.NewErrs(fmt.Errorf("cannot pass choice schema %s to Validate", schema.Name))
 	}
-	return util.AppendErrs(errs, util.NewErrs(fmt.Errorf("unknown schema type for type %T, value %v", value, value)))
+	return util.NewErrs(fmt.Errorf("unknown schema type for type %T, value %v", value, value))
 }
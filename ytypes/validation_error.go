@@ -0,0 +1,364 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// ConstraintKind is the kind of schema constraint that a data node does not
+// satisfy.
+type ConstraintKind int
+
+const (
+	// UnknownConstraint is used where the kind of constraint is not known.
+	UnknownConstraint ConstraintKind = iota
+	// TypeConstraint indicates that a value is not of the type specified by
+	// the schema, for example an enumerated value that is not defined.
+	TypeConstraint
+	// RangeConstraint indicates that a numeric value is outside of the
+	// range specified by the schema.
+	RangeConstraint
+	// LengthConstraint indicates that the length of a string or binary
+	// value is outside of the length specified by the schema.
+	LengthConstraint
+	// PatternConstraint indicates that a string value does not match a
+	// pattern specified by the schema.
+	PatternConstraint
+	// LeafrefConstraint indicates that the value of a leafref does not
+	// match any of the nodes that its path refers to.
+	LeafrefConstraint
+	// MinElementsConstraint indicates that a list or leaf-list has fewer
+	// entries than its min-elements statement allows.
+	MinElementsConstraint
+	// MaxElementsConstraint indicates that a list or leaf-list has more
+	// entries than its max-elements statement allows.
+	MaxElementsConstraint
+	// KeyConstraint indicates that the key of a list entry does not match
+	// the values of its key leaves.
+	KeyConstraint
+	// UniqueConstraint indicates that the entries of a list do not satisfy
+	// one of its unique statements.
+	UniqueConstraint
+	// ChoiceConstraint indicates that more than one case of a choice is
+	// selected.
+	ChoiceConstraint
+	// MandatoryConstraint indicates that a mandatory leaf is not populated,
+	// or that no case of a mandatory choice is selected.
+	MandatoryConstraint
+	// MustConstraint indicates that a must statement is not satisfied, or
+	// cannot be evaluated.
+	MustConstraint
+	// WhenConstraint indicates that a node is populated whilst its when
+	// statement is false, or that the statement cannot be evaluated.
+	WhenConstraint
+	// CustomConstraint indicates that a custom validator registered using
+	// a CustomValidatorRegistry returned an error.
+	CustomConstraint
+)
+
+// String returns the name of the constraint kind, which is the keyword of
+// the YANG statement that specifies the constraint where one exists.
+func (k ConstraintKind) String() string {
+	switch k {
+	case TypeConstraint:
+		return "type"
+	case RangeConstraint:
+		return "range"
+	case LengthConstraint:
+		return "length"
+	case PatternConstraint:
+		return "pattern"
+	case LeafrefConstraint:
+		return "leafref"
+	case MinElementsConstraint:
+		return "min-elements"
+	case MaxElementsConstraint:
+		return "max-elements"
+	case KeyConstraint:
+		return "key"
+	case UniqueConstraint:
+		return "unique"
+	case ChoiceConstraint:
+		return "choice"
+	case MandatoryConstraint:
+		return "mandatory"
+	case MustConstraint:
+		return "must"
+	case WhenConstraint:
+		return "when"
+	case CustomConstraint:
+		return "custom"
+	}
+	return "unknown"
+}
+
+// ValidationError is an error returned by Validate where a node within the
+// data tree does not satisfy a constraint specified by the schema. The Errors
+// returned by Validate, and hence by the generated ΛValidate methods, can be
+// inspected using errors.As, or using ValidationErrors.
+type ValidationError struct {
+	// Path is the path of the node that does not satisfy the constraint,
+	// relative to the node at which validation was requested. Where
+	// validation is requested at the root of the data tree, the path is
+	// absolute. List keys are included where they can be determined.
+	Path *gpb.Path
+	// SchemaPath is the path of the schema node that specifies the
+	// constraint, as returned by (*yang.Entry).Path.
+	SchemaPath string
+	// Kind is the kind of constraint that is not satisfied.
+	Kind ConstraintKind
+	// Value is the offending value, where the constraint applies to the
+	// value of a single node.
+	Value interface{}
+	// ErrorMessage and ErrorAppTag are the contents of the error-message
+	// and error-app-tag statements of the constraint, if any.
+	ErrorMessage string
+	ErrorAppTag  string
+	// Err is the underlying error, which describes the failure.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors returns each of the ValidationErrors within err, which is
+// typically the error returned by Validate or a generated ΛValidate method.
+func ValidationErrors(err error) []*ValidationError {
+	var ves []*ValidationError
+	var collect func(error)
+	collect = func(err error) {
+		if errs, ok := err.(util.Errors); ok {
+			for _, e := range errs {
+				collect(e)
+			}
+			return
+		}
+		var ve *ValidationError
+		if errors.As(err, &ve) {
+			ves = append(ves, ve)
+		}
+	}
+	collect(err)
+	return ves
+}
+
+// newValidationError returns a ValidationError for the constraint of the
+// supplied kind, which is specified by schema, with the underlying error err.
+func newValidationError(kind ConstraintKind, schema *yang.Entry, value interface{}, err error) *ValidationError {
+	ve := &ValidationError{
+		Path:  &gpb.Path{},
+		Kind:  kind,
+		Value: value,
+		Err:   err,
+	}
+	if schema != nil {
+		ve.SchemaPath = schema.Path()
+	}
+	return ve
+}
+
+// setErrorStatements records the arguments of the error-message and
+// error-app-tag statements of the constraint that ve describes, and appends
+// them to its message.
+func (ve *ValidationError) setErrorStatements(message, appTag string) {
+	if message != "" {
+		ve.Err = fmt.Errorf("%w: %s", ve.Err, message)
+	}
+	if appTag != "" {
+		ve.Err = fmt.Errorf("%w (error-app-tag %s)", ve.Err, appTag)
+	}
+	ve.ErrorMessage = message
+	ve.ErrorAppTag = appTag
+}
+
+// newRestrictionError returns a ValidationError for the range, length or
+// pattern restriction of the supplied kind, which the value of a node of type
+// schemaType does not satisfy, with the underlying error err. pattern is the
+// argument of the pattern statement that is not satisfied, if any. The
+// error-message and error-app-tag statements of the restriction are determined
+// from the type statement of the leaf schema where it is available, and
+// otherwise from the typedefs that schemaType is derived from.
+func newRestrictionError(kind ConstraintKind, schema *yang.Entry, schemaType *yang.YangType, value interface{}, pattern string, err error) *ValidationError {
+	ve := newValidationError(kind, nil, value, err)
+	res := util.EntryRestrictionErrors(schema)
+	if res == nil {
+		res = util.YangTypeRestrictionErrors(schemaType)
+	}
+	for _, r := range res {
+		if r.Statement == kind.String() && (kind != PatternConstraint || r.Argument == pattern) {
+			ve.setErrorStatements(r.ErrorMessage, r.ErrorAppTag)
+			break
+		}
+	}
+	return ve
+}
+
+// leafValidationErrors converts each of the errors returned when validating
+// the value of the leaf with the supplied schema to a ValidationError. Where
+// an error wraps a ValidationError returned by one of the Validate*Restrictions
+// functions, its kind and error statements are retained, otherwise the value
+// is considered not to be of the type specified by the schema.
+func leafValidationErrors(schema *yang.Entry, value interface{}, errs util.Errors) util.Errors {
+	if len(errs) == 0 {
+		return nil
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		value = rv.Elem().Interface()
+	}
+	out := make(util.Errors, 0, len(errs))
+	for _, err := range errs {
+		ve := newValidationError(TypeConstraint, schema, value, err)
+		var inner *ValidationError
+		if errors.As(err, &inner) {
+			ve.Kind = inner.Kind
+			ve.ErrorMessage = inner.ErrorMessage
+			ve.ErrorAppTag = inner.ErrorAppTag
+		}
+		out = append(out, ve)
+	}
+	return out
+}
+
+// prefixValidationErrors prepends the path elements elems to the path of each
+// ValidationError within errs, and prefixes the message of each error within
+// errs with pfx where it is non-empty, as per util.PrefixErrors.
+func prefixValidationErrors(errs util.Errors, pfx string, elems ...*gpb.PathElem) util.Errors {
+	if len(errs) == 0 {
+		return errs
+	}
+	out := make(util.Errors, 0, len(errs))
+	for _, err := range errs {
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			if pfx != "" {
+				err = fmt.Errorf("%s: %s", pfx, err)
+			}
+			out = append(out, err)
+			continue
+		}
+		if len(elems) > 0 {
+			ve.Path.Elem = append(append([]*gpb.PathElem{}, elems...), ve.Path.Elem...)
+		}
+		if pfx != "" {
+			ve.Err = fmt.Errorf("%s: %w", pfx, ve.Err)
+		}
+		out = append(out, ve)
+	}
+	return out
+}
+
+// fieldPathElems returns the elements of the path from the struct that
+// contains the field f to the node with the schema cschema that the field
+// stores, excluding the element for the node itself, which is added when the
+// node is validated. The returned elements are those of containers that are
+// compressed out of the generated code.
+func fieldPathElems(f reflect.StructField, cschema *yang.Entry) []*gpb.PathElem {
+	ps, err := util.SchemaPaths(f)
+	if err != nil {
+		return nil
+	}
+	for _, p := range ps {
+		if len(p) == 0 || p[len(p)-1] != cschema.Name {
+			continue
+		}
+		var elems []*gpb.PathElem
+		for _, n := range p[:len(p)-1] {
+			elems = append(elems, &gpb.PathElem{Name: n})
+		}
+		return elems
+	}
+	return nil
+}
+
+// listEntryPathElem returns the path element of the list entry v, which is a
+// pointer to a struct, whose schema is supplied. The keys of the entry are
+// included where they can be determined.
+func listEntryPathElem(schema *yang.Entry, v reflect.Value) *gpb.PathElem {
+	pe := &gpb.PathElem{Name: schema.Name}
+	if schema.Key == "" {
+		return pe
+	}
+	if k, err := ygot.PathKeyFromStruct(v); err == nil {
+		pe.Key = k
+	}
+	return pe
+}
+
+// nodeInfoPath returns the path of the node described by ni, as traversed by
+// util.ForEachField, relative to the root of the traversal.
+func nodeInfoPath(ni *util.NodeInfo) *gpb.Path {
+	var elems []*gpb.PathElem
+	var keys map[string]string
+	for c := ni; c != nil && c.Parent != nil; c = c.Parent {
+		if isListEntryNodeInfo(c) {
+			// The element for the list itself is added by the
+			// parent of the entry.
+			if k, err := ygot.PathKeyFromStruct(c.FieldValue); err == nil {
+				keys = k
+			}
+			continue
+		}
+		var pes []*gpb.PathElem
+		for _, n := range c.PathFromParent {
+			pes = append(pes, &gpb.PathElem{Name: util.StripModulePrefix(n)})
+		}
+		if len(pes) > 0 && keys != nil {
+			pes[len(pes)-1].Key = keys
+		}
+		keys = nil
+		elems = append(pes, elems...)
+	}
+	return &gpb.Path{Elem: elems}
+}
+
+// isListEntryNodeInfo reports whether ni describes an entry of a list, rather
+// than the list itself. util.ForEachField describes each entry using a copy of
+// the NodeInfo of the list, whose parent is the NodeInfo of the list.
+func isListEntryNodeInfo(ni *util.NodeInfo) bool {
+	if ni.Parent == nil || ni.Parent.Schema == nil || !ni.Parent.Schema.IsList() {
+		return false
+	}
+	return ni.StructField.Name == ni.Parent.StructField.Name && ni.StructField.Type == ni.Parent.StructField.Type
+}
+
+// xpathValidationError returns a ValidationError for the constraint of the
+// supplied kind that is not satisfied by the node n of the data tree used to
+// evaluate XPath expressions.
+func xpathValidationError(kind ConstraintKind, n *xpathNode, err error) *ValidationError {
+	ve := newValidationError(kind, n.schema, nil, err)
+	ve.Path = n.gnmiPath()
+	if n.isLeaf() && !n.isDefault {
+		if v := n.leafValue(); v.IsValid() {
+			ve.Value = v.Interface()
+		}
+	}
+	return ve
+}
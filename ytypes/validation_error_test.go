@@ -0,0 +1,349 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestValidationErrors(t *testing.T) {
+	ifElem := func(name string) *gpb.PathElem {
+		return &gpb.PathElem{Name: "interface", Key: map[string]string{"name": name}}
+	}
+	neighborPath := []*gpb.PathElem{
+		{Name: "bgp"},
+		{Name: "neighbors"},
+		{Name: "neighbor", Key: map[string]string{"neighbor-address": "192.0.2.254"}},
+	}
+
+	tests := []struct {
+		desc     string
+		inModify func(*yang.Entry)
+		inData   func(*xpathTestDevice)
+		inOpts   []ygot.ValidationOption
+		want     []*ValidationError
+	}{{
+		desc: "range",
+		inModify: func(root *yang.Entry) {
+			root.Dir["interfaces"].Dir["interface"].Dir["config"].Dir["mtu"].Type.Range = yang.YangRange{{Min: yang.FromInt(68), Max: yang.FromInt(9000)}}
+		},
+		inData: func(d *xpathTestDevice) {
+			d.Interface["eth0"].Mtu = ygot.Uint16(9216)
+		},
+		want: []*ValidationError{{
+			Path:       &gpb.Path{Elem: []*gpb.PathElem{{Name: "interfaces"}, ifElem("eth0"), {Name: "config"}, {Name: "mtu"}}},
+			SchemaPath: "/device/interfaces/interface/config/mtu",
+			Kind:       RangeConstraint,
+			Value:      uint16(9216),
+		}},
+	}, {
+		desc: "pattern within leaf-list",
+		inModify: func(root *yang.Entry) {
+			e := root.Dir["interfaces"].Dir["interface"].Dir["subinterfaces"].Dir["subinterface"].Dir["config"].Dir["address"]
+			e.Type.Pattern = []string{`192\.0\.2\.[0-9]+`}
+		},
+		inData: func(d *xpathTestDevice) {
+			d.Interface["eth0"].Subinterface[0].Address = []string{"198.51.100.1"}
+		},
+		want: []*ValidationError{{
+			Path: &gpb.Path{Elem: []*gpb.PathElem{
+				{Name: "interfaces"}, ifElem("eth0"), {Name: "subinterfaces"},
+				{Name: "subinterface", Key: map[string]string{"index": "0"}}, {Name: "config"}, {Name: "address"},
+			}},
+			SchemaPath: "/device/interfaces/interface/subinterfaces/subinterface/config/address",
+			Kind:       PatternConstraint,
+			Value:      "198.51.100.1",
+		}},
+	}, {
+		desc: "leafref",
+		inData: func(d *xpathTestDevice) {
+			d.Bgp.Neighbor["192.0.2.254"].Interface = ygot.String("eth1")
+		},
+		want: []*ValidationError{{
+			Path:       &gpb.Path{Elem: append(neighborPath, &gpb.PathElem{Name: "config"}, &gpb.PathElem{Name: "interface"})},
+			SchemaPath: "/device/bgp/neighbors/neighbor/config/interface",
+			Kind:       LeafrefConstraint,
+			Value:      "eth1",
+		}},
+	}, {
+		desc: "must with error-message and error-app-tag",
+		inModify: func(root *yang.Entry) {
+			root.Dir["bgp"].Dir["neighbors"].Dir["neighbor"].Extra = map[string][]interface{}{"must": {&yang.Must{
+				Name:         "config/peer-as != /bgp/global/config/as",
+				ErrorMessage: &yang.Value{Name: "iBGP peers are not supported"},
+				ErrorAppTag:  &yang.Value{Name: "ibgp-unsupported"},
+			}}}
+		},
+		inData: func(d *xpathTestDevice) {
+			d.Bgp.Neighbor["192.0.2.254"].PeerAs = ygot.Uint32(64512)
+		},
+		inOpts: []ygot.ValidationOption{&MustOptions{}},
+		want: []*ValidationError{{
+			Path:         &gpb.Path{Elem: neighborPath},
+			SchemaPath:   "/device/bgp/neighbors/neighbor",
+			Kind:         MustConstraint,
+			ErrorMessage: "iBGP peers are not supported",
+			ErrorAppTag:  "ibgp-unsupported",
+		}},
+	}, {
+		desc: "mandatory",
+		inModify: func(root *yang.Entry) {
+			root.Dir["interfaces"].Dir["interface"].Dir["config"].Dir["mtu"].Mandatory = yang.TSTrue
+		},
+		inOpts: []ygot.ValidationOption{&MandatoryOptions{}},
+		want: []*ValidationError{{
+			Path:       &gpb.Path{Elem: []*gpb.PathElem{{Name: "interfaces"}, ifElem("lo0"), {Name: "config"}, {Name: "mtu"}}},
+			SchemaPath: "/device/interfaces/interface/config/mtu",
+			Kind:       MandatoryConstraint,
+		}},
+	}, {
+		desc: "min-elements",
+		inModify: func(root *yang.Entry) {
+			root.Dir["interfaces"].Dir["interface"].Dir["subinterfaces"].Dir["subinterface"].ListAttr.MinElements = 1
+		},
+		inData: func(d *xpathTestDevice) {
+			d.Interface["lo0"].Subinterface = map[uint32]*xpathTestSubinterface{}
+		},
+		want: []*ValidationError{{
+			Path:       &gpb.Path{Elem: []*gpb.PathElem{{Name: "interfaces"}, ifElem("lo0"), {Name: "subinterfaces"}, {Name: "subinterface"}}},
+			SchemaPath: "/device/interfaces/interface/subinterfaces/subinterface",
+			Kind:       MinElementsConstraint,
+			Value:      uint64(0),
+		}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := xpathTestSchema(tt.inModify)
+			d := xpathTestData()
+			if tt.inData != nil {
+				tt.inData(d)
+			}
+			errs := Validate(schema, d, tt.inOpts...)
+			got := ValidationErrors(errs)
+			if len(got) != len(errs) {
+				t.Errorf("ValidationErrors(): got %d ValidationErrors, want %d, errors: %v", len(got), len(errs), errs)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform(), cmpopts.IgnoreFields(ValidationError{}, "Err")); diff != "" {
+				t.Errorf("Validate(): did not get expected ValidationErrors, (-want, +got):\n%s", diff)
+			}
+			for _, ve := range got {
+				var target *ValidationError
+				if !errors.As(errs, &target) {
+					t.Errorf("errors.As(%v): did not find ValidationError", errs)
+				}
+				if ve.Error() == "" {
+					t.Errorf("ValidationError %v: got empty message", ve)
+				}
+			}
+		})
+	}
+}
+
+func TestConstraintKindString(t *testing.T) {
+	for k, want := range map[ConstraintKind]string{
+		UnknownConstraint:     "unknown",
+		RangeConstraint:       "range",
+		MinElementsConstraint: "min-elements",
+		WhenConstraint:        "when",
+	} {
+		if got := k.String(); got != want {
+			t.Errorf("%d.String(): got %s, want %s", k, got, want)
+		}
+	}
+}
+
+func TestRestrictionErrorStatements(t *testing.T) {
+	ms := yang.NewModules()
+	if err := ms.Parse(`
+		module test {
+			prefix "t";
+			namespace "urn:t";
+
+			typedef name {
+				type string {
+					length "1..32" {
+						error-message "name is too long";
+					}
+					pattern "[a-z]+" {
+						error-message "name must be lower case";
+						error-app-tag "bad-name";
+					}
+				}
+			}
+
+			container c {
+				leaf mtu {
+					type uint16 {
+						range "68..9000" {
+							error-message "mtu out of range";
+							error-app-tag "bad-mtu";
+						}
+					}
+				}
+				leaf name { type name; }
+				leaf short-name {
+					type name {
+						length "1..4";
+					}
+				}
+				leaf ratio {
+					type decimal64 {
+						fraction-digits 2;
+						range "0..1" {
+							error-message "ratio out of range";
+						}
+					}
+				}
+				leaf data {
+					type binary {
+						length "1..2" {
+							error-app-tag "bad-data";
+						}
+					}
+				}
+			}
+		}`, "test.yang"); err != nil {
+		t.Fatalf("cannot parse module: %v", err)
+	}
+	if errs := ms.Process(); errs != nil {
+		t.Fatalf("cannot process module: %v", errs)
+	}
+	c := yang.ToEntry(ms.Modules["test"]).Dir["c"]
+
+	// withAnnotation returns a copy of e without its Node, whose error
+	// statements are instead stored in the annotation as they are within
+	// the schema serialised by ygen.
+	withAnnotation := func(e *yang.Entry) *yang.Entry {
+		b, err := json.Marshal(util.EntryRestrictionErrors(e))
+		if err != nil {
+			t.Fatalf("cannot marshal restriction errors: %v", err)
+		}
+		var a []interface{}
+		if err := json.Unmarshal(b, &a); err != nil {
+			t.Fatalf("cannot unmarshal restriction errors: %v", err)
+		}
+		ce := *e
+		ce.Node = nil
+		ce.Annotation = map[string]interface{}{util.RestrictionErrorsAnnotation: a}
+		return &ce
+	}
+
+	tests := []struct {
+		desc        string
+		inSchema    *yang.Entry
+		inValue     interface{}
+		wantKind    ConstraintKind
+		wantMessage string
+		wantAppTag  string
+		wantErr     string
+	}{{
+		desc:        "range",
+		inSchema:    c.Dir["mtu"],
+		inValue:     ygot.Uint16(9216),
+		wantKind:    RangeConstraint,
+		wantMessage: "mtu out of range",
+		wantAppTag:  "bad-mtu",
+		wantErr:     `schema "mtu": unsigned integer value 9216 is outside specified ranges: mtu out of range (error-app-tag bad-mtu)`,
+	}, {
+		desc:        "range from annotation",
+		inSchema:    withAnnotation(c.Dir["mtu"]),
+		inValue:     ygot.Uint16(9216),
+		wantKind:    RangeConstraint,
+		wantMessage: "mtu out of range",
+		wantAppTag:  "bad-mtu",
+		wantErr:     `schema "mtu": unsigned integer value 9216 is outside specified ranges: mtu out of range (error-app-tag bad-mtu)`,
+	}, {
+		desc:        "pattern of typedef",
+		inSchema:    c.Dir["name"],
+		inValue:     ygot.String("ETH0"),
+		wantKind:    PatternConstraint,
+		wantMessage: "name must be lower case",
+		wantAppTag:  "bad-name",
+	}, {
+		desc:        "length of typedef",
+		inSchema:    c.Dir["name"],
+		inValue:     ygot.String("abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz"),
+		wantKind:    LengthConstraint,
+		wantMessage: "name is too long",
+	}, {
+		desc:     "length of typedef replaced by leaf",
+		inSchema: c.Dir["short-name"],
+		inValue:  ygot.String("abcde"),
+		wantKind: LengthConstraint,
+	}, {
+		desc:        "decimal range",
+		inSchema:    c.Dir["ratio"],
+		inValue:     ygot.Float64(1.5),
+		wantKind:    RangeConstraint,
+		wantMessage: "ratio out of range",
+	}, {
+		desc:       "binary length from annotation",
+		inSchema:   withAnnotation(c.Dir["data"]),
+		inValue:    Binary{1, 2, 3},
+		wantKind:   LengthConstraint,
+		wantAppTag: "bad-data",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := ValidationErrors(Validate(tt.inSchema, tt.inValue))
+			if len(got) != 1 {
+				t.Fatalf("Validate(): got %d ValidationErrors, want 1: %v", len(got), got)
+			}
+			ve := got[0]
+			if ve.Kind != tt.wantKind || ve.ErrorMessage != tt.wantMessage || ve.ErrorAppTag != tt.wantAppTag {
+				t.Errorf("Validate(): got kind %v, error-message %q, error-app-tag %q, want %v, %q, %q", ve.Kind, ve.ErrorMessage, ve.ErrorAppTag, tt.wantKind, tt.wantMessage, tt.wantAppTag)
+			}
+			if tt.wantErr != "" && ve.Error() != tt.wantErr {
+				t.Errorf("Validate(): got error %q, want %q", ve.Error(), tt.wantErr)
+			}
+		})
+	}
+
+	// The error statements of typedefs are available where only the type
+	// is supplied.
+	var ve *ValidationError
+	if err := ValidateStringRestrictions(c.Dir["name"].Type, "ETH0"); !errors.As(err, &ve) || ve.ErrorMessage != "name must be lower case" {
+		t.Errorf("ValidateStringRestrictions(): got %v, want error with error-message", err)
+	}
+
+	// POSIX patterns are attributed to the pattern statement at the same
+	// index only where there are as many of each.
+	posix := func(patterns ...string) *yang.YangType {
+		yt := *c.Dir["name"].Type
+		yt.POSIXPattern = patterns
+		return &yt
+	}
+	if err := ValidateStringRestrictions(posix("^[a-z]+$"), "ETH0"); !errors.As(err, &ve) || ve.Kind != PatternConstraint || ve.ErrorMessage != "name must be lower case" {
+		t.Errorf("ValidateStringRestrictions() with POSIX pattern: got %v, want error with error-message", err)
+	}
+	unaligned := posix("^b.*$")
+	unaligned.Pattern = []string{"[a-z]+", "b.*"}
+	if err := ValidateStringRestrictions(unaligned, "xyz"); !errors.As(err, &ve) || ve.Kind != PatternConstraint || ve.ErrorMessage != "" || ve.ErrorAppTag != "" {
+		t.Errorf("ValidateStringRestrictions() with unaligned POSIX patterns: got %v, want error without error statements", err)
+	}
+}
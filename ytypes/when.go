@@ -0,0 +1,221 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// Refer to: https://tools.ietf.org/html/rfc7950#section-7.21.5.
+
+// WhenOptions enables the evaluation of the YANG when statements in the
+// schema during validation. A node that is populated in the data tree whose
+// when statement evaluates to false results in a validation error. Since when
+// statements may reference any node in the data tree, they should be evaluated
+// when validating the root of the data tree, such that absolute paths can be
+// resolved.
+type WhenOptions struct {
+	// Prune specifies that nodes whose when statement evaluates to false
+	// are removed from the data tree that is being validated, rather than
+	// resulting in a validation error. Since removing a node can change the
+	// result of other when statements, the data tree is re-evaluated until
+	// no further nodes are removed.
+	Prune bool
+	// IgnoreUnsupported specifies that when statements whose XPath
+	// expression cannot be parsed, or that use XPath features that are not
+	// supported by the evaluator, are skipped rather than resulting in a
+	// validation error.
+	IgnoreUnsupported bool
+}
+
+// IsValidationOption ensures that WhenOptions implements the ValidationOption
+// interface.
+func (*WhenOptions) IsValidationOption() {}
+
+// validateWhen evaluates the when statements of each populated node in the
+// data tree rooted at value, whose schema is supplied, and returns an error
+// for each node whose when statement is false, or removes such nodes if
+// pruning is requested. Where the change filter f is non-nil, only the
+// statements that may be affected by the changes are evaluated.
+func validateWhen(schema *yang.Entry, value interface{}, opt *WhenOptions, f *changeFilter) util.Errors {
+	for {
+		_, top := newXPathTree(schema, value)
+		status := f.xpathStatusFunc()
+		var errs util.Errors
+		var prune []*xpathNode
+		pruneWhen := map[*xpathNode]*xpathStatement{}
+		walkXPathTree(top, func(n *xpathNode) bool {
+			s := status(n)
+			if f.skipXPathSubtree(n, s) {
+				return false
+			}
+			// The context node of the when statements of the
+			// top-level node is outside of the data tree being
+			// validated where it is not the root.
+			if n.parent == nil || n.parent.schema == nil {
+				return true
+			}
+			w, err := evalWhen(n, opt, f, s)
+			switch {
+			case err != nil:
+				errs = util.AppendErr(errs, err)
+				return true
+			case w == nil:
+				return true
+			case opt.Prune:
+				prune = append(prune, n)
+				pruneWhen[n] = w
+			default:
+				errs = util.AppendErr(errs, xpathValidationError(WhenConstraint, n, fmt.Errorf("%s: node is populated but its when statement %q is false", n.pathString(), w.expr)))
+			}
+			return false
+		})
+		if !opt.Prune || len(prune) == 0 {
+			return errs
+		}
+
+		// Nodes are removed in the reverse order to which they were
+		// visited, such that removing a member of a slice does not
+		// change the index of any other node that is to be removed.
+		var removed bool
+		var kept []*xpathNode
+		for i := len(prune) - 1; i >= 0; i-- {
+			util.DbgPrint("pruning %s since its when statement is false", prune[i].pathString())
+			ok, err := prune[i].remove()
+			switch {
+			case err != nil:
+				return util.AppendErr(errs, fmt.Errorf("%s: cannot remove node: %v", prune[i].pathString(), err))
+			case ok:
+				removed = true
+			default:
+				kept = append(kept, prune[i])
+			}
+		}
+		// Where no node could be removed, the data tree is unchanged,
+		// such that evaluating it again cannot remove any further
+		// nodes. The nodes that remain populated are reported.
+		if !removed {
+			for i := len(kept) - 1; i >= 0; i-- {
+				n := kept[i]
+				errs = util.AppendErr(errs, xpathValidationError(WhenConstraint, n, fmt.Errorf("%s: node is populated but its when statement %q is false, and it cannot be removed since it shares data with another node", n.pathString(), pruneWhen[n].expr)))
+			}
+			return errs
+		}
+	}
+}
+
+// evalWhen evaluates the when statements that apply to the node n, including
+// those of the choice and case statements that the node is within, and
+// returns the first statement that is false, or nil if they are all true. An
+// error is returned if a statement cannot be evaluated. Statements that are
+// not affected by the changes described by f, given the status s of the node,
+// are not evaluated.
+func evalWhen(n *xpathNode, opt *WhenOptions, f *changeFilter, s changeStatus) (*xpathStatement, error) {
+	ws, err := whenStatements(n.schema)
+	if err != nil {
+		return nil, xpathValidationError(WhenConstraint, n, fmt.Errorf("%s: %v", n.pathString(), err))
+	}
+	for e := n.schema.Parent; e != nil && util.IsChoiceOrCase(e); e = e.Parent {
+		cws, err := whenStatements(e)
+		if err != nil {
+			return nil, xpathValidationError(WhenConstraint, n, fmt.Errorf("%s: %v", n.pathString(), err))
+		}
+		ws = append(ws, cws...)
+	}
+	for _, w := range ws {
+		if !f.evaluate(s, w.expr) {
+			continue
+		}
+		ctx := n
+		if w.parentContext {
+			ctx = n.parent
+		}
+		ok, err := evalXPathBool(w.expr, ctx)
+		switch {
+		case err != nil && opt.IgnoreUnsupported:
+			util.DbgPrint("skipping when statement %q at %s: %v", w.expr, n.pathString(), err)
+		case err != nil:
+			return nil, xpathValidationError(WhenConstraint, n, fmt.Errorf("%s: cannot evaluate when statement %q: %v", n.pathString(), w.expr, err))
+		case !ok:
+			return w, nil
+		}
+	}
+	return nil, nil
+}
+
+// remove removes the node from the data tree, such that it is no longer
+// populated. It reports whether the node was removed, which is not the case
+// for a container that is compressed out of the generated code where it
+// remains populated by a field that it shares with another node, such as the
+// key of a list.
+func (n *xpathNode) remove() (bool, error) {
+	switch {
+	case len(n.prefix) > 0:
+		// The node is a container that is compressed out of the
+		// generated code, hence each field that is solely within it is
+		// cleared.
+		sv := n.value.Elem()
+		var populated bool
+		for i := 0; i < sv.NumField(); i++ {
+			sf := sv.Type().Field(i)
+			if util.IsYgotAnnotation(sf) {
+				continue
+			}
+			ps, err := util.SchemaPaths(sf)
+			if err != nil {
+				continue
+			}
+			var within, shared bool
+			for _, p := range ps {
+				if len(p) > 1 && p[0] == n.holderSchema.Name && n.holderSchema.Dir[p[0]] == nil {
+					p = p[1:]
+				}
+				if len(p) > len(n.prefix) && pathMatchesPrefix(p, n.prefix) {
+					within = true
+				} else {
+					shared = true
+				}
+			}
+			switch {
+			case !within:
+			case !shared:
+				sv.Field(i).Set(reflect.Zero(sf.Type))
+			case !isEmptyDataValue(sv.Field(i)):
+				populated = true
+			}
+		}
+		return !populated, nil
+	case !n.field.CanSet():
+		return false, fmt.Errorf("cannot set field of type %s", n.field.Type())
+	case n.schema.IsList() && n.key.IsValid():
+		if om, ok := n.field.Interface().(ygot.GoOrderedMap); ok {
+			_, err := yreflect.DeleteFromOrderedMap(om, n.key)
+			return err == nil, err
+		}
+		n.field.SetMapIndex(n.key, reflect.Value{})
+		return true, nil
+	case n.schema.IsList() || n.schema.IsLeafList():
+		n.field.Set(reflect.AppendSlice(n.field.Slice(0, n.index), n.field.Slice(n.index+1, n.field.Len())))
+		return true, nil
+	}
+	n.field.Set(reflect.Zero(n.field.Type()))
+	return true, nil
+}
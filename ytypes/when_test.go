@@ -0,0 +1,278 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestWhenStatements(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      *yang.Entry
+		want    []*xpathStatement
+		wantErr bool
+	}{{
+		desc: "no when statements",
+		in:   &yang.Entry{Name: "leaf", Kind: yang.LeafEntry},
+	}, {
+		desc: "statement without node or annotation",
+		in: &yang.Entry{
+			Name:  "leaf",
+			Kind:  yang.LeafEntry,
+			Extra: map[string][]interface{}{"when": {&yang.Value{Name: "../a = 'b'"}}},
+		},
+		wantErr: true,
+	}, {
+		desc: "own statement with annotation",
+		in: &yang.Entry{
+			Name:       "leaf",
+			Kind:       yang.LeafEntry,
+			Extra:      map[string][]interface{}{"when": {&yang.Value{Name: "../a = 'b'"}}},
+			Annotation: map[string]interface{}{util.WhenXPathAnnotation: "../a = 'b'"},
+		},
+		want: []*xpathStatement{{expr: "../a = 'b'"}},
+	}, {
+		desc: "own and inherited statements from JSON schema",
+		in: &yang.Entry{
+			Name: "leaf",
+			Kind: yang.LeafEntry,
+			Extra: map[string][]interface{}{"when": {
+				map[string]interface{}{"Name": "../a = 'b'"},
+				map[string]interface{}{"Name": "a = 'c'"},
+			}},
+			Annotation: map[string]interface{}{util.WhenXPathAnnotation: "../a = 'b'"},
+		},
+		want: []*xpathStatement{{expr: "../a = 'b'"}, {expr: "a = 'c'", parentContext: true}},
+	}, {
+		desc: "inherited statement only",
+		in: &yang.Entry{
+			Name:       "leaf",
+			Kind:       yang.LeafEntry,
+			Extra:      map[string][]interface{}{"when": {map[string]interface{}{"Name": "a = 'c'"}}},
+			Annotation: map[string]interface{}{util.WhenXPathAnnotation: ""},
+		},
+		want: []*xpathStatement{{expr: "a = 'c'", parentContext: true}},
+	}, {
+		desc: "case statement",
+		in: &yang.Entry{
+			Name:  "case",
+			Kind:  yang.CaseEntry,
+			Extra: map[string][]interface{}{"when": {&yang.Value{Name: "a = 'c'"}}},
+		},
+		want: []*xpathStatement{{expr: "a = 'c'", parentContext: true}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := whenStatements(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("whenStatements(): got error %v, want error: %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(xpathStatement{})); diff != "" {
+				t.Errorf("whenStatements(): (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateWhen(t *testing.T) {
+	// addWhen returns a function that adds the supplied when statements to
+	// the schema entry at the path p, relative to the root.
+	addWhen := func(p []string, annotation *string, ws ...interface{}) func(*yang.Entry) {
+		return func(root *yang.Entry) {
+			e := root
+			for _, n := range p {
+				e = e.Dir[n]
+			}
+			e.Extra = map[string][]interface{}{"when": ws}
+			if annotation != nil {
+				e.Annotation = map[string]interface{}{util.WhenXPathAnnotation: *annotation}
+			}
+		}
+	}
+	// ownWhen returns a function that adds the supplied when statement to
+	// the schema entry at the path p as the entry's own statement.
+	ownWhen := func(p []string, expr string) func(*yang.Entry) {
+		return addWhen(p, &expr, &yang.Value{Name: expr})
+	}
+	all := func(fns ...func(*yang.Entry)) func(*yang.Entry) {
+		return func(root *yang.Entry) {
+			for _, fn := range fns {
+				fn(root)
+			}
+		}
+	}
+	noOwn := ""
+	ifPath := []string{"interfaces", "interface"}
+	mtuPath := []string{"interfaces", "interface", "config", "mtu"}
+	subintfsPath := []string{"interfaces", "interface", "subinterfaces"}
+	mtuWhen := ownWhen(mtuPath, "../type = 'ianaift:ethernetCsmacd'")
+	// subintfsWhen is a when statement that is inherited from an augment
+	// whose target is the interface list.
+	subintfsWhen := addWhen(subintfsPath, &noOwn, map[string]interface{}{"Name": "config/type = 'ianaift:ethernetCsmacd'"})
+
+	withLoopbackMtu := func() *xpathTestDevice {
+		d := xpathTestData()
+		d.Interface["lo0"].Mtu = ygot.Uint16(1500)
+		return d
+	}
+	withLoopbackSubintf := func() *xpathTestDevice {
+		d := xpathTestData()
+		d.Interface["lo0"].Subinterface = map[uint32]*xpathTestSubinterface{
+			0: {Index: ygot.Uint32(0)},
+		}
+		return d
+	}
+	withLoopbackNeighbor := func() *xpathTestDevice {
+		d := withLoopbackMtu()
+		d.Bgp.Neighbor["192.0.2.1"] = &xpathTestNeighbor{
+			NeighborAddress: ygot.String("192.0.2.1"),
+			Interface:       ygot.String("lo0"),
+		}
+		return d
+	}
+
+	tests := []struct {
+		desc       string
+		inModify   func(*yang.Entry)
+		inData     *xpathTestDevice
+		inOpts     []ygot.ValidationOption
+		wantErrors []string
+		wantData   *xpathTestDevice
+	}{{
+		desc:     "when statement true",
+		inModify: mtuWhen,
+		inData:   xpathTestData(),
+		inOpts:   []ygot.ValidationOption{&WhenOptions{}},
+	}, {
+		desc:     "populated leaf with false when statement",
+		inModify: mtuWhen,
+		inData:   withLoopbackMtu(),
+		inOpts:   []ygot.ValidationOption{&WhenOptions{}},
+		wantErrors: []string{
+			`/interfaces/interface[name=lo0]/config/mtu: node is populated but its when statement "../type = 'ianaift:ethernetCsmacd'" is false`,
+		},
+	}, {
+		desc:     "own when statement not annotated",
+		inModify: addWhen(mtuPath, nil, map[string]interface{}{"Name": "../type = 'ianaift:ethernetCsmacd'"}),
+		inData:   withLoopbackMtu(),
+		inOpts:   []ygot.ValidationOption{&WhenOptions{Prune: true}},
+		wantErrors: []string{
+			`/interfaces/interface[name=eth0]/config/mtu: cannot distinguish the when statement of schema entry /device/interfaces/interface/config/mtu from those inherited from augment and uses statements, the generated code must be regenerated`,
+			`/interfaces/interface[name=lo0]/config/mtu: cannot distinguish the when statement of schema entry /device/interfaces/interface/config/mtu from those inherited from augment and uses statements, the generated code must be regenerated`,
+		},
+		wantData: withLoopbackMtu(),
+	}, {
+		desc:     "when statements not evaluated without option",
+		inModify: mtuWhen,
+		inData:   withLoopbackMtu(),
+	}, {
+		desc:     "leaf with false when statement pruned",
+		inModify: mtuWhen,
+		inData:   withLoopbackMtu(),
+		inOpts:   []ygot.ValidationOption{&WhenOptions{Prune: true}},
+		wantData: xpathTestData(),
+	}, {
+		desc:     "inherited when statement on compressed container",
+		inModify: subintfsWhen,
+		inData:   withLoopbackSubintf(),
+		inOpts:   []ygot.ValidationOption{&WhenOptions{}},
+		wantErrors: []string{
+			`/interfaces/interface[name=lo0]/subinterfaces: node is populated but its when statement "config/type = 'ianaift:ethernetCsmacd'" is false`,
+		},
+	}, {
+		desc:     "compressed container pruned",
+		inModify: subintfsWhen,
+		inData:   withLoopbackSubintf(),
+		inOpts:   []ygot.ValidationOption{&WhenOptions{Prune: true}},
+		wantData: xpathTestData(),
+	}, {
+		desc:     "compressed container sharing a list key is not pruned",
+		inModify: ownWhen([]string{"interfaces", "interface", "config"}, "../name = 'eth0'"),
+		inData:   xpathTestData(),
+		inOpts:   []ygot.ValidationOption{&WhenOptions{Prune: true}},
+		wantErrors: []string{
+			`/interfaces/interface[name=lo0]/config: node is populated but its when statement "../name = 'eth0'" is false, and it cannot be removed since it shares data with another node`,
+		},
+		wantData: func() *xpathTestDevice {
+			d := xpathTestData()
+			d.Interface["lo0"] = &xpathTestInterface{Name: ygot.String("lo0")}
+			return d
+		}(),
+	}, {
+		desc:     "leaf-list members pruned",
+		inModify: ownWhen([]string{"interfaces", "interface", "subinterfaces", "subinterface", "config", "address"}, ". != '192.0.2.1'"),
+		inData:   xpathTestData(),
+		inOpts:   []ygot.ValidationOption{&WhenOptions{Prune: true}},
+		wantData: func() *xpathTestDevice {
+			d := xpathTestData()
+			d.Interface["eth0"].Subinterface[0].Address = []string{"192.0.2.2"}
+			return d
+		}(),
+	}, {
+		desc: "removal of list entry cascades",
+		inModify: all(
+			ownWhen(ifPath, "config/type != 'ianaift:softwareLoopback'"),
+			ownWhen([]string{"bgp", "neighbors", "neighbor", "config", "interface"}, "/interfaces/interface[name = current()]"),
+		),
+		inData: withLoopbackNeighbor(),
+		inOpts: []ygot.ValidationOption{&WhenOptions{Prune: true}, &LeafrefOptions{IgnoreMissingData: true}},
+		wantData: func() *xpathTestDevice {
+			d := xpathTestData()
+			delete(d.Interface, "lo0")
+			d.Bgp.Neighbor["192.0.2.1"] = &xpathTestNeighbor{NeighborAddress: ygot.String("192.0.2.1")}
+			return d
+		}(),
+	}, {
+		desc:     "unsupported expression",
+		inModify: ownWhen(mtuPath, "unknown-function(.)"),
+		inData:   xpathTestData(),
+		inOpts:   []ygot.ValidationOption{&WhenOptions{}},
+		wantErrors: []string{
+			`/interfaces/interface[name=eth0]/config/mtu: cannot evaluate when statement "unknown-function(.)": unsupported XPath function unknown-function()`,
+		},
+	}, {
+		desc:     "unsupported expression ignored",
+		inModify: ownWhen(mtuPath, "unknown-function(.)"),
+		inData:   xpathTestData(),
+		inOpts:   []ygot.ValidationOption{&WhenOptions{IgnoreUnsupported: true}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := xpathTestSchema(tt.inModify)
+			errs := Validate(schema, tt.inData, tt.inOpts...)
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tt.wantErrors, got); diff != "" {
+				t.Errorf("Validate(): did not get expected errors, (-want, +got):\n%s", diff)
+			}
+			if tt.wantData == nil {
+				return
+			}
+			if diff := cmp.Diff(tt.wantData, tt.inData); diff != "" {
+				t.Errorf("Validate(): did not get expected pruned data, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// UnmarshalXML unmarshals the XML encoded data into parent, using the given
+// schema, with the same semantics as Unmarshal. data is an XML fragment whose
+// top-level elements are the children of the node described by schema, as
+// output by ygot.MarshalXML, such that schema must be that of a container or
+// list, and parent the corresponding struct ptr. Elements are matched to the
+// fields of the GoStructs by their local names, and the prefixes of
+// identityref values are discarded, as for the module names of RFC7951 JSON.
+//
+// The supported options are IgnoreExtraFields and PreferShadowPath.
+func UnmarshalXML(schema *yang.Entry, parent interface{}, data []byte, opts ...UnmarshalOpt) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema for parent type %T", parent)
+	}
+	if !schema.IsContainer() && !schema.IsList() {
+		return fmt.Errorf("cannot unmarshal XML into schema %s, expect container or list", schema.Name)
+	}
+
+	tree, err := xmlToTree(data)
+	if err != nil {
+		return err
+	}
+	if schema.IsList() {
+		// The elements are those of a single entry of the list.
+		newSchema := *schema
+		newSchema.ListAttr = nil
+		schema = &newSchema
+	}
+	return unmarshalGeneric(schema, parent, tree, XMLEncoding, opts...)
+}
+
+// xmlToTree returns the data tree that corresponds to the XML fragment data,
+// which is used as the value unmarshalled with XMLEncoding. The tree is a
+// map[string]interface{} keyed by the local names of the top-level elements.
+// The value of an element that has child elements is the tree of its
+// children, and that of an element that does not is its character data, as a
+// string. Where there are multiple elements with the same name, the value is
+// a []interface{} containing the value of each in order.
+func xmlToTree(data []byte) (map[string]interface{}, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	root := map[string]interface{}{}
+	if err := xmlChildren(d, root, nil); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// xmlChildren decodes the child elements of the element start, whose start
+// element has been read, into tree. start is nil for the top level of the
+// fragment, where decoding continues until the end of the input.
+func xmlChildren(d *xml.Decoder, tree map[string]interface{}, start *xml.StartElement) error {
+	for {
+		t, err := d.Token()
+		switch {
+		case err == io.EOF && start == nil:
+			return nil
+		case err != nil:
+			return fmt.Errorf("invalid XML: %v", err)
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			v, err := xmlElementValue(d, t)
+			if err != nil {
+				return err
+			}
+			xmlAddValue(tree, t.Name.Local, v)
+		case xml.EndElement:
+			return nil
+		case xml.CharData:
+			if strings.TrimSpace(string(t)) != "" {
+				name := "top level of XML fragment"
+				if start != nil {
+					name = "element " + start.Name.Local
+				}
+				return fmt.Errorf("unexpected character data %q at %s", string(t), name)
+			}
+		}
+	}
+}
+
+// xmlElementValue returns the value of the element whose start element start
+// has been read, as described by xmlToTree.
+func xmlElementValue(d *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	var text strings.Builder
+	for {
+		t, err := d.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML: %v", err)
+		}
+
+		switch t := t.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+			if strings.TrimSpace(text.String()) != "" {
+				return nil, fmt.Errorf("element %s contains both character data and elements", start.Name.Local)
+			}
+			tree := map[string]interface{}{}
+			v, err := xmlElementValue(d, t)
+			if err != nil {
+				return nil, err
+			}
+			xmlAddValue(tree, t.Name.Local, v)
+			if err := xmlChildren(d, tree, &start); err != nil {
+				return nil, err
+			}
+			return tree, nil
+		case xml.EndElement:
+			return text.String(), nil
+		}
+	}
+}
+
+// xmlAddValue adds the value v of the element name to tree.
+func xmlAddValue(tree map[string]interface{}, name string, v interface{}) {
+	e, ok := tree[name]
+	switch {
+	case !ok:
+		tree[name] = v
+	case isXMLValueList(e):
+		tree[name] = append(e.([]interface{}), v)
+	default:
+		tree[name] = []interface{}{e, v}
+	}
+}
+
+// isXMLValueList returns true if v is the value of multiple elements.
+func isXMLValueList(v interface{}) bool {
+	_, ok := v.([]interface{})
+	return ok
+}
+
+// xmlContainerValue returns the XML data tree value v of a container or list
+// entry as a map[string]interface{}, where it is that of an element without
+// children.
+func xmlContainerValue(v interface{}) interface{} {
+	if s, ok := v.(string); ok && strings.TrimSpace(s) == "" {
+		return map[string]interface{}{}
+	}
+	return v
+}
+
+// xmlListValue returns the XML data tree value v of a list or leaf-list as a
+// []interface{}, where it is the value of a single element.
+func xmlListValue(v interface{}) []interface{} {
+	if l, ok := v.([]interface{}); ok {
+		return l
+	}
+	return []interface{}{v}
+}
+
+// xmlToJSONValue returns the character data value of a leaf as the value that
+// would be unmarshalled from its RFC7951 JSON representation, for a leaf of
+// the YANG type ykind.
+func xmlToJSONValue(ykind yang.TypeKind, value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("got %T type, want XML character data", value)
+	}
+
+	switch ykind {
+	case yang.Yint8, yang.Yint16, yang.Yint32:
+		i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return float64(i), nil
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		u, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return float64(u), nil
+	case yang.Ybool:
+		switch strings.TrimSpace(s) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, fmt.Errorf("invalid boolean value %q", s)
+	case yang.Yempty:
+		if strings.TrimSpace(s) != "" {
+			return nil, fmt.Errorf("empty leaves must not have content, got %q", s)
+		}
+		return []interface{}{nil}, nil
+	case yang.Yint64, yang.Yuint64, yang.Ydecimal64, yang.Yenum, yang.Yidentityref:
+		return strings.TrimSpace(s), nil
+	}
+	return s, nil
+}
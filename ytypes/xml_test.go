@@ -0,0 +1,410 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// xpathTestXML is the XML representation of xpathTestData.
+const xpathTestXML = `
+<interfaces xmlns="urn:oc-if">
+  <interface>
+    <name>eth0</name>
+    <config>
+      <name>eth0</name>
+      <mtu>1500</mtu>
+      <type xmlns:ianaift="urn:iana-if-type">ianaift:ethernetCsmacd</type>
+    </config>
+    <subinterfaces>
+      <subinterface>
+        <index>0</index>
+        <config><index>0</index><address>192.0.2.1</address><address>192.0.2.2</address></config>
+      </subinterface>
+      <subinterface>
+        <index>1</index>
+        <config><index>1</index><description>sub one</description></config>
+      </subinterface>
+    </subinterfaces>
+  </interface>
+  <interface>
+    <name>lo0</name>
+    <config>
+      <name>lo0</name>
+      <type>softwareLoopback</type>
+      <enabled>false</enabled>
+      <description>loopback</description>
+    </config>
+  </interface>
+</interfaces>
+<bgp xmlns="urn:oc-bgp">
+  <global><config><as>64512</as></config></global>
+  <neighbors>
+    <neighbor>
+      <neighbor-address>192.0.2.254</neighbor-address>
+      <config>
+        <neighbor-address>192.0.2.254</neighbor-address>
+        <peer-as>64513</peer-as>
+        <interface>eth0</interface>
+      </config>
+    </neighbor>
+  </neighbors>
+</bgp>`
+
+func TestUnmarshalXML(t *testing.T) {
+	tests := []struct {
+		desc     string
+		inSchema func(*yang.Entry) *yang.Entry
+		inParent ygot.GoStruct
+		inXML    string
+		inOpts   []UnmarshalOpt
+		want     ygot.GoStruct
+		wantErr  string
+	}{{
+		desc:  "data tree",
+		inXML: xpathTestXML,
+		want:  xpathTestData(),
+	}, {
+		desc:  "empty fragment",
+		inXML: ``,
+		want:  &xpathTestDevice{},
+	}, {
+		desc:  "empty container",
+		inXML: `<bgp/>`,
+		want:  &xpathTestDevice{Bgp: &xpathTestBgp{}},
+	}, {
+		desc:  "single leaf-list entry",
+		inXML: `<interfaces><interface><name>eth0</name><subinterfaces><subinterface><index>0</index><config><address>192.0.2.1</address></config></subinterface></subinterfaces></interface></interfaces>`,
+		want: &xpathTestDevice{Interface: map[string]*xpathTestInterface{
+			"eth0": {
+				Name: ygot.String("eth0"),
+				Subinterface: map[uint32]*xpathTestSubinterface{
+					0: {Index: ygot.Uint32(0), Address: []string{"192.0.2.1"}},
+				},
+			},
+		}},
+	}, {
+		desc: "merge into existing list entries",
+		inParent: func() ygot.GoStruct {
+			d := xpathTestData()
+			d.Bgp = nil
+			return d
+		}(),
+		inXML: `<interfaces>
+		  <interface><name>eth0</name><config><name>eth0</name><mtu>9000</mtu></config></interface>
+		  <interface><name>eth1</name><config><name>eth1</name></config></interface>
+		</interfaces>`,
+		want: func() ygot.GoStruct {
+			d := xpathTestData()
+			d.Bgp = nil
+			d.Interface["eth0"].Mtu = ygot.Uint16(9000)
+			d.Interface["eth1"] = &xpathTestInterface{Name: ygot.String("eth1")}
+			return d
+		}(),
+	}, {
+		desc: "list entry",
+		inSchema: func(root *yang.Entry) *yang.Entry {
+			return root.Dir["bgp"].Dir["neighbors"].Dir["neighbor"]
+		},
+		inParent: &xpathTestNeighbor{},
+		inXML:    `<neighbor-address>192.0.2.1</neighbor-address><key-chain>kc</key-chain><key-id>1</key-id>`,
+		want: &xpathTestNeighbor{
+			NeighborAddress: ygot.String("192.0.2.1"),
+			KeyChain:        ygot.String("kc"),
+			KeyId:           ygot.Uint32(1),
+		},
+	}, {
+		desc:    "unexpected element",
+		inXML:   `<bgp><global><config><as>1</as><router-id>192.0.2.1</router-id></config></global></bgp>`,
+		wantErr: "parent container bgp (type *ytypes.xpathTestBgp): JSON contains unexpected field router-id",
+	}, {
+		desc:   "unexpected element with IgnoreExtraFields",
+		inXML:  `<bgp><global><config><as>1</as><router-id><a>1</a></router-id></config></global></bgp>`,
+		inOpts: []UnmarshalOpt{&IgnoreExtraFields{}},
+		want:   &xpathTestDevice{Bgp: &xpathTestBgp{As: ygot.Uint32(1)}},
+	}, {
+		desc:    "different values at the paths of a field",
+		inXML:   `<interfaces><interface><name>eth0</name><config><name>eth1</name></config></interface></interfaces>`,
+		wantErr: "values at paths [config name] and [name] are different: eth1 != eth0",
+	}, {
+		desc:    "bad leaf value",
+		inXML:   `<bgp><global><config><as>one</as></config></global></bgp>`,
+		wantErr: `error parsing one for schema as: strconv.ParseUint: parsing "one": invalid syntax`,
+	}, {
+		desc:    "leaf with child elements",
+		inXML:   `<bgp><global><config><as><a/></as></config></global></bgp>`,
+		wantErr: "got map[string]interface {} type, want XML character data",
+	}, {
+		desc:    "mixed content",
+		inXML:   `<bgp>text<global/></bgp>`,
+		wantErr: "element bgp contains both character data and elements",
+	}, {
+		desc:    "character data at top level",
+		inXML:   `text`,
+		wantErr: `unexpected character data "text" at top level of XML fragment`,
+	}, {
+		desc:    "malformed XML",
+		inXML:   `<bgp><global></bgp>`,
+		wantErr: "invalid XML",
+	}, {
+		desc: "leaf schema",
+		inSchema: func(root *yang.Entry) *yang.Entry {
+			return root.Dir["bgp"].Dir["global"].Dir["config"].Dir["as"]
+		},
+		inXML:   `<as>1</as>`,
+		wantErr: "cannot unmarshal XML into schema as, expect container or list",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := xpathTestSchema(nil)
+			if tt.inSchema != nil {
+				schema = tt.inSchema(schema)
+			}
+			parent := tt.inParent
+			if parent == nil {
+				parent = &xpathTestDevice{}
+			}
+			err := UnmarshalXML(schema, parent, []byte(tt.inXML), tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalXML: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, parent); diff != "" {
+				t.Errorf("UnmarshalXML (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// xmlTestStruct is a GoStruct containing a field of each of the types that
+// are supported by ytypes, used within the XML tests.
+type xmlTestStruct struct {
+	Int8Leaf      *int8           `path:"int8-leaf" module:"xt"`
+	Int16Leaf     *int16          `path:"int16-leaf" module:"xt"`
+	Int32Leaf     *int32          `path:"int32-leaf" module:"xt"`
+	Int64Leaf     *int64          `path:"int64-leaf" module:"xt"`
+	Uint8Leaf     *uint8          `path:"uint8-leaf" module:"xt"`
+	Uint16Leaf    *uint16         `path:"uint16-leaf" module:"xt"`
+	Uint32Leaf    *uint32         `path:"uint32-leaf" module:"xt"`
+	Uint64Leaf    *uint64         `path:"uint64-leaf" module:"xt"`
+	DecimalLeaf   *float64        `path:"decimal-leaf" module:"xt"`
+	BoolLeaf      *bool           `path:"bool-leaf" module:"xt"`
+	StringLeaf    *string         `path:"string-leaf" module:"xt"`
+	BinaryLeaf    Binary          `path:"binary-leaf" module:"xt"`
+	EmptyLeaf     YANGEmpty       `path:"empty-leaf" module:"xt"`
+	EnumLeaf      EnumType        `path:"enum-leaf" module:"xt"`
+	Int8LeafList  []int8          `path:"int8-leaflist" module:"xt"`
+	UnionLeaf     UnionLeafType   `path:"union-leaf" module:"xt"`
+	UnionLeafList []UnionLeafType `path:"union-leaflist" module:"xt"`
+}
+
+func (*xmlTestStruct) IsYANGGoStruct()                          {}
+func (*xmlTestStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*xmlTestStruct) ΛBelongingModule() string                 { return "xt" }
+
+func (*xmlTestStruct) ΛEnumTypeMap() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		"/xml-test/union-leaf":     {reflect.TypeOf(EnumType(0))},
+		"/xml-test/union-leaflist": {reflect.TypeOf(EnumType(0))},
+	}
+}
+
+func (*xmlTestStruct) To_UnionLeafType(i interface{}) (UnionLeafType, error) {
+	switch v := i.(type) {
+	case string:
+		return &UnionLeafType_String{v}, nil
+	case uint32:
+		return &UnionLeafType_Uint32{v}, nil
+	case EnumType:
+		return &UnionLeafType_EnumType{v}, nil
+	}
+	return nil, fmt.Errorf("cannot convert %v to UnionLeafType, unknown union type, got: %T", i, i)
+}
+
+// xmlTestStructSchema returns the schema for xmlTestStruct.
+func xmlTestStructSchema() *yang.Entry {
+	union := &yang.YangType{
+		Kind: yang.Yunion,
+		Type: []*yang.YangType{
+			{Kind: yang.Yuint32},
+			{Kind: yang.Yenum},
+			{Kind: yang.Ystring},
+		},
+	}
+	root := &yang.Entry{Name: "xml-test", Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{}}
+	for _, s := range []*yang.Entry{
+		typeToLeafSchema("int8-leaf", yang.Yint8),
+		typeToLeafSchema("int16-leaf", yang.Yint16),
+		typeToLeafSchema("int32-leaf", yang.Yint32),
+		typeToLeafSchema("int64-leaf", yang.Yint64),
+		typeToLeafSchema("uint8-leaf", yang.Yuint8),
+		typeToLeafSchema("uint16-leaf", yang.Yuint16),
+		typeToLeafSchema("uint32-leaf", yang.Yuint32),
+		typeToLeafSchema("uint64-leaf", yang.Yuint64),
+		typeToLeafSchema("decimal-leaf", yang.Ydecimal64),
+		typeToLeafSchema("bool-leaf", yang.Ybool),
+		typeToLeafSchema("string-leaf", yang.Ystring),
+		typeToLeafSchema("binary-leaf", yang.Ybinary),
+		typeToLeafSchema("empty-leaf", yang.Yempty),
+		typeToLeafSchema("enum-leaf", yang.Yenum),
+		{Name: "int8-leaflist", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yint8}, ListAttr: yang.NewDefaultListAttr()},
+		{Name: "union-leaf", Kind: yang.LeafEntry, Type: union},
+		{Name: "union-leaflist", Kind: yang.LeafEntry, Type: union, ListAttr: yang.NewDefaultListAttr()},
+	} {
+		s.Parent = root
+		root.Dir[s.Name] = s
+	}
+	return root
+}
+
+func TestUnmarshalXMLTypes(t *testing.T) {
+	tests := []struct {
+		desc    string
+		inXML   string
+		want    *xmlTestStruct
+		wantErr string
+	}{{
+		desc: "integers",
+		inXML: `<int8-leaf>-8</int8-leaf><int16-leaf>-16</int16-leaf><int32-leaf>-32</int32-leaf><int64-leaf>-64</int64-leaf>` +
+			`<uint8-leaf>8</uint8-leaf><uint16-leaf>16</uint16-leaf><uint32-leaf>32</uint32-leaf><uint64-leaf>18446744073709551615</uint64-leaf>`,
+		want: &xmlTestStruct{
+			Int8Leaf:   ygot.Int8(-8),
+			Int16Leaf:  ygot.Int16(-16),
+			Int32Leaf:  ygot.Int32(-32),
+			Int64Leaf:  ygot.Int64(-64),
+			Uint8Leaf:  ygot.Uint8(8),
+			Uint16Leaf: ygot.Uint16(16),
+			Uint32Leaf: ygot.Uint32(32),
+			Uint64Leaf: ygot.Uint64(18446744073709551615),
+		},
+	}, {
+		desc:  "other scalar types",
+		inXML: `<decimal-leaf>42.42</decimal-leaf><bool-leaf>true</bool-leaf><string-leaf> a &amp; b </string-leaf><binary-leaf>AQI=</binary-leaf><empty-leaf/>`,
+		want: &xmlTestStruct{
+			DecimalLeaf: ygot.Float64(42.42),
+			BoolLeaf:    ygot.Bool(true),
+			StringLeaf:  ygot.String(" a & b "),
+			BinaryLeaf:  Binary{1, 2},
+			EmptyLeaf:   true,
+		},
+	}, {
+		desc:  "enumeration and leaf-list",
+		inXML: `<enum-leaf>E_VALUE_FORTY_TWO</enum-leaf><int8-leaflist>1</int8-leaflist><int8-leaflist>2</int8-leaflist>`,
+		want:  &xmlTestStruct{EnumLeaf: EnumType(42), Int8LeafList: []int8{1, 2}},
+	}, {
+		desc:  "unions",
+		inXML: `<union-leaf>42</union-leaf><union-leaflist>forty-two</union-leaflist><union-leaflist>E_VALUE_FORTY_ONE</union-leaflist>`,
+		want: &xmlTestStruct{
+			UnionLeaf:     &UnionLeafType_Uint32{42},
+			UnionLeafList: []UnionLeafType{&UnionLeafType_String{"forty-two"}, &UnionLeafType_EnumType{41}},
+		},
+	}, {
+		desc:    "out of range integer",
+		inXML:   `<int8-leaf>128</int8-leaf>`,
+		wantErr: "error parsing 128 for schema int8-leaf",
+	}, {
+		desc:    "bad boolean",
+		inXML:   `<bool-leaf>yes</bool-leaf>`,
+		wantErr: `invalid boolean value "yes"`,
+	}, {
+		desc:    "empty leaf with content",
+		inXML:   `<empty-leaf>x</empty-leaf>`,
+		wantErr: `empty leaves must not have content, got "x"`,
+	}, {
+		desc:    "bad enumeration",
+		inXML:   `<enum-leaf>E_VALUE_FORTY_THREE</enum-leaf>`,
+		wantErr: "E_VALUE_FORTY_THREE is not a valid value for enum field EnumLeaf",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &xmlTestStruct{}
+			err := UnmarshalXML(xmlTestStructSchema(), got, []byte(tt.inXML))
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalXML: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalXML (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	cfg := &ygot.XMLConfig{Namespaces: map[string]string{"iana-if-type": "urn:iana-if-type", "xt": "urn:xt"}}
+	tests := []struct {
+		desc     string
+		inSchema *yang.Entry
+		in       ygot.GoStruct
+		new      func() ygot.GoStruct
+	}{{
+		desc:     "data tree",
+		inSchema: xpathTestSchema(nil),
+		in:       xpathTestData(),
+		new:      func() ygot.GoStruct { return &xpathTestDevice{} },
+	}, {
+		desc:     "all types",
+		inSchema: xmlTestStructSchema(),
+		in: &xmlTestStruct{
+			Int8Leaf:      ygot.Int8(-128),
+			Int16Leaf:     ygot.Int16(32767),
+			Int32Leaf:     ygot.Int32(-2147483648),
+			Int64Leaf:     ygot.Int64(-9223372036854775808),
+			Uint8Leaf:     ygot.Uint8(255),
+			Uint16Leaf:    ygot.Uint16(65535),
+			Uint32Leaf:    ygot.Uint32(4294967295),
+			Uint64Leaf:    ygot.Uint64(18446744073709551615),
+			DecimalLeaf:   ygot.Float64(-0.125),
+			BoolLeaf:      ygot.Bool(false),
+			StringLeaf:    ygot.String("<&'\">"),
+			BinaryLeaf:    Binary("binary"),
+			EmptyLeaf:     true,
+			EnumLeaf:      EnumType(41),
+			Int8LeafList:  []int8{3, 1, 2},
+			UnionLeaf:     &UnionLeafType_EnumType{42},
+			UnionLeafList: []UnionLeafType{&UnionLeafType_Uint32{1}, &UnionLeafType_String{"one"}},
+		},
+		new: func() ygot.GoStruct { return &xmlTestStruct{} },
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := ygot.MarshalXML(tt.in, cfg, ygot.XMLIndent("  "))
+			if err != nil {
+				t.Fatalf("MarshalXML: %v", err)
+			}
+			got := tt.new()
+			if err := UnmarshalXML(tt.inSchema, got, b); err != nil {
+				t.Fatalf("UnmarshalXML(%s): %v", b, err)
+			}
+			if diff := cmp.Diff(tt.in, got); diff != "" {
+				t.Errorf("round trip of %s (-want, +got):\n%s", b, diff)
+			}
+		})
+	}
+}
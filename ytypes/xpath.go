@@ -0,0 +1,703 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/openconfig/ygot/util"
+)
+
+// This file contains a parser for the subset of XPath 1.0 that is used within
+// YANG must and when statements. Refer to:
+// https://www.w3.org/TR/1999/REC-xpath-19991116/ and
+// https://tools.ietf.org/html/rfc7950#section-6.4.
+
+// xpathTokenKind is the kind of a lexical token within an XPath expression.
+type xpathTokenKind int
+
+const (
+	// xpathTokEOF marks the end of the expression.
+	xpathTokEOF xpathTokenKind = iota
+	// xpathTokName is a name test, which may be a QName, "*" or "prefix:*".
+	xpathTokName
+	// xpathTokNumber is a numeric literal.
+	xpathTokNumber
+	// xpathTokLiteral is a quoted string literal.
+	xpathTokLiteral
+	// xpathTokOp is an operator or punctuation, including the operator names
+	// "and", "or", "div" and "mod".
+	xpathTokOp
+	// xpathTokFunc is the name of a function call, the following "(" is
+	// consumed as part of the token.
+	xpathTokFunc
+	// xpathTokAxis is an axis name, the following "::" is consumed as part of
+	// the token.
+	xpathTokAxis
+	// xpathTokNodeType is a node type test such as node() or text(), the
+	// following "()" is consumed as part of the token.
+	xpathTokNodeType
+	// xpathTokVar is a variable reference.
+	xpathTokVar
+)
+
+// xpathToken is a lexical token within an XPath expression.
+type xpathToken struct {
+	kind xpathTokenKind
+	val  string
+}
+
+// xpathAxes is the set of axis names supported by the parser.
+var xpathAxes = map[string]bool{
+	"ancestor":           true,
+	"ancestor-or-self":   true,
+	"attribute":          true,
+	"child":              true,
+	"descendant":         true,
+	"descendant-or-self": true,
+	"following":          true,
+	"following-sibling":  true,
+	"namespace":          true,
+	"parent":             true,
+	"preceding":          true,
+	"preceding-sibling":  true,
+	"self":               true,
+}
+
+// xpathNodeTypes is the set of node type tests defined by XPath 1.0.
+var xpathNodeTypes = map[string]bool{
+	"comment":                true,
+	"node":                   true,
+	"processing-instruction": true,
+	"text":                   true,
+}
+
+// lexXPath splits the XPath expression expr into tokens.
+func lexXPath(expr string) ([]xpathToken, error) {
+	var toks []xpathToken
+	rs := []rune(expr)
+
+	// operatorContext reports whether the preceding token means that a "*"
+	// or NCName must be interpreted as an operator, per section 3.7 of the
+	// XPath 1.0 specification.
+	operatorContext := func() bool {
+		if len(toks) == 0 {
+			return false
+		}
+		last := toks[len(toks)-1]
+		switch last.kind {
+		case xpathTokAxis, xpathTokFunc:
+			return false
+		case xpathTokOp:
+			switch last.val {
+			case ")", "]", ".", "..":
+				return true
+			}
+			return false
+		}
+		return true
+	}
+
+	isNameStart := func(r rune) bool { return r == '_' || unicode.IsLetter(r) }
+	isNameChar := func(r rune) bool {
+		return isNameStart(r) || unicode.IsDigit(r) || r == '-' || r == '.'
+	}
+	skipSpace := func(i int) int {
+		for i < len(rs) && unicode.IsSpace(rs[i]) {
+			i++
+		}
+		return i
+	}
+
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(rs) && rs[end] != r {
+				end++
+			}
+			if end == len(rs) {
+				return nil, fmt.Errorf("unterminated string literal at offset %d in %q", i, expr)
+			}
+			toks = append(toks, xpathToken{kind: xpathTokLiteral, val: string(rs[i+1 : end])})
+			i = end + 1
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(rs) && unicode.IsDigit(rs[i+1])):
+			end := i
+			for end < len(rs) && (unicode.IsDigit(rs[end]) || rs[end] == '.') {
+				end++
+			}
+			toks = append(toks, xpathToken{kind: xpathTokNumber, val: string(rs[i:end])})
+			i = end
+		case r == '.':
+			if i+1 < len(rs) && rs[i+1] == '.' {
+				toks = append(toks, xpathToken{kind: xpathTokOp, val: ".."})
+				i += 2
+				continue
+			}
+			toks = append(toks, xpathToken{kind: xpathTokOp, val: "."})
+			i++
+		case r == '/':
+			if i+1 < len(rs) && rs[i+1] == '/' {
+				toks = append(toks, xpathToken{kind: xpathTokOp, val: "//"})
+				i += 2
+				continue
+			}
+			toks = append(toks, xpathToken{kind: xpathTokOp, val: "/"})
+			i++
+		case r == '!' || r == '<' || r == '>':
+			if i+1 < len(rs) && rs[i+1] == '=' {
+				toks = append(toks, xpathToken{kind: xpathTokOp, val: string(rs[i : i+2])})
+				i += 2
+				continue
+			}
+			if r == '!' {
+				return nil, fmt.Errorf("unexpected character '!' at offset %d in %q", i, expr)
+			}
+			toks = append(toks, xpathToken{kind: xpathTokOp, val: string(r)})
+			i++
+		case r == ':' && i+1 < len(rs) && rs[i+1] == ':':
+			return nil, fmt.Errorf("unexpected '::' at offset %d in %q", i, expr)
+		case strings.ContainsRune("()[],@|+-=", r):
+			toks = append(toks, xpathToken{kind: xpathTokOp, val: string(r)})
+			i++
+		case r == '*':
+			if operatorContext() {
+				toks = append(toks, xpathToken{kind: xpathTokOp, val: "*"})
+			} else {
+				toks = append(toks, xpathToken{kind: xpathTokName, val: "*"})
+			}
+			i++
+		case r == '$':
+			end := i + 1
+			for end < len(rs) && isNameChar(rs[end]) {
+				end++
+			}
+			toks = append(toks, xpathToken{kind: xpathTokVar, val: string(rs[i+1 : end])})
+			i = end
+		case isNameStart(r):
+			end := i
+			for end < len(rs) && isNameChar(rs[end]) {
+				end++
+			}
+			name := string(rs[i:end])
+			if operatorContext() {
+				switch name {
+				case "and", "or", "div", "mod":
+					toks = append(toks, xpathToken{kind: xpathTokOp, val: name})
+					i = end
+					continue
+				}
+				return nil, fmt.Errorf("unexpected name %q at offset %d in %q", name, i, expr)
+			}
+			// Handle QNames of the form prefix:name and prefix:*.
+			if end+1 < len(rs) && rs[end] == ':' && rs[end+1] != ':' {
+				switch {
+				case rs[end+1] == '*':
+					name = string(rs[i : end+2])
+					end += 2
+				case isNameStart(rs[end+1]):
+					e := end + 1
+					for e < len(rs) && isNameChar(rs[e]) {
+						e++
+					}
+					name = string(rs[i:e])
+					end = e
+				}
+			}
+			next := skipSpace(end)
+			switch {
+			case next+1 < len(rs) && rs[next] == ':' && rs[next+1] == ':':
+				if !xpathAxes[name] {
+					return nil, fmt.Errorf("unknown axis %q in %q", name, expr)
+				}
+				toks = append(toks, xpathToken{kind: xpathTokAxis, val: name})
+				end = next + 2
+			case next < len(rs) && rs[next] == '(':
+				if xpathNodeTypes[name] {
+					close := skipSpace(next + 1)
+					if close >= len(rs) || rs[close] != ')' {
+						return nil, fmt.Errorf("node type test %s() must not have arguments in %q", name, expr)
+					}
+					toks = append(toks, xpathToken{kind: xpathTokNodeType, val: name})
+					end = close + 1
+				} else {
+					toks = append(toks, xpathToken{kind: xpathTokFunc, val: name})
+					end = next + 1
+				}
+			default:
+				toks = append(toks, xpathToken{kind: xpathTokName, val: name})
+			}
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d in %q", r, i, expr)
+		}
+	}
+	return append(toks, xpathToken{kind: xpathTokEOF}), nil
+}
+
+// xpathExpr is a node of a parsed XPath expression. It is implemented by each
+// of the xpath*Expr types below.
+type xpathExpr interface {
+	isXPathExpr()
+}
+
+// xpathBinaryExpr is an expression combining two operands with one of the
+// XPath binary operators (or, and, =, !=, <, <=, >, >=, +, -, *, div, mod, |).
+type xpathBinaryExpr struct {
+	op       string
+	lhs, rhs xpathExpr
+}
+
+// xpathNegateExpr is a unary minus expression.
+type xpathNegateExpr struct {
+	expr xpathExpr
+}
+
+// xpathLiteralExpr is a string literal.
+type xpathLiteralExpr struct {
+	val string
+}
+
+// xpathNumberExpr is a numeric literal.
+type xpathNumberExpr struct {
+	val float64
+}
+
+// xpathFuncExpr is a call of the function name with the supplied arguments.
+type xpathFuncExpr struct {
+	name string
+	args []xpathExpr
+}
+
+// xpathFilterExpr is a primary expression that is filtered by one or more
+// predicates.
+type xpathFilterExpr struct {
+	expr  xpathExpr
+	preds []xpathExpr
+}
+
+// xpathPathExpr is a location path. If filter is non-nil, the steps are
+// evaluated relative to the node-set that it evaluates to, otherwise they are
+// evaluated relative to the context node, or the root of the tree if abs is
+// set.
+type xpathPathExpr struct {
+	filter xpathExpr
+	abs    bool
+	steps  []*xpathStep
+	// query identifies the path within the path query memo of the node
+	// that it is evaluated from. It is empty where the nodes that the path
+	// selects depend on more than that node, such as where it calls
+	// current(), in which case they are not memoized.
+	query string
+}
+
+// xpathStep is a single step within a location path.
+type xpathStep struct {
+	// axis is the name of the axis along which the step selects nodes.
+	axis string
+	// name is the name test of the step with any module prefix removed, it
+	// is "*" for a wildcard name test. It is empty where nodeType is set.
+	name string
+	// nodeType is the node type test of the step, e.g., "node".
+	nodeType string
+	// preds is the set of predicates that filter the nodes selected by
+	// the step.
+	preds []xpathExpr
+}
+
+func (*xpathBinaryExpr) isXPathExpr()  {}
+func (*xpathNegateExpr) isXPathExpr()  {}
+func (*xpathLiteralExpr) isXPathExpr() {}
+func (*xpathNumberExpr) isXPathExpr()  {}
+func (*xpathFuncExpr) isXPathExpr()    {}
+func (*xpathFilterExpr) isXPathExpr()  {}
+func (*xpathPathExpr) isXPathExpr()    {}
+
+// xpathParser is a recursive descent parser for XPath expressions.
+type xpathParser struct {
+	expr string
+	toks []xpathToken
+	pos  int
+}
+
+// xpathCacheEntry is a previously parsed XPath expression, or the error that
+// was encountered when parsing it.
+type xpathCacheEntry struct {
+	expr xpathExpr
+	err  error
+}
+
+// xpathCache stores parsed XPath expressions keyed by their source string,
+// since the same must or when statement is typically evaluated for many
+// nodes in the data tree.
+var xpathCache sync.Map
+
+// parseXPath parses the XPath expression expr, returning an error if it is
+// not a valid expression. Previously parsed expressions are returned from a
+// cache.
+func parseXPath(expr string) (xpathExpr, error) {
+	if v, ok := xpathCache.Load(expr); ok {
+		ce := v.(*xpathCacheEntry)
+		return ce.expr, ce.err
+	}
+	e, err := parseXPathUncached(expr)
+	xpathCache.Store(expr, &xpathCacheEntry{expr: e, err: err})
+	return e, err
+}
+
+// parseXPathUncached parses the XPath expression expr.
+func parseXPathUncached(expr string) (xpathExpr, error) {
+	toks, err := lexXPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &xpathParser{expr: expr, toks: toks}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != xpathTokEOF {
+		return nil, fmt.Errorf("unexpected token %q in %q", t.val, expr)
+	}
+	return e, nil
+}
+
+// peek returns the next token without consuming it.
+func (p *xpathParser) peek() xpathToken {
+	return p.toks[p.pos]
+}
+
+// next consumes and returns the next token.
+func (p *xpathParser) next() xpathToken {
+	t := p.toks[p.pos]
+	if t.kind != xpathTokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isOp reports whether the next token is the operator op.
+func (p *xpathParser) isOp(ops ...string) bool {
+	t := p.peek()
+	if t.kind != xpathTokOp {
+		return false
+	}
+	for _, op := range ops {
+		if t.val == op {
+			return true
+		}
+	}
+	return false
+}
+
+// expectOp consumes the operator op, returning an error if the next token is
+// not op.
+func (p *xpathParser) expectOp(op string) error {
+	if !p.isOp(op) {
+		return fmt.Errorf("expected %q, got %q in %q", op, p.peek().val, p.expr)
+	}
+	p.next()
+	return nil
+}
+
+// parseBinary parses a left-associative sequence of operands separated by one
+// of ops, each of which is parsed by operand.
+func (p *xpathParser) parseBinary(operand func() (xpathExpr, error), ops ...string) (xpathExpr, error) {
+	lhs, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp(ops...) {
+		op := p.next().val
+		rhs, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &xpathBinaryExpr{op: op, lhs: lhs, rhs: rhs}
+	}
+	return lhs, nil
+}
+
+func (p *xpathParser) parseOr() (xpathExpr, error) {
+	return p.parseBinary(p.parseAnd, "or")
+}
+
+func (p *xpathParser) parseAnd() (xpathExpr, error) {
+	return p.parseBinary(p.parseEquality, "and")
+}
+
+func (p *xpathParser) parseEquality() (xpathExpr, error) {
+	return p.parseBinary(p.parseRelational, "=", "!=")
+}
+
+func (p *xpathParser) parseRelational() (xpathExpr, error) {
+	return p.parseBinary(p.parseAdditive, "<", "<=", ">", ">=")
+}
+
+func (p *xpathParser) parseAdditive() (xpathExpr, error) {
+	return p.parseBinary(p.parseMultiplicative, "+", "-")
+}
+
+func (p *xpathParser) parseMultiplicative() (xpathExpr, error) {
+	return p.parseBinary(p.parseUnary, "*", "div", "mod")
+}
+
+func (p *xpathParser) parseUnary() (xpathExpr, error) {
+	if p.isOp("-") {
+		p.next()
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &xpathNegateExpr{expr: e}, nil
+	}
+	return p.parseBinary(p.parsePath, "|")
+}
+
+// parsePath parses a PathExpr, which is either a location path, or a filter
+// expression optionally followed by a relative location path.
+func (p *xpathParser) parsePath() (xpathExpr, error) {
+	start := p.pos
+	t := p.peek()
+	isPrimary := t.kind == xpathTokLiteral || t.kind == xpathTokNumber || t.kind == xpathTokFunc || t.kind == xpathTokVar || (t.kind == xpathTokOp && t.val == "(")
+	if !isPrimary {
+		return p.parseLocationPath()
+	}
+
+	prim, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	var preds []xpathExpr
+	for p.isOp("[") {
+		pred, err := p.parsePredicate()
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+	if len(preds) != 0 {
+		prim = &xpathFilterExpr{expr: prim, preds: preds}
+	}
+	if !p.isOp("/", "//") {
+		return prim, nil
+	}
+	path := &xpathPathExpr{filter: prim}
+	if err := p.parseRelativeSteps(path); err != nil {
+		return nil, err
+	}
+	path.query = p.pathQuery(start)
+	return path, nil
+}
+
+// parsePrimary parses a PrimaryExpr.
+func (p *xpathParser) parsePrimary() (xpathExpr, error) {
+	t := p.next()
+	switch t.kind {
+	case xpathTokLiteral:
+		return &xpathLiteralExpr{val: t.val}, nil
+	case xpathTokNumber:
+		f, err := strconv.ParseFloat(t.val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q in %q", t.val, p.expr)
+		}
+		return &xpathNumberExpr{val: f}, nil
+	case xpathTokVar:
+		return nil, fmt.Errorf("variable references are not supported, got $%s in %q", t.val, p.expr)
+	case xpathTokFunc:
+		fn := &xpathFuncExpr{name: util.StripModulePrefix(t.val)}
+		if p.isOp(")") {
+			p.next()
+			return fn, nil
+		}
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			fn.args = append(fn.args, arg)
+			if p.isOp(",") {
+				p.next()
+				continue
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return fn, nil
+		}
+	}
+	// The only remaining primary expression is a parenthesised expression.
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectOp(")"); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// parsePredicate parses a predicate of the form [Expr].
+func (p *xpathParser) parsePredicate() (xpathExpr, error) {
+	if err := p.expectOp("["); err != nil {
+		return nil, err
+	}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectOp("]"); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// parseLocationPath parses an absolute or relative location path.
+func (p *xpathParser) parseLocationPath() (xpathExpr, error) {
+	start := p.pos
+	path := &xpathPathExpr{}
+	switch {
+	case p.isOp("/"):
+		p.next()
+		path.abs = true
+		// The path "/" on its own selects the root node.
+		if !p.startsStep() {
+			path.query = p.pathQuery(start)
+			return path, nil
+		}
+	case p.isOp("//"):
+		p.next()
+		path.abs = true
+		path.steps = append(path.steps, &xpathStep{axis: "descendant-or-self", nodeType: "node"})
+	}
+	if err := p.parseStepsFrom(path); err != nil {
+		return nil, err
+	}
+	path.query = p.pathQuery(start)
+	return path, nil
+}
+
+// pathQuery returns the query of the path whose tokens are those from the
+// index start to the current position, as per xpathPathExpr. It is empty where
+// the path calls current(), or position() or last(), whose values are not
+// solely determined by the node that the path is evaluated from.
+func (p *xpathParser) pathQuery(start int) string {
+	var q []string
+	for _, t := range p.toks[start:p.pos] {
+		switch t.kind {
+		case xpathTokFunc:
+			switch util.StripModulePrefix(t.val) {
+			case "current", "position", "last":
+				return ""
+			}
+			q = append(q, t.val+"(")
+		case xpathTokLiteral:
+			q = append(q, strconv.Quote(t.val))
+		case xpathTokAxis:
+			q = append(q, t.val+"::")
+		case xpathTokNodeType:
+			q = append(q, t.val+"()")
+		case xpathTokVar:
+			q = append(q, "$"+t.val)
+		default:
+			q = append(q, t.val)
+		}
+	}
+	return strings.Join(q, " ")
+}
+
+// parseRelativeSteps parses a sequence of "/" or "//" separated steps that
+// follows a filter expression.
+func (p *xpathParser) parseRelativeSteps(path *xpathPathExpr) error {
+	for p.isOp("/", "//") {
+		if p.next().val == "//" {
+			path.steps = append(path.steps, &xpathStep{axis: "descendant-or-self", nodeType: "node"})
+		}
+		s, err := p.parseStep()
+		if err != nil {
+			return err
+		}
+		path.steps = append(path.steps, s)
+	}
+	return nil
+}
+
+// parseStepsFrom parses a relative location path, appending its steps to
+// path.
+func (p *xpathParser) parseStepsFrom(path *xpathPathExpr) error {
+	s, err := p.parseStep()
+	if err != nil {
+		return err
+	}
+	path.steps = append(path.steps, s)
+	return p.parseRelativeSteps(path)
+}
+
+// startsStep reports whether the next token can begin a location step.
+func (p *xpathParser) startsStep() bool {
+	switch t := p.peek(); t.kind {
+	case xpathTokName, xpathTokAxis, xpathTokNodeType:
+		return true
+	case xpathTokOp:
+		return t.val == "." || t.val == ".." || t.val == "@"
+	}
+	return false
+}
+
+// parseStep parses a single location step.
+func (p *xpathParser) parseStep() (*xpathStep, error) {
+	switch {
+	case p.isOp("."):
+		p.next()
+		return &xpathStep{axis: "self", nodeType: "node"}, nil
+	case p.isOp(".."):
+		p.next()
+		return &xpathStep{axis: "parent", nodeType: "node"}, nil
+	}
+
+	s := &xpathStep{axis: "child"}
+	switch t := p.peek(); {
+	case t.kind == xpathTokAxis:
+		s.axis = p.next().val
+	case t.kind == xpathTokOp && t.val == "@":
+		p.next()
+		s.axis = "attribute"
+	}
+
+	switch t := p.next(); t.kind {
+	case xpathTokName:
+		s.name = util.StripModulePrefix(t.val)
+	case xpathTokNodeType:
+		s.nodeType = t.val
+	default:
+		return nil, fmt.Errorf("expected node test, got %q in %q", t.val, p.expr)
+	}
+
+	for p.isOp("[") {
+		pred, err := p.parsePredicate()
+		if err != nil {
+			return nil, err
+		}
+		s.preds = append(s.preds, pred)
+	}
+	return s, nil
+}
@@ -0,0 +1,1289 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// xpathNode is a node within the data tree that XPath expressions are
+// evaluated against. The tree is built lazily from a GoStruct and follows the
+// YANG data tree rather than the generated Go structs, such that containers
+// that are removed by path compression are still present, and leaves whose
+// default value is in use are present, as required by the accessible tree
+// defined in RFC7950 Section 6.4.1.
+//
+// Leafref paths are instead resolved by GetNode against the generated Go
+// structs, since they consist of only child and parent steps with key
+// predicates. XPath expressions may use any axis and function, and select
+// nodes that are not present in the Go structs, hence they are evaluated
+// against this tree. Both memoize the nodes selected by the paths that they
+// query from each node using a util.PathQueryNodeMemo.
+type xpathNode struct {
+	// schema is the schema of the node. It is nil for a synthetic root
+	// node that is used when the data tree does not have a fake root.
+	schema *yang.Entry
+	// parent is the parent node in the data tree.
+	parent *xpathNode
+	// value is the GoStruct storing the data for a container or list
+	// entry, or the value of a leaf or leaf-list member.
+	value reflect.Value
+	// holderSchema is the schema of the GoStruct stored in value for
+	// container and list entry nodes.
+	holderSchema *yang.Entry
+	// prefix is the schema path from holderSchema to the node. It is
+	// non-empty only for containers that were compressed out of the
+	// generated code.
+	prefix []string
+	// key is the map key of a keyed list entry.
+	key reflect.Value
+	// field is the struct field of the parent GoStruct that stores the
+	// node, and index is the index of the node within it where the field
+	// is a slice. They are used to remove the node from the data tree.
+	field reflect.Value
+	index int
+	// virtual indicates that the node does not hold any data, and is
+	// present only since it is a non-presence container.
+	virtual bool
+	// isDefault indicates that the node is a leaf that is not set in the
+	// data tree, but whose default value, defaultValue, is in use.
+	isDefault    bool
+	defaultValue string
+
+	// expanded indicates that children has been populated.
+	expanded bool
+	children []*xpathNode
+	// memo stores the nodes selected by the location paths that have
+	// been evaluated from the node.
+	memo *util.PathQueryNodeMemo
+}
+
+// newXPathTree returns the root node of the XPath data tree for the supplied
+// value and its schema, along with the node corresponding to value itself.
+// Where schema is a fake root, these nodes are the same node, otherwise
+// value is represented as the only child of a synthetic root.
+func newXPathTree(schema *yang.Entry, value any) (root, top *xpathNode) {
+	top = &xpathNode{
+		schema:       schema,
+		value:        reflect.ValueOf(value),
+		holderSchema: schema,
+	}
+	if util.IsFakeRoot(schema) {
+		return top, top
+	}
+	root = &xpathNode{expanded: true, children: []*xpathNode{top}}
+	top.parent = root
+	return root, top
+}
+
+// isLeaf reports whether the node is a leaf or leaf-list member.
+func (n *xpathNode) isLeaf() bool {
+	return n.schema != nil && (n.schema.IsLeaf() || n.schema.IsLeafList())
+}
+
+// name returns the name of the node.
+func (n *xpathNode) name() string {
+	if n.schema == nil || util.IsFakeRoot(n.schema) {
+		return ""
+	}
+	return n.schema.Name
+}
+
+// queryMemo returns the memo of the location paths that have been evaluated
+// from the node, whose parent is that of the parent node.
+func (n *xpathNode) queryMemo() *util.PathQueryNodeMemo {
+	if n.memo == nil {
+		n.memo = &util.PathQueryNodeMemo{}
+		if n.parent != nil {
+			n.memo.Parent = n.parent.queryMemo()
+		}
+	}
+	return n.memo
+}
+
+// root returns the root node of the tree that n belongs to.
+func (n *xpathNode) root() *xpathNode {
+	for n.parent != nil {
+		n = n.parent
+	}
+	return n
+}
+
+// childNodes returns the children of the node in document order.
+func (n *xpathNode) childNodes() []*xpathNode {
+	if n.expanded {
+		return n.children
+	}
+	n.expanded = true
+	if n.isLeaf() || !n.value.IsValid() || !util.IsTypeStructPtr(n.value.Type()) {
+		return nil
+	}
+
+	var sv reflect.Value
+	st := n.value.Type().Elem()
+	if !n.value.IsNil() {
+		sv = n.value.Elem()
+	}
+
+	intermediate := map[string]*xpathNode{}
+	seen := map[*yang.Entry]bool{}
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if util.IsYgotAnnotation(sf) {
+			continue
+		}
+		ps, err := util.SchemaPaths(sf)
+		if err != nil {
+			continue
+		}
+		var fv reflect.Value
+		if sv.IsValid() {
+			fv = sv.Field(i)
+		} else {
+			fv = reflect.Zero(sf.Type)
+		}
+		for _, p := range ps {
+			if len(p) > 1 && p[0] == n.holderSchema.Name && n.holderSchema.Dir[p[0]] == nil {
+				p = p[1:]
+			}
+			if len(p) <= len(n.prefix) || !pathMatchesPrefix(p, n.prefix) {
+				continue
+			}
+			rel := p[:len(n.prefix)+1]
+			cs := util.FirstChild(n.holderSchema, append(append([]string{}, n.prefix...), p[len(n.prefix)]))
+			if cs == nil {
+				continue
+			}
+
+			if len(p) > len(rel) {
+				// The field is below a container that is compressed
+				// out of the generated code.
+				c, ok := intermediate[cs.Name]
+				if !ok {
+					c = &xpathNode{
+						schema:       cs,
+						parent:       n,
+						value:        n.value,
+						holderSchema: n.holderSchema,
+						prefix:       rel,
+						virtual:      true,
+					}
+					intermediate[cs.Name] = c
+					n.children = append(n.children, c)
+				}
+				if !isEmptyDataValue(fv) {
+					c.virtual = false
+				}
+				continue
+			}
+
+			if seen[cs] {
+				continue
+			}
+			seen[cs] = true
+			n.children = append(n.children, newXPathChildNodes(n, cs, fv)...)
+		}
+	}
+	return n.children
+}
+
+// newXPathChildNodes returns the data nodes for the field value fv whose
+// schema is cs, which is a child of the node parent.
+func newXPathChildNodes(parent *xpathNode, cs *yang.Entry, fv reflect.Value) []*xpathNode {
+	var out []*xpathNode
+	switch {
+	case cs.IsLeaf():
+		if !isEmptyDataValue(fv) {
+			return []*xpathNode{{schema: cs, parent: parent, value: fv, field: fv, expanded: true}}
+		}
+		if dv, ok := xpathDefault(parent, cs); ok && len(dv) == 1 {
+			return []*xpathNode{{schema: cs, parent: parent, isDefault: true, defaultValue: dv[0], expanded: true}}
+		}
+	case cs.IsLeafList():
+		if fv.Kind() == reflect.Slice && fv.Len() > 0 {
+			for i := 0; i < fv.Len(); i++ {
+				out = append(out, &xpathNode{schema: cs, parent: parent, value: fv.Index(i), field: fv, index: i, expanded: true})
+			}
+			return out
+		}
+		if dv, ok := xpathDefault(parent, cs); ok {
+			for _, d := range dv {
+				out = append(out, &xpathNode{schema: cs, parent: parent, isDefault: true, defaultValue: d, expanded: true})
+			}
+		}
+	case cs.IsList():
+		addEntry := func(k, v reflect.Value) {
+			out = append(out, &xpathNode{schema: cs, parent: parent, value: v, holderSchema: cs, key: k, field: fv, index: len(out)})
+		}
+		if om, ok := fv.Interface().(ygot.GoOrderedMap); ok {
+			if !util.IsValueNil(om) {
+				// Errors can only be returned for malformed ordered
+				// maps, which are reported by validateList.
+				_ = yreflect.RangeOrderedMap(om, func(k, v reflect.Value) bool {
+					addEntry(k, v)
+					return true
+				})
+			}
+			return out
+		}
+		switch fv.Kind() {
+		case reflect.Map:
+			keys := fv.MapKeys()
+			sortXPathKeys(keys)
+			for _, k := range keys {
+				addEntry(k, fv.MapIndex(k))
+			}
+		case reflect.Slice:
+			for i := 0; i < fv.Len(); i++ {
+				addEntry(reflect.Value{}, fv.Index(i))
+			}
+		}
+	case cs.IsContainer():
+		if !util.IsTypeStructPtr(fv.Type()) {
+			return nil
+		}
+		c := &xpathNode{schema: cs, parent: parent, value: fv, holderSchema: cs, field: fv}
+		if fv.IsNil() {
+			if isPresenceContainer(cs) {
+				return nil
+			}
+			c.virtual = true
+		}
+		return []*xpathNode{c}
+	}
+	return out
+}
+
+// xpathDefault returns the default values of the leaf or leaf-list schema cs,
+// which is a child of parent, if they are in use. Defaults are not considered
+// for nodes within a choice, since they depend on the selected case.
+func xpathDefault(parent *xpathNode, cs *yang.Entry) ([]string, bool) {
+	for e := cs.Parent; e != nil && e != parent.schema; e = e.Parent {
+		if util.IsChoiceOrCase(e) {
+			return nil, false
+		}
+	}
+	dv := cs.DefaultValues()
+	return dv, len(dv) != 0
+}
+
+// sortXPathKeys sorts the supplied map keys such that the order of list
+// entries in the XPath data tree is deterministic.
+func sortXPathKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+}
+
+// isEmptyDataValue reports whether the field value v does not contain any
+// data. Enumerated values are unset where they have the zero value.
+func isEmptyDataValue(v reflect.Value) bool {
+	if util.IsNilOrInvalidValue(v) {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	}
+	switch t := v.Interface().(type) {
+	case ygot.GoOrderedMap:
+		return t.Len() == 0
+	case ygot.GoEnum:
+		return v.Kind() == reflect.Int64 && v.Int() == 0
+	}
+	return false
+}
+
+// isPresenceContainer reports whether the schema entry e is a YANG presence
+// container.
+func isPresenceContainer(e *yang.Entry) bool {
+	return e.IsContainer() && len(e.Extra["presence"]) > 0
+}
+
+// leafValue returns the value of a leaf node with any pointer dereferenced,
+// or an invalid value for a default.
+func (n *xpathNode) leafValue() reflect.Value {
+	v := n.value
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Ptr && !v.IsNil() && !util.IsValueStructPtr(v) {
+		v = v.Elem()
+	}
+	return v
+}
+
+// isIdentityref reports whether the node is a leaf whose value is an identity.
+func (n *xpathNode) isIdentityref() bool {
+	if !n.isLeaf() {
+		return false
+	}
+	s, err := util.ResolveIfLeafRef(n.schema)
+	if err != nil || s.Type == nil {
+		return false
+	}
+	switch s.Type.Kind {
+	case yang.Yidentityref:
+		return true
+	case yang.Yunion:
+		if n.isDefault {
+			return strings.Contains(n.defaultValue, ":")
+		}
+		_, isEnum := n.leafValue().Interface().(ygot.GoEnum)
+		if !isEnum {
+			return false
+		}
+		for _, t := range util.FlattenedTypes(s.Type.Type) {
+			if t.Kind == yang.Yidentityref {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringValue returns the XPath string-value of the node.
+func (n *xpathNode) stringValue() string {
+	if !n.isLeaf() {
+		var b strings.Builder
+		for _, c := range n.childNodes() {
+			b.WriteString(c.stringValue())
+		}
+		return b.String()
+	}
+	if n.isDefault {
+		if n.isIdentityref() {
+			return util.StripModulePrefix(n.defaultValue)
+		}
+		return n.defaultValue
+	}
+	if n.schema.Type != nil && n.schema.Type.Kind == yang.Yempty {
+		return ""
+	}
+	v := n.leafValue()
+	if !v.IsValid() {
+		return ""
+	}
+	s, err := ygot.KeyValueAsString(v.Interface())
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// gnmiPath returns the path of the node from the root of its tree.
+func (n *xpathNode) gnmiPath() *gpb.Path {
+	var elems []*gpb.PathElem
+	for c := n; c != nil && c.name() != ""; c = c.parent {
+		pe := &gpb.PathElem{Name: c.name()}
+		if c.schema.IsList() && c.schema.Key != "" {
+			pe.Key = map[string]string{}
+			for _, k := range strings.Fields(c.schema.Key) {
+				for _, ch := range c.childNodes() {
+					if ch.name() == k && ch.isLeaf() && !ch.isDefault {
+						pe.Key[k] = ch.stringValue()
+						break
+					}
+				}
+			}
+		}
+		elems = append([]*gpb.PathElem{pe}, elems...)
+	}
+	return &gpb.Path{Elem: elems}
+}
+
+// pathString returns the path of the node as a human-readable string.
+func (n *xpathNode) pathString() string {
+	s, err := ygot.PathToString(n.gnmiPath())
+	if err != nil {
+		return n.schema.Path()
+	}
+	return s
+}
+
+// xpathContext is the context in which an XPath expression is evaluated.
+type xpathContext struct {
+	// node is the context node.
+	node *xpathNode
+	// current is the node returned by the YANG current() function.
+	current *xpathNode
+	// root is the root of the data tree.
+	root *xpathNode
+	// pos and size are the context position and size.
+	pos, size int
+}
+
+// xpathIdentity is the value of an identityref leaf, which compares equal to
+// strings that specify the same identity with a module prefix.
+type xpathIdentity string
+
+// evalXPathBool evaluates the XPath expression expr with n as the context
+// node and the current node, and returns its value converted to a boolean.
+func evalXPathBool(expr string, n *xpathNode) (bool, error) {
+	e, err := parseXPath(expr)
+	if err != nil {
+		return false, err
+	}
+	v, err := evalXPath(e, &xpathContext{node: n, current: n, root: n.root(), pos: 1, size: 1})
+	if err != nil {
+		return false, err
+	}
+	return xpathToBool(v), nil
+}
+
+// evalXPathNodes evaluates the XPath expression expr with n as the context
+// node and the current node, and returns the node-set that it selects. An
+// error is returned if the expression does not evaluate to a node-set.
+func evalXPathNodes(expr string, n *xpathNode) ([]*xpathNode, error) {
+	e, err := parseXPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	v, err := evalXPath(e, &xpathContext{node: n, current: n, root: n.root(), pos: 1, size: 1})
+	if err != nil {
+		return nil, err
+	}
+	ns, ok := v.([]*xpathNode)
+	if !ok {
+		return nil, fmt.Errorf("expression %q does not select a node-set", expr)
+	}
+	return ns, nil
+}
+
+// evalXPath evaluates the parsed expression e in the context ctx. It returns
+// either a node-set ([]*xpathNode), a string, a number (float64) or a bool.
+func evalXPath(e xpathExpr, ctx *xpathContext) (any, error) {
+	switch e := e.(type) {
+	case *xpathLiteralExpr:
+		return e.val, nil
+	case *xpathNumberExpr:
+		return e.val, nil
+	case *xpathNegateExpr:
+		v, err := evalXPath(e.expr, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return -xpathToNumber(v), nil
+	case *xpathFuncExpr:
+		return evalXPathFunc(e, ctx)
+	case *xpathFilterExpr:
+		v, err := evalXPath(e.expr, ctx)
+		if err != nil {
+			return nil, err
+		}
+		ns, ok := v.([]*xpathNode)
+		if !ok {
+			return nil, fmt.Errorf("predicate applied to non node-set value %v", v)
+		}
+		return applyXPathPredicates(ns, e.preds, ctx)
+	case *xpathPathExpr:
+		return evalXPathPath(e, ctx)
+	case *xpathBinaryExpr:
+		return evalXPathBinary(e, ctx)
+	}
+	return nil, fmt.Errorf("unknown XPath expression type %T", e)
+}
+
+// evalXPathBinary evaluates the binary expression e in the context ctx.
+func evalXPathBinary(e *xpathBinaryExpr, ctx *xpathContext) (any, error) {
+	lhs, err := evalXPath(e.lhs, ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The boolean operators do not evaluate the right-hand side where the
+	// result is determined by the left-hand side.
+	switch e.op {
+	case "or":
+		if xpathToBool(lhs) {
+			return true, nil
+		}
+	case "and":
+		if !xpathToBool(lhs) {
+			return false, nil
+		}
+	}
+	rhs, err := evalXPath(e.rhs, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "or", "and":
+		return xpathToBool(rhs), nil
+	case "=", "!=", "<", "<=", ">", ">=":
+		return xpathCompare(e.op, lhs, rhs), nil
+	case "|":
+		l, lok := lhs.([]*xpathNode)
+		r, rok := rhs.([]*xpathNode)
+		if !lok || !rok {
+			return nil, fmt.Errorf("union of non node-set values")
+		}
+		return uniqueXPathNodes(append(append([]*xpathNode{}, l...), r...)), nil
+	}
+
+	l, r := xpathToNumber(lhs), xpathToNumber(rhs)
+	switch e.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "div":
+		return l / r, nil
+	case "mod":
+		return math.Mod(l, r), nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", e.op)
+}
+
+// evalXPathPath evaluates the location path e in the context ctx. Where the
+// nodes that the path selects depend only on the node that it is evaluated
+// from, which is the root for an absolute path, they are memoized for that
+// node.
+func evalXPathPath(e *xpathPathExpr, ctx *xpathContext) ([]*xpathNode, error) {
+	if e.query == "" {
+		return selectXPathPath(e, ctx)
+	}
+	memo := ctx.node.queryMemo()
+	if e.abs {
+		memo = memo.GetRoot()
+	}
+	vs, err := memoizedPathQuery(memo, e.query, func() ([]interface{}, error) {
+		ns, err := selectXPathPath(e, ctx)
+		vs := make([]interface{}, len(ns))
+		for i, n := range ns {
+			vs[i] = n
+		}
+		return vs, err
+	})
+	var nodes []*xpathNode
+	for _, v := range vs {
+		nodes = append(nodes, v.(*xpathNode))
+	}
+	return nodes, err
+}
+
+// selectXPathPath returns the nodes selected by the location path e in the
+// context ctx.
+func selectXPathPath(e *xpathPathExpr, ctx *xpathContext) ([]*xpathNode, error) {
+	var nodes []*xpathNode
+	switch {
+	case e.filter != nil:
+		v, err := evalXPath(e.filter, ctx)
+		if err != nil {
+			return nil, err
+		}
+		ns, ok := v.([]*xpathNode)
+		if !ok {
+			return nil, fmt.Errorf("location path applied to non node-set value %v", v)
+		}
+		nodes = ns
+	case e.abs:
+		nodes = []*xpathNode{ctx.root}
+	default:
+		nodes = []*xpathNode{ctx.node}
+	}
+
+	for _, s := range e.steps {
+		var out []*xpathNode
+		for _, n := range nodes {
+			cands, err := xpathAxis(n, s.axis)
+			if err != nil {
+				return nil, err
+			}
+			var matched []*xpathNode
+			for _, c := range cands {
+				if xpathNodeTest(c, s) {
+					matched = append(matched, c)
+				}
+			}
+			matched, err = applyXPathPredicates(matched, s.preds, ctx)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, matched...)
+		}
+		nodes = uniqueXPathNodes(out)
+	}
+	return nodes, nil
+}
+
+// applyXPathPredicates filters the node-set ns by each of the predicates
+// preds in turn.
+func applyXPathPredicates(ns []*xpathNode, preds []xpathExpr, ctx *xpathContext) ([]*xpathNode, error) {
+	for _, pred := range preds {
+		var out []*xpathNode
+		for i, n := range ns {
+			v, err := evalXPath(pred, &xpathContext{node: n, current: ctx.current, root: ctx.root, pos: i + 1, size: len(ns)})
+			if err != nil {
+				return nil, err
+			}
+			keep := false
+			if f, ok := v.(float64); ok {
+				keep = f == float64(i+1)
+			} else {
+				keep = xpathToBool(v)
+			}
+			if keep {
+				out = append(out, n)
+			}
+		}
+		ns = out
+	}
+	return ns, nil
+}
+
+// uniqueXPathNodes removes duplicate nodes from ns, retaining the first
+// instance of each node.
+func uniqueXPathNodes(ns []*xpathNode) []*xpathNode {
+	seen := map[*xpathNode]bool{}
+	var out []*xpathNode
+	for _, n := range ns {
+		if !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// xpathNodeTest reports whether the node n satisfies the node test of step s.
+func xpathNodeTest(n *xpathNode, s *xpathStep) bool {
+	switch s.nodeType {
+	case "node":
+		return true
+	case "":
+		return s.name == "*" && n.name() != "" || n.name() == s.name
+	}
+	// There are no text, comment or processing instruction nodes in the
+	// data tree.
+	return false
+}
+
+// xpathAxis returns the nodes along the named axis from n, in axis order.
+func xpathAxis(n *xpathNode, axis string) ([]*xpathNode, error) {
+	var out []*xpathNode
+	switch axis {
+	case "child":
+		return n.childNodes(), nil
+	case "self":
+		return []*xpathNode{n}, nil
+	case "parent":
+		if n.parent != nil {
+			out = append(out, n.parent)
+		}
+	case "ancestor", "ancestor-or-self":
+		if axis == "ancestor-or-self" {
+			out = append(out, n)
+		}
+		for p := n.parent; p != nil; p = p.parent {
+			out = append(out, p)
+		}
+	case "descendant", "descendant-or-self":
+		if axis == "descendant-or-self" {
+			out = append(out, n)
+		}
+		var walk func(*xpathNode)
+		walk = func(p *xpathNode) {
+			for _, c := range p.childNodes() {
+				out = append(out, c)
+				walk(c)
+			}
+		}
+		walk(n)
+	case "following-sibling", "preceding-sibling":
+		if n.parent == nil {
+			return nil, nil
+		}
+		sibs := n.parent.childNodes()
+		idx := -1
+		for i, s := range sibs {
+			if s == n {
+				idx = i
+			}
+		}
+		if axis == "following-sibling" {
+			return append(out, sibs[idx+1:]...), nil
+		}
+		for i := idx - 1; i >= 0; i-- {
+			out = append(out, sibs[i])
+		}
+	case "attribute", "namespace":
+		// There are no attribute or namespace nodes in the data tree.
+	default:
+		return nil, fmt.Errorf("unsupported axis %s", axis)
+	}
+	return out, nil
+}
+
+// xpathToBool converts the XPath value v to a boolean.
+func xpathToBool(v any) bool {
+	switch v := v.(type) {
+	case []*xpathNode:
+		return len(v) != 0
+	case string:
+		return v != ""
+	case xpathIdentity:
+		return v != ""
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case bool:
+		return v
+	}
+	return false
+}
+
+// xpathToString converts the XPath value v to a string.
+func xpathToString(v any) string {
+	switch v := v.(type) {
+	case []*xpathNode:
+		if len(v) == 0 {
+			return ""
+		}
+		return v[0].stringValue()
+	case string:
+		return v
+	case xpathIdentity:
+		return string(v)
+	case float64:
+		return xpathNumberToString(v)
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+	return ""
+}
+
+// xpathToNumber converts the XPath value v to a number.
+func xpathToNumber(v any) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(xpathToString(v)), 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return f
+}
+
+// xpathNumberToString returns the XPath string representation of f.
+func xpathNumberToString(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	case f == 0:
+		return "0"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// xpathAtoms returns the values that are compared when v is an operand of
+// a comparison. For a node-set these are the string-values of each node.
+func xpathAtoms(v any) []any {
+	ns, ok := v.([]*xpathNode)
+	if !ok {
+		return []any{v}
+	}
+	var out []any
+	for _, n := range ns {
+		if n.isIdentityref() {
+			out = append(out, xpathIdentity(n.stringValue()))
+			continue
+		}
+		out = append(out, n.stringValue())
+	}
+	return out
+}
+
+// xpathCompare evaluates the comparison lhs op rhs according to the rules in
+// section 3.4 of the XPath 1.0 specification.
+func xpathCompare(op string, lhs, rhs any) bool {
+	_, lns := lhs.([]*xpathNode)
+	_, rns := rhs.([]*xpathNode)
+	// A node-set compared with a boolean is converted to a boolean.
+	if _, ok := rhs.(bool); ok && lns {
+		lhs = xpathToBool(lhs)
+		lns = false
+	}
+	if _, ok := lhs.(bool); ok && rns {
+		rhs = xpathToBool(rhs)
+	}
+
+	for _, l := range xpathAtoms(lhs) {
+		for _, r := range xpathAtoms(rhs) {
+			if xpathCompareAtoms(op, l, r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// xpathCompareAtoms compares two values that are not node-sets.
+func xpathCompareAtoms(op string, l, r any) bool {
+	if op == "=" || op == "!=" {
+		var eq bool
+		_, lb := l.(bool)
+		_, rb := r.(bool)
+		_, lf := l.(float64)
+		_, rf := r.(float64)
+		_, li := l.(xpathIdentity)
+		_, ri := r.(xpathIdentity)
+		switch {
+		case lb || rb:
+			eq = xpathToBool(l) == xpathToBool(r)
+		case lf || rf:
+			eq = xpathToNumber(l) == xpathToNumber(r)
+		case li || ri:
+			eq = util.StripModulePrefix(xpathToString(l)) == util.StripModulePrefix(xpathToString(r))
+		default:
+			eq = xpathToString(l) == xpathToString(r)
+		}
+		return eq == (op == "=")
+	}
+
+	lf, rf := xpathToNumber(l), xpathToNumber(r)
+	switch op {
+	case "<":
+		return lf < rf
+	case "<=":
+		return lf <= rf
+	case ">":
+		return lf > rf
+	case ">=":
+		return lf >= rf
+	}
+	return false
+}
+
+// xpathArgs evaluates the arguments of the function call f, checking that
+// there are between minArgs and maxArgs of them. A negative maxArgs means
+// that there is no upper bound.
+func xpathArgs(f *xpathFuncExpr, ctx *xpathContext, minArgs, maxArgs int) ([]any, error) {
+	if len(f.args) < minArgs || (maxArgs >= 0 && len(f.args) > maxArgs) {
+		return nil, fmt.Errorf("invalid number of arguments %d to function %s()", len(f.args), f.name)
+	}
+	var out []any
+	for _, a := range f.args {
+		v, err := evalXPath(a, ctx)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// xpathNodeSetArg returns the argument v as a node-set, returning an error
+// if it is not a node-set.
+func xpathNodeSetArg(f *xpathFuncExpr, v any) ([]*xpathNode, error) {
+	ns, ok := v.([]*xpathNode)
+	if !ok {
+		return nil, fmt.Errorf("function %s() requires a node-set argument, got %v", f.name, v)
+	}
+	return ns, nil
+}
+
+// xpathStringArgOrContext returns the string value of the first argument in
+// args, or of the context node if there are no arguments.
+func xpathStringArgOrContext(args []any, ctx *xpathContext) string {
+	if len(args) == 0 {
+		return ctx.node.stringValue()
+	}
+	return xpathToString(args[0])
+}
+
+// evalXPathFunc evaluates the function call f in the context ctx. The core
+// function library of XPath 1.0 is supported along with the functions
+// defined in RFC7950 Section 10, except for those that operate on
+// instance-identifiers and on namespaces.
+func evalXPathFunc(f *xpathFuncExpr, ctx *xpathContext) (any, error) {
+	switch f.name {
+	case "current":
+		if _, err := xpathArgs(f, ctx, 0, 0); err != nil {
+			return nil, err
+		}
+		return []*xpathNode{ctx.current}, nil
+	case "last", "position":
+		if _, err := xpathArgs(f, ctx, 0, 0); err != nil {
+			return nil, err
+		}
+		if f.name == "last" {
+			return float64(ctx.size), nil
+		}
+		return float64(ctx.pos), nil
+	case "true", "false":
+		if _, err := xpathArgs(f, ctx, 0, 0); err != nil {
+			return nil, err
+		}
+		return f.name == "true", nil
+	case "count", "sum":
+		args, err := xpathArgs(f, ctx, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		ns, err := xpathNodeSetArg(f, args[0])
+		if err != nil {
+			return nil, err
+		}
+		if f.name == "count" {
+			return float64(len(ns)), nil
+		}
+		var sum float64
+		for _, n := range ns {
+			sum += xpathToNumber(n.stringValue())
+		}
+		return sum, nil
+	case "local-name", "name":
+		args, err := xpathArgs(f, ctx, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		ns := []*xpathNode{ctx.node}
+		if len(args) == 1 {
+			if ns, err = xpathNodeSetArg(f, args[0]); err != nil {
+				return nil, err
+			}
+		}
+		if len(ns) == 0 {
+			return "", nil
+		}
+		return ns[0].name(), nil
+	case "namespace-uri":
+		if _, err := xpathArgs(f, ctx, 0, 1); err != nil {
+			return nil, err
+		}
+		return "", nil
+	case "string", "string-length", "normalize-space":
+		args, err := xpathArgs(f, ctx, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		s := xpathStringArgOrContext(args, ctx)
+		switch f.name {
+		case "string-length":
+			return float64(utf8.RuneCountInString(s)), nil
+		case "normalize-space":
+			return strings.Join(strings.Fields(s), " "), nil
+		}
+		return s, nil
+	case "number":
+		args, err := xpathArgs(f, ctx, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 0 {
+			return xpathToNumber(ctx.node.stringValue()), nil
+		}
+		return xpathToNumber(args[0]), nil
+	case "boolean", "not":
+		args, err := xpathArgs(f, ctx, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		b := xpathToBool(args[0])
+		if f.name == "not" {
+			return !b, nil
+		}
+		return b, nil
+	case "lang":
+		if _, err := xpathArgs(f, ctx, 1, 1); err != nil {
+			return nil, err
+		}
+		return false, nil
+	case "floor", "ceiling", "round":
+		args, err := xpathArgs(f, ctx, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		n := xpathToNumber(args[0])
+		switch f.name {
+		case "floor":
+			return math.Floor(n), nil
+		case "ceiling":
+			return math.Ceil(n), nil
+		}
+		return math.Floor(n + 0.5), nil
+	case "concat":
+		args, err := xpathArgs(f, ctx, 2, -1)
+		if err != nil {
+			return nil, err
+		}
+		var b strings.Builder
+		for _, a := range args {
+			b.WriteString(xpathToString(a))
+		}
+		return b.String(), nil
+	case "starts-with", "contains", "substring-before", "substring-after":
+		args, err := xpathArgs(f, ctx, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		s, t := xpathToString(args[0]), xpathToString(args[1])
+		switch f.name {
+		case "starts-with":
+			return strings.HasPrefix(s, t), nil
+		case "contains":
+			return strings.Contains(s, t), nil
+		}
+		i := strings.Index(s, t)
+		switch {
+		case i < 0:
+			return "", nil
+		case f.name == "substring-before":
+			return s[:i], nil
+		}
+		return s[i+len(t):], nil
+	case "substring":
+		args, err := xpathArgs(f, ctx, 2, 3)
+		if err != nil {
+			return nil, err
+		}
+		rs := []rune(xpathToString(args[0]))
+		start := math.Floor(xpathToNumber(args[1]) + 0.5)
+		end := math.Inf(1)
+		if len(args) == 3 {
+			end = start + math.Floor(xpathToNumber(args[2])+0.5)
+		}
+		var b strings.Builder
+		for i, r := range rs {
+			if p := float64(i + 1); p >= start && p < end {
+				b.WriteRune(r)
+			}
+		}
+		return b.String(), nil
+	case "translate":
+		args, err := xpathArgs(f, ctx, 3, 3)
+		if err != nil {
+			return nil, err
+		}
+		from, to := []rune(xpathToString(args[1])), []rune(xpathToString(args[2]))
+		var b strings.Builder
+		for _, r := range xpathToString(args[0]) {
+			idx := -1
+			for i, fr := range from {
+				if fr == r {
+					idx = i
+					break
+				}
+			}
+			switch {
+			case idx < 0:
+				b.WriteRune(r)
+			case idx < len(to):
+				b.WriteRune(to[idx])
+			}
+		}
+		return b.String(), nil
+	case "re-match":
+		args, err := xpathArgs(f, ctx, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		re, err := reCache.compilePattern("^(?:"+xpathToString(args[1])+")$", false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern in re-match(): %v", err)
+		}
+		return re.MatchString(xpathToString(args[0])), nil
+	case "derived-from", "derived-from-or-self":
+		args, err := xpathArgs(f, ctx, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		ns, err := xpathNodeSetArg(f, args[0])
+		if err != nil {
+			return nil, err
+		}
+		base := util.StripModulePrefix(xpathToString(args[1]))
+		for _, n := range ns {
+			if !n.isIdentityref() {
+				continue
+			}
+			v := n.stringValue()
+			if f.name == "derived-from-or-self" && v == base {
+				return true, nil
+			}
+			if isDerivedIdentity(n.schema, v, base) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "enum-value":
+		args, err := xpathArgs(f, ctx, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		ns, err := xpathNodeSetArg(f, args[0])
+		if err != nil {
+			return nil, err
+		}
+		if len(ns) == 0 || !ns[0].isLeaf() {
+			return math.NaN(), nil
+		}
+		if v, ok := enumValue(ns[0].schema, ns[0].stringValue()); ok {
+			return float64(v), nil
+		}
+		return math.NaN(), nil
+	case "bit-is-set":
+		args, err := xpathArgs(f, ctx, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		ns, err := xpathNodeSetArg(f, args[0])
+		if err != nil {
+			return nil, err
+		}
+		if len(ns) == 0 {
+			return false, nil
+		}
+		bit := xpathToString(args[1])
+		for _, b := range strings.Fields(ns[0].stringValue()) {
+			if b == bit {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "deref":
+		args, err := xpathArgs(f, ctx, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		ns, err := xpathNodeSetArg(f, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return derefXPathNode(ns)
+	}
+	return nil, fmt.Errorf("unsupported XPath function %s()", f.name)
+}
+
+// derefXPathNode returns the nodes that are referenced by the first node in
+// ns, which must be a leafref leaf.
+func derefXPathNode(ns []*xpathNode) ([]*xpathNode, error) {
+	if len(ns) == 0 || !ns[0].isLeaf() || ns[0].schema.Type == nil || ns[0].schema.Type.Kind != yang.Yleafref {
+		return nil, nil
+	}
+	n := ns[0]
+	e, err := parseXPath(n.schema.Type.Path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := evalXPath(e, &xpathContext{node: n, current: n, root: n.root(), pos: 1, size: 1})
+	if err != nil {
+		return nil, err
+	}
+	targets, ok := v.([]*xpathNode)
+	if !ok {
+		return nil, fmt.Errorf("leafref path %s does not evaluate to a node-set", n.schema.Type.Path)
+	}
+	var out []*xpathNode
+	for _, t := range targets {
+		if t.stringValue() == n.stringValue() {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// isDerivedIdentity reports whether the identity named name is derived from
+// the identity named base, using the identities that are referenced by the
+// type of the leaf schema.
+func isDerivedIdentity(schema *yang.Entry, name, base string) bool {
+	s, err := util.ResolveIfLeafRef(schema)
+	if err != nil || s.Type == nil {
+		return false
+	}
+	types := []*yang.YangType{s.Type}
+	if s.Type.Kind == yang.Yunion {
+		types = util.FlattenedTypes(s.Type.Type)
+	}
+	for _, t := range types {
+		if t.Kind != yang.Yidentityref || t.IdentityBase == nil {
+			continue
+		}
+		b := findIdentity(t.IdentityBase, base)
+		if b == nil {
+			continue
+		}
+		for _, v := range b.Values {
+			if v.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findIdentity returns the identity named name from id and the identities
+// that are derived from it, or nil if it is not found.
+func findIdentity(id *yang.Identity, name string) *yang.Identity {
+	if id.Name == name {
+		return id
+	}
+	for _, v := range id.Values {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// enumValue returns the integer value assigned to the enum named name in the
+// type of the leaf schema.
+func enumValue(schema *yang.Entry, name string) (int64, bool) {
+	s, err := util.ResolveIfLeafRef(schema)
+	if err != nil || s.Type == nil {
+		return 0, false
+	}
+	types := []*yang.YangType{s.Type}
+	if s.Type.Kind == yang.Yunion {
+		types = util.FlattenedTypes(s.Type.Type)
+	}
+	for _, t := range types {
+		if t.Kind != yang.Yenum || t.Enum == nil {
+			continue
+		}
+		if v, ok := t.Enum.NameMap()[name]; ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,579 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// xpathTestIfType is an identityref enumeration used within the XPath tests.
+type xpathTestIfType int64
+
+func (xpathTestIfType) IsYANGGoEnum() {}
+
+func (xpathTestIfType) ΛMap() map[string]map[int64]ygot.EnumDefinition {
+	return map[string]map[int64]ygot.EnumDefinition{
+		"xpathTestIfType": {
+			1: {Name: "ethernetCsmacd", DefiningModule: "iana-if-type"},
+			2: {Name: "softwareLoopback", DefiningModule: "iana-if-type"},
+			3: {Name: "ieee8023adLag", DefiningModule: "iana-if-type"},
+		},
+	}
+}
+
+func (e xpathTestIfType) String() string {
+	return ygot.EnumLogString(e, int64(e), "xpathTestIfType")
+}
+
+const (
+	xpathTestEthernet xpathTestIfType = 1
+	xpathTestLoopback xpathTestIfType = 2
+	xpathTestLag      xpathTestIfType = 3
+)
+
+// xpathTestDevice and the structs below are a compressed representation of
+// the schema returned by xpathTestSchema.
+type xpathTestDevice struct {
+	Interface map[string]*xpathTestInterface `path:"interfaces/interface"`
+	Bgp       *xpathTestBgp                  `path:"bgp"`
+}
+
+func (*xpathTestDevice) IsYANGGoStruct() {}
+
+type xpathTestInterface struct {
+	Name         *string                           `path:"config/name|name"`
+	Mtu          *uint16                           `path:"config/mtu"`
+	Type         xpathTestIfType                   `path:"config/type"`
+	Enabled      *bool                             `path:"config/enabled"`
+	Description  *string                           `path:"config/description"`
+	Subinterface map[uint32]*xpathTestSubinterface `path:"subinterfaces/subinterface"`
+}
+
+func (*xpathTestInterface) IsYANGGoStruct() {}
+
+func (t *xpathTestInterface) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"name": *t.Name}, nil
+}
+
+type xpathTestSubinterface struct {
+	Index       *uint32  `path:"config/index|index"`
+	Description *string  `path:"config/description"`
+	Address     []string `path:"config/address"`
+}
+
+func (*xpathTestSubinterface) IsYANGGoStruct() {}
+
+func (t *xpathTestSubinterface) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"index": *t.Index}, nil
+}
+
+type xpathTestBgp struct {
+	As       *uint32                       `path:"global/config/as"`
+	Neighbor map[string]*xpathTestNeighbor `path:"neighbors/neighbor"`
+}
+
+func (*xpathTestBgp) IsYANGGoStruct() {}
+
+type xpathTestNeighbor struct {
+	NeighborAddress *string `path:"config/neighbor-address|neighbor-address"`
+	PeerAs          *uint32 `path:"config/peer-as"`
+	Interface       *string `path:"config/interface"`
+	Password        *string `path:"password"`
+	KeyChain        *string `path:"key-chain"`
+	KeyId           *uint32 `path:"key-id"`
+}
+
+func (*xpathTestNeighbor) IsYANGGoStruct() {}
+
+func (t *xpathTestNeighbor) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"neighbor-address": *t.NeighborAddress}, nil
+}
+
+// xpathTestSchema returns the schema for xpathTestDevice. The supplied
+// function, if non-nil, is called with the schema before the parent
+// pointers within it are populated, such that tests can add statements to
+// it.
+func xpathTestSchema(modify func(*yang.Entry)) *yang.Entry {
+	ifType := &yang.YangType{
+		Kind: yang.Yidentityref,
+		IdentityBase: &yang.Identity{
+			Name: "iana-interface-type",
+			Values: []*yang.Identity{
+				{Name: "ethernetCsmacd"},
+				{Name: "ieee8023adLag"},
+				{Name: "softwareLoopback"},
+			},
+		},
+	}
+	leaf := func(name string, t *yang.YangType) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: t}
+	}
+	dir := func(name string, children ...*yang.Entry) *yang.Entry {
+		e := &yang.Entry{Name: name, Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{}}
+		for _, c := range children {
+			e.Dir[c.Name] = c
+		}
+		return e
+	}
+	choiceOrCase := func(name string, kind yang.EntryKind, children ...*yang.Entry) *yang.Entry {
+		e := dir(name, children...)
+		e.Kind = kind
+		return e
+	}
+	list := func(name, key string, children ...*yang.Entry) *yang.Entry {
+		e := dir(name, children...)
+		e.Key = key
+		e.ListAttr = yang.NewDefaultListAttr()
+		e.Config = yang.TSTrue
+		return e
+	}
+
+	enabled := leaf("enabled", &yang.YangType{Kind: yang.Ybool})
+	enabled.Default = []string{"true"}
+	address := leaf("address", &yang.YangType{Kind: yang.Ystring})
+	address.ListAttr = yang.NewDefaultListAttr()
+
+	root := dir("device",
+		dir("interfaces",
+			list("interface", "name",
+				leaf("name", &yang.YangType{Kind: yang.Yleafref, Path: "../config/name"}),
+				dir("config",
+					leaf("name", &yang.YangType{Kind: yang.Ystring}),
+					leaf("mtu", &yang.YangType{Kind: yang.Yuint16}),
+					leaf("type", ifType),
+					enabled,
+					leaf("description", &yang.YangType{Kind: yang.Ystring}),
+				),
+				dir("subinterfaces",
+					list("subinterface", "index",
+						leaf("index", &yang.YangType{Kind: yang.Yleafref, Path: "../config/index"}),
+						dir("config",
+							leaf("index", &yang.YangType{Kind: yang.Yuint32}),
+							leaf("description", &yang.YangType{Kind: yang.Ystring}),
+							address,
+						),
+					),
+				),
+			),
+		),
+		dir("bgp",
+			dir("global",
+				dir("config",
+					leaf("as", &yang.YangType{Kind: yang.Yuint32}),
+				),
+			),
+			dir("neighbors",
+				list("neighbor", "neighbor-address",
+					leaf("neighbor-address", &yang.YangType{Kind: yang.Yleafref, Path: "../config/neighbor-address"}),
+					dir("config",
+						leaf("neighbor-address", &yang.YangType{Kind: yang.Ystring}),
+						leaf("peer-as", &yang.YangType{Kind: yang.Yuint32}),
+						leaf("interface", &yang.YangType{Kind: yang.Yleafref, Path: "/interfaces/interface/name"}),
+					),
+					choiceOrCase("auth", yang.ChoiceEntry,
+						choiceOrCase("password", yang.CaseEntry,
+							leaf("password", &yang.YangType{Kind: yang.Ystring}),
+						),
+						choiceOrCase("keychain", yang.CaseEntry,
+							leaf("key-chain", &yang.YangType{Kind: yang.Ystring}),
+							leaf("key-id", &yang.YangType{Kind: yang.Yuint32}),
+						),
+					),
+				),
+			),
+		),
+	)
+	root.Annotation = map[string]interface{}{"isFakeRoot": true}
+	if modify != nil {
+		modify(root)
+	}
+	populateParentField(nil, root)
+	return root
+}
+
+// xpathTestData returns a populated xpathTestDevice.
+func xpathTestData() *xpathTestDevice {
+	return &xpathTestDevice{
+		Interface: map[string]*xpathTestInterface{
+			"eth0": {
+				Name: ygot.String("eth0"),
+				Mtu:  ygot.Uint16(1500),
+				Type: xpathTestEthernet,
+				Subinterface: map[uint32]*xpathTestSubinterface{
+					0: {Index: ygot.Uint32(0), Address: []string{"192.0.2.1", "192.0.2.2"}},
+					1: {Index: ygot.Uint32(1), Description: ygot.String("sub one")},
+				},
+			},
+			"lo0": {
+				Name:        ygot.String("lo0"),
+				Type:        xpathTestLoopback,
+				Enabled:     ygot.Bool(false),
+				Description: ygot.String("loopback"),
+			},
+		},
+		Bgp: &xpathTestBgp{
+			As: ygot.Uint32(64512),
+			Neighbor: map[string]*xpathTestNeighbor{
+				"192.0.2.254": {
+					NeighborAddress: ygot.String("192.0.2.254"),
+					PeerAs:          ygot.Uint32(64513),
+					Interface:       ygot.String("eth0"),
+				},
+			},
+		},
+	}
+}
+
+func TestParseXPath(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      string
+		want    xpathExpr
+		wantErr bool
+	}{{
+		desc: "relative path",
+		in:   "../config/name",
+		want: &xpathPathExpr{steps: []*xpathStep{
+			{axis: "parent", nodeType: "node"},
+			{axis: "child", name: "config"},
+			{axis: "child", name: "name"},
+		}},
+	}, {
+		desc: "absolute path with prefixes and predicate",
+		in:   "/oc-if:interfaces/oc-if:interface[oc-if:name = current()/../name]",
+		want: &xpathPathExpr{abs: true, steps: []*xpathStep{
+			{axis: "child", name: "interfaces"},
+			{axis: "child", name: "interface", preds: []xpathExpr{
+				&xpathBinaryExpr{
+					op:  "=",
+					lhs: &xpathPathExpr{steps: []*xpathStep{{axis: "child", name: "name"}}},
+					rhs: &xpathPathExpr{
+						filter: &xpathFuncExpr{name: "current"},
+						steps: []*xpathStep{
+							{axis: "parent", nodeType: "node"},
+							{axis: "child", name: "name"},
+						},
+					},
+				},
+			}},
+		}},
+	}, {
+		desc: "operator precedence",
+		in:   "1 + 2 * 3 > 4 or not(a) and b",
+		want: &xpathBinaryExpr{
+			op: "or",
+			lhs: &xpathBinaryExpr{
+				op: ">",
+				lhs: &xpathBinaryExpr{
+					op:  "+",
+					lhs: &xpathNumberExpr{val: 1},
+					rhs: &xpathBinaryExpr{op: "*", lhs: &xpathNumberExpr{val: 2}, rhs: &xpathNumberExpr{val: 3}},
+				},
+				rhs: &xpathNumberExpr{val: 4},
+			},
+			rhs: &xpathBinaryExpr{
+				op:  "and",
+				lhs: &xpathFuncExpr{name: "not", args: []xpathExpr{&xpathPathExpr{steps: []*xpathStep{{axis: "child", name: "a"}}}}},
+				rhs: &xpathPathExpr{steps: []*xpathStep{{axis: "child", name: "b"}}},
+			},
+		},
+	}, {
+		desc: "wildcard, axis and operator names as node names",
+		in:   "count(child::*) - count(descendant-or-self::and)",
+		want: &xpathBinaryExpr{
+			op:  "-",
+			lhs: &xpathFuncExpr{name: "count", args: []xpathExpr{&xpathPathExpr{steps: []*xpathStep{{axis: "child", name: "*"}}}}},
+			rhs: &xpathFuncExpr{name: "count", args: []xpathExpr{&xpathPathExpr{steps: []*xpathStep{{axis: "descendant-or-self", name: "and"}}}}},
+		},
+	}, {
+		desc: "descendant abbreviation and literal",
+		in:   "//name != 'eth0'",
+		want: &xpathBinaryExpr{
+			op: "!=",
+			lhs: &xpathPathExpr{abs: true, steps: []*xpathStep{
+				{axis: "descendant-or-self", nodeType: "node"},
+				{axis: "child", name: "name"},
+			}},
+			rhs: &xpathLiteralExpr{val: "eth0"},
+		},
+	}, {
+		desc:    "unterminated literal",
+		in:      "name = 'eth0",
+		wantErr: true,
+	}, {
+		desc:    "unbalanced brackets",
+		in:      "interface[name = 'eth0'",
+		wantErr: true,
+	}, {
+		desc:    "variable reference",
+		in:      "$foo = 1",
+		wantErr: true,
+	}, {
+		desc:    "trailing tokens",
+		in:      "a b",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := parseXPathUncached(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseXPathUncached(%q): got error %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(xpathBinaryExpr{}, xpathNegateExpr{}, xpathLiteralExpr{}, xpathNumberExpr{}, xpathFuncExpr{}, xpathFilterExpr{}, xpathPathExpr{}, xpathStep{}), cmpopts.IgnoreFields(xpathPathExpr{}, "query")); diff != "" {
+				t.Errorf("parseXPathUncached(%q): (-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestXPathPathQuery(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		want string
+	}{{
+		desc: "absolute path",
+		in:   "/interfaces/interface[name = 'eth0']/config/mtu",
+		want: `/ interfaces / interface [ name = "eth0" ] / config / mtu`,
+	}, {
+		desc: "literal is distinct from name",
+		in:   "a[b = c]",
+		want: "a [ b = c ]",
+	}, {
+		desc: "filter expression",
+		in:   "deref(.)/../config/mtu",
+		want: "deref( . ) / .. / config / mtu",
+	}, {
+		desc: "current",
+		in:   "../../name[. = current()/../name]",
+	}, {
+		desc: "position",
+		in:   "interface[position() = 1]",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := parseXPathUncached(tt.in)
+			if err != nil {
+				t.Fatalf("parseXPathUncached(%q): unexpected error: %v", tt.in, err)
+			}
+			p, ok := got.(*xpathPathExpr)
+			if !ok {
+				t.Fatalf("parseXPathUncached(%q): got %T, want *xpathPathExpr", tt.in, got)
+			}
+			if p.query != tt.want {
+				t.Errorf("parseXPathUncached(%q): got query %q, want %q", tt.in, p.query, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalXPathMemo(t *testing.T) {
+	root, _ := newXPathTree(xpathTestSchema(nil), xpathTestData())
+	ifaces, err := evalXPathNodes("/interfaces/interface", root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ifaces) != 2 {
+		t.Fatalf("got %d interfaces, want 2", len(ifaces))
+	}
+
+	// The absolute path is memoized for the root, such that it is
+	// evaluated once for all of the interfaces.
+	for _, n := range ifaces {
+		got, err := evalXPathBool("count(/interfaces/interface/name) = 2", n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got {
+			t.Errorf("%s: got false, want true", n.pathString())
+		}
+	}
+	if got := len(root.queryMemo().Memo); got != 2 {
+		t.Errorf("got %d queries memoized for the root, want 2", got)
+	}
+	for _, n := range ifaces {
+		if got := len(n.queryMemo().Memo); got != 0 {
+			t.Errorf("%s: got %d queries memoized, want 0", n.pathString(), got)
+		}
+	}
+
+	// A path that calls current() is not memoized.
+	if _, err := evalXPathBool("name = current()/name", ifaces[0]); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(ifaces[0].queryMemo().Memo), 1; got != want {
+		t.Errorf("got %d queries memoized for %s, want %d", got, ifaces[0].pathString(), want)
+	}
+}
+
+func TestEvalXPath(t *testing.T) {
+	schema := xpathTestSchema(nil)
+	root, _ := newXPathTree(schema, xpathTestData())
+
+	// find returns the node at the supplied path from the root of the tree.
+	find := func(path string) *xpathNode {
+		e, err := parseXPath(path)
+		if err != nil {
+			t.Fatalf("cannot parse path %s: %v", path, err)
+		}
+		v, err := evalXPath(e, &xpathContext{node: root, current: root, root: root})
+		if err != nil {
+			t.Fatalf("cannot evaluate path %s: %v", path, err)
+		}
+		ns := v.([]*xpathNode)
+		if len(ns) != 1 {
+			t.Fatalf("path %s: got %d nodes, want 1", path, len(ns))
+		}
+		return ns[0]
+	}
+
+	tests := []struct {
+		desc    string
+		context string
+		expr    string
+		want    any
+		wantErr bool
+	}{{
+		desc: "count of list entries",
+		expr: "count(/interfaces/interface)",
+		want: float64(2),
+	}, {
+		desc: "compressed containers are present",
+		expr: "/interfaces/interface[name='eth0']/config/mtu",
+		want: "1500",
+	}, {
+		desc:    "relative path with current",
+		context: "/interfaces/interface[name='eth0']/config/mtu",
+		expr:    "../../name = current()/../name",
+		want:    true,
+	}, {
+		desc:    "number comparison",
+		context: "/interfaces/interface[name='eth0']/config/mtu",
+		expr:    ". >= 1280 and . <= 9000",
+		want:    true,
+	}, {
+		desc:    "default value in use",
+		context: "/interfaces/interface[name='eth0']",
+		expr:    "config/enabled = 'true'",
+		want:    true,
+	}, {
+		desc:    "explicitly set value",
+		context: "/interfaces/interface[name='lo0']",
+		expr:    "config/enabled = 'false'",
+		want:    true,
+	}, {
+		desc:    "identity comparison ignores prefix",
+		context: "/interfaces/interface[name='lo0']",
+		expr:    "config/type = 'ianaift:softwareLoopback'",
+		want:    true,
+	}, {
+		desc:    "derived-from",
+		context: "/interfaces/interface[name='eth0']",
+		expr:    "derived-from(config/type, 'ianaift:iana-interface-type')",
+		want:    true,
+	}, {
+		desc:    "derived-from excludes self",
+		context: "/interfaces/interface[name='eth0']",
+		expr:    "derived-from(config/type, 'ianaift:ethernetCsmacd')",
+		want:    false,
+	}, {
+		desc:    "derived-from-or-self",
+		context: "/interfaces/interface[name='eth0']",
+		expr:    "derived-from-or-self(config/type, 'ianaift:ethernetCsmacd')",
+		want:    true,
+	}, {
+		desc: "leaf-list membership",
+		expr: "/interfaces/interface/subinterfaces/subinterface/config/address = '192.0.2.2'",
+		want: true,
+	}, {
+		desc: "string functions",
+		expr: "concat(substring-before('a-b', '-'), translate('xyz', 'xz', 'X'), string-length('four'), substring('12345', 2, 3))",
+		want: "aXy4234",
+	}, {
+		desc: "re-match",
+		expr: "re-match('192.0.2.1', '\\d+\\.\\d+\\.\\d+\\.\\d+')",
+		want: true,
+	}, {
+		desc:    "deref",
+		context: "/bgp/neighbors/neighbor/config/interface",
+		expr:    "deref(.)/../config/mtu",
+		want:    "1500",
+	}, {
+		desc: "positional predicate",
+		expr: "string(/interfaces/interface[2]/name)",
+		want: "lo0",
+	}, {
+		desc: "arithmetic",
+		expr: "(7 mod 4) * 2 div 4 - -1",
+		want: 2.5,
+	}, {
+		desc:    "unsupported function",
+		expr:    "foo()",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx := root
+			if tt.context != "" {
+				ctx = find(tt.context)
+			}
+			e, err := parseXPath(tt.expr)
+			if err != nil {
+				t.Fatalf("parseXPath(%q): unexpected error: %v", tt.expr, err)
+			}
+			v, err := evalXPath(e, &xpathContext{node: ctx, current: ctx, root: root, pos: 1, size: 1})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("evalXPath(%q): got error %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if ns, ok := v.([]*xpathNode); ok {
+				v = xpathToString(ns)
+			}
+			if diff := cmp.Diff(tt.want, v); diff != "" {
+				t.Errorf("evalXPath(%q): (-want, +got):\n%s", tt.expr, diff)
+			}
+		})
+	}
+}
+
+func TestXPathNodePath(t *testing.T) {
+	schema := xpathTestSchema(nil)
+	root, _ := newXPathTree(schema, xpathTestData())
+	e, err := parseXPath("/interfaces/interface[name='eth0']/subinterfaces/subinterface[index=1]/config/description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := evalXPath(e, &xpathContext{node: root, current: root, root: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns := v.([]*xpathNode)
+	if len(ns) != 1 {
+		t.Fatalf("got %d nodes, want 1", len(ns))
+	}
+	if got, want := ns[0].pathString(), "/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=1]/config/description"; got != want {
+		t.Errorf("pathString(): got %s, want %s", got, want)
+	}
+}